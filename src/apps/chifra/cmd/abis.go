@@ -46,7 +46,8 @@ const longAbis = `Purpose:
 
 const notesAbis = `
 Notes:
-  - Search for either four byte signatures or event signatures with the --find option.`
+  - Search for either four byte signatures or event signatures with the --find option.
+  - The --find option reports every candidate from the local signature database before falling back to a brute-force search.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra abis
@@ -62,7 +63,9 @@ func init() {
 	abisCmd.Flags().BoolVarP(&abisPkg.GetOptions().Count, "count", "c", false, `show the number of abis downloaded`)
 	abisCmd.Flags().StringSliceVarP(&abisPkg.GetOptions().Find, "find", "f", nil, `search for function or event declarations given a four- or 32-byte code(s)`)
 	abisCmd.Flags().StringSliceVarP(&abisPkg.GetOptions().Hint, "hint", "n", nil, `for the --find option only, provide hints to speed up the search`)
+	abisCmd.Flags().StringVarP(&abisPkg.GetOptions().Calldata, "calldata", "", "", `for the --find option only, rank the candidates by whether or not they decode this calldata`)
 	abisCmd.Flags().StringVarP(&abisPkg.GetOptions().Encode, "encode", "e", "", `generate the 32-byte encoding for a given cannonical function or event signature`)
+	abisCmd.Flags().StringVarP(&abisPkg.GetOptions().ImportSigs, "import_sigs", "", "", `import a public signature list (text or json) into the local signature database`)
 	globals.InitGlobals("abis", abisCmd, &abisPkg.GetOptions().Globals, capabilities)
 
 	abisCmd.SetUsageTemplate(UsageWithNotes(notesAbis))
@@ -0,0 +1,73 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package cmd
+
+// EXISTING_CODE
+import (
+	"os"
+
+	clustersPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/clusters"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/globals"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/caps"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/spf13/cobra"
+)
+
+// EXISTING_CODE
+
+// clustersCmd represents the clusters command
+var clustersCmd = &cobra.Command{
+	Use:     usageClusters,
+	Long:    longClusters,
+	Version: versionText,
+	PreRun: outputHelpers.PreRunWithJsonWriter("clusters", func() *globals.GlobalOptions {
+		return &clustersPkg.GetOptions().Globals
+	}),
+	RunE: file.RunWithFileSupport("clusters", clustersPkg.RunClusters, clustersPkg.ResetOptions),
+	PostRun: outputHelpers.PostRunWithJsonWriter(func() *globals.GlobalOptions {
+		return &clustersPkg.GetOptions().Globals
+	}),
+}
+
+const usageClusters = `clusters [flags] <address> [address...]
+
+Arguments:
+  addrs - one or more monitored addresses whose appearances and neighbors to cluster (required)`
+
+const longClusters = `Purpose:
+  Group monitored addresses and their neighbors into entities using transaction patterns.`
+
+const notesClusters = `
+Notes:
+  - The funder heuristic groups addresses first funded by the same account. The sweep heuristic groups deposit addresses that empty themselves into the same account. The deployer heuristic groups contracts deployed by the same account. The create2 heuristic groups contracts created by the same CREATE2 factory.
+  - Confidence falls as the shared account becomes busier (for example, an exchange funding thousands of unrelated addresses).
+  - The --accept option tags each member with 81-Clusters:<id>. Members that already have tags keep them.`
+
+func init() {
+	var capabilities caps.Capability // capabilities for chifra clusters
+	capabilities = capabilities.Add(caps.Default)
+	capabilities = capabilities.Add(caps.Names)
+
+	clustersCmd.Flags().SortFlags = false
+
+	clustersCmd.Flags().StringSliceVarP(&clustersPkg.GetOptions().Heuristics, "heuristics", "", nil, `the heuristics used to group addresses
+One or more of [ funder | sweep | deployer | create2 | all ]`)
+	clustersCmd.Flags().Float64VarP(&clustersPkg.GetOptions().MinConfidence, "min_confidence", "m", 0.5, `report only clusters with at least this confidence (between 0.0 and 1.0)`)
+	clustersCmd.Flags().BoolVarP(&clustersPkg.GetOptions().Accept, "accept", "a", false, `write the reported clusters to the custom names database as tags`)
+	globals.InitGlobals("clusters", clustersCmd, &clustersPkg.GetOptions().Globals, capabilities)
+
+	clustersCmd.SetUsageTemplate(UsageWithNotes(notesClusters))
+	clustersCmd.SetOut(os.Stderr)
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	chifraCmd.AddCommand(clustersCmd)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/globals"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/caps"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
 	"github.com/spf13/cobra"
 )
@@ -59,7 +60,9 @@ Notes:
   - If the --reversed option is present, the appearance list is reversed prior to all processing (including filtering).
   - The --decache option will remove all cache items (blocks, transactions, traces, etc.) for the given address(es).
   - The --withdrawals option is only available on certain chains. It is ignored otherwise.
-  - The --traces option requires your RPC to provide trace data. See the README for more information.`
+  - The --traces option requires your RPC to provide trace data. See the README for more information.
+  - With --group, transactions, receipts, logs, traces, and appearances are exported as one stream in block order with each transaction reported once. Accounting, balances, and withdrawals are exported per member.
+  - With --incremental, a checkpoint is ignored (and the monitor reconciled from its first appearance) if the names, the price sources, or the earlier appearances have changed.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra export
@@ -91,12 +94,14 @@ func init() {
 	exportCmd.Flags().StringSliceVarP(&exportPkg.GetOptions().Asset, "asset", "P", nil, `for the accounting options only, export statements only for this asset`)
 	exportCmd.Flags().StringVarP(&exportPkg.GetOptions().Flow, "flow", "f", "", `for the accounting options only, export statements with incoming, outgoing, or zero value
 One of [ in | out | zero ]`)
+	exportCmd.Flags().BoolVarP(&exportPkg.GetOptions().Incremental, "incremental", "", false, `for the accounting options only, reconcile only the appearances after the checkpoint of each monitor and save a new checkpoint`)
 	exportCmd.Flags().BoolVarP(&exportPkg.GetOptions().Factory, "factory", "y", false, `for --traces only, report addresses created by (or self-destructed by) the given address(es)`)
 	exportCmd.Flags().BoolVarP(&exportPkg.GetOptions().Unripe, "unripe", "u", false, `export transactions labeled unripe (i.e. less than 28 blocks old)`)
 	exportCmd.Flags().BoolVarP(&exportPkg.GetOptions().Reversed, "reversed", "E", false, `produce results in reverse chronological order`)
 	exportCmd.Flags().BoolVarP(&exportPkg.GetOptions().NoZero, "no_zero", "z", false, `for the --count option only, suppress the display of zero appearance accounts`)
 	exportCmd.Flags().Uint64VarP((*uint64)(&exportPkg.GetOptions().FirstBlock), "first_block", "F", 0, `first block to process (inclusive)`)
 	exportCmd.Flags().Uint64VarP((*uint64)(&exportPkg.GetOptions().LastBlock), "last_block", "L", 0, `last block to process (inclusive)`)
+	exportCmd.Flags().StringVarP(&exportPkg.GetOptions().Group, "group", "", "", `export the combined records of the members of this monitor group (see chifra monitors --group)`)
 	globals.InitGlobals("export", exportCmd, &exportPkg.GetOptions().Globals, capabilities)
 
 	exportCmd.SetUsageTemplate(UsageWithNotes(notesExport))
@@ -107,6 +112,10 @@ One of [ in | out | zero ]`)
 	var unused bool
 	exportCmd.Flags().BoolVarP(&unused, "txs", "", false, "no-op options shows transactions (same as default)")
 	_ = exportCmd.Flags().MarkHidden("txs")
+	// The block flags accept any single block identifier (dates, specials, and periods such as 2023-06-15:monthend@ny)
+	firstFlag, lastFlag := exportCmd.Flags().Lookup("first_block"), exportCmd.Flags().Lookup("last_block")
+	firstFlag.Value = identifiers.NewBlockFlag(firstFlag.Value, &exportPkg.GetOptions().FirstBlock)
+	lastFlag.Value = identifiers.NewBlockFlag(lastFlag.Value, &exportPkg.GetOptions().LastBlock)
 	// EXISTING_CODE
 
 	chifraCmd.AddCommand(exportCmd)
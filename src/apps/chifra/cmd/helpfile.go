@@ -14,6 +14,7 @@ const helpText = `  Accounts:
     monitors      add, remove, clean, and list address monitors
     names         query addresses or names of well-known accounts
     abis          fetches the ABI for a smart contract
+    clusters      group monitored addresses and their neighbors into entities using transaction patterns
   Chain Data:
     blocks        retrieve one or more blocks from the chain or local cache
     transactions  retrieve one or more transactions from the chain or local cache
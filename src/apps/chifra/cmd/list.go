@@ -47,7 +47,8 @@ const longList = `Purpose:
 const notesList = `
 Notes:
   - An address must be either an ENS name or start with '0x' and be forty-two characters long.
-  - No other options are permitted when --silent is selected.`
+  - No other options are permitted when --silent is selected.
+  - The members of a --group are freshened together and their appearances are listed in block order.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra list
@@ -67,6 +68,7 @@ func init() {
 	listCmd.Flags().StringVarP(&listPkg.GetOptions().Publisher, "publisher", "P", "", `for some query options, the publisher of the index (hidden)`)
 	listCmd.Flags().Uint64VarP((*uint64)(&listPkg.GetOptions().FirstBlock), "first_block", "F", 0, `first block to export (inclusive, ignored when freshening)`)
 	listCmd.Flags().Uint64VarP((*uint64)(&listPkg.GetOptions().LastBlock), "last_block", "L", 0, `last block to export (inclusive, ignored when freshening)`)
+	listCmd.Flags().StringVarP(&listPkg.GetOptions().Group, "group", "", "", `list the combined appearances of the members of this monitor group (see chifra monitors --group)`)
 	if os.Getenv("TEST_MODE") != "true" {
 		_ = listCmd.Flags().MarkHidden("publisher")
 	}
@@ -48,9 +48,13 @@ const notesMonitors = `
 Notes:
   - An address must be either an ENS name or start with '0x' and be forty-two characters long.
   - If no address is presented to the --clean command, all existing monitors will be cleaned.
-  - The --watch option requires two additional parameters to be specified: --watchlist and --commands.
+  - The --watch option requires --watchlist and at least one of --commands or --rules.
   - Addresses provided on the command line are ignored in --watch mode.
-  - Providing the value existing to the --watchlist monitors all existing monitor files (see --list).`
+  - Providing the value existing to the --watchlist monitors all existing monitor files (see --list).
+  - The --rules file may replace or accompany --commands. Rules match transfers over an amount, calls to a function selector, or a balance below an amount. Sinks are webhooks, files, unix sockets, or shell commands.
+  - With --group, --delete removes the given addresses from the group and --remove removes the group itself. The monitors of its members are not changed.
+  - The v2 monitor format is compressed and keeps the timestamp of each appearance. New monitors are v1 unless monitorFormat is v2 in the settings section of the configuration file. Both formats may always be read.
+  - With --subscribe, the watcher refreshes the monitors as soon as a block arrives. Without a websocket or IPC rpcProvider, it sleeps between runs as usual. If the subscription drops, it polls for the latest block every --sleep seconds until it can subscribe again.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra monitors
@@ -67,12 +71,17 @@ func init() {
 	monitorsCmd.Flags().BoolVarP(&monitorsPkg.GetOptions().List, "list", "l", false, `list monitors in the cache (--verbose for more detail)`)
 	monitorsCmd.Flags().BoolVarP(&monitorsPkg.GetOptions().Count, "count", "c", false, `show the number of active monitors (included deleted but not removed monitors)`)
 	monitorsCmd.Flags().BoolVarP(&monitorsPkg.GetOptions().Staged, "staged", "S", false, `for --clean, --list, and --count options only, include staged monitors`)
+	monitorsCmd.Flags().StringVarP(&monitorsPkg.GetOptions().Migrate, "migrate", "", "", `rewrite the given monitors (or all monitors if none are given) in place in the given file format
+One of [ v1 | v2 ]`)
 	monitorsCmd.Flags().BoolVarP(&monitorsPkg.GetOptions().Watch, "watch", "w", false, `continually scan for new blocks and extract data as per the command file`)
+	monitorsCmd.Flags().StringVarP(&monitorsPkg.GetOptions().Group, "group", "", "", `create or show a named group of monitors, adding any given addresses to it (with --watch, watch the group instead of a --watchlist)`)
 	monitorsCmd.Flags().StringVarP(&monitorsPkg.GetOptions().Watchlist, "watchlist", "a", "", `available with --watch option only, a file containing the addresses to watch`)
 	monitorsCmd.Flags().StringVarP(&monitorsPkg.GetOptions().Commands, "commands", "d", "", `available with --watch option only, the file containing the list of commands to apply to each watched address`)
 	monitorsCmd.Flags().Uint64VarP(&monitorsPkg.GetOptions().BatchSize, "batch_size", "b", 8, `available with --watch option only, the number of monitors to process in each batch`)
 	monitorsCmd.Flags().Uint64VarP(&monitorsPkg.GetOptions().RunCount, "run_count", "u", 0, `available with --watch option only, run the monitor this many times, then quit`)
 	monitorsCmd.Flags().Float64VarP(&monitorsPkg.GetOptions().Sleep, "sleep", "s", 14, `available with --watch option only, the number of seconds to sleep between runs`)
+	monitorsCmd.Flags().BoolVarP(&monitorsPkg.GetOptions().Subscribe, "subscribe", "", false, `available with --watch option only, wake as soon as a block arrives from a newHeads subscription rather than sleeping between runs`)
+	monitorsCmd.Flags().StringVarP(&monitorsPkg.GetOptions().Rules, "rules", "", "", `available with --watch option only, a TOML file of rules evaluated against each new appearance and the sinks that receive matches`)
 	globals.InitGlobals("monitors", monitorsCmd, &monitorsPkg.GetOptions().Globals, capabilities)
 
 	monitorsCmd.SetUsageTemplate(UsageWithNotes(notesMonitors))
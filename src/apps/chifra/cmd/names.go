@@ -47,7 +47,13 @@ const longNames = `Purpose:
 const notesNames = `
 Notes:
   - The tool will accept up to three terms, each of which must match against any field in the database.
-  - The --match_case option enables case sensitive matching.`
+  - The --match_case option enables case sensitive matching.
+  - Terms match words in the name, symbol, address or tags. Whole words rank above the start of a word which ranks above any other part of a word. End a term with * to match by prefix only or with ~ (or ~2) to allow misspellings.
+  - Terms of the form tag:value restrict the results to names with a matching tag (for example tag:tokens).
+  - The search index is kept in the cache between runs. Set namesIndex to memory in the settings section of the configuration file to rebuild it on each run instead.
+  - The --export option signs the bundle with the private key in the secret field of the [keys.names] section of the configuration file.
+  - The --import option merges each field of each name against the last bundle merged from the same author. Fields changed on both sides are reported as conflicts. Only bundles signed by an address listed in namesAuthors in the settings section of the configuration file are imported.
+  - The --discover option records where each name came from in its source field (for example ENS or TokenList: Uniswap Labels).`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra names
@@ -63,9 +69,19 @@ func init() {
 	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Addr, "addr", "s", false, `display only addresses in the results (useful for scripting, assumes --no_header)`)
 	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Tags, "tags", "g", false, `export the list of tags and subtags only`)
 	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Clean, "clean", "C", false, `clean the data (addrs to lower case, sort by addr)`)
-	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Regular, "regular", "r", false, `only available with --clean, cleans regular names database`)
-	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().DryRun, "dry_run", "d", false, `only available with --clean or --autoname, outputs changes to stdout instead of updating databases`)
+	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Regular, "regular", "r", false, `only available with --clean or --discover, cleans or adds to the regular names database`)
+	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().DryRun, "dry_run", "d", false, `only available with --clean, --autoname, --discover, or --import, outputs changes to stdout instead of updating databases`)
 	namesCmd.Flags().StringVarP(&namesPkg.GetOptions().Autoname, "autoname", "A", "", `an address assumed to be a token, added automatically to names database if true`)
+	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Regex, "regex", "", false, `treat each term as a regular expression (the search semantics of earlier versions)`)
+	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Rank, "rank", "", false, `order the results by relevance to the search terms rather than by address`)
+	namesCmd.Flags().StringVarP(&namesPkg.GetOptions().Export, "export", "", "", `export the custom names to a signed bundle at this path`)
+	namesCmd.Flags().StringVarP(&namesPkg.GetOptions().Import, "import", "", "", `merge a signed bundle of names exported by a teammate into the custom names`)
+	namesCmd.Flags().StringVarP(&namesPkg.GetOptions().Strategy, "strategy", "", "", `for the --import option only, resolve conflicts by keeping ours, taking theirs, or taking the newer value
+One of [ ours | theirs | newest ]`)
+	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Discover, "discover", "", false, `name every unnamed address in the terms, in the appearances of monitored terms, or in files given as terms`)
+	namesCmd.Flags().StringSliceVarP(&namesPkg.GetOptions().Sources, "sources", "", nil, `for the --discover option only, the sources to name addresses from (in order of preference)
+One or more of [ tokens | erc20 | ens | abis | creation | all ]`)
+	namesCmd.Flags().StringVarP(&namesPkg.GetOptions().TokenList, "token_list", "", "", `for the --discover option only, the path or URL of a token list in the Uniswap token list format`)
 	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Create, "create", "", false, `create a new name record (hidden)`)
 	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Update, "update", "", false, `edit an existing name (hidden)`)
 	namesCmd.Flags().BoolVarP(&namesPkg.GetOptions().Delete, "delete", "", false, `delete a name, but do not remove it (hidden)`)
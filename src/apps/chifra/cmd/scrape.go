@@ -45,7 +45,8 @@ const notesScrape = `
 Notes:
   - The --touch option may only be used for blocks after the latest scraped block (if any). It will be snapped back to the latest snap_to block.
   - This command requires your RPC to provide trace data. See the README for more information.
-  - The --notify option requires proper configuration. Additionally, IPFS must be running locally. See the README.md file.`
+  - The --notify option requires proper configuration. Additionally, IPFS must be running locally. See the README.md file.
+  - The --subscribe option requires a websocket or IPC rpcProvider (or one among the rpcProviders). Without one, the scraper sleeps between passes as usual. While subscribed, the scraper takes the head of the chain from the subscription. If the subscription drops, it polls for the latest block every --sleep seconds until it can subscribe again.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra scrape
@@ -63,6 +64,7 @@ func init() {
 	scrapeCmd.Flags().Uint64VarP((*uint64)(&scrapePkg.GetOptions().Touch), "touch", "l", 0, `first block to visit when scraping (snapped back to most recent snap_to_grid mark)`)
 	scrapeCmd.Flags().Uint64VarP(&scrapePkg.GetOptions().RunCount, "run_count", "u", 0, `run the scraper this many times, then quit`)
 	scrapeCmd.Flags().BoolVarP(&scrapePkg.GetOptions().DryRun, "dry_run", "d", false, `show the configuration that would be applied if run,no changes are made`)
+	scrapeCmd.Flags().BoolVarP(&scrapePkg.GetOptions().Subscribe, "subscribe", "", false, `wake as soon as a block arrives from a newHeads subscription rather than sleeping between passes`)
 	scrapeCmd.Flags().BoolVarP(&scrapePkg.GetOptions().Notify, "notify", "o", false, `enable the notify feature`)
 	scrapeCmd.Flags().Uint64VarP(&scrapePkg.GetOptions().Settings.AppsPerChunk, "apps_per_chunk", "", 2000000, `the number of appearances to build into a chunk before consolidating it (hidden)`)
 	scrapeCmd.Flags().Uint64VarP(&scrapePkg.GetOptions().Settings.SnapToGrid, "snap_to_grid", "", 250000, `an override to apps_per_chunk to snap-to-grid at every modulo of this value, this allows easier corrections to the index (hidden)`)
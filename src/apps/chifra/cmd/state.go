@@ -54,7 +54,10 @@ Notes:
   - Balance is the default mode. To select a single mode use none first, followed by that mode.
   - Valid parameters for --call include Solidity-like syntax: balanceOf(0x316b...183d), a four-byte followed by parameters: 0x70a08231(0x316b...183d), or encoded input data.
   - You may specify multiple parts on a single line.
-  - In the --call string, you may separate multiple calls with a colon.`
+  - In the --call string, you may separate multiple calls with a colon.
+  - In the --storage string, you may separate multiple slots or variables with a colon. With --layout, variables may be paths such as balances[0x...], owners[1].name, or allowed[0x...][0x...].
+  - With --proof, the --storage option accepts only raw slots and the node must support eth_getProof at the given blocks.
+  - The node that returns a proof also reports the state root it is checked against, so a proof that checks out is only consistent with what the node says. It is verified only against a state root given with --root.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra state
@@ -72,6 +75,10 @@ One or more of [ balance | nonce | code | proxy | deployed | accttype | some | a
 	stateCmd.Flags().StringVarP(&statePkg.GetOptions().Call, "call", "l", "", `call a smart contract with one or more solidity calls, four-byte plus parameters, or encoded call data strings`)
 	stateCmd.Flags().BoolVarP(&statePkg.GetOptions().Articulate, "articulate", "a", false, `for the --call option only, articulate the retrieved data if ABIs can be found`)
 	stateCmd.Flags().StringVarP(&statePkg.GetOptions().ProxyFor, "proxy_for", "r", "", `for the --call option only, redirects calls to this implementation`)
+	stateCmd.Flags().StringVarP(&statePkg.GetOptions().Storage, "storage", "", "", `read one or more storage slots (or, with --layout, named variables) from a smart contract`)
+	stateCmd.Flags().StringVarP(&statePkg.GetOptions().Layout, "layout", "", "", `for the --storage option only, a solc storage layout JSON file used to locate and decode named variables`)
+	stateCmd.Flags().BoolVarP(&statePkg.GetOptions().Proof, "proof", "", false, `fetch a Merkle proof of each account (and any --storage slots) and check it against the block's state root`)
+	stateCmd.Flags().StringVarP(&statePkg.GetOptions().Root, "root", "", "", `for the --proof option only, a state root from a source you trust against which the proof of a single block is verified`)
 	globals.InitGlobals("state", stateCmd, &statePkg.GetOptions().Globals, capabilities)
 
 	stateCmd.SetUsageTemplate(UsageWithNotes(notesState))
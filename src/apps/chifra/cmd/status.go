@@ -48,7 +48,8 @@ const longStatus = `Purpose:
 const notesStatus = `
 Notes:
   - The some mode includes index, monitors, names, slurps, and abis.
-  - If no mode is supplied, a terse report is generated.`
+  - If no mode is supplied, a terse report is generated.
+  - The RPC endpoints and how each has served requests are reported only by the daemon, whose requests they count.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra status
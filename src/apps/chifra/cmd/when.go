@@ -48,7 +48,9 @@ const notesWhen = `
 Notes:
   - The block list may contain any combination of number, hash, date, special named blocks.
   - Block numbers, timestamps, or dates in the future are estimated with 13 second blocks.
-  - Dates must be formatted in JSON format: YYYY-MM-DD[THH[:MM[:SS]]].`
+  - Dates must be formatted in JSON format: YYYY-MM-DD[THH[:MM[:SS]]].
+  - Periods may name a calendar or time zone after an @ (for example :monthend@ny, :daily@nyclose, or :4h@America/Chicago).
+  - On chains whose timestamp rules allow duplicates (many L2s), --check accepts blocks that share a timestamp.`
 
 func init() {
 	var capabilities caps.Capability // capabilities for chifra when
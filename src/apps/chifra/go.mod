@@ -10,6 +10,7 @@ require (
 	github.com/gocarina/gocsv v0.0.0-20230123225133-763e25b40669
 	github.com/gorilla/mux v1.8.0
 	github.com/gorilla/websocket v1.5.0
+	github.com/graphql-go/graphql v0.8.1
 	github.com/ipfs/go-cid v0.4.1
 	github.com/ipfs/go-ipfs-api v0.6.1
 	github.com/panjf2000/ants/v2 v2.10.0
@@ -20,6 +21,7 @@ require (
 	golang.org/x/crypto v0.25.0
 	golang.org/x/term v0.22.0
 	golang.org/x/time v0.3.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
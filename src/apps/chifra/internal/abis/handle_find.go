@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	ants "github.com/panjf2000/ants/v2"
 
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
@@ -34,6 +35,36 @@ func (opts *AbisOptions) HandleFind(rCtx *output.RenderCtx) error {
 	// TODO: we might want to use utils.IterateOver Map here
 
 	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		// Report every candidate the signature database knows about first. Anything it
+		// does not know about falls through to the brute-force search below.
+		remaining := opts.Find
+		if db, err := abi.LoadSignatureDb(opts.Globals.Chain); err != nil {
+			logger.Warn("signature database not available", "error", err)
+		} else {
+			remaining = make([]string, 0, len(opts.Find))
+			for _, arg := range opts.Find {
+				candidates := db.Lookup(arg)
+				if len(candidates) == 0 {
+					remaining = append(remaining, arg)
+					continue
+				}
+				if len(opts.Calldata) > 0 && strings.HasPrefix(strings.ToLower(opts.Calldata), strings.ToLower(arg)) {
+					for _, ranked := range abi.Rank(candidates, opts.Calldata) {
+						function := ranked.Function
+						modelChan <- &function
+					}
+				} else {
+					for i := range candidates {
+						modelChan <- &candidates[i]
+					}
+				}
+			}
+		}
+		if len(remaining) == 0 {
+			return
+		}
+		scanBar.Wanted = uint64(len(remaining))
+
 		var results []types.Function
 		var wg sync.WaitGroup
 		mutex := sync.Mutex{}
@@ -41,14 +72,14 @@ func (opts *AbisOptions) HandleFind(rCtx *output.RenderCtx) error {
 			defer wg.Done()
 			byts := []byte(testSig.(string))
 			sigBytes := crypto.Keccak256(byts)
-			for _, arg := range opts.Find {
+			for _, arg := range remaining {
 				if !opts.Globals.TestMode {
 					scanBar.Report(os.Stderr, "Scanning", testSig.(string))
 				}
 				str, _ := hex.DecodeString(arg[2:])
 				if bytes.Equal(sigBytes[:len(str)], str) {
 					scanBar.Found++
-					logger.Progress(len(opts.Find) < 2, "Found", scanBar.Found, "of", scanBar.Wanted, arg, testSig)
+					logger.Progress(len(remaining) < 2, "Found", scanBar.Found, "of", scanBar.Wanted, arg, testSig)
 					found := types.Function{
 						Encoding:  arg,
 						Signature: testSig.(string),
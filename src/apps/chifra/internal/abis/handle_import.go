@@ -0,0 +1,33 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package abisPkg
+
+import (
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// HandleImportSigs handles the chifra abis --import_sigs command. It adds the signatures found in
+// a public signature list to the local signature database and reports how many were new.
+func (opts *AbisOptions) HandleImportSigs(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		count, err := abi.ImportSignatureFile(chain, opts.ImportSigs)
+		if err != nil {
+			errorChan <- err
+			return
+		}
+
+		s := types.Count{
+			Count: uint64(count),
+		}
+
+		modelChan <- &s
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
@@ -28,17 +28,19 @@ import (
 
 // AbisOptions provides all command options for the chifra abis command.
 type AbisOptions struct {
-	Addrs    []string              `json:"addrs,omitempty"`    // A list of one or more smart contracts whose ABIs to display
-	Known    bool                  `json:"known,omitempty"`    // Load common 'known' ABIs from cache
-	ProxyFor string                `json:"proxyFor,omitempty"` // Redirects the query to this implementation
-	List     bool                  `json:"list,omitempty"`     // A list of downloaded abi files
-	Count    bool                  `json:"count,omitempty"`    // Show the number of abis downloaded
-	Find     []string              `json:"find,omitempty"`     // Search for function or event declarations given a four- or 32-byte code(s)
-	Hint     []string              `json:"hint,omitempty"`     // For the --find option only, provide hints to speed up the search
-	Encode   string                `json:"encode,omitempty"`   // Generate the 32-byte encoding for a given cannonical function or event signature
-	Globals  globals.GlobalOptions `json:"globals,omitempty"`  // The global options
-	Conn     *rpc.Connection       `json:"conn,omitempty"`     // The connection to the RPC server
-	BadFlag  error                 `json:"badFlag,omitempty"`  // An error flag if needed
+	Addrs      []string              `json:"addrs,omitempty"`      // A list of one or more smart contracts whose ABIs to display
+	Known      bool                  `json:"known,omitempty"`      // Load common 'known' ABIs from cache
+	ProxyFor   string                `json:"proxyFor,omitempty"`   // Redirects the query to this implementation
+	List       bool                  `json:"list,omitempty"`       // A list of downloaded abi files
+	Count      bool                  `json:"count,omitempty"`      // Show the number of abis downloaded
+	Find       []string              `json:"find,omitempty"`       // Search for function or event declarations given a four- or 32-byte code(s)
+	Hint       []string              `json:"hint,omitempty"`       // For the --find option only, provide hints to speed up the search
+	Calldata   string                `json:"calldata,omitempty"`   // For the --find option only, rank the candidates by whether or not they decode this calldata
+	Encode     string                `json:"encode,omitempty"`     // Generate the 32-byte encoding for a given cannonical function or event signature
+	ImportSigs string                `json:"importSigs,omitempty"` // Import a public signature list (text or json) into the local signature database
+	Globals    globals.GlobalOptions `json:"globals,omitempty"`    // The global options
+	Conn       *rpc.Connection       `json:"conn,omitempty"`       // The connection to the RPC server
+	BadFlag    error                 `json:"badFlag,omitempty"`    // An error flag if needed
 	// EXISTING_CODE
 	// EXISTING_CODE
 }
@@ -54,7 +56,9 @@ func (opts *AbisOptions) testLog() {
 	logger.TestLog(opts.Count, "Count: ", opts.Count)
 	logger.TestLog(len(opts.Find) > 0, "Find: ", opts.Find)
 	logger.TestLog(len(opts.Hint) > 0, "Hint: ", opts.Hint)
+	logger.TestLog(len(opts.Calldata) > 0, "Calldata: ", opts.Calldata)
 	logger.TestLog(len(opts.Encode) > 0, "Encode: ", opts.Encode)
+	logger.TestLog(len(opts.ImportSigs) > 0, "ImportSigs: ", opts.ImportSigs)
 	opts.Conn.TestLog(opts.getCaches())
 	opts.Globals.TestLog()
 }
@@ -103,8 +107,12 @@ func AbisFinishParseInternal(w io.Writer, values url.Values) *AbisOptions {
 				s := strings.Split(val, " ") // may contain space separated items
 				opts.Hint = append(opts.Hint, s...)
 			}
+		case "calldata":
+			opts.Calldata = value[0]
 		case "encode":
 			opts.Encode = value[0]
+		case "importSigs":
+			opts.ImportSigs = value[0]
 		default:
 			if !copy.Globals.Caps.HasKey(key) {
 				err := validate.Usage("Invalid key ({0}) in {1} route.", key, "abis")
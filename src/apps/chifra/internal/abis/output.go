@@ -34,7 +34,7 @@ func RunAbis(cmd *cobra.Command, args []string) error {
 // ServeAbis handles the abis command for the API. Returns an error.
 func ServeAbis(w http.ResponseWriter, r *http.Request) error {
 	opts := abisFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("abis", w, &opts.Globals)
@@ -58,6 +58,8 @@ func (opts *AbisOptions) AbisInternal(rCtx *output.RenderCtx) error {
 		err = opts.HandleDecache(rCtx)
 	} else if len(opts.Find) > 0 {
 		err = opts.HandleFind(rCtx)
+	} else if len(opts.ImportSigs) > 0 {
+		err = opts.HandleImportSigs(rCtx)
 	} else if opts.Count {
 		err = opts.HandleCount(rCtx)
 	} else if opts.List {
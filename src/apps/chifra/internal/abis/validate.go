@@ -5,8 +5,11 @@
 package abisPkg
 
 import (
+	"strings"
+
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
 
@@ -41,6 +44,7 @@ func (opts *AbisOptions) validateAbis() error {
 
 	if len(opts.Globals.File) == 0 &&
 		len(opts.Encode) == 0 &&
+		len(opts.ImportSigs) == 0 &&
 		len(opts.Find) == 0 &&
 		!opts.Count &&
 		!opts.List &&
@@ -58,6 +62,27 @@ func (opts *AbisOptions) validateAbis() error {
 		return validate.Usage("The {0} options must be used alone.", "--count and --list")
 	}
 
+	if len(opts.ImportSigs) > 0 {
+		if len(opts.Find) > 0 || len(opts.Encode) > 0 || opts.Count || opts.List {
+			return validate.Usage("The {0} option must be used alone.", "--import_sigs")
+		}
+		if opts.Globals.IsApiMode() {
+			return validate.Usage("The {0} option is not available{1}.", "--import_sigs", " in api mode")
+		}
+		if !file.FileExists(opts.ImportSigs) {
+			return validate.Usage("The {0} option ({1}) must {2}", "import_sigs", opts.ImportSigs, "exist")
+		}
+	}
+
+	if len(opts.Calldata) > 0 {
+		if len(opts.Find) == 0 {
+			return validate.Usage("The {0} option is only available with the {1} option.", "--calldata", "--find")
+		}
+		if len(opts.Calldata) < 10 || len(opts.Calldata)%2 != 0 || !strings.HasPrefix(opts.Calldata, "0x") || !base.IsHex(opts.Calldata) {
+			return validate.Usage("The {0} option ({1}) must be {2}.", "--calldata", opts.Calldata, "hex-encoded call data starting with a four-byte selector")
+		}
+	}
+
 	if len(opts.Find) > 0 && len(opts.Encode) > 0 {
 		return validate.Usage("Please choose only one of {0}.", "--find or --encode")
 	}
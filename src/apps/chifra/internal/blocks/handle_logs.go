@@ -46,6 +46,7 @@ func (opts *BlocksOptions) HandleLogs(rCtx *output.RenderCtx) error {
 			showProgress := opts.Globals.ShowProgress()
 			bar := logger.NewBar(logger.BarOptions{
 				Enabled: showProgress,
+				Tracker: rCtx.Progress,
 				Total:   int64(cnt),
 			})
 
@@ -44,6 +44,7 @@ func (opts *BlocksOptions) HandleTraces(rCtx *output.RenderCtx) error {
 			showProgress := opts.Globals.ShowProgress()
 			bar := logger.NewBar(logger.BarOptions{
 				Enabled: showProgress,
+				Tracker: rCtx.Progress,
 				Total:   int64(cnt),
 			})
 
@@ -41,6 +41,7 @@ func (opts *BlocksOptions) HandleUncles(rCtx *output.RenderCtx) error {
 			showProgress := opts.Globals.ShowProgress()
 			bar := logger.NewBar(logger.BarOptions{
 				Enabled: showProgress,
+				Tracker: rCtx.Progress,
 				Total:   int64(cnt),
 			})
 
@@ -192,7 +192,7 @@ func (opts *ChunksOptions) check(rCtx *output.RenderCtx, blockNums []base.Blknum
 
 	if opts.Deep {
 		deep := types.ReportCheck{Reason: "Deep checks for " + opts.Mode}
-		if err := opts.CheckDeep(cacheManifest, &deep); err != nil {
+		if err := opts.CheckDeep(rCtx, cacheManifest, &deep); err != nil {
 			return err, false
 		}
 		reports = append(reports, deep)
@@ -18,6 +18,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/manifest"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
 	shell "github.com/ipfs/go-ipfs-api"
@@ -33,7 +34,7 @@ type reporter struct {
 // that all addresses in the index return true when checked against its corresponding
 // Bloom filter. In `manifest` mode, it checks that each IPFS hash in the manifest is
 // actually pinned. The later requires a locally running IPFS node.
-func (opts *ChunksOptions) CheckDeep(cacheMan *manifest.Manifest, report *types.ReportCheck) error {
+func (opts *ChunksOptions) CheckDeep(rCtx *output.RenderCtx, cacheMan *manifest.Manifest, report *types.ReportCheck) error {
 	chain := opts.Globals.Chain
 	testMode := opts.Globals.TestMode
 	nErrors := 0
@@ -47,6 +48,7 @@ func (opts *ChunksOptions) CheckDeep(cacheMan *manifest.Manifest, report *types.
 	showProgress := opts.Globals.ShowProgress()
 	bar := logger.NewBar(logger.BarOptions{
 		Enabled: showProgress,
+		Tracker: rCtx.Progress,
 		Total:   int64(len(appMap)),
 	})
 
@@ -123,7 +125,7 @@ func (opts *ChunksOptions) CheckDeep(cacheMan *manifest.Manifest, report *types.
 	}
 
 	iterErrorChan := make(chan error)
-	iterCtx, iterCancel := context.WithCancel(context.Background())
+	iterCtx, iterCancel := context.WithCancel(rCtx.Ctx)
 	defer iterCancel()
 	go utils.IterateOverMap(iterCtx, iterErrorChan, appMap, iterFunc)
 	for err := range iterErrorChan {
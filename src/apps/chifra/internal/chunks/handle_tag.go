@@ -42,6 +42,7 @@ func (opts *ChunksOptions) HandleTag(rCtx *output.RenderCtx, blockNums []base.Bl
 		showProgress := opts.Globals.ShowProgress()
 		bar := logger.NewBar(logger.BarOptions{
 			Enabled: showProgress,
+			Tracker: rCtx.Progress,
 			Total:   int64(len(man.Chunks)),
 		})
 		tagIndex := func(walker *walk.CacheWalker, path string, first bool) (bool, error) {
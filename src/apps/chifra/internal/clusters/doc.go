@@ -0,0 +1,22 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+// clustersPkg implements the chifra clusters command.
+//
+// chifra clusters groups addresses into entities. It reads the appearances of one or more monitored
+// addresses, looks at the transactions they appear in, and applies a set of heuristics to the
+// addresses it finds there: addresses first funded by the same account, deposit addresses that sweep
+// their balance into the same account, contracts deployed by the same account, and contracts created
+// by the same CREATE2 factory.
+//
+// Each cluster is reported with a confidence score. The score is lower when the shared account is
+// busy with many unrelated addresses, such as an exchange's hot wallet or a public factory.
+//
+// When you're happy with the clusters, use --accept to record them as tags in your custom names
+// database so that they show up in chifra names and everywhere names are used.
+package clustersPkg
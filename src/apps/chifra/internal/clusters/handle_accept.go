@@ -0,0 +1,90 @@
+package clustersPkg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// clusterTag is the tag accepted clusters write to the names database. The cluster's id is its subtag.
+const clusterTag = "81-Clusters"
+
+func (opts *ClustersOptions) HandleAccept(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+
+	known, err := names.LoadNamesMap(chain, types.All, nil)
+	if err != nil {
+		return err
+	}
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		clusters, canceled, err := opts.findClusters(rCtx)
+		if err != nil {
+			errorChan <- err
+			return
+		}
+		if canceled {
+			return
+		}
+
+		author, now := names.LocalAuthor(), base.Timestamp(time.Now().Unix())
+		type change struct {
+			prev *types.Name
+			next types.Name
+		}
+		changes := make([]change, 0)
+		for _, c := range clusters {
+			for _, member := range c.Members {
+				prev, ok := known[member]
+				if ok && len(prev.Tags) > 0 && !strings.HasPrefix(prev.Tags, clusterTag) {
+					logger.Info("Keeping the tags of", member.Hex(), "("+prev.Tags+") instead of", c.Id)
+					continue
+				}
+				next := types.Name{
+					Address: member,
+					Name:    "Cluster " + c.Id + " member",
+					Source:  "Clusters",
+				}
+				var prevPtr *types.Name
+				if ok {
+					next = prev
+					prevPtr = &prev
+				}
+				next.Tags = clusterTag + ":" + c.Id
+				names.AddName(names.DatabaseCustom, &next)
+				known[member] = next
+				changes = append(changes, change{prev: prevPtr, next: next})
+			}
+		}
+
+		if len(changes) > 0 {
+			if err := names.CustomWriteNames(chain, false); err != nil {
+				errorChan <- err
+				return
+			}
+			for _, ch := range changes {
+				ch := ch
+				prov := names.Provenance{Source: "Clusters", Author: author, Timestamp: now}
+				if err := names.StampProvenance(chain, ch.prev, &ch.next, prov); err != nil {
+					errorChan <- err
+					return
+				}
+			}
+		}
+		logger.Info(fmt.Sprintf("Tagged %d addresses in %d clusters", len(changes), len(clusters)))
+
+		for _, c := range clusters {
+			c := c
+			c.Accepted = true
+			modelChan <- &c
+		}
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
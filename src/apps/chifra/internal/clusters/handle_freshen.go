@@ -0,0 +1,8 @@
+package clustersPkg
+
+import "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+
+func (opts *ClustersOptions) FreshenMonitorsForClusters(monitorArray *[]monitor.Monitor) (bool, error) {
+	var updater = monitor.NewUpdater(opts.Globals.Chain, opts.Globals.TestMode, false /* skipFreshen */, opts.Addrs)
+	return updater.FreshenMonitors(monitorArray)
+}
@@ -0,0 +1,211 @@
+package clustersPkg
+
+import (
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/cluster"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// findClusters freshens the monitors, gathers observations from their appearances and
+// returns the clusters the requested heuristics find in them
+func (opts *ClustersOptions) findClusters(rCtx *output.RenderCtx) ([]types.Cluster, bool, error) {
+	monitorArray := make([]monitor.Monitor, 0, len(opts.Addrs))
+	if canceled, err := opts.FreshenMonitorsForClusters(&monitorArray); err != nil || canceled {
+		return nil, canceled, err
+	}
+
+	heuristics := opts.heuristics()
+	g := gatherer{
+		conn: opts.Conn,
+		obs:  &cluster.Observations{},
+	}
+	for _, h := range heuristics {
+		switch h {
+		case cluster.Funder:
+			g.funders = true
+		case cluster.Sweep:
+			g.sweeps = true
+		case cluster.Deployer, cluster.Create2:
+			g.creations = true
+		}
+	}
+	if err, ok := opts.Conn.IsNodeTracing(); !ok {
+		logger.Warn("The node is not tracing, internal transfers and factory creations will be missed:", err)
+	} else {
+		g.traces = true
+	}
+
+	for _, mon := range monitorArray {
+		apps, cnt, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), false)
+		mon.Close()
+		if err != nil {
+			return nil, false, err
+		}
+
+		bar := logger.NewBar(logger.BarOptions{
+			Enabled: opts.Globals.ShowProgress(),
+			Tracker: rCtx.Progress,
+			Total:   int64(cnt),
+			Prefix:  mon.Address.Hex(),
+		})
+		funded := false
+		for _, app := range apps {
+			if rCtx.WasCanceled() {
+				return nil, true, nil
+			}
+			bar.Tick()
+			app := app
+			tx, err := opts.Conn.GetTransactionByAppearance(&app, g.traces)
+			if err != nil {
+				return nil, false, err
+			}
+			if !funded {
+				funded = g.addFunding(mon.Address, tx)
+			}
+			if err := g.addOutgoing(mon.Address, tx); err != nil {
+				return nil, false, err
+			}
+			g.addCreations(tx)
+		}
+		bar.Finish(true /* newLine */)
+	}
+
+	return cluster.Find(g.obs, heuristics, opts.MinConfidence), false, nil
+}
+
+// heuristics returns the requested heuristics with 'all' expanded
+func (opts *ClustersOptions) heuristics() []cluster.Heuristic {
+	seen := make(map[cluster.Heuristic]bool)
+	ret := make([]cluster.Heuristic, 0, len(cluster.AllHeuristics))
+	for _, h := range opts.Heuristics {
+		list := []cluster.Heuristic{cluster.Heuristic(h)}
+		if h == "all" {
+			list = cluster.AllHeuristics
+		}
+		for _, l := range list {
+			if !seen[l] {
+				seen[l] = true
+				ret = append(ret, l)
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return cluster.AllHeuristics
+	}
+	return ret
+}
+
+// gatherer records what the heuristics need to know about the transactions of the monitors
+type gatherer struct {
+	conn      *rpc.Connection
+	obs       *cluster.Observations
+	traces    bool
+	funders   bool
+	sweeps    bool
+	creations bool
+}
+
+// addFunding records the funder of a monitored address if the transaction is the first to
+// send it value. It returns true once the address has been funded (by anyone).
+func (g *gatherer) addFunding(addr base.Address, tx *types.Transaction) bool {
+	if tx.To == addr && tx.From != addr && !tx.Value.IsZero() {
+		if !tx.From.IsZero() && g.funders {
+			g.obs.AddFunding(cluster.Funding{Funder: tx.From, Funded: addr, BlockNumber: tx.BlockNumber})
+		}
+		return true
+	}
+	for _, trace := range tx.Traces {
+		if trace.Action == nil || trace.Action.To != addr || trace.Action.From == addr || trace.Action.Value.IsZero() {
+			continue
+		}
+		if g.funders {
+			g.obs.AddFunding(cluster.Funding{Funder: trace.Action.From, Funded: addr, BlockNumber: tx.BlockNumber})
+		}
+		return true
+	}
+	return false
+}
+
+// addOutgoing records the addresses a monitored address funded for the first time and the
+// transfers that (nearly) emptied it
+func (g *gatherer) addOutgoing(addr base.Address, tx *types.Transaction) error {
+	if tx.From != addr || tx.To.IsZero() || tx.To == addr || tx.Value.IsZero() {
+		return nil
+	}
+
+	if g.funders && tx.BlockNumber > 0 {
+		state, err := g.conn.GetState(types.Balance|types.Nonce, tx.To, tx.BlockNumber-1, rpc.StateFilters{})
+		if err != nil {
+			return err
+		}
+		if state.Balance.IsZero() && state.Nonce == 0 {
+			g.obs.AddFunding(cluster.Funding{Funder: addr, Funded: tx.To, BlockNumber: tx.BlockNumber})
+		}
+	}
+
+	if g.sweeps {
+		// A sweep leaves less than one percent of what it sent behind
+		remaining, err := g.conn.GetBalanceAt(addr, tx.BlockNumber)
+		if err != nil {
+			return err
+		}
+		hundred := base.NewWei(100)
+		if remaining.Mul(remaining, hundred).Cmp(&tx.Value) <= 0 {
+			g.obs.Sweeps = append(g.obs.Sweeps, cluster.Transfer{From: addr, To: tx.To, BlockNumber: tx.BlockNumber})
+		}
+	}
+
+	return nil
+}
+
+// addCreations records the contracts the transaction created
+func (g *gatherer) addCreations(tx *types.Transaction) {
+	if !g.creations {
+		return
+	}
+
+	if tx.Receipt != nil && !tx.Receipt.ContractAddress.IsZero() {
+		g.obs.AddCreation(cluster.Creation{Contract: tx.Receipt.ContractAddress, Deployer: tx.From, BlockNumber: tx.BlockNumber})
+	}
+
+	for _, trace := range tx.Traces {
+		if trace.TraceType != "create" || trace.Action == nil || trace.Result == nil || trace.Result.Address.IsZero() {
+			continue
+		}
+		c := cluster.Creation{Contract: trace.Result.Address, Deployer: tx.From, BlockNumber: tx.BlockNumber}
+		if len(trace.TraceAddress) > 0 {
+			c.Factory = trace.Action.From
+			c.Create2 = g.isCreate2(c.Factory, c.Contract, tx.BlockNumber)
+		}
+		g.obs.AddCreation(c)
+	}
+}
+
+// isCreate2 returns true if the factory didn't create the contract with CREATE. A CREATE
+// address depends only on the factory's nonce, so if none of the nonces the factory used in
+// the block produce the contract's address, it came from CREATE2.
+func (g *gatherer) isCreate2(factory, contract base.Address, bn base.Blknum) bool {
+	if bn == 0 {
+		return false
+	}
+	before, err := g.conn.GetState(types.Nonce, factory, bn-1, rpc.StateFilters{})
+	if err != nil {
+		return false
+	}
+	after, err := g.conn.GetState(types.Nonce, factory, bn, rpc.StateFilters{})
+	if err != nil {
+		return false
+	}
+	for n := before.Nonce; n < after.Nonce; n++ {
+		if crypto.CreateAddress(factory.Common(), uint64(n)) == contract.Common() {
+			return false
+		}
+	}
+	return after.Nonce > before.Nonce
+}
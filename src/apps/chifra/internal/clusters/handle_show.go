@@ -0,0 +1,26 @@
+package clustersPkg
+
+import (
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func (opts *ClustersOptions) HandleShow(rCtx *output.RenderCtx) error {
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		clusters, canceled, err := opts.findClusters(rCtx)
+		if err != nil {
+			errorChan <- err
+			return
+		}
+		if canceled {
+			return
+		}
+
+		for _, c := range clusters {
+			c := c
+			modelChan <- &c
+		}
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
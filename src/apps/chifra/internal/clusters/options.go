@@ -0,0 +1,173 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package clustersPkg
+
+import (
+	// EXISTING_CODE
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/globals"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/caps"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/walk"
+	// EXISTING_CODE
+)
+
+// ClustersOptions provides all command options for the chifra clusters command.
+type ClustersOptions struct {
+	Addrs         []string              `json:"addrs,omitempty"`         // One or more monitored addresses whose appearances and neighbors to cluster
+	Heuristics    []string              `json:"heuristics,omitempty"`    // The heuristics used to group addresses
+	MinConfidence float64               `json:"minConfidence,omitempty"` // Report only clusters with at least this confidence (between 0.0 and 1.0)
+	Accept        bool                  `json:"accept,omitempty"`        // Write the reported clusters to the custom names database as tags
+	Globals       globals.GlobalOptions `json:"globals,omitempty"`       // The global options
+	Conn          *rpc.Connection       `json:"conn,omitempty"`          // The connection to the RPC server
+	BadFlag       error                 `json:"badFlag,omitempty"`       // An error flag if needed
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+var defaultClustersOptions = ClustersOptions{
+	MinConfidence: 0.5,
+}
+
+// testLog is used only during testing to export the options for this test case.
+func (opts *ClustersOptions) testLog() {
+	logger.TestLog(len(opts.Addrs) > 0, "Addrs: ", opts.Addrs)
+	logger.TestLog(len(opts.Heuristics) > 0, "Heuristics: ", opts.Heuristics)
+	logger.TestLog(opts.MinConfidence != float64(0.5), "MinConfidence: ", opts.MinConfidence)
+	logger.TestLog(opts.Accept, "Accept: ", opts.Accept)
+	opts.Conn.TestLog(opts.getCaches())
+	opts.Globals.TestLog()
+}
+
+// String implements the Stringer interface
+func (opts *ClustersOptions) String() string {
+	b, _ := json.MarshalIndent(opts, "", "  ")
+	return string(b)
+}
+
+// clustersFinishParseApi finishes the parsing for server invocations. Returns a new ClustersOptions.
+func clustersFinishParseApi(w http.ResponseWriter, r *http.Request) *ClustersOptions {
+	values := r.URL.Query()
+	if r.Header.Get("User-Agent") == "testRunner" {
+		values.Set("testRunner", "true")
+	}
+	return ClustersFinishParseInternal(w, values)
+}
+
+func ClustersFinishParseInternal(w io.Writer, values url.Values) *ClustersOptions {
+	copy := defaultClustersOptions
+	copy.Globals.Caps = getCaps()
+	opts := &copy
+	opts.MinConfidence = 0.5
+	for key, value := range values {
+		switch key {
+		case "addrs":
+			for _, val := range value {
+				s := strings.Split(val, " ") // may contain space separated items
+				opts.Addrs = append(opts.Addrs, s...)
+			}
+		case "heuristics":
+			for _, val := range value {
+				s := strings.Split(val, " ") // may contain space separated items
+				opts.Heuristics = append(opts.Heuristics, s...)
+			}
+		case "minConfidence":
+			opts.MinConfidence = base.MustParseFloat64(value[0])
+		case "accept":
+			opts.Accept = true
+		default:
+			if !copy.Globals.Caps.HasKey(key) {
+				err := validate.Usage("Invalid key ({0}) in {1} route.", key, "clusters")
+				if opts.BadFlag == nil || opts.BadFlag.Error() > err.Error() {
+					opts.BadFlag = err
+				}
+			}
+		}
+	}
+	opts.Conn = opts.Globals.FinishParseApi(w, values, opts.getCaches())
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+	opts.Addrs, _ = opts.Conn.GetEnsAddresses(opts.Addrs)
+
+	return opts
+}
+
+// clustersFinishParse finishes the parsing for command line invocations. Returns a new ClustersOptions.
+func clustersFinishParse(args []string) *ClustersOptions {
+	// remove duplicates from args if any (not needed in api mode because the server does it).
+	dedup := map[string]int{}
+	if len(args) > 0 {
+		tmp := []string{}
+		for _, arg := range args {
+			if value := dedup[arg]; value == 0 {
+				tmp = append(tmp, arg)
+			}
+			dedup[arg]++
+		}
+		args = tmp
+	}
+
+	defFmt := "txt"
+	opts := GetOptions()
+	opts.Conn = opts.Globals.FinishParse(args, opts.getCaches())
+
+	// EXISTING_CODE
+	// EXISTING_CODE
+	opts.Addrs, _ = opts.Conn.GetEnsAddresses(opts.Addrs)
+	if len(opts.Globals.Format) == 0 || opts.Globals.Format == "none" {
+		opts.Globals.Format = defFmt
+	}
+
+	return opts
+}
+
+func GetOptions() *ClustersOptions {
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return &defaultClustersOptions
+}
+
+func getCaps() caps.Capability {
+	var capabilities caps.Capability // capabilities for chifra clusters
+	capabilities = capabilities.Add(caps.Default)
+	capabilities = capabilities.Add(caps.Names)
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return capabilities
+}
+
+func ResetOptions(testMode bool) {
+	// We want to keep writer between command file calls
+	w := GetOptions().Globals.Writer
+	opts := ClustersOptions{}
+	globals.SetDefaults(&opts.Globals)
+	opts.Globals.TestMode = testMode
+	opts.Globals.Writer = w
+	opts.Globals.Caps = getCaps()
+	opts.MinConfidence = 0.5
+	defaultClustersOptions = opts
+}
+
+func (opts *ClustersOptions) getCaches() (caches map[walk.CacheType]bool) {
+	// EXISTING_CODE
+	// EXISTING_CODE
+	return
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -0,0 +1,74 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package clustersPkg
+
+// EXISTING_CODE
+import (
+	"net/http"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/globals"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/spf13/cobra"
+)
+
+// EXISTING_CODE
+
+// RunClusters handles the clusters command for the command line. Returns error only as per cobra.
+func RunClusters(cmd *cobra.Command, args []string) error {
+	opts := clustersFinishParse(args)
+	rCtx := output.NewRenderContext()
+	// EXISTING_CODE
+	// EXISTING_CODE
+	outputHelpers.SetWriterForCommand("clusters", &opts.Globals)
+	return opts.ClustersInternal(rCtx)
+}
+
+// ServeClusters handles the clusters command for the API. Returns an error.
+func ServeClusters(w http.ResponseWriter, r *http.Request) error {
+	opts := clustersFinishParseApi(w, r)
+	rCtx := output.RenderContextFrom(r.Context())
+	// EXISTING_CODE
+	// EXISTING_CODE
+	outputHelpers.InitJsonWriterApi("clusters", w, &opts.Globals)
+	err := opts.ClustersInternal(rCtx)
+	outputHelpers.CloseJsonWriterIfNeededApi("clusters", err, &opts.Globals)
+	return err
+}
+
+// ClustersInternal handles the internal workings of the clusters command. Returns an error.
+func (opts *ClustersOptions) ClustersInternal(rCtx *output.RenderCtx) error {
+	var err error
+	if err = opts.validateClusters(); err != nil {
+		return err
+	}
+
+	timer := logger.NewTimer()
+	msg := "chifra clusters"
+	// EXISTING_CODE
+	// EXISTING_CODE
+	if opts.Accept {
+		err = opts.HandleAccept(rCtx)
+	} else {
+		err = opts.HandleShow(rCtx)
+	}
+	timer.Report(msg)
+
+	return err
+}
+
+// GetClustersOptions returns the options for this tool so other tools may use it.
+func GetClustersOptions(args []string, g *globals.GlobalOptions) *ClustersOptions {
+	ret := clustersFinishParse(args)
+	if g != nil {
+		ret.Globals = *g
+	}
+	return ret
+}
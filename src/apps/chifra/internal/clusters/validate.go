@@ -0,0 +1,51 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package clustersPkg
+
+import (
+	"errors"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
+)
+
+func (opts *ClustersOptions) validateClusters() error {
+	chain := opts.Globals.Chain
+
+	opts.testLog()
+
+	if opts.BadFlag != nil {
+		return opts.BadFlag
+	}
+
+	if !config.IsChainConfigured(chain) {
+		return validate.Usage("chain {0} is not properly configured.", chain)
+	}
+
+	if err := validate.ValidateEnumSlice("--heuristics", opts.Heuristics, "[funder|sweep|deployer|create2|all]"); err != nil {
+		return err
+	}
+
+	if opts.MinConfidence < 0 || opts.MinConfidence > 1 {
+		return validate.Usage("The {0} option must be between {1}.", "--min_confidence", "0.0 and 1.0")
+	}
+
+	if len(opts.Globals.File) == 0 {
+		if err := validate.ValidateAtLeastOneAddr(opts.Addrs); err != nil {
+			return err
+		}
+	}
+
+	if err := index.IsInitialized(chain, config.ExpectedVersion()); err != nil {
+		if (errors.Is(err, index.ErrNotInitialized) || errors.Is(err, index.ErrIncorrectHash)) && !opts.Globals.IsApiMode() {
+			logger.Fatal(err)
+		}
+		return err
+	}
+
+	return opts.Globals.Validate()
+}
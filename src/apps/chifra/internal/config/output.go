@@ -34,7 +34,7 @@ func RunConfig(cmd *cobra.Command, args []string) error {
 // ServeConfig handles the config command for the API. Returns an error.
 func ServeConfig(w http.ResponseWriter, r *http.Request) error {
 	opts := configFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("config", w, &opts.Globals)
@@ -0,0 +1,188 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/gorilla/websocket"
+)
+
+// The scopes an API key may carry. A key with any scope may make read-only requests.
+const (
+	ScopeReadOnly      = "read-only"
+	ScopeNamesWrite    = "names-write"
+	ScopeMonitorsAdmin = "monitors-admin"
+	ScopeChunksAdmin   = "chunks-admin"
+)
+
+// isMutating returns true if the request changes local data. Every request other than a GET
+// does, as do GET requests with one of the route's mutating parameters.
+func isMutating(route Route, r *http.Request) bool {
+	// GraphQL clients post their queries, which only read
+	isQuery := r.Method == http.MethodPost && route.Pattern == "/graphql"
+	if (r.Method != http.MethodGet && !isQuery) || route.Pattern == "/init" {
+		return true
+	}
+	params := r.URL.Query()
+	for _, param := range route.Mutating {
+		if params.Has(param) && params.Get(param) != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredScope returns the scope a key needs to make the request. Mutating requests need the
+// route's scope. Mutating requests to read-only routes (for example, --decache) need chunks-admin,
+// which administers the index and the caches.
+func requiredScope(route Route, r *http.Request) string {
+	if !isMutating(route, r) {
+		return ScopeReadOnly
+	}
+	if route.Scope == "" || route.Scope == ScopeReadOnly {
+		return ScopeChunksAdmin
+	}
+	return route.Scope
+}
+
+// requestKey returns the API key sent with the request either as a bearer token, in the
+// X-API-Key header, or (when opening a websocket) as a subprotocol
+func requestKey(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	if key := r.Header.Get("X-API-Key"); len(key) > 0 {
+		return key
+	}
+	for _, protocol := range websocket.Subprotocols(r) {
+		if key, ok := strings.CutPrefix(protocol, keyProtocol); ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// findKey returns the configured key matching the one sent with the request
+func findKey(keys []configtypes.ApiKeyGroup, sent string) (configtypes.ApiKeyGroup, bool) {
+	found, ok := configtypes.ApiKeyGroup{}, false
+	if len(sent) == 0 {
+		return found, ok
+	}
+	// compare against every key so the time taken doesn't depend on which key matched
+	for _, key := range keys {
+		if len(key.Key) > 0 && subtle.ConstantTimeCompare([]byte(key.Key), []byte(sent)) == 1 {
+			found, ok = key, true
+		}
+	}
+	return found, ok
+}
+
+// hasScope returns true if the key may make a request needing the scope
+func hasScope(key configtypes.ApiKeyGroup, scope string) bool {
+	if scope == ScopeReadOnly {
+		return len(key.Scopes) > 0
+	}
+	for _, s := range key.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize checks the request's API key against the keys in the configuration file and logs
+// mutating requests to the audit log. If no keys are configured, the server is open.
+func Authorize(route Route, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyName := ""
+		if isMutating(route, r) {
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			w = rec
+			defer func() {
+				writeAudit(auditEntry{
+					Time:   time.Now().UTC().Format(time.RFC3339),
+					Key:    keyName,
+					Remote: r.RemoteAddr,
+					Method: r.Method,
+					Uri:    r.RequestURI,
+					Route:  route.Name,
+					Status: rec.status,
+				})
+			}()
+		}
+
+		keys := config.GetDaemon().ApiKeys
+		if len(keys) > 0 {
+			key, ok := findKey(keys, requestKey(r))
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="chifra"`)
+				RespondWithError(w, http.StatusUnauthorized, errors.New("a valid API key is required"))
+				return
+			}
+			keyName = key.Name
+			if scope := requiredScope(route, r); !hasScope(key, scope) {
+				RespondWithError(w, http.StatusForbidden, errors.New("the API key does not have the "+scope+" scope"))
+				return
+			}
+		}
+
+		inner.ServeHTTP(w, r)
+	})
+}
+
+// statusRecorder remembers the status written to the response for the audit log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// auditEntry is one line of the audit log. The key itself is never logged, only its name.
+type auditEntry struct {
+	Time   string `json:"time"`
+	Key    string `json:"key,omitempty"`
+	Remote string `json:"remote"`
+	Method string `json:"method"`
+	Uri    string `json:"uri"`
+	Route  string `json:"route"`
+	Status int    `json:"status"`
+}
+
+var auditMutex sync.Mutex
+
+func writeAudit(entry auditEntry) {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+
+	line, _ := json.Marshal(entry)
+	f, err := os.OpenFile(config.PathToAuditLog(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		logger.Warn("Could not write to the audit log:", err)
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
@@ -0,0 +1,136 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+)
+
+// setDaemonConfig replaces the daemon's settings for the duration of the test
+func setDaemonConfig(t *testing.T, daemon configtypes.DaemonGroup) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	cfg := config.GetRootConfig()
+	saved := cfg.Daemon
+	cfg.Daemon = daemon
+	t.Cleanup(func() { cfg.Daemon = saved })
+}
+
+func TestAuthorize(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	setDaemonConfig(t, configtypes.DaemonGroup{
+		ApiKeys: []configtypes.ApiKeyGroup{
+			{Name: "reader", Key: "r-key", Scopes: []string{ScopeReadOnly}},
+			{Name: "namer", Key: "n-key", Scopes: []string{ScopeNamesWrite}},
+		},
+		AuditLog: auditLog,
+	})
+
+	names := Route{Name: "CreateName", Method: "POST", Pattern: "/names", Scope: ScopeNamesWrite}
+	blocks, clusters, when := routeNamed(t, "RouteBlocks"), routeNamed(t, "RouteClusters"), routeNamed(t, "RouteWhen")
+	graphql := Route{Name: "GraphQLPost", Method: "POST", Pattern: "/graphql", Scope: ScopeReadOnly}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		route  Route
+		method string
+		uri    string
+		header string
+		value  string
+		status int
+	}{
+		{blocks, "GET", "/blocks?blocks=1", "", "", http.StatusUnauthorized},
+		{blocks, "GET", "/blocks?blocks=1", "Authorization", "Bearer wrong", http.StatusUnauthorized},
+		{blocks, "GET", "/blocks?blocks=1", "Authorization", "Bearer r-key", http.StatusOK},
+		{blocks, "GET", "/blocks?blocks=1", "X-API-Key", "n-key", http.StatusOK},
+		{blocks, "GET", "/blocks?blocks=1&decache", "X-API-Key", "n-key", http.StatusForbidden},
+		{clusters, "GET", "/clusters?addrs=0x1&accept", "X-API-Key", "n-key", http.StatusForbidden},
+		{when, "GET", "/when?timestamps&repair", "X-API-Key", "r-key", http.StatusForbidden},
+		{when, "GET", "/when?timestamps&repair=false", "X-API-Key", "r-key", http.StatusOK},
+		{names, "POST", "/names?create", "X-API-Key", "r-key", http.StatusForbidden},
+		{names, "POST", "/names?create", "Authorization", "Bearer n-key", http.StatusOK},
+		{graphql, "POST", "/graphql", "X-API-Key", "r-key", http.StatusOK},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, test.uri, nil)
+		if test.header != "" {
+			r.Header.Set(test.header, test.value)
+		}
+		w := httptest.NewRecorder()
+		Authorize(test.route, ok).ServeHTTP(w, r)
+		if w.Code != test.status {
+			t.Error("Expected", test.status, "for", test.method, test.uri, test.value, "got", w.Code)
+		}
+	}
+
+	// only the five mutating requests are audited
+	contents, err := os.ReadFile(auditLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	if len(lines) != 5 {
+		t.Fatal("Expected five audit entries, got", lines)
+	}
+	var entry auditEntry
+	if err := json.Unmarshal([]byte(lines[4]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Key != "namer" || entry.Route != "CreateName" || entry.Status != http.StatusOK || strings.Contains(lines[4], "n-key") {
+		t.Error("Unexpected audit entry", lines[4])
+	}
+}
+
+// routeNamed returns one of the daemon's routes
+func routeNamed(t *testing.T, name string) Route {
+	for _, route := range routes {
+		if route.Name == name {
+			return route
+		}
+	}
+	t.Fatal("No route named", name)
+	return Route{}
+}
+
+func TestAuthorizeOpen(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+
+	route := Route{Name: "DeleteMonitors", Method: "DELETE", Pattern: "/monitors", Scope: ScopeMonitorsAdmin}
+	w := httptest.NewRecorder()
+	Authorize(route, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, httptest.NewRequest("DELETE", "/monitors?delete", nil))
+	if w.Code != http.StatusOK {
+		t.Error("Expected the server to be open when no keys are configured, got", w.Code)
+	}
+}
+
+func TestCorsOrigins(t *testing.T) {
+	tests := []struct {
+		origins []string
+		origin  string
+		allowed string
+	}{
+		{nil, "https://a.example", "*"},
+		{[]string{"https://a.example"}, "https://a.example", "https://a.example"},
+		{[]string{"https://a.example"}, "https://b.example", ""},
+	}
+	for _, test := range tests {
+		setDaemonConfig(t, configtypes.DaemonGroup{CorsOrigins: test.origins})
+		r := httptest.NewRequest("OPTIONS", "/blocks", nil)
+		r.Header.Set("Origin", test.origin)
+		w := httptest.NewRecorder()
+		OptionsHandler.ServeHTTP(w, r)
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != test.allowed {
+			t.Error("Expected", test.allowed, "for", test.origin, "got", got)
+		}
+	}
+}
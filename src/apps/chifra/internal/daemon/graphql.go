@@ -0,0 +1,695 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/ledger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/walk"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// The GraphQL schema has an object type for each of the data models (described by graphqlModels,
+// which is generated from the same definitions as the models into graphql_schema.go). The types'
+// fields are resolved from the models' JSON output and their Go structs. To these, the daemon
+// adds the relationships the models don't carry themselves (an appearance's transaction and
+// statements, for example) and the root fields from which queries start (blocks, transactions,
+// and accounts). Queries are parsed, validated, and executed by github.com/graphql-go/graphql.
+
+// graphqlModel describes the object type of one of the data models
+type graphqlModel struct {
+	Name        string
+	Description string
+	Fields      []graphqlField
+}
+
+// graphqlField describes one of a model's fields. Its type is the name of another model or one
+// of the graphqlScalars.
+type graphqlField struct {
+	Name        string
+	Type        string
+	List        bool
+	Description string
+}
+
+// graphqlMaxDepth is how deeply a query's fields may nest
+const graphqlMaxDepth = 10
+
+// graphqlLimits are the most blocks, transactions (including receipts), and appearances a single
+// query may read. Queries needing more are refused rather than left to hold the daemon.
+var graphqlLimits = map[string]int{
+	"blocks":       1000,
+	"transactions": 10000,
+	"appearances":  10000,
+}
+
+// graphqlQuery is the state of one GraphQL request: its chain, the connection its resolvers use
+// to read the chain (and the cache), the accounts it has read, and how much it has read.
+type graphqlQuery struct {
+	chain    string
+	conn     *rpc.Connection
+	models   map[types.Modeler]map[string]any
+	accounts map[base.Address]*graphqlAccount
+	counts   map[string]int
+}
+
+// graphqlAccount is an address whose appearances a query has read. Its ledger, which produces
+// the statements of its appearances, is made the first time it is needed.
+type graphqlAccount struct {
+	Address base.Address
+	apps    []types.Appearance
+	ledger  *ledger.Ledger
+}
+
+type graphqlQueryKey struct{}
+
+func newGraphqlQuery(chain string, cache bool) *graphqlQuery {
+	caches := map[walk.CacheType]bool{
+		walk.Cache_Blocks:       true,
+		walk.Cache_Transactions: true,
+		walk.Cache_Receipts:     true,
+	}
+	return &graphqlQuery{
+		chain:    chain,
+		conn:     rpc.NewConnection(chain, cache, caches),
+		models:   make(map[types.Modeler]map[string]any),
+		accounts: make(map[base.Address]*graphqlAccount),
+		counts:   make(map[string]int),
+	}
+}
+
+func graphqlQueryFrom(ctx context.Context) *graphqlQuery {
+	return ctx.Value(graphqlQueryKey{}).(*graphqlQuery)
+}
+
+// read counts n more of what the query reads (one of the graphqlLimits) and fails once the
+// query has read more than the limit
+func (q *graphqlQuery) read(what string, n int) error {
+	q.counts[what] += n
+	if limit := graphqlLimits[what]; q.counts[what] > limit {
+		return fmt.Errorf("the query reads more than %d %s, the most a query may read", limit, what)
+	}
+	return nil
+}
+
+var (
+	graphqlOnce   sync.Once
+	graphqlSchema graphql.Schema
+)
+
+// getGraphqlSchema returns the schema, which is built the first time it is needed
+func getGraphqlSchema() *graphql.Schema {
+	graphqlOnce.Do(func() {
+		objects := graphqlObjects()
+		query := graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"blocks": {
+					Type:        graphql.NewList(objects["Block"]),
+					Description: "the blocks with the given identifiers (block numbers, ranges such as 18000000-18100000, hashes, or special names)",
+					Args:        graphql.FieldConfigArgument{"ids": {Type: graphql.NewNonNull(graphql.NewList(graphql.String))}},
+					Resolve:     resolveBlocks,
+				},
+				"transactions": {
+					Type:        graphql.NewList(objects["Transaction"]),
+					Description: "the transactions with the given identifiers (hashes, blockNumber.transactionIndex, or blockHash.transactionIndex)",
+					Args:        graphql.FieldConfigArgument{"ids": {Type: graphql.NewNonNull(graphql.NewList(graphql.String))}},
+					Resolve:     resolveTransactions,
+				},
+				"account": {
+					Type:        objects["Account"],
+					Description: "the account with the given address",
+					Args:        graphql.FieldConfigArgument{"address": {Type: graphql.NewNonNull(graphql.String)}},
+					Resolve:     resolveAccount,
+				},
+			},
+		})
+
+		var err error
+		if graphqlSchema, err = graphql.NewSchema(graphql.SchemaConfig{Query: query}); err != nil {
+			// the schema is built from the models' definitions, so this is a bug
+			panic(err)
+		}
+	})
+	return &graphqlSchema
+}
+
+// graphqlObjects returns an object type for each of the models, with the relationships the
+// daemon adds, and the Account type
+func graphqlObjects() map[string]*graphql.Object {
+	objects := make(map[string]*graphql.Object)
+	models := make(map[string]graphqlModel)
+
+	// the fields are made once every object exists, so the objects may refer to each other
+	var relations map[string]graphql.Fields
+	for _, model := range graphqlModels() {
+		model := model
+		models[model.Name] = model
+		objects[model.Name] = graphql.NewObject(graphql.ObjectConfig{
+			Name:        model.Name,
+			Description: model.Description,
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				fields := graphql.Fields{}
+				for _, f := range model.Fields {
+					fields[f.Name] = modelField(objects, models, f)
+				}
+				for name, field := range relations[model.Name] {
+					fields[name] = field
+				}
+				return fields
+			}),
+		})
+	}
+
+	relations = map[string]graphql.Fields{
+		"Transaction": {
+			"receipt": {
+				Type:        objects["Receipt"],
+				Description: "the transaction's receipt, which holds its logs",
+				Resolve:     resolveReceipt,
+			},
+		},
+		"Appearance": {
+			"transaction": {
+				Type:        objects["Transaction"],
+				Description: "the transaction in which the address appears",
+				Resolve:     resolveAppearanceTransaction,
+			},
+			"statements": {
+				Type:        graphql.NewList(objects["Statement"]),
+				Description: "the reconciliations of the address's assets in the transaction (only for the appearances of an account)",
+				Resolve:     resolveStatements,
+			},
+		},
+	}
+
+	objects["Account"] = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Account",
+		Description: "an address and its history",
+		Fields: graphql.Fields{
+			"address": {
+				Type:        graphql.String,
+				Description: "the address of the account",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(*graphqlAccount).Address.Hex(), nil
+				},
+			},
+			"appearances": {
+				Type:        graphql.NewList(objects["Appearance"]),
+				Description: "the appearances of the address in its monitor, which must exist (chifra list or chifra export creates it)",
+				Args: graphql.FieldConfigArgument{
+					"firstBlock": {Type: graphqlInt64, Description: "the first block of the appearances"},
+					"lastBlock":  {Type: graphqlInt64, Description: "the last block of the appearances"},
+					"first":      {Type: graphql.Int, Description: "return at most this many appearances"},
+				},
+				Resolve: resolveAppearances,
+			},
+		},
+	})
+
+	return objects
+}
+
+// modelField returns one of a model's fields. Lists of models may be filtered (see filterList).
+func modelField(objects map[string]*graphql.Object, models map[string]graphqlModel, f graphqlField) *graphql.Field {
+	name := f.Name
+	obj, isObject := objects[f.Type]
+	var typ graphql.Output = graphqlScalars[f.Type]
+	if isObject {
+		typ = obj
+	}
+
+	field := &graphql.Field{
+		Type:        typ,
+		Description: f.Description,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return modelValue(p.Context, p.Source, name, isObject), nil
+		},
+	}
+	if f.List {
+		field.Type = graphql.NewList(typ)
+		if isObject {
+			filterList(field, models[f.Type], objects)
+		}
+	}
+	return field
+}
+
+// filterList lets a list of models be filtered by any of the item type's scalar fields (the items
+// whose field equals the argument are kept) and limited to the first n items
+func filterList(field *graphql.Field, item graphqlModel, objects map[string]*graphql.Object) {
+	field.Args = graphql.FieldConfigArgument{}
+	for _, f := range item.Fields {
+		if _, isObject := objects[f.Type]; !f.List && !isObject {
+			field.Args[f.Name] = &graphql.ArgumentConfig{
+				Type:        graphqlScalars[f.Type],
+				Description: "keep the items whose " + f.Name + " is this value",
+			}
+		}
+	}
+	if _, ok := field.Args["first"]; !ok {
+		field.Args["first"] = &graphql.ArgumentConfig{Type: graphql.Int, Description: "keep at most this many items"}
+	}
+
+	resolve := field.Resolve
+	field.Resolve = func(p graphql.ResolveParams) (any, error) {
+		value, err := resolve(p)
+		rv := reflect.ValueOf(value)
+		if err != nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+			return value, err
+		}
+
+		first := -1
+		if n, ok := p.Args["first"].(int); ok && item.fieldType("first") == "" {
+			if n < 0 {
+				return nil, errors.New("argument \"first\" may not be negative")
+			}
+			first = n
+		}
+
+		ret := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len() && (first < 0 || len(ret) < first); i++ {
+			value := rv.Index(i).Interface()
+			keep := true
+			for name, want := range p.Args {
+				if name != "first" || item.fieldType("first") != "" {
+					keep = keep && equalValues(modelValue(p.Context, value, name, false), want)
+				}
+			}
+			if keep {
+				ret = append(ret, value)
+			}
+		}
+		return ret, nil
+	}
+}
+
+// fieldType returns the type of the model's field or an empty string if it has no such field
+func (m *graphqlModel) fieldType(name string) string {
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return f.Type
+		}
+	}
+	return ""
+}
+
+// equalValues compares a resolved value with an argument by their text, ignoring case so that
+// addresses and hashes match however they are written
+func equalValues(got, want any) bool {
+	if got == nil {
+		return want == nil
+	}
+	return strings.EqualFold(fmt.Sprint(got), fmt.Sprint(want))
+}
+
+// modelValue returns the value of a model's field. The fields of models are the values of their
+// JSON output, which includes calculated fields (such as date), or, for objects, lists of
+// objects, and members the output omits, the value of the struct's member with the field's JSON
+// tag.
+func modelValue(ctx context.Context, source any, name string, isObject bool) any {
+	if m, ok := source.(map[string]any); ok {
+		return basicValue(m[name])
+	}
+
+	if modeler, ok := source.(types.Modeler); ok && !isObject {
+		if value, ok := graphqlQueryFrom(ctx).model(modeler)[name]; ok {
+			return basicValue(value)
+		}
+	}
+
+	if member, ok := jsonMember(source, name); ok {
+		return basicValue(memberValue(member))
+	}
+
+	if modeler, ok := source.(types.Modeler); ok && isObject {
+		return graphqlQueryFrom(ctx).model(modeler)[name]
+	}
+	return nil
+}
+
+// model returns (and keeps) the model's JSON output
+func (q *graphqlQuery) model(modeler types.Modeler) map[string]any {
+	if data, ok := q.models[modeler]; ok {
+		return data
+	}
+	data := modeler.Model(q.chain, "json", true, nil).Data
+	q.models[modeler] = data
+	return data
+}
+
+// jsonMember finds the member of the struct to which source points whose JSON tag is name
+func jsonMember(source any, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(source)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		if tag == name && v.Type().Field(i).IsExported() {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// memberValue returns a struct member as a value the GraphQL engine can use. Structs (and the
+// structs in slices) are returned as pointers, so they are models (whose methods have pointer
+// receivers) and marshal as they do in the JSON output.
+func memberValue(member reflect.Value) any {
+	if member.Kind() == reflect.Pointer && !member.IsNil() && member.Elem().Kind() == reflect.Slice {
+		member = member.Elem()
+	}
+	switch member.Kind() {
+	case reflect.Slice:
+		ret := make([]any, 0, member.Len())
+		for i := 0; i < member.Len(); i++ {
+			ret = append(ret, memberValue(member.Index(i)))
+		}
+		return ret
+	case reflect.Struct:
+		if member.CanAddr() {
+			return member.Addr().Interface()
+		}
+	}
+	return member.Interface()
+}
+
+// basicValue converts values of named basic types (base.Blknum or base.Float, for example) to
+// their underlying types, which are the only ones GraphQL's scalars recognize
+func basicValue(value any) any {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Slice:
+		if items, ok := value.([]any); ok {
+			ret := make([]any, 0, len(items))
+			for _, item := range items {
+				ret = append(ret, basicValue(item))
+			}
+			return ret
+		}
+	}
+	return value
+}
+
+func resolveBlocks(p graphql.ResolveParams) (any, error) {
+	q := graphqlQueryFrom(p.Context)
+	ret := []*types.Block{}
+	for _, id := range p.Args["ids"].([]any) {
+		br, err := identifiers.NewBlockRange(id.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid block identifier %s: %w", id, err)
+		}
+
+		// a range that names too many blocks (by step or period, too) is refused before its
+		// blocks are listed
+		n, err := br.CountBlocks(q.chain)
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(graphqlLimits["blocks"]) {
+			return nil, q.read("blocks", graphqlLimits["blocks"]+1)
+		}
+
+		bns, err := br.ResolveBlocks(q.chain)
+		if err != nil {
+			return nil, err
+		}
+		if err := q.read("blocks", len(bns)); err != nil {
+			return nil, err
+		}
+		for _, bn := range bns {
+			if err := p.Context.Err(); err != nil {
+				return nil, err
+			}
+			block, err := q.conn.GetBlockBodyByNumber(bn)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, &block)
+		}
+	}
+	return ret, nil
+}
+
+func resolveTransactions(p graphql.ResolveParams) (any, error) {
+	q := graphqlQueryFrom(p.Context)
+	ret := []*types.Transaction{}
+	for _, id := range p.Args["ids"].([]any) {
+		txr, err := identifiers.NewTxRange(id.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction identifier %s: %w", id, err)
+		}
+		apps, err := txr.ResolveTxs(q.chain)
+		if err != nil {
+			return nil, err
+		}
+		if err := q.read("transactions", len(apps)); err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			if err := p.Context.Err(); err != nil {
+				return nil, err
+			}
+			tx, err := q.conn.GetTransactionByAppearance(&app, false)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, tx)
+		}
+	}
+	return ret, nil
+}
+
+func resolveAccount(p graphql.ResolveParams) (any, error) {
+	address := p.Args["address"].(string)
+	if !base.IsValidAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	return &graphqlAccount{Address: base.HexToAddress(address)}, nil
+}
+
+// resolveAppearances reads the account's appearances from its monitor. Queries only read, so the
+// monitor is neither created nor freshened: it holds the appearances up to its last scan.
+func resolveAppearances(p graphql.ResolveParams) (any, error) {
+	q := graphqlQueryFrom(p.Context)
+	account := p.Source.(*graphqlAccount)
+
+	if !file.FileExists(monitor.PathToMonitorFile(q.chain, account.Address)) {
+		return nil, fmt.Errorf("there is no monitor for %s (chifra list or chifra export creates one)", account.Address.Hex())
+	}
+	mon, _ := monitor.NewMonitor(q.chain, account.Address, false /* create */)
+	defer mon.Close()
+
+	blocks := base.BlockRange{First: 0, Last: base.NOPOSN}
+	if first, ok := p.Args["firstBlock"].(int64); ok {
+		blocks.First = base.Blknum(first)
+	}
+	if last, ok := p.Args["lastBlock"].(int64); ok {
+		blocks.Last = base.Blknum(last)
+	}
+	records := base.RecordRange{First: 0, Last: base.NOPOS}
+	if first, ok := p.Args["first"].(int); ok {
+		records.Last = uint64(first)
+	}
+
+	apps, _, err := mon.ReadAndFilterAppearances(filter.NewFilter(false, false, []string{}, blocks, records), false /* withCount */)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.read("appearances", len(apps)); err != nil {
+		return nil, err
+	}
+
+	account.apps = apps
+	q.accounts[account.Address] = account
+	ret := make([]*types.Appearance, 0, len(apps))
+	for i := range apps {
+		apps[i].Address = account.Address
+		ret = append(ret, &apps[i])
+	}
+	return ret, nil
+}
+
+func resolveReceipt(p graphql.ResolveParams) (any, error) {
+	tx, ok := p.Source.(*types.Transaction)
+	if !ok {
+		return modelValue(p.Context, p.Source, "receipt", true), nil
+	}
+	if tx.Receipt == nil {
+		q := graphqlQueryFrom(p.Context)
+		if err := q.read("transactions", 1); err != nil {
+			return nil, err
+		}
+		receipt, err := q.conn.GetReceipt(tx.BlockNumber, tx.TransactionIndex, tx.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		tx.Receipt = &receipt
+	}
+	return tx.Receipt, nil
+}
+
+func resolveAppearanceTransaction(p graphql.ResolveParams) (any, error) {
+	q := graphqlQueryFrom(p.Context)
+	if err := q.read("transactions", 1); err != nil {
+		return nil, err
+	}
+	return q.conn.GetTransactionByAppearance(p.Source.(*types.Appearance), false)
+}
+
+// resolveStatements reconciles the appearance's transaction for the account the appearance
+// belongs to, as chifra export --accounting does
+func resolveStatements(p graphql.ResolveParams) (any, error) {
+	q := graphqlQueryFrom(p.Context)
+	app := p.Source.(*types.Appearance)
+	account, ok := q.accounts[app.Address]
+	if !ok {
+		return nil, errors.New("statements are available only for the appearances of an account")
+	}
+
+	if account.ledger == nil {
+		account.ledger = ledger.NewLedger(q.conn, account.Address, 0, base.NOPOSN, false /* asEther */, false /* testMode */, false /* noZero */, false /* useTraces */, false /* reversed */, &[]string{})
+		if err := account.ledger.SetContexts(q.chain, account.apps); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := q.conn.GetTransactionByAppearance(app, false)
+	if err != nil {
+		return nil, err
+	}
+	blocks := base.BlockRange{First: 0, Last: base.NOPOSN}
+	records := base.RecordRange{First: 0, Last: base.NOPOS}
+	return account.ledger.GetStatements(q.conn, filter.NewFilter(false, false, []string{}, blocks, records), tx)
+}
+
+// graphqlRequest is a GraphQL request as clients send it
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// executeGraphql parses and validates the request, refuses it if it nests too deeply, and
+// executes it
+func executeGraphql(ctx context.Context, schema *graphql.Schema, req graphqlRequest) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if result := graphql.ValidateDocument(schema, doc, nil); !result.IsValid {
+		return &graphql.Result{Errors: result.Errors}
+	}
+	if depth := graphqlDepth(doc); depth > graphqlMaxDepth {
+		err := fmt.Errorf("the query nests fields %d deep, more than the %d allowed", depth, graphqlMaxDepth)
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        *schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// ServeGraphQL executes a GraphQL query. The query is posted as JSON ({"query": ...,
+// "variables": ..., "operationName": ...}) or as the body of an application/graphql request, or
+// given in the query, variables, and operationName parameters of a GET. The chain and cache
+// parameters work as they do for the other routes.
+func ServeGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	params := r.URL.Query()
+	if r.Method == http.MethodPost {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/graphql") {
+			req.Query = string(body)
+		} else if err := json.Unmarshal(body, &req); err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+			return
+		}
+	} else {
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if vars := params.Get("variables"); len(vars) > 0 {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				RespondWithError(w, http.StatusBadRequest, fmt.Errorf("invalid variables: %w", err))
+				return
+			}
+		}
+	}
+	if len(req.Query) == 0 {
+		RespondWithError(w, http.StatusBadRequest, errors.New("a query is required"))
+		return
+	}
+
+	chain := params.Get("chain")
+	if len(chain) == 0 {
+		chain = config.GetSettings().DefaultChain
+	}
+	if !config.IsChainConfigured(chain) {
+		RespondWithError(w, http.StatusBadRequest, fmt.Errorf("chain %s is not properly configured", chain))
+		return
+	}
+
+	q := newGraphqlQuery(chain, params.Has("cache") && params.Get("cache") != "false")
+	resp := executeGraphql(context.WithValue(r.Context(), graphqlQueryKey{}, q), getGraphqlSchema(), req)
+
+	status := http.StatusOK
+	if resp.Data == nil {
+		// the query could not be executed
+		status = http.StatusBadRequest
+	}
+	marshalled, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(marshalled)
+}
+
+// ServeGraphQLSchema returns the schema in GraphQL's schema definition language
+func ServeGraphQLSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(graphqlSDL(getGraphqlSchema())))
+}
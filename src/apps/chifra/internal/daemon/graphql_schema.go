@@ -0,0 +1,601 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * This file was auto generated. DO NOT EDIT.
+ */
+
+package daemonPkg
+
+// graphqlModels describes an object type for each of the data models with a field for each of
+// the model's members
+func graphqlModels() []graphqlModel {
+	return []graphqlModel{
+		{Name: "Appearance", Description: "an appearance (`<blockNumber,transactionIndex>`) of an address anywhere on the chain (note that in some cases, not all fields will appear depending on the command)", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the address of the appearance"},
+			{Name: "blockNumber", Type: "Int", Description: "the number of the block"},
+			{Name: "transactionIndex", Type: "Int", Description: "the index of the transaction in the block"},
+			{Name: "traceIndex", Type: "Int", Description: "the zero-based index of the trace in the transaction"},
+			{Name: "reason", Type: "String", Description: "the location in the data where the appearance was found"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp for this appearance"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+		}},
+		{Name: "Monitor", Description: "a local file indicating a user's interest in an address. Includes caches for reconicilations, transactions, and appearances as well as an optional association to named account", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the address of this monitor"},
+			{Name: "name", Type: "String", Description: "the name of this monitor (if any)"},
+			{Name: "nRecords", Type: "Int64", Description: "the number of appearances for this monitor"},
+			{Name: "fileSize", Type: "Int64", Description: "the size of this monitor on disc"},
+			{Name: "lastScanned", Type: "Int", Description: "the last scanned block number"},
+			{Name: "isEmpty", Type: "Boolean", Description: "`true` if the monitor has no appearances, `false` otherwise"},
+			{Name: "isStaged", Type: "Boolean", Description: "`true` if the monitor file in on the stage, `false` otherwise"},
+			{Name: "deleted", Type: "Boolean", Description: "`true` if this monitor has been deleted, `false` otherwise"},
+		}},
+		{Name: "Name", Description: "an association between a human-readable name and an address used throughout TrueBlocks", Fields: []graphqlField{
+			{Name: "tags", Type: "String", Description: "colon separated list of tags"},
+			{Name: "address", Type: "String", Description: "the address associated with this name"},
+			{Name: "name", Type: "String", Description: "the name associated with this address (retrieved from on-chain data if available)"},
+			{Name: "symbol", Type: "String", Description: "the symbol for this address (retrieved from on-chain data if available)"},
+			{Name: "source", Type: "String", Description: "user supplied source of where this name was found (or on-chain if name is on-chain)"},
+			{Name: "decimals", Type: "Int64", Description: "number of decimals retrieved from an ERC20 smart contract, defaults to 18"},
+			{Name: "deleted", Type: "Boolean", Description: "`true` if deleted, `false` otherwise"},
+			{Name: "isCustom", Type: "Boolean", Description: "`true` if the address is a custom address, `false` otherwise"},
+			{Name: "isPrefund", Type: "Boolean", Description: "`true` if the address was one of the prefund addresses, `false` otherwise"},
+			{Name: "isContract", Type: "Boolean", Description: "`true` if the address is a smart contract, `false` otherwise"},
+			{Name: "isErc20", Type: "Boolean", Description: "`true` if the address is an ERC20, `false` otherwise"},
+			{Name: "isErc721", Type: "Boolean", Description: "`true` if the address is an ERC720, `false` otherwise"},
+		}},
+		{Name: "NameConflict", Description: "a field of a custom name that was changed both locally and in an imported name bundle", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the address of the conflicting name"},
+			{Name: "name", Type: "String", Description: "the local name of the address"},
+			{Name: "field", Type: "String", Description: "the field of the name that conflicts"},
+			{Name: "base", Type: "String", Description: "the value of the field when the two databases were last merged"},
+			{Name: "ours", Type: "String", Description: "the value of the field in the local database"},
+			{Name: "theirs", Type: "String", Description: "the value of the field in the imported bundle"},
+			{Name: "oursAuthor", Type: "String", Description: "the author of the local value"},
+			{Name: "oursTimestamp", Type: "Int64", Description: "the time the local value was last changed"},
+			{Name: "theirsAuthor", Type: "String", Description: "the author of the imported value"},
+			{Name: "theirsTimestamp", Type: "Int64", Description: "the time the imported value was last changed"},
+			{Name: "resolution", Type: "String", Description: "one of `ours` or `theirs` if the conflict was resolved, empty otherwise"},
+		}},
+		{Name: "Bounds", Description: "show first block and last block an address appears in along with timestamps and dates", Fields: []graphqlField{
+			{Name: "count", Type: "Int64", Description: "the number of appearances for this address"},
+			{Name: "firstApp", Type: "Appearance", Description: "the block number and transaction id of the first appearance of this address"},
+			{Name: "firstTs", Type: "Int64", Description: "the timestamp of the first appearance of this address"},
+			{Name: "firstDate", Type: "String", Description: "the first appearance timestamp as a date"},
+			{Name: "latestApp", Type: "Appearance", Description: "the block number and transaction id of the latest appearance of this address"},
+			{Name: "latestTs", Type: "Int64", Description: "the timestamp of the latest appearance of this address"},
+			{Name: "latestDate", Type: "String", Description: "the latest appearance timestamp as a date"},
+		}},
+		{Name: "Statement", Description: "a statement, including all inflows and outflows, for a single transfer of an asset (including ETH) to or from a given address", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the zero-indexed position of the transaction in the block"},
+			{Name: "logIndex", Type: "Int64", Description: "the zero-indexed position the log in the block, if applicable"},
+			{Name: "transactionHash", Type: "String", Description: "the hash of the transaction that triggered this reconciliation"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the object"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "assetAddr", Type: "String", Description: "0xeeee...eeee for ETH reconciliations, the token address otherwise"},
+			{Name: "assetSymbol", Type: "String", Description: "either ETH, WEI, or the symbol of the asset being reconciled as extracted from the chain"},
+			{Name: "decimals", Type: "Int64", Description: "the value of `decimals` from an ERC20 contract or, if ETH or WEI, then 18"},
+			{Name: "spotPrice", Type: "Float", Description: "the on-chain price in USD (or if a token in ETH, or zero) at the time of the transaction"},
+			{Name: "priceSource", Type: "String", Description: "the on-chain source from which the spot price was taken"},
+			{Name: "accountedFor", Type: "String", Description: "the address being accounted for in this reconciliation"},
+			{Name: "sender", Type: "String", Description: "the initiator of the transfer (the sender)"},
+			{Name: "recipient", Type: "String", Description: "the receiver of the transfer (the recipient)"},
+			{Name: "begBal", Type: "String", Description: "the beginning balance of the asset prior to the transaction"},
+			{Name: "amountNet", Type: "String", Description: "totalIn - totalOut"},
+			{Name: "endBal", Type: "String", Description: "the on-chain balance of the asset (see notes about intra-block reconciliations)"},
+			{Name: "reconciliationType", Type: "String", Description: "one of `regular`, `prevDiff-same`, `same-nextDiff`, or `same-same`. Appended with `eth` or `token`"},
+			{Name: "reconciled", Type: "Boolean", Description: "true if `endBal === endBalCalc` and `begBal === prevBal`. `false` otherwise."},
+			{Name: "totalIn", Type: "String", Description: "the sum of the following `In` fields"},
+			{Name: "amountIn", Type: "String", Description: "the top-level value of the incoming transfer for the accountedFor address"},
+			{Name: "internalIn", Type: "String", Description: "the internal value of the incoming transfer for the accountedFor address"},
+			{Name: "selfDestructIn", Type: "String", Description: "the incoming value of a self-destruct if recipient is the accountedFor address"},
+			{Name: "minerBaseRewardIn", Type: "String", Description: "the base fee reward if the miner is the accountedFor address"},
+			{Name: "minerNephewRewardIn", Type: "String", Description: "the nephew reward if the miner is the accountedFor address"},
+			{Name: "minerTxFeeIn", Type: "String", Description: "the transaction fee reward if the miner is the accountedFor address"},
+			{Name: "minerUncleRewardIn", Type: "String", Description: "the uncle reward if the miner who won the uncle block is the accountedFor address"},
+			{Name: "correctingIn", Type: "String", Description: "for unreconciled token transfers only, the incoming amount needed to correct the transfer so it balances"},
+			{Name: "prefundIn", Type: "String", Description: "at block zero (0) only, the amount of genesis income for the accountedFor address"},
+			{Name: "totalOut", Type: "String", Description: "the sum of the following `Out` fields"},
+			{Name: "amountOut", Type: "String", Description: "the amount (in units of the asset) of regular outflow during this transaction"},
+			{Name: "internalOut", Type: "String", Description: "the value of any internal value transfers out of the accountedFor account"},
+			{Name: "correctingOut", Type: "String", Description: "for unreconciled token transfers only, the outgoing amount needed to correct the transfer so it balances"},
+			{Name: "selfDestructOut", Type: "String", Description: "the value of the self-destructed value out if the accountedFor address was self-destructed"},
+			{Name: "gasOut", Type: "String", Description: "if the transaction's original sender is the accountedFor address, the amount of gas expended"},
+			{Name: "totalOutLessGas", Type: "String", Description: "totalOut - gasOut"},
+			{Name: "prevBal", Type: "String", Description: "the account balance for the given asset for the previous reconciliation"},
+			{Name: "begBalDiff", Type: "String", Description: "difference between expected beginning balance and balance at last reconciliation, if non-zero, the reconciliation failed"},
+			{Name: "endBalDiff", Type: "String", Description: "endBal - endBalCalc, if non-zero, the reconciliation failed"},
+			{Name: "endBalCalc", Type: "String", Description: "begBal + amountNet"},
+			{Name: "correctingReason", Type: "String", Description: "the reason for the correcting entries, if any"},
+		}},
+		{Name: "AppearanceTable", Description: "an appearance table for an address", Fields: []graphqlField{
+			{Name: "AddressRecord", Type: "JSON", Description: "the address record for these appearances"},
+			{Name: "Appearances", Type: "JSON", List: true, Description: "all the appearances for this address"},
+		}},
+		{Name: "Cluster", Description: "a group of addresses that transaction patterns suggest belong to the same entity", Fields: []graphqlField{
+			{Name: "id", Type: "String", Description: "the heuristic and the shared account that identify the cluster"},
+			{Name: "heuristic", Type: "String", Description: "one of `funder`, `sweep`, `deployer` or `create2`"},
+			{Name: "anchor", Type: "String", Description: "the account the members share (the funder, sweep destination, deployer or factory)"},
+			{Name: "members", Type: "String", List: true, Description: "the addresses in the cluster"},
+			{Name: "confidence", Type: "Float", Description: "how likely the members belong to the same entity (between 0.0 and 1.0)"},
+			{Name: "reason", Type: "String", Description: "a short description of the evidence for the cluster"},
+			{Name: "accepted", Type: "Boolean", Description: "true if the cluster was written to the custom names database"},
+		}},
+		{Name: "Block", Description: "block data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "gasLimit", Type: "Int64", Description: "the system-wide maximum amount of gas permitted in this block"},
+			{Name: "gasUsed", Type: "Int64", Description: "the total amount of gas used in this block"},
+			{Name: "hash", Type: "String", Description: "the hash of the current block"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "parentHash", Type: "String", Description: "hash of previous block"},
+			{Name: "miner", Type: "String", Description: "address of block's winning miner"},
+			{Name: "difficulty", Type: "Int64", Description: "the computational difficulty at this block"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the object"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "transactions", Type: "Transaction", List: true, Description: "a possibly empty array of transactions"},
+			{Name: "baseFeePerGas", Type: "Int64", Description: "the base fee for this block"},
+			{Name: "uncles", Type: "String", List: true, Description: "a possibly empty array of uncle hashes"},
+			{Name: "withdrawals", Type: "Withdrawal", List: true, Description: "a possibly empty array of withdrawals (post Shanghai)"},
+		}},
+		{Name: "Transaction", Description: "transaction data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "hash", Type: "String", Description: "the hash of the transaction"},
+			{Name: "blockHash", Type: "String", Description: "the hash of the block containing this transaction"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the zero-indexed position of the transaction in the block"},
+			{Name: "nonce", Type: "Int64", Description: "sequence number of the transactions sent by the sender"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the object"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "from", Type: "String", Description: "address from which the transaction was sent"},
+			{Name: "to", Type: "String", Description: "address to which the transaction was sent"},
+			{Name: "value", Type: "String", Description: "the amount of wei sent with this transactions"},
+			{Name: "ether", Type: "String", Description: "if --ether is specified, the value in ether"},
+			{Name: "gas", Type: "Int64", Description: "the maximum number of gas allowed for this transaction"},
+			{Name: "gasPrice", Type: "Int64", Description: "the number of wei per unit of gas the sender is willing to spend"},
+			{Name: "traces", Type: "Trace", List: true},
+			{Name: "maxPriorityFeePerGas", Type: "Int64"},
+			{Name: "input", Type: "String", Description: "byte data either containing a message or funcational data for a smart contracts. See the --articulate"},
+			{Name: "isError", Type: "Boolean", Description: "`true` if the transaction ended in error, `false` otherwise"},
+			{Name: "hasToken", Type: "Boolean", Description: "`true` if the transaction is token related, `false` otherwise"},
+			{Name: "receipt", Type: "Receipt"},
+			{Name: "maxFeePerGas", Type: "Int64"},
+			{Name: "statements", Type: "Statement", List: true, Description: "array of reconciliations"},
+			{Name: "articulatedTx", Type: "Function"},
+			{Name: "compressedTx", Type: "String", Description: "truncated, more readable version of the articulation"},
+			{Name: "gasUsed", Type: "Int64"},
+			{Name: "type", Type: "String"},
+			{Name: "revertReason", Type: "Function", Description: "if the transaction failed and --articulate is on, the decoded revert reason"},
+		}},
+		{Name: "Withdrawal", Description: "withdrawal record for post-Shanghai withdrawals from the consensus layer", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the recipient for the withdrawn ether"},
+			{Name: "amount", Type: "String", Description: "a nonzero amount of ether given in gwei (1e9 wei)"},
+			{Name: "ether", Type: "String", Description: "if --ether is specified, the amount in ether"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of this block"},
+			{Name: "index", Type: "Int64", Description: "a monotonically increasing zero-based index that increments by 1 per withdrawal to uniquely identify each withdrawal"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp for this block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "validatorIndex", Type: "Int64", Description: "the validator_index of the validator on the consensus layer the withdrawal corresponds to"},
+		}},
+		{Name: "Receipt", Description: "receipt data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "blockHash", Type: "String"},
+			{Name: "blockNumber", Type: "Int64"},
+			{Name: "contractAddress", Type: "String", Description: "the address of the newly created contract, if any"},
+			{Name: "cumulativeGasUsed", Type: "Int64"},
+			{Name: "from", Type: "String"},
+			{Name: "gasUsed", Type: "Int64", Description: "the amount of gas actually used by the transaction"},
+			{Name: "effectiveGasPrice", Type: "Int64"},
+			{Name: "isError", Type: "Boolean"},
+			{Name: "logs", Type: "Log", List: true, Description: "a possibly empty array of logs"},
+			{Name: "status", Type: "Int64", Description: "`1` on transaction suceess, `null` if tx preceeds Byzantium, `0` otherwise"},
+			{Name: "to", Type: "String"},
+			{Name: "transactionHash", Type: "String"},
+			{Name: "transactionIndex", Type: "Int64"},
+			{Name: "revertReason", Type: "Function", Description: "if the transaction failed and --articulate is on, the decoded revert reason"},
+		}},
+		{Name: "Log", Description: "log data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the zero-indexed position of the transaction in the block"},
+			{Name: "logIndex", Type: "Int64", Description: "the zero-indexed position of this log relative to the block"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block this log appears in"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "address", Type: "String", Description: "the smart contract that emitted this log"},
+			{Name: "topics", Type: "String", List: true, Description: "the first topic hashes event signature of the log, up to 3 additional index parameters may appear"},
+			{Name: "data", Type: "String", Description: "any remaining un-indexed parameters to the event"},
+			{Name: "transactionHash", Type: "String", Description: "the hash of the transction"},
+			{Name: "blockHash", Type: "String", Description: "the hash of the block"},
+			{Name: "articulatedLog", Type: "Function", Description: "a human-readable version of the topic and data fields"},
+			{Name: "compressedLog", Type: "String", Description: "a truncated, more readable version of the articulation"},
+		}},
+		{Name: "Trace", Description: "trace data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "blockHash", Type: "String", Description: "the hash of the block containing this trace"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "transactionHash", Type: "String", Description: "the transaction's hash containing this trace"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the zero-indexed position of the transaction in the block"},
+			{Name: "traceAddress", Type: "Int64", List: true, Description: "a particular trace's address in the trace tree"},
+			{Name: "subtraces", Type: "Int64", Description: "the number of children traces that the trace hash"},
+			{Name: "type", Type: "String", Description: "the type of the trace"},
+			{Name: "error", Type: "String"},
+			{Name: "action", Type: "TraceAction", Description: "the trace action for this trace"},
+			{Name: "result", Type: "TraceResult", Description: "the trace result of this trace"},
+			{Name: "articulatedTrace", Type: "Function", Description: "human readable version of the trace action input data"},
+			{Name: "compressedTrace", Type: "String", Description: "a compressed string version of the articulated trace"},
+			{Name: "revertReason", Type: "Function", Description: "if the trace reverted and --articulate is on, the decoded revert reason"},
+		}},
+		{Name: "TraceAction", Description: "trace action data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "init", Type: "String"},
+			{Name: "from", Type: "String", Description: "address from which the trace was sent"},
+			{Name: "to", Type: "String", Description: "address to which the trace was sent"},
+			{Name: "gas", Type: "Int64", Description: "the maximum number of gas allowed for this trace"},
+			{Name: "input", Type: "String", Description: "an encoded version of the function call"},
+			{Name: "callType", Type: "String", Description: "the type of call"},
+			{Name: "refundAddress", Type: "String", Description: "if the call type is self-destruct, the address to which the refund is sent"},
+			{Name: "rewardType", Type: "String", Description: "the type of reward"},
+			{Name: "value", Type: "String", Description: "the value (in wei) of this trace action"},
+			{Name: "ether", Type: "String", Description: "if --ether is specified, the value in ether"},
+			{Name: "selfDestructed", Type: "String", Description: "`true` if the contract self-destructed, `false` otherwise"},
+			{Name: "balance", Type: "String", Description: "if self-destructed, the balance of the contract at that time"},
+			{Name: "balanceEth", Type: "String", Description: "if --ether is specified, the balance in ether"},
+			{Name: "address", Type: "String"},
+			{Name: "author", Type: "String"},
+		}},
+		{Name: "TraceResult", Description: "trace result data as returned from the RPC (with slight enhancements)", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "address of new contract, if any"},
+			{Name: "code", Type: "String", Description: "if this trace is creating a new smart contract, the byte code of that contract"},
+			{Name: "gasUsed", Type: "Int64", Description: "the amount of gas used by this trace"},
+			{Name: "output", Type: "String", Description: "the result of the call of this trace"},
+		}},
+		{Name: "TraceCount", Description: "counts the number of traces in a transaction", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block number"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the transaction index"},
+			{Name: "transactionHash", Type: "String", Description: "the transaction's hash"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "tracesCnt", Type: "Int64", Description: "the number of traces in the transaction"},
+		}},
+		{Name: "TraceFilter", Description: "used by chifra traces --filter option to query for traces", Fields: []graphqlField{
+			{Name: "fromBlock", Type: "Int64", Description: "the first block to include in the queried list of traces."},
+			{Name: "toBlock", Type: "Int64", Description: "the last block to include in the queried list of traces."},
+			{Name: "fromAddress", Type: "String", Description: "if included, only traces `from` this address will be included."},
+			{Name: "toAddress", Type: "String", Description: "if included, only traces `to` this address will be included."},
+			{Name: "after", Type: "Int64", Description: "only traces after this many traces are included."},
+			{Name: "count", Type: "Int64", Description: "only this many traces are included."},
+		}},
+		{Name: "BlockCount", Description: "counts of various parts of the block data such as tx_count, trace_count, etc.", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block's block number"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "transactionsCnt", Type: "Int64", Description: "the number transactions in the block"},
+			{Name: "unclesCnt", Type: "Int64", Description: "the number of uncles in the block"},
+			{Name: "logsCnt", Type: "Int64", Description: "the number of logs in the block"},
+			{Name: "tracesCnt", Type: "Int64", Description: "the number of traces in the block"},
+			{Name: "withdrawalsCnt", Type: "Int64", Description: "the number of withdrawals in the block"},
+			{Name: "addressCnt", Type: "Int64", Description: "the number of address appearances in the block"},
+		}},
+		{Name: "NamedBlock", Description: "a block that has been given a particular name such as `first` or `latest`", Fields: []graphqlField{
+			{Name: "component", Type: "String", Description: "the name of the componet for which this record exists"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "name", Type: "String", Description: "an optional name for the block"},
+			{Name: "description", Type: "String", Description: "an optional description of the block"},
+		}},
+		{Name: "Timestamp", Description: "the timestamp, date and difference in timestamp of previous block produced by chifra when", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "diff", Type: "Int64", Description: "the number of seconds since the last block"},
+		}},
+		{Name: "LightBlock", Description: "a block containing only the hashes of the transactions", Fields: []graphqlField{
+			{Name: "gasLimit", Type: "Int64", Description: "the system-wide maximum amount of gas permitted in this block"},
+			{Name: "gasUsed", Type: "Int64", Description: "the total amount of gas used in this block"},
+			{Name: "hash", Type: "String", Description: "the hash of the current block"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "parentHash", Type: "String", Description: "hash of previous block"},
+			{Name: "miner", Type: "String", Description: "address of block's winning miner"},
+			{Name: "difficulty", Type: "Int64", Description: "the computational difficulty at this block"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the object"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "transactions", Type: "String", List: true, Description: "a possibly empty array of transaction hashes"},
+			{Name: "baseFeePerGas", Type: "Int64", Description: "the base fee for this block"},
+			{Name: "uncles", Type: "String", List: true, Description: "a possibly empty array of uncle hashes"},
+			{Name: "withdrawals", Type: "Withdrawal", List: true, Description: "a possibly empty array of withdrawals (post Shanghai)"},
+		}},
+		{Name: "State", Description: "the state of an Ethereum account (EOA or smart contract) on-chain", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block number at which this call was made"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block for this call"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "address", Type: "String", Description: "the address of contract being called"},
+			{Name: "accountType", Type: "String", Description: "the type of account at the given block"},
+			{Name: "balance", Type: "String", Description: "the balance of the account at the given block"},
+			{Name: "ether", Type: "String", Description: "if --ether is specified, the balance in ether"},
+			{Name: "code", Type: "String", Description: "the code of the account"},
+			{Name: "deployed", Type: "Int64", Description: "for smart contracts only, the block number at which the contract was deployed"},
+			{Name: "nonce", Type: "Int64", Description: "the nonce of the account at the given block"},
+			{Name: "proxy", Type: "String", Description: "the proxy address of the account at the given block"},
+			{Name: "parts", Type: "JSON", Description: "the parts of the state in the cache"},
+		}},
+		{Name: "Token", Description: "on-chain token-related data such as totalSupply, symbol, decimals, and individual balances for a given address at a given block", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block at which the report is made"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the transaction index (if applicable) at which the report is made"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "totalSupply", Type: "String", Description: "the total supply of the token contract"},
+			{Name: "address", Type: "String", Description: "the address of the token contract"},
+			{Name: "holder", Type: "String", Description: "the holder address for which we are reporting"},
+			{Name: "priorBalance", Type: "String", Description: "the holder's asset balance at its prior appearance"},
+			{Name: "balance", Type: "String", Description: "the holder's asset balance at the given block height"},
+			{Name: "balanceDec", Type: "Float", Description: "the holder's asset balance (in Ether) at the given block height"},
+			{Name: "diff", Type: "String", Description: "the difference, if any, between the prior and current balance"},
+			{Name: "name", Type: "String", Description: "the name of the token contract, if available"},
+			{Name: "symbol", Type: "String", Description: "the symbol of the token contract"},
+			{Name: "decimals", Type: "Int64", Description: "the number of decimals for the token contract"},
+			{Name: "type", Type: "JSON", Description: "the type of token (ERC20 or ERC721) or none"},
+		}},
+		{Name: "Result", Description: "the result (articulated if possible, as bytes otherwise) of a call to a smart contract", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block number at which this call was made"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block for this call"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "address", Type: "String", Description: "the address of contract being called"},
+			{Name: "name", Type: "String", Description: "the name of the function call"},
+			{Name: "encoding", Type: "String", Description: "the encoding for the function call"},
+			{Name: "signature", Type: "String", Description: "the canonical signature of the interface"},
+			{Name: "encodedArguments", Type: "String", Description: "the bytes data following the encoding of the call"},
+			{Name: "articulatedOut", Type: "Function", Description: "the result of the call articulated as other models"},
+			{Name: "revertReason", Type: "Function", Description: "if the call reverted and --articulate is on, the decoded revert reason"},
+		}},
+		{Name: "Slot", Description: "the raw and, if a storage layout is provided, decoded contents of a smart contract's storage slot", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block number at which the slot was read"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "address", Type: "String", Description: "the address of the contract whose storage was read"},
+			{Name: "slot", Type: "String", Description: "the storage slot that was read"},
+			{Name: "offset", Type: "Int64", Description: "for packed variables, the byte offset of the variable within the slot"},
+			{Name: "variable", Type: "String", Description: "if a layout is provided, the path of the variable stored in the slot"},
+			{Name: "slotType", Type: "String", Description: "if a layout is provided, the Solidity type of the variable"},
+			{Name: "value", Type: "String", Description: "the raw thirty-two byte contents of the slot"},
+			{Name: "decoded", Type: "String", Description: "if a layout is provided, the value of the variable decoded per its type"},
+		}},
+		{Name: "Proof", Description: "a Merkle proof of an account's state (and optionally its storage) checked against a block's state root", Fields: []graphqlField{
+			{Name: "blockNumber", Type: "Int64", Description: "the block number at which the proof was made"},
+			{Name: "timestamp", Type: "Int64", Description: "the timestamp of the block"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "address", Type: "String", Description: "the address of the account being proven"},
+			{Name: "stateRoot", Type: "String", Description: "the state root of the block against which the proof was verified"},
+			{Name: "balance", Type: "String", Description: "the balance of the account at the given block"},
+			{Name: "ether", Type: "String", Description: "if --ether is specified, the balance in ether"},
+			{Name: "nonce", Type: "Int64", Description: "the nonce of the account at the given block"},
+			{Name: "codeHash", Type: "String", Description: "the hash of the account's code"},
+			{Name: "storageHash", Type: "String", Description: "the root of the account's storage trie"},
+			{Name: "accountProof", Type: "String", List: true, Description: "the RLP encoded trie nodes from the state root to the account"},
+			{Name: "storageProof", Type: "StorageProof", List: true, Description: "the proofs of any requested storage slots"},
+			{Name: "consistent", Type: "Boolean", Description: "true if the account proof and every storage proof check out against the state root the node reported"},
+			{Name: "verified", Type: "Boolean", Description: "true if the proof is consistent and the state root matches the one given with --root"},
+		}},
+		{Name: "StorageProof", Description: "a Merkle proof of the value stored in a smart contract's storage slot", Fields: []graphqlField{
+			{Name: "key", Type: "String", Description: "the storage slot being proven"},
+			{Name: "value", Type: "String", Description: "the value stored in the slot"},
+			{Name: "proof", Type: "String", List: true, Description: "the RLP encoded trie nodes from the account's storage root to the slot"},
+			{Name: "verified", Type: "Boolean", Description: "true if the proof verified against the account's storage root"},
+		}},
+		{Name: "Status", Description: "status-related data about the TrueBlocks system including the server and local binary caches", Fields: []graphqlField{
+			{Name: "cachePath", Type: "String", Description: "the path to the local binary caches"},
+			{Name: "caches", Type: "CacheItem", List: true, Description: "a collection of information concerning the binary caches"},
+			{Name: "chain", Type: "String", Description: "the current chain"},
+			{Name: "chainConfig", Type: "String", Description: "the path to the chain configuration folder"},
+			{Name: "clientVersion", Type: "String", Description: "the version string as reported by the rpcProvider"},
+			{Name: "chainId", Type: "String", Description: "the path to config files"},
+			{Name: "hasEsKey", Type: "Boolean", Description: "`true` if an Etherscan key is present"},
+			{Name: "hasPinKey", Type: "Boolean", Description: "`true` if a Pinata API key is present"},
+			{Name: "indexPath", Type: "String", Description: "the path to the local binary indexes"},
+			{Name: "isApi", Type: "Boolean", Description: "`true` if the server is running in API mode"},
+			{Name: "isArchive", Type: "Boolean", Description: "`true` if the rpcProvider is an archive node"},
+			{Name: "isTesting", Type: "Boolean", Description: "`true` if the server is running in test mode"},
+			{Name: "isTracing", Type: "Boolean", Description: "`true` if the rpcProvider provides Parity traces"},
+			{Name: "isScraping", Type: "Boolean", Description: "`true` if the scraper is running"},
+			{Name: "networkId", Type: "String", Description: "the network id as reported by the rpcProvider"},
+			{Name: "progress", Type: "String", Description: "the progress string of the system"},
+			{Name: "rootConfig", Type: "String", Description: "the path to the root configuration folder"},
+			{Name: "rpcProvider", Type: "String", Description: "the current rpcProvider"},
+			{Name: "version", Type: "String", Description: "the TrueBlocks version string"},
+			{Name: "chains", Type: "Chain", List: true, Description: "a list of available chains in the config file"},
+			{Name: "endpoints", Type: "RpcEndpoint", List: true, Description: "the RPC endpoints configured for the chain and how each has served the daemon, reported only by the daemon"},
+		}},
+		{Name: "Manifest", Description: "a JSON object containing records for each bloom filter and index chunk in the Unchained Index", Fields: []graphqlField{
+			{Name: "version", Type: "String", Description: "the version string hashed into the chunk data"},
+			{Name: "chain", Type: "String", Description: "the chain to which this manifest belongs"},
+			{Name: "specification", Type: "String", Description: "IPFS cid of the specification"},
+			{Name: "chunks", Type: "ChunkRecord", List: true, Description: "a list of the IPFS hashes of all of the chunks in the unchained index"},
+		}},
+		{Name: "ChunkRecord", Description: "a single record in the manifest detailing the IPFS hases and file sizes for each bloom filter and index chunk", Fields: []graphqlField{
+			{Name: "range", Type: "String", Description: "the block range (inclusive) covered by this chunk"},
+			{Name: "bloomHash", Type: "String", Description: "the IPFS hash of the bloom filter at that range"},
+			{Name: "indexHash", Type: "String", Description: "the IPFS hash of the index chunk at that range"},
+			{Name: "bloomSize", Type: "Int64", Description: "the size of the bloom filter in bytes"},
+			{Name: "indexSize", Type: "Int64", Description: "the size of the index portion in bytes"},
+			{Name: "rangeDates", Type: "RangeDates", Description: "if verbose, the block and timestamp bounds of the chunk (may be null)"},
+		}},
+		{Name: "ChunkIndex", Description: "internal-use only data model detailing a single index chunk file", Fields: []graphqlField{
+			{Name: "range", Type: "String", Description: "the block range (inclusive) covered by this chunk"},
+			{Name: "magic", Type: "String", Description: "an internal use only magic number to indicate file format"},
+			{Name: "hash", Type: "String", Description: "the hash of the specification under which this chunk was generated"},
+			{Name: "nAddresses", Type: "Int64", Description: "the number of addresses in this chunk"},
+			{Name: "nAppearances", Type: "Int64", Description: "the number of appearances in this chunk"},
+			{Name: "size", Type: "Int64", Description: "the size of the chunk in bytes"},
+			{Name: "rangeDates", Type: "RangeDates", Description: "if verbose, the block and timestamp bounds of the chunk (may be null)"},
+		}},
+		{Name: "ChunkBloom", Description: "internal-use only data model detailing a single bloom filter file", Fields: []graphqlField{
+			{Name: "range", Type: "String", Description: "the block range (inclusive) covered by this chunk"},
+			{Name: "magic", Type: "String", Description: "an internal use only magic number to indicate file format"},
+			{Name: "hash", Type: "String", Description: "the hash of the specification under which this chunk was generated"},
+			{Name: "nBlooms", Type: "Int64", Description: "the number of individual bloom filters in this bloom file"},
+			{Name: "nInserted", Type: "Int64", Description: "the number of addresses inserted into the bloom file"},
+			{Name: "size", Type: "Int64", Description: "the size on disc in bytes of this bloom file"},
+			{Name: "byteWidth", Type: "Int64", Description: "the width of the bloom filter"},
+			{Name: "rangeDates", Type: "RangeDates", Description: "if verbose, the block and timestamp bounds of the chunk (may be null)"},
+		}},
+		{Name: "ChunkAddress", Description: "internal-use only data model detailing a single address record in the address table of an index chunk", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the address in this record"},
+			{Name: "range", Type: "String", Description: "the block range of the chunk from which this address record was taken"},
+			{Name: "offset", Type: "Int64", Description: "the offset into the appearance table of the first record for this address"},
+			{Name: "count", Type: "Int64", Description: "the number of records in teh appearance table for this address"},
+			{Name: "rangeDates", Type: "RangeDates", Description: "if verbose, the block and timestamp bounds of the chunk (may be null)"},
+		}},
+		{Name: "IpfsPin", Description: "internal-use only data model detailing a single remote or local ipfs pinned file", Fields: []graphqlField{
+			{Name: "cid", Type: "String", Description: "the CID of the file"},
+			{Name: "datePinned", Type: "String", Description: "the date the CID was first created"},
+			{Name: "status", Type: "String", Description: "the status of the file (one of [all|pinned|unpinned|pending])"},
+			{Name: "size", Type: "Int64", Description: "the size of the file in bytes"},
+			{Name: "fileName", Type: "String", Description: "the metadata name of the pinned file"},
+		}},
+		{Name: "ChunkStats", Description: "summary statistics about an Unchained Index bloom filter and index chunk", Fields: []graphqlField{
+			{Name: "range", Type: "String", Description: "the block range (inclusive) covered by this chunk"},
+			{Name: "nAddrs", Type: "Int64", Description: "the number of addresses in the chunk"},
+			{Name: "nApps", Type: "Int64", Description: "the number of appearances in the chunk"},
+			{Name: "nBlocks", Type: "Int64", Description: "the number of blocks in the chunk"},
+			{Name: "nBlooms", Type: "Int64", Description: "the number of bloom filters in the chunk's bloom"},
+			{Name: "recWid", Type: "Int64", Description: "the record width of a single bloom filter"},
+			{Name: "bloomSz", Type: "Int64", Description: "the size of the bloom filters on disc in bytes"},
+			{Name: "chunkSz", Type: "Int64", Description: "the size of the chunks on disc in bytes"},
+			{Name: "addrsPerBlock", Type: "Float", Description: "the average number of addresses per block"},
+			{Name: "appsPerBlock", Type: "Float", Description: "the average number of appearances per block"},
+			{Name: "appsPerAddr", Type: "Float", Description: "the average number of appearances per address"},
+			{Name: "ratio", Type: "Float", Description: "the ratio of appearances to addresses"},
+			{Name: "rangeDates", Type: "RangeDates", Description: "if verbose, the block and timestamp bounds of the chunk (may be null)"},
+		}},
+		{Name: "MonitorClean", Description: "report on cleaning dups out of monitors", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the address being cleaned"},
+			{Name: "sizeThen", Type: "Int64", Description: "the number of appearances in the monitor prior to cleaning"},
+			{Name: "sizeNow", Type: "Int64", Description: "the number of appearances in the monitor after cleaning"},
+			{Name: "dups", Type: "Int64", Description: "the number of duplicates removed"},
+			{Name: "staged", Type: "Boolean", Description: "`true` if the address is in the stage, `false` otherwise"},
+			{Name: "removed", Type: "Boolean", Description: "`true` if the address was removed from the stage, `false` otherwise"},
+		}},
+		{Name: "CacheItem", Description: "a single entry in the results of a status query when `--verbose` is enabled", Fields: []graphqlField{
+			{Name: "type", Type: "String", Description: "the type of the cache"},
+			{Name: "items", Type: "JSON", List: true, Description: "the individual items in the cache (if --verbose)"},
+			{Name: "lastCached", Type: "String", Description: "the date of the most recent item added to the cache"},
+			{Name: "nFiles", Type: "Int64", Description: "the number of items in the cache"},
+			{Name: "nFolders", Type: "Int64", Description: "the number of folders holding that many items"},
+			{Name: "path", Type: "String", Description: "the path to the top of the given cache"},
+			{Name: "sizeInBytes", Type: "Int64", Description: "the size of the cache in bytes"},
+		}},
+		{Name: "ReportCheck", Description: "report on checking contents of chunks", Fields: []graphqlField{
+			{Name: "result", Type: "String", Description: "the result of the check"},
+			{Name: "visitedCnt", Type: "Int64", Description: "the number of visited items in the cache"},
+			{Name: "checkedCnt", Type: "Int64", Description: "the number of checks"},
+			{Name: "skippedCnt", Type: "Int64", Description: "the number of skipped checks"},
+			{Name: "passedCnt", Type: "Int64", Description: "the number of passed checks"},
+			{Name: "failedCnt", Type: "Int64", Description: "the number of failed checks"},
+			{Name: "msgStrings", Type: "String", List: true, Description: "an array of messages explaining failed checks"},
+			{Name: "reason", Type: "String", Description: "the reason for the test"},
+		}},
+		{Name: "ChunkPin", Description: "a JSON object containing the results of pinning the Unchained Index", Fields: []graphqlField{
+			{Name: "version", Type: "String", Description: "the version string hashed into the chunk data"},
+			{Name: "chain", Type: "String", Description: "the chain to which this manifest belongs"},
+			{Name: "timestampHash", Type: "String", Description: "IPFS cid of file containing timestamps"},
+			{Name: "specHash", Type: "String", Description: "IPFS cid of the specification"},
+			{Name: "manifestHash", Type: "String", Description: "IPFS cid of file containing CIDs for the various chunks"},
+		}},
+		{Name: "Chain", Description: "a configuration item carrying information about a single chain", Fields: []graphqlField{
+			{Name: "chain", Type: "String", Description: "the common name of the chain"},
+			{Name: "chainId", Type: "Int64", Description: "the chain id as reported by the RPC"},
+			{Name: "symbol", Type: "String", Description: "the symbol of the base currency on the chain"},
+			{Name: "rpcProvider", Type: "String", Description: "a valid RPC provider for the chain"},
+			{Name: "remoteExplorer", Type: "String", Description: "a remote explorer for the chain such as Etherscan"},
+			{Name: "localExplorer", Type: "String", Description: "the local explorer for the chain (typically TrueBlocks Explorer)"},
+			{Name: "ipfsGateway", Type: "String", Description: "an IPFS gateway for pinning the index if enabled"},
+		}},
+		{Name: "RpcEndpoint", Description: "one of the RPC endpoints configured for a chain along with how it has served requests", Fields: []graphqlField{
+			{Name: "url", Type: "String", Description: "the url of the endpoint"},
+			{Name: "weight", Type: "Int64", Description: "the relative share of requests the endpoint should serve"},
+			{Name: "rateLimit", Type: "Float", Description: "the most requests per second sent to the endpoint (zero for no limit)"},
+			{Name: "state", Type: "String", Description: "the state of the endpoint's circuit breaker: `closed`, `open`, or `half-open`"},
+			{Name: "served", Type: "Int64", Description: "the number of requests the endpoint has served"},
+			{Name: "failures", Type: "Int64", Description: "the number of requests to the endpoint that failed and were retried or failed over"},
+			{Name: "latency", Type: "Float", Description: "the average time (in milliseconds) the endpoint takes to respond"},
+			{Name: "lastError", Type: "String", Description: "the most recent error returned by the endpoint"},
+		}},
+		{Name: "RangeDates", Description: "shows first and last timestamps and dates for a given block range", Fields: []graphqlField{
+			{Name: "firstTs", Type: "Int64", Description: "the timestamp of the first block in this range"},
+			{Name: "firstDate", Type: "String", Description: "the first timestamp as a date"},
+			{Name: "lastTs", Type: "Int64", Description: "the timestamp of the most recent block in this range"},
+			{Name: "lastDate", Type: "String", Description: "the last timestamp as a date"},
+		}},
+		{Name: "Abi", Description: "a human-readable representation of a Solidity smart contract", Fields: []graphqlField{
+			{Name: "address", Type: "String", Description: "the address for the ABI"},
+			{Name: "name", Type: "String", Description: "the filename of the ABI (likely the smart contract address)"},
+			{Name: "path", Type: "String", Description: "the folder holding the abi file"},
+			{Name: "fileSize", Type: "Int64", Description: "the size of this file on disc"},
+			{Name: "lastModDate", Type: "String", Description: "the last update date of the file"},
+			{Name: "isKnown", Type: "Boolean", Description: "true if this is the ABI for a known smart contract or protocol"},
+			{Name: "isEmpty", Type: "Boolean", Description: "true if the ABI could not be found (and won't be looked for again)"},
+			{Name: "nFunctions", Type: "Int64", Description: "if verbose, the number of functions in the ABI"},
+			{Name: "nEvents", Type: "Int64", Description: "if verbose, the number of events in the ABI"},
+			{Name: "hasConstructor", Type: "Boolean", Description: "if verbose and the abi has a constructor, then `true`, else `false`"},
+			{Name: "hasFallback", Type: "Boolean", Description: "if verbose and the abi has a fallback, then `true`, else `false`"},
+			{Name: "functions", Type: "Function", List: true, Description: "the functions for this address"},
+		}},
+		{Name: "Function", Description: "a human-readable representation of a Solidity function call or event", Fields: []graphqlField{
+			{Name: "name", Type: "String", Description: "the name of the interface"},
+			{Name: "type", Type: "String", Description: "the type of the interface, either 'event' or 'function'"},
+			{Name: "anonymous", Type: "Boolean"},
+			{Name: "constant", Type: "Boolean"},
+			{Name: "stateMutability", Type: "String"},
+			{Name: "signature", Type: "String", Description: "the canonical signature of the interface"},
+			{Name: "encoding", Type: "String", Description: "the signature encoded with keccak"},
+			{Name: "message", Type: "String"},
+			{Name: "inputs", Type: "Parameter", List: true, Description: "the input parameters to the function, if any"},
+			{Name: "outputs", Type: "Parameter", List: true, Description: "the output parameters to the function, if any"},
+		}},
+		{Name: "Parameter", Description: "an input or output parameter to a Solidity function or event", Fields: []graphqlField{
+			{Name: "type", Type: "String", Description: "the type of this parameter"},
+			{Name: "name", Type: "String", Description: "the name of this parameter"},
+			{Name: "strDefault", Type: "String", Description: "the default value of this parameter, if any"},
+			{Name: "value", Type: "String"},
+			{Name: "indexed", Type: "Boolean", Description: "`true` if this parameter is indexed"},
+			{Name: "internalType", Type: "String", Description: "for composite types, the internal type of the parameter"},
+			{Name: "components", Type: "Parameter", List: true, Description: "for composite types, the parameters making up the composite"},
+		}},
+		{Name: "Slurp", Description: "transaction data as returned from by remote APIs", Fields: []graphqlField{
+			{Name: "hash", Type: "String", Description: "the hash of the transaction"},
+			{Name: "blockHash", Type: "String", Description: "the hash of the block containing this transaction"},
+			{Name: "blockNumber", Type: "Int64", Description: "the number of the block"},
+			{Name: "transactionIndex", Type: "Int64", Description: "the zero-indexed position of the transaction in the block"},
+			{Name: "nonce", Type: "Int64", Description: "sequence number of the transactions sent by the sender"},
+			{Name: "timestamp", Type: "Int64", Description: "the Unix timestamp of the object"},
+			{Name: "date", Type: "String", Description: "the timestamp as a date"},
+			{Name: "from", Type: "String", Description: "address from which the transaction was sent"},
+			{Name: "to", Type: "String", Description: "address to which the transaction was sent"},
+			{Name: "value", Type: "String", Description: "the amount of wei sent with this transactions"},
+			{Name: "ether", Type: "String", Description: "if --ether is specified, the value in ether"},
+			{Name: "gas", Type: "Int64", Description: "the maximum number of gas allowed for this transaction"},
+			{Name: "gasPrice", Type: "Int64", Description: "the number of wei per unit of gas the sender is willing to spend"},
+			{Name: "input", Type: "String", Description: "byte data either containing a message or funcational data for a smart contracts. See the --articulate"},
+			{Name: "hasToken", Type: "Boolean", Description: "`true` if the transaction is token related, `false` otherwise"},
+			{Name: "articulatedTx", Type: "Function", Description: "if present, the function that was called in the transaction"},
+			{Name: "compressedTx", Type: "String", Description: "truncated, more readable version of the articulation"},
+			{Name: "functionName", Type: "String", Description: "the name of the articulated function if any"},
+			{Name: "methodId", Type: "String", Description: "the fourbyte of the function"},
+			{Name: "gasUsed", Type: "Int64", Description: "the amount of gas used by the transaction (from the receipt)"},
+			{Name: "contractAddress", Type: "String", Description: "if created, the address of the newly-created contract"},
+			{Name: "cumulativeGasUsed", Type: "String", Description: "a basically unused field showing all gas used"},
+			{Name: "txReceiptStatus", Type: "String", Description: "the status field from the receipt"},
+			{Name: "withdrawalIndex", Type: "Int64", Description: "for withdrawal transactions only, the index of the withdrawal since inception"},
+			{Name: "validatorIndex", Type: "Int64", Description: "for withdrawal transactions only, the index of the validator receiving the withdrawal"},
+		}},
+		{Name: "Message", Description: "used for various responses when no real data is generated", Fields: []graphqlField{
+			{Name: "msg", Type: "String", Description: "the message"},
+			{Name: "num", Type: "Int64", Description: "a number if needed"},
+		}},
+		{Name: "Count", Description: "the number of items in the given database", Fields: []graphqlField{
+			{Name: "count", Type: "Int64", Description: "the number of items in the given database"},
+		}},
+		{Name: "Destination", Description: "an enhanced url used by chifra explore", Fields: []graphqlField{
+			{Name: "term", Type: "String", Description: "the term used to produce the url"},
+			{Name: "termType", Type: "JSON", Description: "the type of the term"},
+			{Name: "url", Type: "String", Description: "the url produced"},
+			{Name: "source", Type: "String", Description: "the option that produced the url"},
+		}},
+	}
+}
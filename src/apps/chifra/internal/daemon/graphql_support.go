@@ -0,0 +1,194 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// graphqlScalars are the types of the models' fields that are not models themselves
+var graphqlScalars = map[string]*graphql.Scalar{
+	"Int":     graphql.Int,
+	"Int64":   graphqlInt64,
+	"Float":   graphql.Float,
+	"Boolean": graphql.Boolean,
+	"String":  graphql.String,
+	"JSON":    graphqlJSON,
+}
+
+// graphqlInt64 holds the 64-bit integers (block numbers, timestamps, and gas, for example) that
+// GraphQL's Int, which has 32 bits, cannot
+var graphqlInt64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Int64",
+	Description: "a 64-bit integer",
+	Serialize:   coerceInt64,
+	ParseValue:  coerceInt64,
+	ParseLiteral: func(value ast.Value) any {
+		if v, ok := value.(*ast.IntValue); ok {
+			if n, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				return n
+			}
+		}
+		return nil
+	},
+})
+
+// coerceInt64 returns the integer as an int64 or a uint64. Variables, which are decoded from
+// JSON, arrive as floats.
+func coerceInt64(value any) any {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); f == float64(int64(f)) {
+			return int64(f)
+		}
+	}
+	return nil
+}
+
+// graphqlJSON holds the values that are neither models nor one of the other scalars. They appear
+// as they do in the JSON output.
+var graphqlJSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "a value as it appears in the JSON output",
+	Serialize:    func(value any) any { return value },
+	ParseValue:   func(value any) any { return value },
+	ParseLiteral: func(value ast.Value) any { return value.GetValue() },
+})
+
+// graphqlDepth returns how deeply the fields of the document's operations nest. Fragments count
+// as if they were written out. Introspection fields, which the schema itself bounds, are not
+// counted.
+func graphqlDepth(doc *ast.Document) int {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok {
+			fragments[frag.Name.Value] = frag
+		}
+	}
+
+	// the depth of each fragment is kept, so fragments spread many times are walked once
+	depths := make(map[string]int)
+	var depth func(set *ast.SelectionSet) int
+	depth = func(set *ast.SelectionSet) int {
+		if set == nil {
+			return 0
+		}
+		deepest := 0
+		for _, sel := range set.Selections {
+			d := 0
+			switch sel := sel.(type) {
+			case *ast.Field:
+				if !strings.HasPrefix(sel.Name.Value, "__") {
+					d = 1 + depth(sel.SelectionSet)
+				}
+			case *ast.InlineFragment:
+				d = depth(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				name := sel.Name.Value
+				if known, ok := depths[name]; ok {
+					d = known
+				} else if frag, ok := fragments[name]; ok {
+					// validation refuses cycles, but a fragment being walked counts as empty
+					depths[name] = 0
+					d = depth(frag.SelectionSet)
+					depths[name] = d
+				}
+			}
+			deepest = max(deepest, d)
+		}
+		return deepest
+	}
+
+	deepest := 0
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			deepest = max(deepest, depth(op.SelectionSet))
+		}
+	}
+	return deepest
+}
+
+// graphqlSDL returns the schema in GraphQL's schema definition language. The types, their
+// fields, and the fields' arguments are sorted by name.
+func graphqlSDL(schema *graphql.Schema) string {
+	var sb strings.Builder
+	query := schema.QueryType()
+	sb.WriteString("schema {\n  query: " + query.Name() + "\n}\n")
+
+	scalars, objects := []string{}, []string{}
+	for name, typ := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch typ.(type) {
+		case *graphql.Scalar:
+			if graphqlScalars[name] != nil && name != "Int64" && name != "JSON" {
+				// GraphQL's own scalars are not declared
+				continue
+			}
+			scalars = append(scalars, name)
+		case *graphql.Object:
+			if name != query.Name() {
+				objects = append(objects, name)
+			}
+		}
+	}
+	sort.Strings(scalars)
+	sort.Strings(objects)
+
+	for _, name := range scalars {
+		sb.WriteString("\n")
+		writeDescription(&sb, "", schema.Type(name).Description())
+		sb.WriteString("scalar " + name + "\n")
+	}
+
+	for _, name := range append([]string{query.Name()}, objects...) {
+		obj := schema.Type(name).(*graphql.Object)
+		sb.WriteString("\n")
+		writeDescription(&sb, "", obj.Description())
+		sb.WriteString("type " + obj.Name() + " {\n")
+
+		fields := obj.Fields()
+		fieldNames := make([]string, 0, len(fields))
+		for name := range fields {
+			fieldNames = append(fieldNames, name)
+		}
+		sort.Strings(fieldNames)
+		for _, name := range fieldNames {
+			f := fields[name]
+			writeDescription(&sb, "  ", f.Description)
+			sb.WriteString("  " + f.Name)
+			if len(f.Args) > 0 {
+				args := make([]string, 0, len(f.Args))
+				for _, a := range f.Args {
+					args = append(args, a.Name()+": "+a.Type.String())
+				}
+				sort.Strings(args)
+				sb.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			sb.WriteString(": " + f.Type.String() + "\n")
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+func writeDescription(sb *strings.Builder, indent, description string) {
+	if len(description) > 0 {
+		sb.WriteString(fmt.Sprintf("%s%q\n", indent, description))
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/graphql-go/graphql"
+)
+
+func TestGraphqlModels(t *testing.T) {
+	emitter := base.HexToAddress("0x00000000000000000000000000000000000000aa")
+	other := base.HexToAddress("0x00000000000000000000000000000000000000bb")
+	block := types.Block{
+		BlockNumber: 18000000,
+		Timestamp:   1693066895,
+		Transactions: []types.Transaction{
+			{
+				BlockNumber:      18000000,
+				TransactionIndex: 0,
+				Hash:             base.HexToHash("0x01"),
+				Receipt: &types.Receipt{Logs: []types.Log{
+					{LogIndex: 0, Address: other},
+					{LogIndex: 1, Address: emitter},
+				}},
+			},
+			{
+				BlockNumber:      18000000,
+				TransactionIndex: 1,
+				Hash:             base.HexToHash("0x02"),
+				Receipt:          &types.Receipt{Logs: []types.Log{{LogIndex: 2, Address: other}}},
+			},
+		},
+	}
+
+	// the models' object types under a root that returns the block
+	objects := graphqlObjects()
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"block": {Type: objects["Block"], Resolve: func(p graphql.ResolveParams) (any, error) { return &block, nil }},
+		},
+	})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := &graphqlQuery{chain: "mainnet", models: make(map[types.Modeler]map[string]any)}
+	ctx := context.WithValue(context.Background(), graphqlQueryKey{}, q)
+	resp := executeGraphql(ctx, &schema, graphqlRequest{Query: `{
+		block {
+			blockNumber
+			transactions {
+				transactionIndex
+				isError
+				receipt { logs(address: "0x00000000000000000000000000000000000000AA") { logIndex address } }
+			}
+		}
+	}`})
+	got, _ := json.Marshal(resp)
+	expected := `{"data":{"block":{"blockNumber":18000000,"transactions":[` +
+		`{"isError":false,"receipt":{"logs":[{"address":"0x00000000000000000000000000000000000000aa","logIndex":1}]},"transactionIndex":0},` +
+		`{"isError":false,"receipt":{"logs":[]},"transactionIndex":1}]}}}`
+	if string(got) != expected {
+		t.Error("Expected", expected, "got", string(got))
+	}
+}
+
+func TestServeGraphQL(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	server := httptest.NewServer(NewRouter(true))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/graphql/schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdl, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	for _, expected := range []string{
+		"type Query {",
+		"  blocks(ids: [String]!): [Block]\n",
+		"logs(address: String, blockHash: String, blockNumber: Int64, compressedLog: String, data: String, date: String, first: Int, logIndex: Int64, timestamp: Int64, transactionHash: String, transactionIndex: Int64): [Log]\n",
+		"scalar Int64\n",
+		"type Account {",
+	} {
+		if !strings.Contains(string(sdl), expected) {
+			t.Errorf("Expected the schema to contain %q", expected)
+		}
+	}
+
+	tests := []struct {
+		body     string
+		status   int
+		expected string
+	}{
+		{`{"query": "{ __typename }"}`, http.StatusOK, `"__typename": "Query"`},
+		{`{"query": "{ blocks { hash } }"}`, http.StatusBadRequest, `argument \"ids\" of type \"[String]!\" is required`},
+		{`{"query": "{ account(address: \"0x12\") { address } }"}`, http.StatusOK, `invalid address 0x12`},
+		{`not json`, http.StatusBadRequest, `invalid request`},
+		{`{"query": "{ blocks(ids: [\"0-18000000\"]) { hash } }"}`, http.StatusOK, `the query reads more than 1000 blocks`},
+		{`{"query": "{ blocks(ids: [\"0-20000000:1\"]) { hash } }"}`, http.StatusOK, `the query reads more than 1000 blocks`},
+		{`{"query": "{ blocks(ids: [\"0-20000000:10000\"]) { hash } }"}`, http.StatusOK, `the query reads more than 1000 blocks`},
+		{`{"query": "{ account(address: \"0x000000000000000000000000000000000000dead\") { appearances { blockNumber } } }"}`, http.StatusOK, `there is no monitor for 0x000000000000000000000000000000000000dead`},
+		{`{"query": "{ account(address: \"0x1\") { appearances { transaction { receipt { logs { articulatedLog { inputs { components { components { components { name } } } } } } } } } } }"}`, http.StatusBadRequest, `the query nests fields 11 deep, more than the 10 allowed`},
+	}
+	for _, test := range tests {
+		resp, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != test.status || !strings.Contains(string(body), test.expected) {
+			t.Error("Expected", test.status, test.expected, "for", test.body, "got", resp.StatusCode, string(body))
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/progress"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// progressInterval is how often the progress of a command run over a websocket is reported
+var progressInterval = 250 * time.Millisecond
+
+// runCommand runs a command sent by the client and streams its records back one by one, each
+// tagged with the command's id. The command is authorized and validated as if it had been sent
+// to its route.
+func (c *Connection) runCommand(id, content string) {
+	fail := func(err error) {
+		c.deliver(&Message{Action: CommandErrorMessage, ID: id, Content: err.Error()})
+		c.deliver(&Message{Action: CommandDoneMessage, ID: id})
+	}
+
+	u, err := url.Parse(content)
+	if err != nil {
+		fail(err)
+		return
+	}
+	name := strings.Trim(u.Path, "/")
+	route, ok := jobRoute(name)
+	if !ok {
+		fail(fmt.Errorf("there is no route named %s", name))
+		return
+	}
+
+	rCtx := output.NewStreamingContext()
+	rCtx.Progress = &progress.Tracker{}
+	if !c.startCommand(id, rCtx) {
+		fail(fmt.Errorf("a command with id %s is already running", id))
+		return
+	}
+	defer c.endCommand(id)
+
+	req := c.request.Clone(output.WithRenderContext(c.ctx, rCtx))
+	req.URL = &url.URL{Path: route.Pattern, RawQuery: u.RawQuery}
+	req.RequestURI = req.URL.RequestURI()
+
+	w := &jobWriter{header: http.Header{}}
+	Authorize(route, ValidateParams(route, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.stream(id, route, w, r, rCtx)
+	}))).ServeHTTP(w, req)
+
+	if w.failed {
+		for _, msg := range errorsFrom(w.body.Bytes()) {
+			c.deliver(&Message{Action: CommandErrorMessage, ID: id, Content: msg})
+		}
+	}
+	c.deliver(&Message{Action: CommandDoneMessage, ID: id})
+}
+
+// stream runs the route with a streaming render context and sends each record, error and change
+// in progress to the client until the route returns
+func (c *Connection) stream(id string, route Route, w http.ResponseWriter, r *http.Request, rCtx *output.RenderCtx) {
+	values := r.URL.Query()
+	chain := values.Get("chain")
+	if len(chain) == 0 {
+		chain = config.GetSettings().DefaultChain
+	}
+	verbose := values.Has("verbose") && values.Get("verbose") != "false"
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		route.HandlerFunc(w, r)
+	}()
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	var lastDone, lastTotal int64
+
+	// keep reading until the route returns even if the client has gone away, otherwise
+	// the route would block sending its next record
+	for {
+		select {
+		case model := <-rCtx.ModelChan:
+			c.deliver(&Message{Action: CommandOutputMessage, ID: id, Data: modelData(model, rCtx.Options, chain, verbose)})
+		case err := <-rCtx.ErrorChan:
+			c.deliver(&Message{Action: CommandErrorMessage, ID: id, Content: err.Error()})
+		case <-ticker.C:
+			if d, t := rCtx.Progress.Progress(); d != lastDone || t != lastTotal {
+				lastDone, lastTotal = d, t
+				c.deliver(&Message{
+					Action:  ProgressMessage,
+					ID:      id,
+					Content: fmt.Sprintf("%d of %d", d, t),
+					Data:    map[string]int64{"done": d, "total": t},
+				})
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// modelData returns the record as the route would render it in JSON. The route's output options
+// carry the options (--articulate or --ether, for example) that change what's rendered.
+func modelData(model types.Modeler, opts *output.OutputOptions, chain string, verbose bool) map[string]any {
+	if opts == nil {
+		return model.Model(chain, "json", verbose, nil).Data
+	}
+	if len(opts.Chain) > 0 {
+		chain = opts.Chain
+	}
+	return model.Model(chain, "json", opts.Verbose, opts.Extra).Data
+}
+
+// startCommand records the running command so it may be cancelled. It fails if the id is in use.
+func (c *Connection) startCommand(id string, rCtx *output.RenderCtx) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.running[id]; ok {
+		return false
+	}
+	c.running[id] = rCtx.Cancel
+
+	// the command is cancelled if the client disconnects
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			rCtx.Cancel()
+		case <-rCtx.Ctx.Done():
+		}
+	}()
+	return true
+}
+
+func (c *Connection) endCommand(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if cancel, ok := c.running[id]; ok {
+		cancel()
+		delete(c.running, id)
+	}
+}
+
+// cancelCommand cancels the client's command with the id
+func (c *Connection) cancelCommand(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if cancel, ok := c.running[id]; ok {
+		cancel()
+	}
+}
+
+// errorsFrom returns the errors reported by RespondWithError or, if the body holds something
+// else, the body itself
+func errorsFrom(body []byte) []string {
+	var response struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err == nil && len(response.Errors) > 0 {
+		return response.Errors
+	}
+	if msg := strings.TrimSpace(string(body)); len(msg) > 0 {
+		return []string{msg}
+	}
+	return []string{"the command failed"}
+}
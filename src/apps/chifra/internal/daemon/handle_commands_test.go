@@ -0,0 +1,249 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/gorilla/websocket"
+)
+
+// streamRoute streams two names and an error, then, if asked to wait, waits to be cancelled
+func streamRoute(t *testing.T, cancelled chan struct{}) {
+	saved := jobRoutes
+	t.Cleanup(func() { jobRoutes = saved })
+	jobRoutes = append(jobRoutes, Route{"RouteStream", "GET", "/stream", ScopeReadOnly, nil, func(w http.ResponseWriter, r *http.Request) {
+		rCtx := output.RenderContextFrom(r.Context())
+		fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+			bar := logger.NewBar(logger.BarOptions{Total: 4, Tracker: rCtx.Progress})
+			modelChan <- &types.Name{Name: "first"}
+			bar.Tick()
+			errorChan <- errors.New("something went wrong")
+			modelChan <- &types.Name{Name: "second"}
+			bar.Tick()
+			if r.URL.Query().Has("wait") {
+				<-rCtx.Ctx.Done()
+				close(cancelled)
+			}
+		}
+		_ = output.StreamMany(rCtx, fetchData, output.OutputOptions{Format: "json"})
+	}})
+}
+
+func dialSockets(t *testing.T) *websocket.Conn {
+	pool := newConnectionPool()
+	go pool.run()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		HandleWebsockets(pool, w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func readUntilDone(t *testing.T, conn *websocket.Conn, id string) []Message {
+	ret := []Message{}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.ID != id {
+			continue
+		}
+		if msg.Action == CommandDoneMessage {
+			return ret
+		}
+		ret = append(ret, msg)
+	}
+}
+
+func TestStreamCommand(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	streamRoute(t, nil)
+	saved := progressInterval
+	progressInterval = time.Millisecond
+	defer func() { progressInterval = saved }()
+
+	conn := dialSockets(t)
+	defer conn.Close()
+
+	_ = conn.WriteJSON(Message{Action: CommandMessage, ID: "req-1", Content: "stream?chain=mainnet"})
+	messages := readUntilDone(t, conn, "req-1")
+
+	names, errs := []string{}, []string{}
+	for _, msg := range messages {
+		switch msg.Action {
+		case CommandOutputMessage:
+			names = append(names, msg.Data.(map[string]any)["name"].(string))
+		case CommandErrorMessage:
+			errs = append(errs, msg.Content)
+		}
+	}
+	if strings.Join(names, ",") != "first,second" {
+		t.Error("Expected both records in order, got", names)
+	}
+	if len(errs) != 1 || errs[0] != "something went wrong" {
+		t.Error("Expected the error, got", errs)
+	}
+
+	_ = conn.WriteJSON(Message{Action: CommandMessage, ID: "req-2", Content: "nosuch"})
+	if messages := readUntilDone(t, conn, "req-2"); len(messages) != 1 || messages[0].Action != CommandErrorMessage {
+		t.Error("Expected an unknown route to fail, got", messages)
+	}
+}
+
+// articulateRoute streams a call's result with the output options a command would use
+func articulateRoute(t *testing.T) {
+	saved := jobRoutes
+	t.Cleanup(func() { jobRoutes = saved })
+	jobRoutes = append(jobRoutes, Route{"RouteArticulate", "GET", "/articulate", ScopeReadOnly, nil, func(w http.ResponseWriter, r *http.Request) {
+		rCtx := output.RenderContextFrom(r.Context())
+		fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+			modelChan <- &types.Result{
+				Encoding:       "0x18160ddd",
+				ArticulatedOut: &types.Function{Name: "totalSupply"},
+				RevertReason:   &types.Function{Name: "Error", Message: "not allowed"},
+			}
+		}
+		extraOpts := map[string]any{"articulate": r.URL.Query().Has("articulate")}
+		_ = output.StreamMany(rCtx, fetchData, output.OutputOptions{Format: "json", Chain: "mainnet", Extra: extraOpts})
+	}})
+}
+
+func TestStreamCommandArticulated(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	articulateRoute(t)
+
+	conn := dialSockets(t)
+	defer conn.Close()
+
+	record := func(id, content string) map[string]any {
+		_ = conn.WriteJSON(Message{Action: CommandMessage, ID: id, Content: content})
+		for _, msg := range readUntilDone(t, conn, id) {
+			if msg.Action == CommandOutputMessage {
+				return msg.Data.(map[string]any)
+			}
+		}
+		t.Fatal("Expected a record for", content)
+		return nil
+	}
+
+	articulated := record("req-1", "articulate?articulate")
+	if callResult, ok := articulated["callResult"].(map[string]any); !ok || callResult["name"] != "totalSupply" {
+		t.Error("Expected an articulated call result, got", articulated["callResult"])
+	}
+	if _, ok := articulated["revertReason"]; !ok {
+		t.Error("Expected the revert reason with --articulate, got", articulated)
+	}
+
+	plain := record("req-2", "articulate")
+	if callResult, _ := plain["callResult"].(map[string]any); callResult["name"] == "totalSupply" {
+		t.Error("Expected a plain call result without --articulate, got", plain["callResult"])
+	}
+	if _, ok := plain["revertReason"]; ok {
+		t.Error("Expected no revert reason without --articulate, got", plain)
+	}
+}
+
+func TestStreamCommandAuthorized(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{
+		ApiKeys:  []configtypes.ApiKeyGroup{{Name: "reader", Key: "r-key", Scopes: []string{ScopeReadOnly}}},
+		AuditLog: filepath.Join(t.TempDir(), "audit.log"),
+	})
+	streamRoute(t, nil)
+
+	// the socket itself isn't behind the router here, so the command's own check rejects it
+	conn := dialSockets(t)
+	defer conn.Close()
+	_ = conn.WriteJSON(Message{Action: CommandMessage, ID: "req-1", Content: "stream"})
+	if messages := readUntilDone(t, conn, "req-1"); len(messages) != 1 || !strings.Contains(messages[0].Content, "API key") {
+		t.Error("Expected the command to be refused, got", messages)
+	}
+}
+
+func TestStreamCancelOnDisconnect(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	cancelled := make(chan struct{})
+	streamRoute(t, cancelled)
+
+	conn := dialSockets(t)
+	_ = conn.WriteJSON(Message{Action: CommandMessage, ID: "req-1", Content: "stream?wait"})
+
+	// wait for the second record, then go away
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for seen := 0; seen < 2; {
+		var msg Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Action == CommandOutputMessage {
+			seen++
+		}
+	}
+	conn.Close()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the command to be cancelled when the client disconnected")
+	}
+}
+
+func TestWebsocketAuth(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{
+		ApiKeys:     []configtypes.ApiKeyGroup{{Name: "reader", Key: "r-key", Scopes: []string{ScopeReadOnly}}},
+		CorsOrigins: []string{"https://a.example"},
+		AuditLog:    filepath.Join(t.TempDir(), "audit.log"),
+	})
+	pool := newConnectionPool()
+	go pool.run()
+	server := httptest.NewServer(Authorize(routeNamed(t, "Websockets"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		HandleWebsockets(pool, w, r)
+	})))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		origin    string
+		protocols []string
+		expected  int
+	}{
+		{"https://b.example", []string{"chifra", keyProtocol + "r-key"}, http.StatusForbidden},
+		{"https://a.example", []string{"chifra"}, http.StatusUnauthorized},
+		{"https://a.example", []string{"chifra", keyProtocol + "x-key"}, http.StatusUnauthorized},
+		{"https://a.example", []string{"chifra", keyProtocol + "r-key"}, http.StatusSwitchingProtocols},
+	}
+	for _, test := range tests {
+		dialer := websocket.Dialer{Subprotocols: test.protocols}
+		conn, resp, err := dialer.Dial(url, http.Header{"Origin": {test.origin}})
+		if resp == nil || resp.StatusCode != test.expected {
+			t.Error("Expected", test.expected, "from", test.origin, test.protocols, "got", resp, err)
+			continue
+		}
+		if conn != nil {
+			if conn.Subprotocol() != "chifra" {
+				t.Error("Expected the chifra subprotocol, got", conn.Subprotocol())
+			}
+			conn.Close()
+		}
+	}
+}
@@ -5,9 +5,11 @@
 package daemonPkg
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/gorilla/websocket"
@@ -19,19 +21,36 @@ type MessageType string
 const (
 	// CommandErrorMessage is a message send when the server encounters an error
 	CommandErrorMessage MessageType = "command_error"
-	// CommandOutputMessage is currently not used, but may in the future carry the actual data
+	// CommandOutputMessage carries one record of a command's output
 	CommandOutputMessage MessageType = "output"
-	// ProgressMessage is a message carried on the stderr stream
+	// ProgressMessage is a message carried on the stderr stream or the progress of a command
 	ProgressMessage MessageType = "progress"
+	// CommandMessage is sent by a client to run a command. Its content is the route followed
+	// by the route's query parameters (for example, export?addrs=trueblocks.eth&accounting)
+	CommandMessage MessageType = "command"
+	// CancelMessage is sent by a client to cancel the command with the message's id
+	CancelMessage MessageType = "cancel"
+	// CommandDoneMessage is sent when a command has no more output
+	CommandDoneMessage MessageType = "done"
 )
 
-var upgrader = websocket.Upgrader{}
+// keyProtocol prefixes the API key when a client sends it as a websocket subprotocol. Browsers
+// cannot set headers on a websocket, so they open it with the subprotocols "chifra" and
+// "chifra.key.<key>". The server answers with "chifra" alone.
+const keyProtocol = "chifra.key."
 
-// Message is a structure used to send messages via websockets
+var upgrader = websocket.Upgrader{
+	CheckOrigin:  func(r *http.Request) bool { return originAllowed(r.Header.Get("Origin")) },
+	Subprotocols: []string{"chifra"},
+}
+
+// Message is a structure used to send messages via websockets. The ID of a message about a
+// command is the id the client gave the command.
 type Message struct {
 	Action  MessageType `json:"action"`
 	ID      string      `json:"id"`
 	Content string      `json:"content"`
+	Data    any         `json:"data,omitempty"`
 }
 
 // Connection is a structure representing a websocket connection
@@ -39,6 +58,11 @@ type Connection struct {
 	connection *websocket.Conn
 	pool       *ConnectionPool
 	send       chan *Message
+	request    *http.Request
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mutex      sync.Mutex
+	running    map[string]context.CancelFunc
 }
 
 // write the message to the connection
@@ -47,17 +71,14 @@ func (c *Connection) write() {
 		c.connection.Close()
 	}()
 
-	// this is a common pattern for a websocket connection
-	//nolint:staticcheck,gosimple
 	for {
 		select {
-		case message, ok := <-c.send:
-			if !ok {
-				c.Log("Connection closed")
-				_ = c.connection.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
+		case <-c.ctx.Done():
+			c.Log("Connection closed")
+			_ = c.connection.WriteMessage(websocket.CloseMessage, []byte{})
+			return
 
+		case message := <-c.send:
 			err := c.connection.WriteJSON(message)
 			if err != nil {
 				// c.Log("Error while sending message, dropping connection: %s", err.Error())
@@ -67,6 +88,38 @@ func (c *Connection) write() {
 	}
 }
 
+// read the client's messages until the client disconnects, which cancels its commands
+func (c *Connection) read() {
+	defer func() {
+		c.pool.unregister <- c
+	}()
+
+	for {
+		var message Message
+		if err := c.connection.ReadJSON(&message); err != nil {
+			return
+		}
+		switch message.Action {
+		case CommandMessage:
+			go c.runCommand(message.ID, message.Content)
+		case CancelMessage:
+			c.cancelCommand(message.ID)
+		default:
+			c.deliver(&Message{Action: CommandErrorMessage, ID: message.ID, Content: fmt.Sprintf("unknown action %s", message.Action)})
+		}
+	}
+}
+
+// deliver queues the message for the client. It returns false if the client has gone away.
+func (c *Connection) deliver(message *Message) bool {
+	select {
+	case c.send <- message:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
+
 // RemoteAddr is the other end of the connection
 func (c *Connection) RemoteAddr() net.Addr {
 	return c.connection.RemoteAddr()
@@ -87,10 +140,10 @@ type ConnectionPool struct {
 	unregister  chan *Connection
 }
 
-// closeAndDelete cleans up a connection
+// closeAndDelete cleans up a connection. Cancelling its context stops its writer and its commands.
 func closeAndDelete(pool *ConnectionPool, connection *Connection) {
 	delete(pool.connections, connection)
-	close(connection.send)
+	connection.cancel()
 }
 
 // newConnectionPool returns a new connection structure
@@ -121,7 +174,7 @@ func (pool *ConnectionPool) run() {
 		// handle a signal to broadcast a message
 		case message := <-pool.broadcast:
 			for connection := range pool.connections {
-				connection.send <- message
+				connection.deliver(message)
 			}
 		}
 	}
@@ -129,20 +182,26 @@ func (pool *ConnectionPool) run() {
 
 // HandleWebsockets handles web sockets
 func HandleWebsockets(pool *ConnectionPool, w http.ResponseWriter, r *http.Request) {
-	// TODO: the line below allows any connection through WebSockets. Once the server
-	// TODO: is ready, we should implement some rules here
-	upgrader.CheckOrigin = func(r *http.Request) bool { return true }
-
 	c, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		logger.Error("upgrade:", err)
 		return
 	}
 
-	connection := &Connection{connection: c, send: make(chan *Message), pool: pool}
+	ctx, cancel := context.WithCancel(context.Background())
+	connection := &Connection{
+		connection: c,
+		send:       make(chan *Message),
+		pool:       pool,
+		request:    r,
+		ctx:        ctx,
+		cancel:     cancel,
+		running:    map[string]context.CancelFunc{},
+	}
 	pool.register <- connection
 
 	go connection.write()
+	go connection.read()
 }
 
 var connectionPool = newConnectionPool()
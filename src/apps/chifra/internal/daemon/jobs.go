@@ -0,0 +1,471 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/progress"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/gorilla/mux"
+)
+
+// A job runs any route in the background. POST /jobs/<route> with the route's usual query
+// parameters starts the job and returns its id. GET /jobs/<id> reports its status and progress,
+// GET /jobs/<id>/result returns its output (in the format given with fmt, or else the format
+// given when it was submitted) and DELETE /jobs/<id> cancels it. The records a job produces are
+// kept as they are and rendered when the result is read. Finished jobs are kept for
+// config.JobRetention. A job belongs to the API key that submitted it (see mayAccess), which
+// may have at most config.MaxJobs jobs running at once.
+
+const (
+	JobRunning   = "running"
+	JobFinished  = "finished"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+type job struct {
+	id       string
+	owner    string
+	route    Route
+	request  *http.Request
+	format   string
+	rCtx     *output.RenderCtx
+	writer   *jobWriter
+	records  []types.Modeler
+	errors   []string
+	started  time.Time
+	finished time.Time
+	status   string
+	canceled bool
+}
+
+// JobSummary is what the job API reports about a job
+type JobSummary struct {
+	Id       string `json:"id"`
+	Key      string `json:"key,omitempty"`
+	Route    string `json:"route"`
+	Params   string `json:"params,omitempty"`
+	Status   string `json:"status"`
+	Done     int64  `json:"done"`
+	Total    int64  `json:"total"`
+	Started  int64  `json:"started"`
+	Finished int64  `json:"finished,omitempty"`
+	Expires  int64  `json:"expires,omitempty"`
+}
+
+type jobManager struct {
+	mutex sync.Mutex
+	jobs  map[string]*job
+}
+
+var jobs = jobManager{jobs: map[string]*job{}}
+
+// errTooManyJobs is returned if the key already has as many jobs running as it may
+var errTooManyJobs = errors.New("too many jobs are running")
+
+// start runs the route's handler in the background. The request's context is replaced so the
+// job outlives the request that submitted it. The route sends its records to the job rather
+// than rendering them.
+func (m *jobManager) start(route Route, r *http.Request) (*job, error) {
+	rCtx := output.NewStreamingContext()
+	rCtx.Progress = &progress.Tracker{}
+	j := &job{
+		id:      newJobId(),
+		owner:   requestKeyName(r),
+		route:   route,
+		request: r.WithContext(output.WithRenderContext(context.Background(), rCtx)),
+		format:  r.URL.Query().Get("fmt"),
+		rCtx:    rCtx,
+		writer:  &jobWriter{header: http.Header{}},
+		started: time.Now(),
+		status:  JobRunning,
+	}
+
+	m.mutex.Lock()
+	running := 0
+	for _, other := range m.jobs {
+		if other.owner == j.owner && other.status == JobRunning {
+			running++
+		}
+	}
+	if running >= config.MaxJobs() {
+		m.mutex.Unlock()
+		return nil, fmt.Errorf("%w: the key may run %d at once", errTooManyJobs, config.MaxJobs())
+	}
+	m.jobs[j.id] = j
+	m.mutex.Unlock()
+
+	go func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer func() {
+				if p := recover(); p != nil {
+					logger.Error(fmt.Sprintf("job %s panicked: %v", j.id, p))
+					j.writer.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+			route.HandlerFunc(j.writer, j.request)
+		}()
+		j.collect(done)
+		m.finish(j)
+	}()
+
+	return j, nil
+}
+
+// collect keeps the records and errors the route sends until the route returns
+func (j *job) collect(done chan struct{}) {
+	for {
+		select {
+		case model := <-j.rCtx.ModelChan:
+			j.records = append(j.records, model)
+		case err := <-j.rCtx.ErrorChan:
+			j.errors = append(j.errors, err.Error())
+		case <-done:
+			return
+		}
+	}
+}
+
+// render writes the job's records in the format. Routes that write their output themselves
+// rather than sending records have it written as they wrote it.
+func (j *job) render(w http.ResponseWriter, format string) {
+	status := j.writer.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	opts := j.rCtx.Options
+	if opts == nil {
+		w.Header().Set("Content-Type", contentTypeFor(j.format))
+		w.WriteHeader(status)
+		_, _ = w.Write(j.writer.body.Bytes())
+		return
+	}
+
+	errs := j.errors
+	if j.writer.failed {
+		errs = append(errs, errorsFrom(j.writer.body.Bytes())...)
+	}
+
+	if format == "json" {
+		data := make([]map[string]any, 0, len(j.records))
+		for _, model := range j.records {
+			data = append(data, model.Model(opts.Chain, format, opts.Verbose, opts.Extra).Data)
+		}
+		marshalled, _ := json.MarshalIndent(struct {
+			Data   []map[string]any `json:"data"`
+			Errors []string         `json:"errors,omitempty"`
+		}{data, errs}, "", "  ")
+		w.Header().Set("Content-Type", contentTypeFor(format))
+		w.WriteHeader(status)
+		_, _ = w.Write(marshalled)
+		return
+	}
+
+	// as with the routes themselves, errors are not part of csv or txt output
+	var buf bytes.Buffer
+	for i, model := range j.records {
+		modelValue := model.Model(opts.Chain, format, opts.Verbose, opts.Extra)
+		if err := output.StreamModel(&buf, modelValue, output.OutputOptions{Format: format, NoHeader: i > 0 || opts.NoHeader}); err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", contentTypeFor(format))
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (m *jobManager) finish(j *job) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	j.finished = time.Now()
+	switch {
+	case j.canceled:
+		j.status = JobCancelled
+	case j.writer.failed:
+		j.status = JobFailed
+	default:
+		j.status = JobFinished
+	}
+}
+
+// cancel cancels the job's render context. The job stops at its next check for cancellation.
+func (m *jobManager) cancel(j *job) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if j.status == JobRunning {
+		j.canceled = true
+		j.rCtx.Cancel()
+	}
+}
+
+// get returns the job with the id after dropping the jobs that have expired
+func (m *jobManager) get(id string) (*job, bool) {
+	m.purge()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	j, ok := m.jobs[id]
+	return j, ok
+}
+
+// list reports on the jobs the request may access
+func (m *jobManager) list(r *http.Request) []JobSummary {
+	m.purge()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	ret := make([]JobSummary, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		if mayAccess(j, r) {
+			ret = append(ret, j.summaryLocked())
+		}
+	}
+	return ret
+}
+
+func (m *jobManager) summary(j *job) JobSummary {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return j.summaryLocked()
+}
+
+// purge drops finished jobs older than the retention period
+func (m *jobManager) purge() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	cutoff := time.Now().Add(-config.JobRetention())
+	for id, j := range m.jobs {
+		if j.status != JobRunning && j.finished.Before(cutoff) {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// summaryLocked reports on the job. The manager's mutex must be held.
+func (j *job) summaryLocked() JobSummary {
+	done, total := j.rCtx.Progress.Progress()
+	ret := JobSummary{
+		Id:      j.id,
+		Key:     j.owner,
+		Route:   j.route.Pattern[1:],
+		Params:  j.request.URL.RawQuery,
+		Status:  j.status,
+		Done:    done,
+		Total:   total,
+		Started: j.started.Unix(),
+	}
+	if j.status != JobRunning {
+		ret.Finished = j.finished.Unix()
+		ret.Expires = j.finished.Add(config.JobRetention()).Unix()
+	}
+	return ret
+}
+
+// requestKeyName returns the name of the API key sent with the request or an empty string if the
+// server has no keys
+func requestKeyName(r *http.Request) string {
+	key, _ := findKey(config.GetDaemon().ApiKeys, requestKey(r))
+	return key.Name
+}
+
+// mayAccess returns true if the request may see, read the result of, or cancel the job. Only
+// the key that submitted a job may access it, unless the job's request needed a scope beyond
+// read-only, in which case any key with that scope may.
+func mayAccess(j *job, r *http.Request) bool {
+	keys := config.GetDaemon().ApiKeys
+	if len(keys) == 0 {
+		return true
+	}
+	key, ok := findKey(keys, requestKey(r))
+	if !ok {
+		return false
+	}
+	if key.Name == j.owner {
+		return true
+	}
+	scope := requiredScope(j.route, j.request)
+	return scope != ScopeReadOnly && hasScope(key, scope)
+}
+
+func newJobId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// jobRoutes are the routes a job may run. They are copied from the routes in init because the
+// job API's handlers are themselves among the routes.
+var jobRoutes []Route
+
+func init() {
+	for _, route := range routes {
+		if route.Method == "GET" && strings.HasPrefix(route.Name, "Route") {
+			jobRoutes = append(jobRoutes, route)
+		}
+	}
+}
+
+// jobRoute returns the route a job may run given its name. Any of the command routes may be run.
+func jobRoute(name string) (Route, bool) {
+	for _, route := range jobRoutes {
+		if route.Pattern == "/"+name {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+// jobWriter collects a job's output
+type jobWriter struct {
+	header http.Header
+	status int
+	failed bool
+	body   bytes.Buffer
+}
+
+func (w *jobWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *jobWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// WriteHeader records the status. Handlers report errors after having written some output, so
+// a failing status marks the job as failed even if it comes late.
+func (w *jobWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	if status >= http.StatusBadRequest {
+		w.failed = true
+	}
+}
+
+func (w *jobWriter) Flush() {}
+
+// respondWithData writes the data to the response in the same shape as the commands' output
+func respondWithData(w http.ResponseWriter, httpStatus int, data any) {
+	marshalled, _ := json.MarshalIndent(struct {
+		Data any `json:"data"`
+	}{data}, "", "  ")
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(marshalled)
+}
+
+// SubmitJob starts a job running the route named in the path. The request is authorized and
+// validated as if it were made to the route itself.
+func SubmitJob(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["route"]
+	route, ok := jobRoute(name)
+	if !ok {
+		RespondWithError(w, http.StatusNotFound, fmt.Errorf("there is no route named %s", name))
+		return
+	}
+
+	req := r.Clone(r.Context())
+	req.Method = http.MethodGet
+	req.URL.Path = route.Pattern
+	Authorize(route, ValidateParams(route, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j, err := jobs.start(route, r)
+		if err != nil {
+			RespondWithError(w, http.StatusTooManyRequests, err)
+			return
+		}
+		w.Header().Set("Location", "/jobs/"+j.id)
+		respondWithData(w, http.StatusAccepted, []JobSummary{jobs.summary(j)})
+	}))).ServeHTTP(w, req)
+}
+
+// ListJobs reports on every job the caller may access that has not expired
+func ListJobs(w http.ResponseWriter, r *http.Request) {
+	respondWithData(w, http.StatusOK, jobs.list(r))
+}
+
+// GetJob reports on the job's status and progress
+func GetJob(w http.ResponseWriter, r *http.Request) {
+	if j, ok := findJob(w, r); ok {
+		respondWithData(w, http.StatusOK, []JobSummary{jobs.summary(j)})
+	}
+}
+
+// GetJobResult returns the output of a job that is no longer running in the format given with
+// fmt or, if there is none, the format given when the job was submitted
+func GetJobResult(w http.ResponseWriter, r *http.Request) {
+	j, ok := findJob(w, r)
+	if !ok {
+		return
+	}
+	if jobs.summary(j).Status == JobRunning {
+		RespondWithError(w, http.StatusConflict, fmt.Errorf("job %s is still running", j.id))
+		return
+	}
+
+	format := r.URL.Query().Get("fmt")
+	if len(format) == 0 {
+		format = j.format
+	}
+	if len(format) == 0 {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "txt" {
+		RespondWithError(w, http.StatusBadRequest, fmt.Errorf("the result may not be rendered as %s", format))
+		return
+	}
+	j.render(w, format)
+}
+
+// CancelJob cancels the job. The caller must be able to access the job and needs the same scope
+// as was needed to submit it.
+func CancelJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := findJob(w, r)
+	if !ok {
+		return
+	}
+
+	req := j.request.Clone(r.Context())
+	req.Header = r.Header
+	req.RemoteAddr = r.RemoteAddr
+	req.RequestURI = r.RequestURI
+	Authorize(j.route, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		jobs.cancel(j)
+		respondWithData(w, http.StatusOK, []JobSummary{jobs.summary(j)})
+	})).ServeHTTP(w, req)
+}
+
+// findJob returns the job named in the path if the caller may access it
+func findJob(w http.ResponseWriter, r *http.Request) (*job, bool) {
+	id := mux.Vars(r)["id"]
+	j, ok := jobs.get(id)
+	if !ok {
+		RespondWithError(w, http.StatusNotFound, fmt.Errorf("there is no job %s", id))
+		return nil, false
+	}
+	if !mayAccess(j, r) {
+		RespondWithError(w, http.StatusForbidden, fmt.Errorf("job %s was submitted with another API key", id))
+		return nil, false
+	}
+	return j, true
+}
@@ -0,0 +1,267 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+)
+
+// slowRoute reports some progress and then, unless told to finish, waits to be cancelled
+func slowRoute(t *testing.T) {
+	saved := jobRoutes
+	t.Cleanup(func() { jobRoutes = saved })
+	jobRoutes = append(jobRoutes, Route{"RouteSlow", "GET", "/slow", ScopeReadOnly, []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
+		rCtx := output.RenderContextFrom(r.Context())
+		bar := logger.NewBar(logger.BarOptions{Total: 10, Tracker: rCtx.Progress})
+		for i := 0; i < 3; i++ {
+			bar.Tick()
+		}
+		fmt.Fprint(w, "a,b\n")
+		if r.URL.Query().Has("finish") {
+			bar.Finish(false)
+			return
+		}
+		<-rCtx.Ctx.Done()
+	}})
+}
+
+func jobRequest(t *testing.T, url, method, path string) (*http.Response, []JobSummary) {
+	return jobRequestWithKey(t, url, method, path, "")
+}
+
+func jobRequestWithKey(t *testing.T, url, method, path, key string) (*http.Response, []JobSummary) {
+	req, _ := http.NewRequest(method, url+path, nil)
+	if len(key) > 0 {
+		req.Header.Set("X-API-Key", key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var data struct {
+		Data []JobSummary `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&data)
+	return resp, data.Data
+}
+
+func waitForJob(t *testing.T, url, id string, ready func(JobSummary) bool) JobSummary {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, summaries := jobRequest(t, url, "GET", "/jobs/"+id)
+		if len(summaries) == 1 && ready(summaries[0]) {
+			return summaries[0]
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for job", id, summaries)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	slowRoute(t)
+	server := httptest.NewServer(NewRouter(true))
+	defer server.Close()
+
+	if resp, _ := jobRequest(t, server.URL, "POST", "/jobs/nosuch"); resp.StatusCode != http.StatusNotFound {
+		t.Error("Expected an unknown route to be rejected, got", resp.StatusCode)
+	}
+
+	resp, submitted := jobRequest(t, server.URL, "POST", "/jobs/slow?fmt=csv")
+	if resp.StatusCode != http.StatusAccepted || len(submitted) != 1 || resp.Header.Get("Location") != "/jobs/"+submitted[0].Id {
+		t.Fatal("Expected the job to be accepted, got", resp.StatusCode, submitted)
+	}
+	id := submitted[0].Id
+
+	running := waitForJob(t, server.URL, id, func(s JobSummary) bool { return s.Done == 3 })
+	if running.Status != JobRunning || running.Total != 10 || running.Route != "slow" {
+		t.Error("Unexpected running job", running)
+	}
+	if resp, _ := jobRequest(t, server.URL, "GET", "/jobs/"+id+"/result"); resp.StatusCode != http.StatusConflict {
+		t.Error("Expected no result while running, got", resp.StatusCode)
+	}
+
+	jobRequest(t, server.URL, "DELETE", "/jobs/"+id)
+	cancelled := waitForJob(t, server.URL, id, func(s JobSummary) bool { return s.Status != JobRunning })
+	if cancelled.Status != JobCancelled || cancelled.Expires <= cancelled.Finished {
+		t.Error("Expected the job to be cancelled, got", cancelled)
+	}
+
+	result, err := http.Get(server.URL + "/jobs/" + id + "/result")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Body.Close()
+	if result.StatusCode != http.StatusOK || result.Header.Get("Content-Type") != "text/csv" {
+		t.Error("Expected the partial result as csv, got", result.StatusCode, result.Header.Get("Content-Type"))
+	}
+
+	_, submitted = jobRequest(t, server.URL, "POST", "/jobs/slow?finish")
+	finished := waitForJob(t, server.URL, submitted[0].Id, func(s JobSummary) bool { return s.Status != JobRunning })
+	if finished.Status != JobFinished || finished.Done != 3 || finished.Total != 3 {
+		t.Error("Expected the job to finish, got", finished)
+	}
+
+	// finished jobs are dropped after the retention period
+	j, _ := jobs.get(id)
+	jobs.mutex.Lock()
+	j.finished = time.Now().Add(-2 * time.Hour)
+	jobs.mutex.Unlock()
+	if resp, _ := jobRequest(t, server.URL, "GET", "/jobs/"+id); resp.StatusCode != http.StatusNotFound {
+		t.Error("Expected the expired job to be gone, got", resp.StatusCode)
+	}
+}
+
+func TestJobsByKey(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{
+		ApiKeys: []configtypes.ApiKeyGroup{
+			{Name: "alice", Key: "a-key", Scopes: []string{ScopeReadOnly}},
+			{Name: "bob", Key: "b-key", Scopes: []string{ScopeReadOnly}},
+			{Name: "admin", Key: "c-key", Scopes: []string{ScopeChunksAdmin}},
+		},
+		AuditLog: filepath.Join(t.TempDir(), "audit.log"),
+	})
+	slowRoute(t)
+	server := httptest.NewServer(NewRouter(true))
+	defer server.Close()
+
+	_, submitted := jobRequestWithKey(t, server.URL, "POST", "/jobs/slow?finish", "a-key")
+	_, decaching := jobRequestWithKey(t, server.URL, "POST", "/jobs/slow?finish&decache", "c-key")
+	if len(submitted) != 1 || submitted[0].Key != "alice" || len(decaching) != 1 {
+		t.Fatal("Expected the jobs to be accepted, got", submitted, decaching)
+	}
+	id := submitted[0].Id
+
+	tests := []struct {
+		path     string
+		key      string
+		expected int
+	}{
+		{"/jobs/" + id, "a-key", http.StatusOK},
+		{"/jobs/" + id, "b-key", http.StatusForbidden},
+		{"/jobs/" + id + "/result", "b-key", http.StatusForbidden},
+		// a read-only job belongs to its key alone
+		{"/jobs/" + id, "c-key", http.StatusForbidden},
+		{"/jobs/" + decaching[0].Id, "a-key", http.StatusForbidden},
+	}
+	for _, test := range tests {
+		if resp, _ := jobRequestWithKey(t, server.URL, "GET", test.path, test.key); resp.StatusCode != test.expected {
+			t.Error("GET", test.path, "with", test.key, "expected", test.expected, "got", resp.StatusCode)
+		}
+	}
+
+	for key, expected := range map[string]int{"a-key": 1, "b-key": 0, "c-key": 1} {
+		if _, listed := jobRequestWithKey(t, server.URL, "GET", "/jobs", key); len(listed) != expected {
+			t.Error("Expected", key, "to list", expected, "jobs, got", listed)
+		}
+	}
+}
+
+func TestJobResultFormats(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	streamRoute(t, nil)
+	server := httptest.NewServer(NewRouter(true))
+	defer server.Close()
+
+	_, submitted := jobRequest(t, server.URL, "POST", "/jobs/stream?fmt=csv")
+	if len(submitted) != 1 {
+		t.Fatal("Expected the job to be accepted, got", submitted)
+	}
+	id := submitted[0].Id
+	waitForJob(t, server.URL, id, func(s JobSummary) bool { return s.Status == JobFinished })
+
+	result := func(query string) (*http.Response, string) {
+		resp, err := http.Get(server.URL + "/jobs/" + id + "/result" + query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	// the format given when the job was submitted
+	resp, body := result("")
+	if resp.Header.Get("Content-Type") != "text/csv" || !strings.HasPrefix(body, "tags,name,") || strings.Count(body, "\n") != 3 {
+		t.Errorf("Expected a header and two rows of csv, got %q", body)
+	}
+
+	resp, body = result("?fmt=json")
+	var data struct {
+		Data   []map[string]any `json:"data"`
+		Errors []string         `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &data); err != nil || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatal("Expected json, got", body, err)
+	}
+	if len(data.Data) != 2 || data.Data[1]["name"] != "second" || len(data.Errors) != 1 {
+		t.Error("Expected both records and the error, got", data)
+	}
+
+	if resp, _ = result("?fmt=txt"); resp.Header.Get("Content-Type") != "text/plain" {
+		t.Error("Expected txt, got", resp.Header.Get("Content-Type"))
+	}
+	if resp, _ = result("?fmt=xml"); resp.StatusCode != http.StatusBadRequest {
+		t.Error("Expected an unknown format to be rejected, got", resp.StatusCode)
+	}
+}
+
+func TestJobsPerKey(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{
+		ApiKeys: []configtypes.ApiKeyGroup{
+			{Name: "carol", Key: "c-key", Scopes: []string{ScopeReadOnly}},
+			{Name: "dave", Key: "d-key", Scopes: []string{ScopeReadOnly}},
+		},
+		AuditLog: filepath.Join(t.TempDir(), "audit.log"),
+		MaxJobs:  1,
+	})
+	slowRoute(t)
+	server := httptest.NewServer(NewRouter(true))
+	defer server.Close()
+
+	resp, submitted := jobRequestWithKey(t, server.URL, "POST", "/jobs/slow", "c-key")
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatal("Expected the first job to be accepted, got", resp.StatusCode)
+	}
+	if resp, _ := jobRequestWithKey(t, server.URL, "POST", "/jobs/slow", "c-key"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Error("Expected a second job for the same key to be refused, got", resp.StatusCode)
+	}
+	_, other := jobRequestWithKey(t, server.URL, "POST", "/jobs/slow", "d-key")
+	if len(other) != 1 {
+		t.Error("Expected another key's job to be accepted")
+	}
+
+	for _, job := range []struct{ id, key string }{{submitted[0].Id, "c-key"}, {other[0].Id, "d-key"}} {
+		jobRequestWithKey(t, server.URL, "DELETE", "/jobs/"+job.id, job.key)
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if _, s := jobRequestWithKey(t, server.URL, "GET", "/jobs/"+job.id, job.key); len(s) == 1 && s[0].Status != JobRunning {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("Timed out waiting for job", job.id)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if resp, _ := jobRequestWithKey(t, server.URL, "POST", "/jobs/slow?finish", "c-key"); resp.StatusCode != http.StatusAccepted {
+		t.Error("Expected a job to be accepted once the first has stopped, got", resp.StatusCode)
+	}
+}
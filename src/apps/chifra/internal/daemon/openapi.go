@@ -0,0 +1,216 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// openApiYaml is the OpenAPI document goMaker generates from the command and model
+// definitions. It is the same document published with the docs.
+//
+//go:embed openapi.yaml
+var openApiYaml []byte
+
+type openApiSchema struct {
+	Type   string         `yaml:"type"`
+	Format string         `yaml:"format"`
+	Enum   []string       `yaml:"enum"`
+	Items  *openApiSchema `yaml:"items"`
+}
+
+type openApiParameter struct {
+	Name   string        `yaml:"name"`
+	In     string        `yaml:"in"`
+	Schema openApiSchema `yaml:"schema"`
+}
+
+type openApiOperation struct {
+	Parameters []openApiParameter `yaml:"parameters"`
+}
+
+type openApiDoc struct {
+	Paths map[string]struct {
+		Get *openApiOperation `yaml:"get"`
+	} `yaml:"paths"`
+}
+
+var (
+	openApiOnce   sync.Once
+	openApiParams map[string]map[string]openApiSchema
+	openApiJson   []byte
+)
+
+// loadOpenApi parses the embedded document once, keeping the query parameters of each path
+// and a JSON rendering of the whole document
+func loadOpenApi() {
+	openApiOnce.Do(func() {
+		openApiParams = make(map[string]map[string]openApiSchema)
+
+		var doc openApiDoc
+		if err := yaml.Unmarshal(openApiYaml, &doc); err != nil {
+			logger.Error("could not parse openapi.yaml:", err)
+			return
+		}
+		for path, item := range doc.Paths {
+			if item.Get == nil {
+				continue
+			}
+			params := make(map[string]openApiSchema)
+			for _, param := range item.Get.Parameters {
+				if param.In == "query" {
+					params[param.Name] = param.Schema
+				}
+			}
+			openApiParams[path] = params
+		}
+
+		var generic map[string]any
+		if err := yaml.Unmarshal(openApiYaml, &generic); err == nil {
+			openApiJson, _ = json.MarshalIndent(generic, "", "  ")
+		}
+	})
+}
+
+// ServeOpenApi returns the OpenAPI document as YAML or, if the path ends with .json, as JSON
+func ServeOpenApi(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, ".json") {
+		loadOpenApi()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openApiJson)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openApiYaml)
+}
+
+// ValidateParams rejects a GET request to one of the command routes with a 400 if any of its
+// query parameters described in the OpenAPI document has a value that doesn't fit the
+// parameter's schema. Unknown parameters and missing required parameters are left to the
+// route, which accepts some parameters the document doesn't describe.
+func ValidateParams(route Route, inner http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if fields := validateQuery(route.Pattern, r.URL.Query()); len(fields) > 0 {
+				respondWithFieldErrors(w, fields)
+				return
+			}
+		}
+		inner.ServeHTTP(w, r)
+	})
+}
+
+// validateQuery returns an error message for each of the path's parameters whose value is invalid
+func validateQuery(path string, values url.Values) map[string]string {
+	loadOpenApi()
+	params, ok := openApiParams[path]
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	for key, value := range values {
+		schema, ok := params[key]
+		if !ok {
+			continue
+		}
+		for _, v := range value {
+			if msg := checkValue(schema, v); len(msg) > 0 {
+				fields[key] = fmt.Sprintf("invalid value (%s): %s", v, msg)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// checkValue returns a message describing why the value doesn't fit the schema or "" if it does
+func checkValue(schema openApiSchema, value string) string {
+	switch schema.Type {
+	case "boolean":
+		// a boolean that is present but has no value is true
+		if len(value) > 0 {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return "must be true or false"
+			}
+		}
+	case "number":
+		switch schema.Format {
+		case "float64":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return "must be a number"
+			}
+		case "blknum":
+			if _, err := strconv.ParseUint(value, 0, 64); err != nil {
+				if _, err := identifiers.NewBlockRange(value); err != nil {
+					return "must be a block number or a block identifier"
+				}
+			}
+		default:
+			if _, err := strconv.ParseUint(value, 0, 64); err != nil {
+				return "must be a non-negative integer"
+			}
+		}
+	case "string":
+		return checkEnum(schema.Enum, value)
+	case "array":
+		if schema.Items != nil {
+			// lists may be given as space separated items
+			for _, item := range strings.Fields(value) {
+				if msg := checkValue(*schema.Items, item); len(msg) > 0 {
+					return msg
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func checkEnum(enum []string, value string) string {
+	if len(enum) == 0 {
+		return ""
+	}
+	for _, e := range enum {
+		if e == value {
+			return ""
+		}
+	}
+	return "must be one of [ " + strings.Join(enum, " | ") + " ]"
+}
+
+// respondWithFieldErrors reports the invalid parameters both as a list of errors, like
+// RespondWithError, and keyed by parameter name
+func respondWithFieldErrors(w http.ResponseWriter, fields map[string]string) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]string, 0, len(names))
+	for _, name := range names {
+		errs = append(errs, name+": "+fields[name])
+	}
+
+	marshalled, _ := json.MarshalIndent(struct {
+		Errors []string          `json:"errors"`
+		Fields map[string]string `json:"fields"`
+	}{errs, fields}, "", "  ")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write(marshalled)
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package daemonPkg
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+)
+
+func TestOpenApiMatchesRoutes(t *testing.T) {
+	loadOpenApi()
+	if len(openApiParams) == 0 {
+		t.Fatal("Expected openapi.yaml to describe the routes")
+	}
+	for path := range openApiParams {
+		if _, ok := jobRoute(strings.TrimPrefix(path, "/")); !ok {
+			t.Error("openapi.yaml describes", path, "which is not a route")
+		}
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		uri     string
+		invalid []string
+	}{
+		{"/blocks?blocks=1&hashes&logs=true&fmt=csv", nil},
+		{"/blocks?hashes=maybe", []string{"hashes"}},
+		{"/blocks?flow=sideways", []string{"flow"}},
+		{"/export?maxRecords=10&firstBlock=0x10&lastBlock=latest", nil},
+		{"/export?maxRecords=ten&firstBlock=x-y-z", []string{"firstBlock", "maxRecords"}},
+		{"/chunks?mode=index&sleep=.5", nil},
+		{"/chunks?mode=index&sleep=soon", []string{"sleep"}},
+		{"/status?modes=index%20blooms", nil},
+		{"/status?modes=index%20flowers", []string{"modes"}},
+		{"/blocks?cacheTxs&unknown=1", nil},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", test.uri, nil)
+		fields := validateQuery(r.URL.Path, r.URL.Query())
+		if len(fields) != len(test.invalid) {
+			t.Error("Expected", test.invalid, "to be invalid for", test.uri, "got", fields)
+			continue
+		}
+		for _, name := range test.invalid {
+			if _, ok := fields[name]; !ok {
+				t.Error("Expected", name, "to be invalid for", test.uri, "got", fields)
+			}
+		}
+	}
+}
+
+func TestServeOpenApi(t *testing.T) {
+	setDaemonConfig(t, configtypes.DaemonGroup{AuditLog: filepath.Join(t.TempDir(), "audit.log")})
+	server := httptest.NewServer(NewRouter(true))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var doc struct {
+		OpenApi string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenApi == "" || doc.Paths["/blocks"] == nil {
+		t.Error("Expected the OpenAPI document, got", doc.OpenApi, len(doc.Paths))
+	}
+
+	bad, err := http.Get(server.URL + "/blocks?blocks=1&flow=sideways")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bad.Body.Close()
+	var body struct {
+		Errors []string          `json:"errors"`
+		Fields map[string]string `json:"fields"`
+	}
+	_ = json.NewDecoder(bad.Body).Decode(&body)
+	if bad.StatusCode != http.StatusBadRequest || len(body.Errors) != 1 || body.Fields["flow"] == "" {
+		t.Error("Expected a 400 naming the flow parameter, got", bad.StatusCode, body)
+	}
+}
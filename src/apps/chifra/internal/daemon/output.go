@@ -38,7 +38,7 @@ func RunDaemon(cmd *cobra.Command, args []string) error {
 // ServeDaemon handles the daemon command for the API. Returns an error.
 func ServeDaemon(w http.ResponseWriter, r *http.Request) error {
 	opts := daemonFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	if true { // defeats linter
 		logger.Fatal("should not happen ==> Daemon is an invalid route for server")
@@ -18,6 +18,7 @@ import (
 	abisPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/abis"
 	blocksPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/blocks"
 	chunksPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/chunks"
+	clustersPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/clusters"
 	configPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/config"
 	explorePkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/explore"
 	exportPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/export"
@@ -37,139 +38,148 @@ import (
 	whenPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/when"
 )
 
-// Route A structure to hold the API's routes
+// Route A structure to hold the API's routes. Scope is the API key scope required by the
+// route's mutating requests. Mutating lists the query parameters that make a request change
+// local data.
 type Route struct {
 	Name        string
 	Method      string
 	Pattern     string
+	Scope       string
+	Mutating    []string
 	HandlerFunc http.HandlerFunc
 }
 
 var routes = []Route{
-	{"RouteList", "GET", "/list", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteList", "GET", "/list", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := listPkg.ServeList(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteExport", "GET", "/export", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteExport", "GET", "/export", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := exportPkg.ServeExport(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteMonitors", "GET", "/monitors", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteMonitors", "GET", "/monitors", "monitors-admin", []string{"delete", "undelete", "remove", "clean", "migrate", "group", "decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := monitorsPkg.ServeMonitors(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteNames", "GET", "/names", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteNames", "GET", "/names", "names-write", []string{"clean", "autoname", "export", "import", "discover", "create", "update", "delete", "undelete", "remove"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := namesPkg.ServeNames(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteAbis", "GET", "/abis", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteAbis", "GET", "/abis", "read-only", []string{"importSigs", "decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := abisPkg.ServeAbis(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteBlocks", "GET", "/blocks", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteClusters", "GET", "/clusters", "read-only", []string{"accept"}, func(w http.ResponseWriter, r *http.Request) {
+		if err := clustersPkg.ServeClusters(w, r); err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err)
+		}
+	}},
+	{"RouteBlocks", "GET", "/blocks", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := blocksPkg.ServeBlocks(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteTransactions", "GET", "/transactions", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteTransactions", "GET", "/transactions", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := transactionsPkg.ServeTransactions(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteReceipts", "GET", "/receipts", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteReceipts", "GET", "/receipts", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := receiptsPkg.ServeReceipts(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteLogs", "GET", "/logs", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteLogs", "GET", "/logs", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := logsPkg.ServeLogs(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteTraces", "GET", "/traces", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteTraces", "GET", "/traces", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := tracesPkg.ServeTraces(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteWhen", "GET", "/when", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteWhen", "GET", "/when", "read-only", []string{"truncate", "repair", "update", "decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := whenPkg.ServeWhen(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteState", "GET", "/state", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteState", "GET", "/state", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := statePkg.ServeState(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteTokens", "GET", "/tokens", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteTokens", "GET", "/tokens", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := tokensPkg.ServeTokens(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteConfig", "GET", "/config", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteConfig", "GET", "/config", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := configPkg.ServeConfig(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteStatus", "GET", "/status", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteStatus", "GET", "/status", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := statusPkg.ServeStatus(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteScrape", "GET", "/scrape", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteScrape", "GET", "/scrape", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := scrapePkg.ServeScrape(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteChunks", "GET", "/chunks", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteChunks", "GET", "/chunks", "chunks-admin", []string{"truncate", "rewrite", "tag"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := chunksPkg.ServeChunks(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteInit", "GET", "/init", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteInit", "GET", "/init", "chunks-admin", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := initPkg.ServeInit(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteExplore", "GET", "/explore", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteExplore", "GET", "/explore", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := explorePkg.ServeExplore(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"RouteSlurp", "GET", "/slurp", func(w http.ResponseWriter, r *http.Request) {
+	{"RouteSlurp", "GET", "/slurp", "read-only", []string{"decache"}, func(w http.ResponseWriter, r *http.Request) {
 		if err := slurpPkg.ServeSlurp(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
 
 	// EXISTING_CODE
-	{"Index", "GET", "/", func(w http.ResponseWriter, r *http.Request) {
+	{"Index", "GET", "/", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "https://trueblocks.io/docs/", http.StatusMovedPermanently)
 	}},
-	{"Websockets", "GET", "/websocket", func(w http.ResponseWriter, r *http.Request) {
+	{"Websockets", "GET", "/websocket", "read-only", nil, func(w http.ResponseWriter, r *http.Request) {
 		HandleWebsockets(connectionPool, w, r)
 	}},
-	{"DeleteMonitors", "DELETE", "/monitors", func(w http.ResponseWriter, r *http.Request) {
+	{"DeleteMonitors", "DELETE", "/monitors", "monitors-admin", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := monitorsPkg.ServeMonitors(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"CreateName", "POST", "/names", func(w http.ResponseWriter, r *http.Request) {
+	{"CreateName", "POST", "/names", "names-write", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := namesPkg.ServeNames(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"EditName", "PUT", "/names", func(w http.ResponseWriter, r *http.Request) {
+	{"EditName", "PUT", "/names", "names-write", nil, func(w http.ResponseWriter, r *http.Request) {
 		if err := namesPkg.ServeNames(w, r); err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
-	{"DeleteName", "DELETE", "/names", func(w http.ResponseWriter, r *http.Request) {
+	{"DeleteName", "DELETE", "/names", "names-write", nil, func(w http.ResponseWriter, r *http.Request) {
 		params := r.URL.Query()
 		if !params.Has("delete") && !params.Has("undelete") && !params.Has("remove") {
 			RespondWithError(
@@ -184,6 +194,16 @@ var routes = []Route{
 			RespondWithError(w, http.StatusInternalServerError, err)
 		}
 	}},
+	{"SubmitJob", "POST", "/jobs/{route}", "", nil, SubmitJob},
+	{"ListJobs", "GET", "/jobs", "read-only", nil, ListJobs},
+	{"GetJob", "GET", "/jobs/{id}", "read-only", nil, GetJob},
+	{"GetJobResult", "GET", "/jobs/{id}/result", "read-only", nil, GetJobResult},
+	{"CancelJob", "DELETE", "/jobs/{id}", "", nil, CancelJob},
+	{"OpenApiYaml", "GET", "/openapi.yaml", "read-only", nil, ServeOpenApi},
+	{"OpenApiJson", "GET", "/openapi.json", "read-only", nil, ServeOpenApi},
+	{"GraphQLQuery", "GET", "/graphql", "read-only", nil, ServeGraphQL},
+	{"GraphQLPost", "POST", "/graphql", "read-only", nil, ServeGraphQL},
+	{"GraphQLSchema", "GET", "/graphql/schema", "read-only", nil, ServeGraphQLSchema},
 	// EXISTING_CODE
 }
 
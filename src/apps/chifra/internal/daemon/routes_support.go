@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/gorilla/mux"
 	"golang.org/x/time/rate"
@@ -39,6 +41,13 @@ func NewRouter(silent bool) *mux.Router {
 	for _, route := range routes {
 		var handler http.Handler
 		handler = route.HandlerFunc
+		if strings.HasPrefix(route.Name, "Route") {
+			handler = ValidateParams(route, handler)
+		}
+		if len(route.Scope) > 0 {
+			// routes without a scope (parts of the job API) authorize against the route the job runs
+			handler = Authorize(route, handler)
+		}
 		handler = Logger(silent, handler, route.Name)
 		router.
 			Methods(route.Method).
@@ -50,20 +59,45 @@ func NewRouter(silent bool) *mux.Router {
 	return router
 }
 
-func addCorsHeaders(w http.ResponseWriter) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept")
+// originAllowed returns true if a browser at the origin may call the server. Any origin may
+// unless the configuration file lists the allowed origins. Requests without an origin do not
+// come from browsers and are always allowed.
+func originAllowed(origin string) bool {
+	origins := config.GetDaemon().CorsOrigins
+	if len(origins) == 0 || len(origin) == 0 {
+		return true
+	}
+	for _, allowed := range origins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCorsHeaders allows any origin unless the configuration file lists the allowed origins, in
+// which case only a listed origin is echoed back
+func addCorsHeaders(w http.ResponseWriter, r *http.Request) {
+	if origins := config.GetDaemon().CorsOrigins; len(origins) == 0 {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); len(origin) > 0 && originAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+	}
+	w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, Authorization, X-API-Key")
 	w.Header().Set("Access-Control-Allow-Methods", "PUT, POST, GET, DELETE, OPTIONS")
 }
 
 var OptionsHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-	addCorsHeaders(w)
+	addCorsHeaders(w, r)
 })
 
 // CorsHandler handles CORS requests
 func CorsHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		addCorsHeaders(w)
+		addCorsHeaders(w, r)
 		next.ServeHTTP(w, r)
 	})
 }
@@ -71,23 +105,23 @@ func CorsHandler(next http.Handler) http.Handler {
 // ContentTypeHandler sets correct Content-Type header on response
 func ContentTypeHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestedFormat := r.URL.Query().Get("fmt")
-
-		var contentType string
-		switch requestedFormat {
-		case "txt":
-			contentType = "text/plain"
-		case "csv":
-			contentType = "text/csv"
-		default:
-			contentType = "application/json"
-		}
-
-		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Type", contentTypeFor(r.URL.Query().Get("fmt")))
 		next.ServeHTTP(w, r)
 	})
 }
 
+// contentTypeFor returns the Content-Type of output in the requested format
+func contentTypeFor(requestedFormat string) string {
+	switch requestedFormat {
+	case "txt":
+		return "text/plain"
+	case "csv":
+		return "text/csv"
+	default:
+		return "application/json"
+	}
+}
+
 var nProcessed int
 
 // Logger sends information to the server's console
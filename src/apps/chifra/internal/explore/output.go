@@ -34,7 +34,7 @@ func RunExplore(cmd *cobra.Command, args []string) error {
 // ServeExplore handles the explore command for the API. Returns an error.
 func ServeExplore(w http.ResponseWriter, r *http.Request) error {
 	opts := exploreFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("explore", w, &opts.Globals)
@@ -22,4 +22,14 @@
 // By default, the results of the extraction are delivered to your console, however, you may export
 // the results to any database (with a little bit of work). The format of the data, its content and
 // its destination are up to you.
+//
+// ### Incremental accounting
+//
+// With --accounting (and optionally --statements), the --incremental option saves a checkpoint
+// for each monitor: the last appearance that reconciled and the ending balance of each asset. The next
+// run with --incremental reconciles only the appearances after the checkpoint, which makes it
+// suitable for chifra monitors --watch. The checkpoint is ignored (and the monitor reconciled from
+// its first appearance) if the names, the options, or the monitor's earlier appearances have
+// changed. Reconciliation stops advancing the checkpoint at the first appearance
+// that does not reconcile, so that appearance is tried again on the next run.
 package exportPkg
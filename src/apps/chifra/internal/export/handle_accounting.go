@@ -11,6 +11,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/ledger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
@@ -29,7 +30,7 @@ func (opts *ExportOptions) HandleAccounting(rCtx *output.RenderCtx, monitorArray
 		opts.Reverted,
 		opts.Fourbytes,
 		base.BlockRange{First: opts.FirstBlock, Last: opts.LastBlock},
-		base.RecordRange{First: opts.FirstRecord, Last: opts.GetMax()},
+		base.RecordRange{First: opts.FirstRecord, Last: opts.maxRecords()},
 	)
 
 	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
@@ -61,7 +62,26 @@ func (opts *ExportOptions) HandleAccounting(rCtx *output.RenderCtx, monitorArray
 		}
 
 		for _, mon := range monitorArray {
-			if apps, cnt, err := mon.ReadAndFilterAppearances(filter, true /* withCount */); err != nil {
+			if opts.Incremental {
+				ledgers = opts.newLedger(&mon)
+				if all, apps, err := opts.resumeLedger(ledgers, &mon); err != nil {
+					errorChan <- err
+					return
+
+				} else {
+					_ = ledgers.SetContexts(chain, apps)
+					for _, app := range apps {
+						if err := visitAppearance(&app); err != nil {
+							errorChan <- err
+							return
+						}
+					}
+					if err := ledgers.Checkpoint(all).Save(chain); err != nil {
+						errorChan <- err
+					}
+				}
+
+			} else if apps, cnt, err := mon.ReadAndFilterAppearances(filter, true /* withCount */); err != nil {
 				errorChan <- err
 				return
 
@@ -101,3 +121,59 @@ func (opts *ExportOptions) HandleAccounting(rCtx *output.RenderCtx, monitorArray
 
 	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOptsWithExtra(extraOpts))
 }
+
+// newLedger returns a ledger for the monitor's address configured by the options
+func (opts *ExportOptions) newLedger(mon *monitor.Monitor) *ledger.Ledger {
+	return ledger.NewLedger(
+		opts.Conn,
+		mon.Address,
+		opts.FirstBlock,
+		opts.LastBlock,
+		opts.Globals.Ether,
+		opts.Globals.TestMode,
+		opts.NoZero,
+		opts.Traces,
+		opts.Reversed,
+		&opts.Asset,
+	)
+}
+
+// resumeLedger continues the ledger from the monitor's checkpoint (if it has one that's still
+// good) and returns the appearances it has yet to reconcile, stopping at --last_block and after
+// --max_records appearances. It also returns all of the monitor's appearances, which are needed
+// to save the ledger's next checkpoint.
+func (opts *ExportOptions) resumeLedger(l *ledger.Ledger, mon *monitor.Monitor) ([]types.Appearance, []types.Appearance, error) {
+	all, _, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), false /* withCount */)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cp, err := ledger.LoadCheckpoint(opts.Globals.Chain, mon.Address)
+	if err != nil {
+		logger.Warn("Ignoring checkpoint:", err)
+	}
+
+	done, reason := l.Resume(cp, all)
+	if cp != nil && len(reason) > 0 {
+		logger.Info("Reconciling", mon.Address.Hex(), "from its first appearance because", reason+".")
+	}
+
+	todo := all[done:]
+	for i, app := range todo {
+		if base.Blknum(app.BlockNumber) > opts.LastBlock || uint64(i) >= opts.GetMax() {
+			todo = todo[:i]
+			break
+		}
+	}
+
+	return all, todo, nil
+}
+
+// maxRecords returns the record limit of the filter. With --incremental, it's the number of
+// appearances that are reconciled that's limited (see resumeLedger), not the records reported.
+func (opts *ExportOptions) maxRecords() uint64 {
+	if opts.Incremental {
+		return base.NOPOS
+	}
+	return opts.GetMax()
+}
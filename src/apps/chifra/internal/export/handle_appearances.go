@@ -42,7 +42,7 @@ func (opts *ExportOptions) HandleAppearances(rCtx *output.RenderCtx, monitorArra
 					modelChan <- &app
 				}
 			} else {
-				errorChan <- fmt.Errorf("no appearances found for %s", mon.Address.Hex())
+				errorChan <- fmt.Errorf("no appearances found for %s", mon.Label())
 				continue
 			}
 		}
@@ -55,6 +55,7 @@ func (opts *ExportOptions) HandleBalances(rCtx *output.RenderCtx, monitorArray [
 					bar := logger.NewBar(logger.BarOptions{
 						Prefix:  mon.Address.Hex(),
 						Enabled: showProgress,
+						Tracker: rCtx.Progress,
 						Total:   int64(cnt),
 					})
 
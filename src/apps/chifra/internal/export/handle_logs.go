@@ -32,7 +32,7 @@ func (opts *ExportOptions) HandleLogs(rCtx *output.RenderCtx, monitorArray []mon
 
 	addrArray := make([]base.Address, 0, len(monitorArray))
 	for _, mon := range monitorArray {
-		addrArray = append(addrArray, mon.Address)
+		addrArray = append(addrArray, mon.Addresses()...)
 	}
 	logFilter := rpc.NewLogFilter(opts.Emitter, opts.Topic)
 
@@ -54,8 +54,9 @@ func (opts *ExportOptions) HandleLogs(rCtx *output.RenderCtx, monitorArray []mon
 				} else {
 					showProgress := opts.Globals.ShowProgress()
 					bar := logger.NewBar(logger.BarOptions{
-						Prefix:  mon.Address.Hex(),
+						Prefix:  mon.Label(),
 						Enabled: showProgress,
+						Tracker: rCtx.Progress,
 						Total:   int64(cnt),
 					})
 
@@ -47,8 +47,9 @@ func (opts *ExportOptions) HandleNeighbors(rCtx *output.RenderCtx, monitorArray
 				} else {
 					showProgress := opts.Globals.ShowProgress()
 					bar := logger.NewBar(logger.BarOptions{
-						Prefix:  mon.Address.Hex(),
+						Prefix:  mon.Label(),
 						Enabled: showProgress,
+						Tracker: rCtx.Progress,
 						Total:   int64(cnt),
 					})
 
@@ -47,8 +47,9 @@ func (opts *ExportOptions) HandleShow(rCtx *output.RenderCtx, monitorArray []mon
 				} else {
 					showProgress := opts.Globals.ShowProgress()
 					bar := logger.NewBar(logger.BarOptions{
-						Prefix:  mon.Address.Hex(),
+						Prefix:  mon.Label(),
 						Enabled: showProgress,
+						Tracker: rCtx.Progress,
 						Total:   int64(cnt),
 					})
 
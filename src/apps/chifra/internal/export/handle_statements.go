@@ -27,15 +27,32 @@ func (opts *ExportOptions) HandleStatements(rCtx *output.RenderCtx, monitorArray
 		opts.Reverted,
 		opts.Fourbytes,
 		base.BlockRange{First: opts.FirstBlock, Last: opts.LastBlock},
-		base.RecordRange{First: opts.FirstRecord, Last: opts.GetMax()},
+		base.RecordRange{First: opts.FirstRecord, Last: opts.maxRecords()},
 	)
 
 	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
 		for _, mon := range monitorArray {
-			if apps, cnt, err := mon.ReadAndFilterAppearances(filter, false /* withCount */); err != nil {
+			var all []types.Appearance
+			var resumed *ledger.Ledger
+			readApps := func() ([]types.Appearance, int, error) {
+				if !opts.Incremental {
+					return mon.ReadAndFilterAppearances(filter, false /* withCount */)
+				}
+				resumed = opts.newLedger(&mon)
+				var apps []types.Appearance
+				var err error
+				all, apps, err = opts.resumeLedger(resumed, &mon)
+				return apps, len(apps), err
+			}
+
+			if apps, cnt, err := readApps(); err != nil {
 				errorChan <- err
 				rCtx.Cancel()
 
+			} else if cnt == 0 && opts.Incremental {
+				logger.Info("No new appearances to reconcile for", mon.Address.Hex())
+				continue
+
 			} else if cnt == 0 {
 				errorChan <- fmt.Errorf("no blocks found for the query")
 				continue
@@ -50,11 +67,13 @@ func (opts *ExportOptions) HandleStatements(rCtx *output.RenderCtx, monitorArray
 					bar := logger.NewBar(logger.BarOptions{
 						Prefix:  mon.Address.Hex(),
 						Enabled: showProgress,
+						Tracker: rCtx.Progress,
 						Total:   int64(cnt),
 					})
 
 					// TODO: BOGUS - THIS IS NOT CONCURRENCY SAFE
 					finished := false
+					reconciled := make(map[types.AppRecord]bool)
 					for _, thisMap := range sliceOfMaps {
 						if rCtx.WasCanceled() {
 							return
@@ -113,18 +132,21 @@ func (opts *ExportOptions) HandleStatements(rCtx *output.RenderCtx, monitorArray
 							})
 						}
 
-						ledgers := ledger.NewLedger(
-							opts.Conn,
-							mon.Address,
-							opts.FirstBlock,
-							opts.LastBlock,
-							opts.Globals.Ether,
-							testMode,
-							opts.NoZero,
-							opts.Traces,
-							opts.Reversed,
-							&opts.Asset,
-						)
+						ledgers := resumed
+						if ledgers == nil {
+							ledgers = ledger.NewLedger(
+								opts.Conn,
+								mon.Address,
+								opts.FirstBlock,
+								opts.LastBlock,
+								opts.Globals.Ether,
+								testMode,
+								opts.NoZero,
+								opts.Traces,
+								opts.Reversed,
+								&opts.Asset,
+							)
+						}
 						_ = ledgers.SetContexts(chain, apps)
 
 						items := make([]types.Statement, 0, len(thisMap))
@@ -150,6 +172,15 @@ func (opts *ExportOptions) HandleStatements(rCtx *output.RenderCtx, monitorArray
 							return items[i].BlockNumber < items[j].BlockNumber
 						})
 
+						for _, item := range items {
+							app := types.AppRecord{BlockNumber: uint32(item.BlockNumber), TransactionIndex: uint32(item.TransactionIndex)}
+							if prev, ok := reconciled[app]; ok {
+								reconciled[app] = prev && item.Reconciled()
+							} else {
+								reconciled[app] = item.Reconciled()
+							}
+						}
+
 						for _, item := range items {
 							var passes bool
 							passes, finished = filter.ApplyCountFilter()
@@ -162,6 +193,17 @@ func (opts *ExportOptions) HandleStatements(rCtx *output.RenderCtx, monitorArray
 						}
 					}
 					bar.Finish(true /* newLine */)
+
+					// v2 monitors remember which appearances reconciled
+					if _, err := mon.MarkReconciled(reconciled); err != nil {
+						errorChan <- err
+					}
+
+					if resumed != nil {
+						if err := resumed.Checkpoint(all).Save(chain); err != nil {
+							errorChan <- err
+						}
+					}
 				}
 			}
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/globals"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/caps"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
@@ -51,12 +52,14 @@ type ExportOptions struct {
 	Reverted    bool                  `json:"reverted,omitempty"`    // Export only transactions that were reverted
 	Asset       []string              `json:"asset,omitempty"`       // For the accounting options only, export statements only for this asset
 	Flow        string                `json:"flow,omitempty"`        // For the accounting options only, export statements with incoming, outgoing, or zero value
+	Incremental bool                  `json:"incremental,omitempty"` // For the accounting options only, reconcile only the appearances after the checkpoint of each monitor and save a new checkpoint
 	Factory     bool                  `json:"factory,omitempty"`     // For --traces only, report addresses created by (or self-destructed by) the given address(es)
 	Unripe      bool                  `json:"unripe,omitempty"`      // Export transactions labeled unripe (i.e. less than 28 blocks old)
 	Reversed    bool                  `json:"reversed,omitempty"`    // Produce results in reverse chronological order
 	NoZero      bool                  `json:"noZero,omitempty"`      // For the --count option only, suppress the display of zero appearance accounts
 	FirstBlock  base.Blknum           `json:"firstBlock,omitempty"`  // First block to process (inclusive)
 	LastBlock   base.Blknum           `json:"lastBlock,omitempty"`   // Last block to process (inclusive)
+	Group       string                `json:"group,omitempty"`       // Export the combined records of the members of this monitor group (see chifra monitors --group)
 	Globals     globals.GlobalOptions `json:"globals,omitempty"`     // The global options
 	Conn        *rpc.Connection       `json:"conn,omitempty"`        // The connection to the RPC server
 	BadFlag     error                 `json:"badFlag,omitempty"`     // An error flag if needed
@@ -94,12 +97,14 @@ func (opts *ExportOptions) testLog() {
 	logger.TestLog(opts.Reverted, "Reverted: ", opts.Reverted)
 	logger.TestLog(len(opts.Asset) > 0, "Asset: ", opts.Asset)
 	logger.TestLog(len(opts.Flow) > 0, "Flow: ", opts.Flow)
+	logger.TestLog(opts.Incremental, "Incremental: ", opts.Incremental)
 	logger.TestLog(opts.Factory, "Factory: ", opts.Factory)
 	logger.TestLog(opts.Unripe, "Unripe: ", opts.Unripe)
 	logger.TestLog(opts.Reversed, "Reversed: ", opts.Reversed)
 	logger.TestLog(opts.NoZero, "NoZero: ", opts.NoZero)
 	logger.TestLog(opts.FirstBlock != 0, "FirstBlock: ", opts.FirstBlock)
 	logger.TestLog(opts.LastBlock != base.NOPOSN && opts.LastBlock != 0, "LastBlock: ", opts.LastBlock)
+	logger.TestLog(len(opts.Group) > 0, "Group: ", opts.Group)
 	opts.Conn.TestLog(opts.getCaches())
 	opts.Globals.TestLog()
 }
@@ -191,6 +196,8 @@ func ExportFinishParseInternal(w io.Writer, values url.Values) *ExportOptions {
 			}
 		case "flow":
 			opts.Flow = value[0]
+		case "incremental":
+			opts.Incremental = true
 		case "factory":
 			opts.Factory = true
 		case "unripe":
@@ -203,6 +210,8 @@ func ExportFinishParseInternal(w io.Writer, values url.Values) *ExportOptions {
 			opts.FirstBlock = base.MustParseBlknum(value[0])
 		case "lastBlock":
 			opts.LastBlock = base.MustParseBlknum(value[0])
+		case "group":
+			opts.Group = value[0]
 		default:
 			if !copy.Globals.Caps.HasKey(key) {
 				err := validate.Usage("Invalid key ({0}) in {1} route.", key, "export")
@@ -215,6 +224,15 @@ func ExportFinishParseInternal(w io.Writer, values url.Values) *ExportOptions {
 	opts.Conn = opts.Globals.FinishParseApi(w, values, opts.getCaches())
 
 	// EXISTING_CODE
+	for key, target := range map[string]*base.Blknum{"firstBlock": &opts.FirstBlock, "lastBlock": &opts.LastBlock} {
+		if value := values.Get(key); len(value) > 0 {
+			if bn, err := identifiers.ParseBlockId(opts.Globals.Chain, value); err != nil {
+				opts.BadFlag = err
+			} else {
+				*target = bn
+			}
+		}
+	}
 	if len(opts.Addrs) > 0 {
 		addrs := []string{}
 		for _, addr := range opts.Addrs {
@@ -257,6 +275,9 @@ func exportFinishParse(args []string) *ExportOptions {
 	opts.Conn = opts.Globals.FinishParse(args, opts.getCaches())
 
 	// EXISTING_CODE
+	if err := identifiers.ResolveBlockFlags(opts.Globals.Chain, &opts.FirstBlock, &opts.LastBlock); err != nil {
+		opts.BadFlag = err
+	}
 	for _, arg := range args {
 		if validate.IsValidTopic(arg) {
 			opts.Topic = append(opts.Topic, arg)
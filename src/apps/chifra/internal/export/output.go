@@ -35,7 +35,7 @@ func RunExport(cmd *cobra.Command, args []string) error {
 // ServeExport handles the export command for the API. Returns an error.
 func ServeExport(w http.ResponseWriter, r *http.Request) error {
 	opts := exportFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("export", w, &opts.Globals)
@@ -58,6 +58,9 @@ func (opts *ExportOptions) ExportInternal(rCtx *output.RenderCtx) error {
 	if canceled, err := opts.FreshenMonitorsForExport(rCtx, &monitorArray); err != nil || canceled {
 		return err
 	}
+	if len(opts.Group) > 0 && opts.combinesGroup() {
+		monitorArray = []monitor.Monitor{monitor.NewGroupMonitor(opts.Globals.Chain, opts.Group, monitorArray, true /* dedupe */)}
+	}
 	// EXISTING_CODE
 	if opts.Globals.Decache {
 		err = opts.HandleDecache(rCtx, monitorArray)
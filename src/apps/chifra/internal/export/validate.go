@@ -13,6 +13,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
 
@@ -61,6 +62,20 @@ func (opts *ExportOptions) validateExport() error {
 		}
 	}
 
+	if len(opts.Group) > 0 {
+		if len(opts.Addrs) > 0 {
+			return validate.Usage("The {0} option may not be used with addresses.", "--group")
+		}
+		group, err := monitor.LoadGroup(chain, opts.Group)
+		if err != nil {
+			return validate.Usage("The {0} option is invalid: {1}.", "--group", err.Error())
+		}
+		if len(group.Addrs) == 0 {
+			return validate.Usage("The monitor group {0} has no members.", opts.Group)
+		}
+		opts.Addrs = group.Strings()
+	}
+
 	if err := validate.ValidateAtLeastOneAddr(opts.Addrs); err != nil {
 		for _, a := range opts.Addrs {
 			if !base.IsValidAddress(a) {
@@ -174,11 +189,24 @@ func (opts *ExportOptions) validateExport() error {
 			}
 		}
 
+		if opts.Incremental {
+			if opts.FirstBlock != 0 || opts.FirstRecord != 0 {
+				return validate.Usage("The {0} option is not available{1}.", "--incremental", " with --first_block or --first_record")
+			}
+			if opts.Reversed || opts.Reverted || len(opts.Fourbytes) > 0 {
+				return validate.Usage("The {0} option is not available{1}.", "--incremental", " with --reversed, --reverted, or fourbytes")
+			}
+		}
+
 	} else {
 		if opts.Statements {
 			return validate.Usage("The {0} option is only available with the {1} option.", "--statements", "--accounting")
 		}
 
+		if opts.Incremental {
+			return validate.Usage("The {0} option is only available with the {1} option.", "--incremental", "--accounting")
+		}
+
 		if opts.Globals.Format == "ofx" {
 			return validate.Usage("The {0} option is only available with the {1} option.", "--fmt ofx", "--accounting")
 		}
@@ -232,3 +260,9 @@ func (opts *ExportOptions) tooMany() bool {
 	}
 	return cnt > 1
 }
+
+// combinesGroup returns true if the mode exports a group as a single de-duplicated stream.
+// Accounting, balances, and withdrawals are reported per member, as is --count.
+func (opts *ExportOptions) combinesGroup() bool {
+	return !opts.Globals.Decache && !opts.Count && !opts.Accounting && !opts.Statements && !opts.Balances && !opts.Withdrawals
+}
@@ -34,7 +34,7 @@ func RunInit(cmd *cobra.Command, args []string) error {
 // ServeInit handles the init command for the API. Returns an error.
 func ServeInit(w http.ResponseWriter, r *http.Request) error {
 	opts := initFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("init", w, &opts.Globals)
@@ -56,7 +56,7 @@ func (opts *ListOptions) HandleShow(rCtx *output.RenderCtx, monitorArray []monit
 					}
 				}
 			} else {
-				errorChan <- fmt.Errorf("no appearances found for %s", mon.Address.Hex())
+				errorChan <- fmt.Errorf("no appearances found for %s", mon.Label())
 				continue
 			}
 		}
@@ -41,6 +41,7 @@ type ListOptions struct {
 	Publisher   string                `json:"publisher,omitempty"`   // For some query options, the publisher of the index
 	FirstBlock  base.Blknum           `json:"firstBlock,omitempty"`  // First block to export (inclusive, ignored when freshening)
 	LastBlock   base.Blknum           `json:"lastBlock,omitempty"`   // Last block to export (inclusive, ignored when freshening)
+	Group       string                `json:"group,omitempty"`       // List the combined appearances of the members of this monitor group (see chifra monitors --group)
 	Globals     globals.GlobalOptions `json:"globals,omitempty"`     // The global options
 	Conn        *rpc.Connection       `json:"conn,omitempty"`        // The connection to the RPC server
 	BadFlag     error                 `json:"badFlag,omitempty"`     // An error flag if needed
@@ -68,6 +69,7 @@ func (opts *ListOptions) testLog() {
 	logger.TestLog(len(opts.Publisher) > 0, "Publisher: ", opts.Publisher)
 	logger.TestLog(opts.FirstBlock != 0, "FirstBlock: ", opts.FirstBlock)
 	logger.TestLog(opts.LastBlock != base.NOPOSN && opts.LastBlock != 0, "LastBlock: ", opts.LastBlock)
+	logger.TestLog(len(opts.Group) > 0, "Group: ", opts.Group)
 	opts.Conn.TestLog(opts.getCaches())
 	opts.Globals.TestLog()
 }
@@ -122,6 +124,8 @@ func ListFinishParseInternal(w io.Writer, values url.Values) *ListOptions {
 			opts.FirstBlock = base.MustParseBlknum(value[0])
 		case "lastBlock":
 			opts.LastBlock = base.MustParseBlknum(value[0])
+		case "group":
+			opts.Group = value[0]
 		default:
 			if !copy.Globals.Caps.HasKey(key) {
 				err := validate.Usage("Invalid key ({0}) in {1} route.", key, "list")
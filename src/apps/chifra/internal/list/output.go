@@ -35,7 +35,7 @@ func RunList(cmd *cobra.Command, args []string) error {
 // ServeList handles the list command for the API. Returns an error.
 func ServeList(w http.ResponseWriter, r *http.Request) error {
 	opts := listFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("list", w, &opts.Globals)
@@ -58,6 +58,9 @@ func (opts *ListOptions) ListInternal(rCtx *output.RenderCtx) error {
 	if canceled, err := opts.FreshenMonitorsForList(&monitorArray); err != nil || canceled {
 		return err
 	}
+	if len(opts.Group) > 0 && !opts.Count && !opts.Bounds {
+		monitorArray = []monitor.Monitor{monitor.NewGroupMonitor(opts.Globals.Chain, opts.Group, monitorArray, false /* dedupe */)}
+	}
 	// EXISTING_CODE
 	if opts.Count {
 		err = opts.HandleCount(rCtx, monitorArray)
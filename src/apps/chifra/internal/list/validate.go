@@ -12,6 +12,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
 
@@ -62,6 +63,20 @@ func (opts *ListOptions) validateList() error {
 		return validate.Usage("The {0} option is only available with the {1} option.", "--no_zero", "--count")
 	}
 
+	if len(opts.Group) > 0 {
+		if len(opts.Addrs) > 0 {
+			return validate.Usage("The {0} option may not be used with addresses.", "--group")
+		}
+		group, err := monitor.LoadGroup(chain, opts.Group)
+		if err != nil {
+			return validate.Usage("The {0} option is invalid: {1}.", "--group", err.Error())
+		}
+		if len(group.Addrs) == 0 {
+			return validate.Usage("The monitor group {0} has no members.", opts.Group)
+		}
+		opts.Addrs = group.Strings()
+	}
+
 	if len(opts.Globals.File) == 0 {
 		err := validate.ValidateAtLeastOneNonSentinal(opts.Addrs)
 		if err != nil {
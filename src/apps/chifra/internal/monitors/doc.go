@@ -25,6 +25,16 @@
 // the monitor (for example, transactions or traces). This is an irreversible operation (except
 // for the fact that the cache can be easily re-created with chifra list <address>). The monitor need not have been previously deleted.
 //
+// ### File formats
+//
+// Monitors are stored in one of two formats. A v1 file is a list of fixed-width block and transaction numbers. A v2 file is delta- and varint-compressed and keeps optional details about each appearance: its timestamp and whether its statements reconciled the last time chifra export --statements produced them. Use chifra monitors --migrate v2 to rewrite every monitor (or only the given addresses) in place, and --migrate v1 to go back. Each file is rewritten alongside the old one and then moved into place. Every tool reads both formats, and appending to a monitor keeps its format. New monitors are created in the v1 format unless monitorFormat = "v2" is set in the [settings] section of trueBlocks.toml.
+//
+// ### Groups
+//
+// A group is a named set of monitors that may be freshened and exported together. chifra monitors --group dao <address>... adds addresses to the group dao (creating it if needed), --group dao --delete <address>... removes addresses from it, and --group dao --remove removes the group itself (but not its monitors). With no addresses, the group's members are listed.
+//
+// Use chifra list --group dao or chifra export --group dao in place of a list of addresses. For transactions, receipts, logs, traces, and appearances, export reports the group as a single stream in block order, listing a transaction only once even if more than one member appears in it. Accounting, balances, and withdrawals are reported for each member. A group is stored as a text file with one address per line, so chifra monitors --watch --group dao watches the group's members.
+//
 // ### Watching addresses
 //
 // The --watch command is special. It starts a long-running process that continually reads the blockchain looking for appearances of the addresses it is instructed to watch. It command requires two additional parameters: --watchlist <filename> and --commands <filename>. The --watchlist file is simply a list of addresses or ENS names, one per line:
@@ -47,4 +57,38 @@
 // The [{ADDRESS}] token is a stand-in for all addresses in the --watchlist. Addresses are processed in groups of batch_size (default 8).
 //
 // Invalid commands or invalid addresses are ignored. If a command fails, the process continues with the next command. If a command fails for a particular address, the process continues with the next address. A warning is generated.
+//
+// To keep the accounting of watched addresses up to date without reconciling each address's entire history every time it appears, use chifra export --accounting --incremental [{ADDRESS}] (optionally with --statements) in the --commands file. Each run reconciles only the appearances found since the previous run. chifra monitors --decache removes an address's checkpoint.
+//
+// ### Rules and sinks
+//
+// Instead of (or in addition to) a --commands file, you may give --watch a --rules file. Each new appearance of a watched address is checked against the rules, and each match is delivered to the sinks as JSON. A rule matches a transfer of an asset (eth or a token address) of at least min in a given direction (in, out, or any), a call to a function selector, or a balance below an amount. Amounts are in whole units of the asset (use decimals for tokens that don't have 18). A sink is a webhook (retried with backoff), a file (one JSON line per match), a unix socket, or a shell command (which receives the match on stdin). A sink may be limited to some of the rules.
+//
+// [toml]
+// record = "events.jsonl"
+//
+// [[rule]]
+// name = "large-usdc"
+// kind = "transfer"
+// asset = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+// direction = "out"
+// min = "1000"
+// decimals = 6
+//
+// [[rule]]
+// name = "low-balance"
+// kind = "balance"
+// below = "0.5"
+//
+// [[sink]]
+// kind = "webhook"
+// target = "http://localhost:8080/alerts"
+// retries = 5
+//
+// [[sink]]
+// kind = "command"
+// target = "notify-send 'chifra' \"$TB_RULE matched $TB_HASH\""
+// rules = ["low-balance"]
+//
+// If record is set, every appearance evaluated is appended to that file so that the stream may be replayed later against different rules. Rules are not applied to the history of a monitor the first time it's freshened.
 package monitorsPkg
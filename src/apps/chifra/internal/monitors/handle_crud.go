@@ -7,6 +7,7 @@ package monitorsPkg
 import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/ledger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
@@ -68,6 +69,7 @@ func (opts *MonitorsOptions) HandleCrud(rCtx *output.RenderCtx) error {
 				if !wasRemoved || err != nil {
 					logger.Info(("Monitor for " + addr + " was not removed (" + err.Error() + ")"))
 				} else {
+					ledger.RemoveCheckpoint(opts.Globals.Chain, m.Address)
 					logger.Info(("Monitor for " + addr + " was permanently removed."))
 				}
 			}
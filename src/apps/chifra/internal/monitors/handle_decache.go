@@ -1,6 +1,7 @@
 package monitorsPkg
 
 import (
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/ledger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
@@ -26,6 +27,7 @@ func (opts *MonitorsOptions) HandleDecache(rCtx *output.RenderCtx) error {
 				errorChan <- err
 				continue
 			} else {
+				ledger.RemoveCheckpoint(opts.Globals.Chain, mon.Address)
 				modelChan <- &types.Message{
 					Msg: result,
 				}
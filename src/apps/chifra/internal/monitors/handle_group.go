@@ -0,0 +1,76 @@
+package monitorsPkg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// HandleGroup handles chifra monitors --group. Addresses are added to the group (which is
+// created if needed) or, with --delete, removed from it. With --remove, the group itself is
+// removed. The group's members are reported in every other case.
+func (opts *MonitorsOptions) HandleGroup(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+
+	if opts.Remove {
+		if err := monitor.RemoveGroup(chain, opts.Group); err != nil {
+			return err
+		}
+		logger.Info("Monitor group", opts.Group, "was removed. Its monitors were not.")
+		return nil
+	}
+
+	addrs := make([]base.Address, 0, len(opts.Addrs))
+	for _, addr := range opts.Addrs {
+		addrs = append(addrs, base.HexToAddress(addr))
+	}
+
+	group, err := monitor.LoadGroup(chain, opts.Group)
+	if errors.Is(err, monitor.ErrGroupNotFound) && len(addrs) > 0 && !opts.Delete {
+		group, err = &monitor.Group{Name: opts.Group}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(addrs) > 0 {
+		if opts.Delete {
+			logger.Info(fmt.Sprintf("Removed %d addresses from monitor group %s", group.Remove(addrs), opts.Group))
+		} else {
+			logger.Info(fmt.Sprintf("Added %d addresses to monitor group %s", group.Add(addrs), opts.Group))
+		}
+		if err := group.Save(chain); err != nil {
+			return err
+		}
+	}
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		for _, addr := range group.Addrs {
+			if rCtx.WasCanceled() {
+				return
+			}
+			mon := monitor.Monitor{Address: addr, Chain: chain}
+			s := types.Monitor{
+				Address:  addr,
+				NRecords: mon.Count(),
+				FileSize: file.FileSize(mon.Path()),
+			}
+			s.IsEmpty = s.NRecords == 0
+			if file.FileExists(mon.Path()) {
+				_ = mon.ReadMonitorHeader()
+				mon.Close()
+				s.LastScanned = mon.LastScanned
+				s.Deleted = mon.Deleted
+			}
+			modelChan <- &s
+		}
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
@@ -0,0 +1,67 @@
+package monitorsPkg
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// HandleMigrate handles chifra monitors --migrate. Each of the given monitors (or every existing
+// monitor, staged or not, if none are given) is rewritten in place in the requested format.
+func (opts *MonitorsOptions) HandleMigrate(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+	format, err := monitor.ParseFormat(opts.Migrate)
+	if err != nil {
+		return err
+	}
+
+	monArray := make([]*monitor.Monitor, 0, len(opts.Addrs))
+	if len(opts.Addrs) == 0 {
+		_, monArray = GetMonitorMap(chain)
+	} else {
+		for _, addr := range opts.Addrs {
+			mon := monitor.Monitor{Address: base.HexToAddress(addr), Chain: chain}
+			if !file.FileExists(mon.Path()) {
+				return fmt.Errorf("monitor not found for address %s", addr)
+			}
+			monArray = append(monArray, &mon)
+		}
+	}
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		for _, mon := range monArray {
+			if rCtx.WasCanceled() {
+				return
+			}
+
+			sizeBefore := file.FileSize(mon.Path())
+			migrated, err := mon.Migrate(format)
+			if err != nil {
+				errorChan <- fmt.Errorf("%s: %w", mon.Address.Hex(), err)
+				continue
+			}
+			if migrated {
+				logger.Info(fmt.Sprintf("Migrated %s to %s (%d bytes to %d bytes)", mon.Address.Hex(), opts.Migrate, sizeBefore, file.FileSize(mon.Path())))
+			}
+
+			_ = mon.ReadMonitorHeader()
+			mon.Close()
+			s := types.Monitor{
+				Address:     mon.Address,
+				NRecords:    mon.Count(),
+				FileSize:    file.FileSize(mon.Path()),
+				LastScanned: mon.LastScanned,
+				Deleted:     mon.Deleted,
+			}
+			s.IsEmpty = s.NRecords == 0
+			modelChan <- &s
+		}
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
@@ -0,0 +1,116 @@
+package monitorsPkg
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rules"
+)
+
+// ruleRunner evaluates the new appearances of watched monitors against the --rules file
+type ruleRunner struct {
+	conn   *rpc.Connection
+	engine *rules.Engine
+	record string
+	traces bool
+}
+
+// newRuleRunner returns nil if there is no --rules file
+func (opts *MonitorsOptions) newRuleRunner() (*ruleRunner, error) {
+	if len(opts.Rules) == 0 {
+		return nil, nil
+	}
+
+	cfg, err := rules.Load(opts.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, err := rules.NewEngine(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	_, tracing := opts.Conn.IsNodeTracing()
+	if !tracing {
+		logger.Warn("The node is not tracing, rules will not see internal ETH transfers.")
+	}
+
+	logger.Info(fmt.Sprintf("Loaded %d rules and %d sinks from %s", len(cfg.Rules), len(cfg.Sinks), opts.Rules))
+	return &ruleRunner{
+		conn:   opts.Conn,
+		engine: engine,
+		record: cfg.Record,
+		traces: tracing,
+	}, nil
+}
+
+// process evaluates the appearances a monitor gained during the last freshen. A monitor
+// freshened for the first time has no new appearances, only history, so it's skipped.
+func (r *ruleRunner) process(mon *monitor.Monitor, countBefore, countAfter int64) error {
+	if countBefore == 0 {
+		logger.Info("Not applying rules to the history of new monitor", mon.Address.Hex())
+		return nil
+	}
+
+	apps, _, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), false)
+	mon.Close()
+	if err != nil {
+		return err
+	}
+	if int64(len(apps)) < countAfter {
+		countAfter = int64(len(apps))
+	}
+	if countBefore >= countAfter {
+		return nil
+	}
+
+	var recorder *os.File
+	if len(r.record) > 0 {
+		if recorder, err = os.OpenFile(r.record, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			return err
+		}
+		defer recorder.Close()
+	}
+
+	for _, app := range apps[countBefore:countAfter] {
+		app := app
+		tx, err := r.conn.GetTransactionByAppearance(&app, r.traces)
+		if err != nil {
+			return err
+		}
+
+		var balance *base.Wei
+		if r.engine.NeedsBalances() {
+			if balance, err = r.conn.GetBalanceAt(mon.Address, base.Blknum(app.BlockNumber)); err != nil {
+				return err
+			}
+		}
+
+		ev := rules.NewEvent(mon.Address, tx, balance)
+		if recorder != nil {
+			if err := rules.WriteEvent(recorder, &ev); err != nil {
+				return err
+			}
+		}
+
+		// Delivery failures are logged by the engine and must not stop the watch
+		alerts, _ := r.engine.Process(&ev)
+		for _, alert := range alerts {
+			logger.Info(fmt.Sprintf("Rule %s matched %d.%d: %s", alert.Rule, app.BlockNumber, app.TransactionIndex, alert.Reason))
+		}
+	}
+
+	return nil
+}
+
+func (r *ruleRunner) close() {
+	if r != nil {
+		r.engine.Close()
+	}
+}
@@ -5,6 +5,7 @@
 package monitorsPkg
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -20,6 +21,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
@@ -47,6 +49,21 @@ func (opts *MonitorsOptions) RunMonitorScraper(wg *sync.WaitGroup, s *Scraper) {
 
 	s.ChangeState(true, tmpPath)
 
+	runner, err := opts.newRuleRunner()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	defer runner.close()
+
+	// With --subscribe, we wake as soon as a new block arrives rather than sleeping
+	var follower *rpc.HeadFollower
+	if opts.Subscribe {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		follower = opts.Conn.FollowHeads(ctx, time.Duration(opts.Sleep*float64(time.Second)))
+	}
+
 	runCount := uint64(0)
 	for {
 		if !s.Running {
@@ -59,7 +76,7 @@ func (opts *MonitorsOptions) RunMonitorScraper(wg *sync.WaitGroup, s *Scraper) {
 				return
 			}
 
-			if canceled, err := opts.Refresh(monitorList); err != nil {
+			if canceled, err := opts.Refresh(monitorList, runner); err != nil {
 				logger.Error(err)
 				return
 			} else {
@@ -74,7 +91,12 @@ func (opts *MonitorsOptions) RunMonitorScraper(wg *sync.WaitGroup, s *Scraper) {
 			}
 
 			sleep := opts.Sleep
-			if sleep > 0 {
+			if follower != nil {
+				if !opts.Globals.TestMode {
+					logger.Info(fmt.Sprintf("Waiting up to %g seconds for the next block", sleep))
+				}
+				follower.Wait(context.Background(), time.Duration(sleep*float64(time.Second)))
+			} else if sleep > 0 {
 				ms := time.Duration(sleep*1000) * time.Millisecond
 				if !opts.Globals.TestMode {
 					logger.Info(fmt.Sprintf("Sleeping for %g seconds", sleep))
@@ -120,7 +142,7 @@ func (c *Command) String() string {
 	return string(b)
 }
 
-func (opts *MonitorsOptions) Refresh(monitors []monitor.Monitor) (bool, error) {
+func (opts *MonitorsOptions) Refresh(monitors []monitor.Monitor, runner *ruleRunner) (bool, error) {
 	theCmds, err := opts.getCommands()
 	if err != nil {
 		return false, err
@@ -168,6 +190,12 @@ func (opts *MonitorsOptions) Refresh(monitors []monitor.Monitor) (bool, error) {
 
 			logger.Info(fmt.Sprintf("Processing item %d in batch %d: %d %d\n", j, i, countsBefore[j], countAfter))
 
+			if runner != nil && countAfter > countsBefore[j] {
+				if err := runner.process(&mon, countsBefore[j], countAfter); err != nil {
+					logger.Error("Applying rules to", mon.Address.Hex(), "failed:", err)
+				}
+			}
+
 			for _, cmd := range theCmds {
 				countBefore := countsBefore[j]
 				if countBefore == 0 || countAfter > countBefore {
@@ -233,6 +261,9 @@ func (opts *MonitorsOptions) cleanLine(lineIn string) (cmd Command, err error) {
 }
 
 func (opts *MonitorsOptions) getCommands() (ret []Command, err error) {
+	if len(opts.Commands) == 0 {
+		return nil, nil
+	}
 	lines := file.AsciiFileToLines(opts.Commands)
 	for _, line := range lines {
 		// orig := line
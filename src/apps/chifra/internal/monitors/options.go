@@ -36,12 +36,16 @@ type MonitorsOptions struct {
 	List      bool                  `json:"list,omitempty"`      // List monitors in the cache (--verbose for more detail)
 	Count     bool                  `json:"count,omitempty"`     // Show the number of active monitors (included deleted but not removed monitors)
 	Staged    bool                  `json:"staged,omitempty"`    // For --clean, --list, and --count options only, include staged monitors
+	Migrate   string                `json:"migrate,omitempty"`   // Rewrite the given monitors (or all monitors if none are given) in place in the given file format
 	Watch     bool                  `json:"watch,omitempty"`     // Continually scan for new blocks and extract data as per the command file
+	Group     string                `json:"group,omitempty"`     // Create or show a named group of monitors, adding any given addresses to it (with --watch, watch the group instead of a --watchlist)
 	Watchlist string                `json:"watchlist,omitempty"` // Available with --watch option only, a file containing the addresses to watch
 	Commands  string                `json:"commands,omitempty"`  // Available with --watch option only, the file containing the list of commands to apply to each watched address
 	BatchSize uint64                `json:"batchSize,omitempty"` // Available with --watch option only, the number of monitors to process in each batch
 	RunCount  uint64                `json:"runCount,omitempty"`  // Available with --watch option only, run the monitor this many times, then quit
 	Sleep     float64               `json:"sleep,omitempty"`     // Available with --watch option only, the number of seconds to sleep between runs
+	Subscribe bool                  `json:"subscribe,omitempty"` // Available with --watch option only, wake as soon as a block arrives from a newHeads subscription rather than sleeping between runs
+	Rules     string                `json:"rules,omitempty"`     // Available with --watch option only, a TOML file of rules evaluated against each new appearance and the sinks that receive matches
 	Globals   globals.GlobalOptions `json:"globals,omitempty"`   // The global options
 	Conn      *rpc.Connection       `json:"conn,omitempty"`      // The connection to the RPC server
 	BadFlag   error                 `json:"badFlag,omitempty"`   // An error flag if needed
@@ -64,12 +68,16 @@ func (opts *MonitorsOptions) testLog() {
 	logger.TestLog(opts.List, "List: ", opts.List)
 	logger.TestLog(opts.Count, "Count: ", opts.Count)
 	logger.TestLog(opts.Staged, "Staged: ", opts.Staged)
+	logger.TestLog(len(opts.Migrate) > 0, "Migrate: ", opts.Migrate)
 	logger.TestLog(opts.Watch, "Watch: ", opts.Watch)
+	logger.TestLog(len(opts.Group) > 0, "Group: ", opts.Group)
 	logger.TestLog(len(opts.Watchlist) > 0, "Watchlist: ", opts.Watchlist)
 	logger.TestLog(len(opts.Commands) > 0, "Commands: ", opts.Commands)
 	logger.TestLog(opts.BatchSize != 8, "BatchSize: ", opts.BatchSize)
 	logger.TestLog(opts.RunCount != 0, "RunCount: ", opts.RunCount)
 	logger.TestLog(opts.Sleep != float64(14), "Sleep: ", opts.Sleep)
+	logger.TestLog(opts.Subscribe, "Subscribe: ", opts.Subscribe)
+	logger.TestLog(len(opts.Rules) > 0, "Rules: ", opts.Rules)
 	opts.Conn.TestLog(opts.getCaches())
 	opts.Globals.TestLog()
 }
@@ -116,8 +124,12 @@ func MonitorsFinishParseInternal(w io.Writer, values url.Values) *MonitorsOption
 			opts.Count = true
 		case "staged":
 			opts.Staged = true
+		case "migrate":
+			opts.Migrate = value[0]
 		case "watch":
 			opts.Watch = true
+		case "group":
+			opts.Group = value[0]
 		case "watchlist":
 			opts.Watchlist = value[0]
 		case "commands":
@@ -128,6 +140,10 @@ func MonitorsFinishParseInternal(w io.Writer, values url.Values) *MonitorsOption
 			opts.RunCount = base.MustParseUint64(value[0])
 		case "sleep":
 			opts.Sleep = base.MustParseFloat64(value[0])
+		case "subscribe":
+			opts.Subscribe = true
+		case "rules":
+			opts.Rules = value[0]
 		default:
 			if !copy.Globals.Caps.HasKey(key) {
 				err := validate.Usage("Invalid key ({0}) in {1} route.", key, "monitors")
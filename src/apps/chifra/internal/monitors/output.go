@@ -36,7 +36,7 @@ func RunMonitors(cmd *cobra.Command, args []string) error {
 // ServeMonitors handles the monitors command for the API. Returns an error.
 func ServeMonitors(w http.ResponseWriter, r *http.Request) error {
 	opts := monitorsFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// TODO: can we move this to Validate?
 	var err1 error
@@ -80,10 +80,14 @@ func (opts *MonitorsOptions) MonitorsInternal(rCtx *output.RenderCtx) error {
 		err = opts.HandleCount(rCtx)
 	} else if opts.Clean {
 		err = opts.HandleClean(rCtx)
+	} else if len(opts.Migrate) > 0 {
+		err = opts.HandleMigrate(rCtx)
 	} else if opts.List {
 		err = opts.HandleList(rCtx)
 	} else if opts.Watch {
 		err = opts.HandleWatch(rCtx)
+	} else if len(opts.Group) > 0 {
+		err = opts.HandleGroup(rCtx)
 	} else if opts.anyCrud() {
 		err = opts.HandleCrud(rCtx)
 	} else {
@@ -12,6 +12,8 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rules"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
 
@@ -47,8 +49,20 @@ func (opts *MonitorsOptions) validateMonitors() error {
 					return validate.Usage("The {0} option is not allowed with the {1} option. Use {2} instead.", "--file", "--watch", "--commands")
 				}
 
+				if len(opts.Rules) > 0 {
+					rulesFile, err := filepath.Abs(opts.Rules)
+					if err != nil || !file.FileExists(rulesFile) {
+						return validate.Usage("The {0} option requires {1} to exist.", "--rules", opts.Rules)
+					}
+					if _, err := rules.Load(rulesFile); err != nil {
+						return validate.Usage("The {0} option is invalid: {1}", "--rules", err.Error())
+					}
+				}
+
 				if len(opts.Commands) == 0 {
-					return validate.Usage("The {0} option requires {1}.", "--watch", "a --commands file")
+					if len(opts.Rules) == 0 {
+						return validate.Usage("The {0} option requires {1}.", "--watch", "a --commands or --rules file")
+					}
 				} else {
 					cmdFile, err := filepath.Abs(opts.Commands)
 					if err != nil || !file.FileExists(cmdFile) {
@@ -59,6 +73,17 @@ func (opts *MonitorsOptions) validateMonitors() error {
 					}
 				}
 
+				if len(opts.Group) > 0 {
+					if len(opts.Watchlist) > 0 {
+						return validate.Usage("The {0} option may not be used with {1}.", "--group", "--watchlist")
+					}
+					if _, err := monitor.LoadGroup(chain, opts.Group); err != nil {
+						return validate.Usage("The {0} option is invalid: {1}", "--group", err.Error())
+					}
+					// A group's file is a list of addresses, so it serves as the watchlist
+					opts.Watchlist = monitor.PathToGroup(chain, opts.Group)
+				}
+
 				if len(opts.Watchlist) == 0 {
 					return validate.Usage("The {0} option requires {1}.", "--watch", "a --watchlist file")
 				} else {
@@ -94,10 +119,53 @@ func (opts *MonitorsOptions) validateMonitors() error {
 					return validate.Usage("The {0} option is not available{1}.", "--run_count", " without --watch")
 				}
 
+				if len(opts.Rules) > 0 {
+					return validate.Usage("The {0} option is not available{1}.", "--rules", " without --watch")
+				}
+
 				if opts.Sleep != 14 {
 					return validate.Usage("The {0} option is not available{1}.", "--sleep", " without --watch")
 				}
 
+				if opts.Subscribe {
+					return validate.Usage("The {0} option is not available{1}.", "--subscribe", " without --watch")
+				}
+
+				if len(opts.Migrate) > 0 {
+					if err := validate.ValidateEnum("migrate", opts.Migrate, "[v1|v2]"); err != nil {
+						return err
+					}
+					if opts.Delete || opts.Undelete || opts.Remove || opts.Clean || len(opts.Group) > 0 || opts.Globals.Decache {
+						return validate.Usage("The {0} option may not be used with {1}.", "--migrate", "--clean, --group, --decache, or the CRUD commands")
+					}
+					if err := validate.ValidateAddresses(opts.Addrs); err != nil {
+						return err
+					}
+					return opts.Globals.Validate()
+				}
+
+				if len(opts.Group) > 0 {
+					if !monitor.IsValidGroupName(opts.Group) {
+						return validate.Usage("The {0} option requires {1}.", "--group", "a name made of letters, digits, dots, dashes, and underscores")
+					}
+					if opts.Undelete || opts.Clean {
+						return validate.Usage("The {0} option may not be used with {1}.", "--group", "--undelete or --clean")
+					}
+					if opts.Delete && opts.Remove {
+						return validate.Usage("The {0} option may not be used with both {1}.", "--group", "--delete and --remove")
+					}
+					if opts.Delete && len(opts.Addrs) == 0 {
+						return validate.Usage("With {0}, the {1} option requires at least one address.", "--group", "--delete")
+					}
+					if opts.Remove && len(opts.Addrs) > 0 {
+						return validate.Usage("With {0}, the {1} option removes the group and does not take addresses.", "--group", "--remove")
+					}
+					if err := validate.ValidateAddresses(opts.Addrs); err != nil {
+						return err
+					}
+					return opts.Globals.Validate()
+				}
+
 				// We validate some of the simpler curd commands here and the rest in HandleCrud
 				if opts.Undelete {
 					if opts.Delete || opts.Remove {
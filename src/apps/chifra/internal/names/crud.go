@@ -43,6 +43,10 @@ func (opts *NamesOptions) getType() types.Parts {
 		ret |= types.Tags
 	}
 
+	if opts.Regex {
+		ret |= types.Regex
+	}
+
 	if opts.Globals.TestMode {
 		ret |= types.Testing
 	}
@@ -2,7 +2,9 @@ package namesPkg
 
 import (
 	"strconv"
+	"time"
 
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/crud"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
@@ -13,8 +15,8 @@ func (opts *NamesOptions) HandleCrud(rCtx *output.RenderCtx) (err error) {
 	chain := opts.Globals.Chain
 
 	parts := opts.getType()
-	// TODO: Why do we do this if we don't use the result?
-	if _, err = names.LoadNamesMap(chain, parts, nil); err != nil {
+	namesMap, err := names.LoadNamesMap(chain, parts, nil)
+	if err != nil {
 		return err
 	}
 
@@ -43,6 +45,21 @@ func (opts *NamesOptions) HandleCrud(rCtx *output.RenderCtx) (err error) {
 		return
 	}
 
+	// Record who changed which fields so the change wins or conflicts properly when teammates
+	// import our names (see --export and --import)
+	var prev *types.Name
+	if existing, ok := namesMap[name.Address]; ok && existing.IsCustom {
+		prev = &existing
+	}
+	prov := names.Provenance{
+		Source:    name.Source,
+		Author:    names.LocalAuthor(),
+		Timestamp: base.Timestamp(time.Now().Unix()),
+	}
+	if err = names.StampProvenance(chain, prev, name, prov); err != nil {
+		return
+	}
+
 	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
 		modelChan <- name
 	}
@@ -0,0 +1,385 @@
+package namesPkg
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/monitor"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/uniq"
+)
+
+// discoverSources are the sources --discover names addresses from in their default order of preference
+var discoverSources = []string{"tokens", "erc20", "ens", "abis", "creation"}
+
+func (opts *NamesOptions) HandleDiscover(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+
+	db := names.DatabaseCustom
+	if opts.Regular {
+		db = names.DatabaseRegular
+	}
+
+	known, err := names.LoadNamesMap(chain, types.All, nil)
+	if err != nil {
+		return err
+	}
+
+	addrs, err := opts.discoverAddresses(known)
+	if err != nil {
+		return err
+	}
+
+	d, err := opts.newDiscoverer(known)
+	if err != nil {
+		return err
+	}
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		bar := logger.NewBar(logger.BarOptions{
+			Enabled: opts.Globals.ShowProgress(),
+			Tracker: rCtx.Progress,
+			Total:   int64(len(addrs)),
+		})
+
+		discovered := make([]types.Name, 0, len(addrs))
+		for _, addr := range addrs {
+			if rCtx.WasCanceled() {
+				return
+			}
+			bar.Tick()
+			name := d.discover(addr)
+			if name == nil {
+				continue
+			}
+			name.IsCustom = !opts.Regular
+			if !opts.DryRun {
+				names.AddName(db, name)
+			}
+			discovered = append(discovered, *name)
+			modelChan <- name
+		}
+		bar.Finish(true /* newLine */)
+
+		logger.Info(fmt.Sprintf("Named %d of %d unnamed addresses", len(discovered), len(addrs)))
+		if opts.DryRun || len(discovered) == 0 {
+			return
+		}
+
+		if opts.Regular {
+			if err := names.RegularWriteNames(chain, false); err != nil {
+				errorChan <- err
+			}
+			return
+		}
+
+		if err := names.CustomWriteNames(chain, false); err != nil {
+			errorChan <- err
+			return
+		}
+		author, now := names.LocalAuthor(), base.Timestamp(time.Now().Unix())
+		for _, name := range discovered {
+			name := name
+			prov := names.Provenance{Source: name.Source, Author: author, Timestamp: now}
+			if err := names.StampProvenance(chain, nil, &name, prov); err != nil {
+				errorChan <- err
+				return
+			}
+		}
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
+
+var addressRegex = regexp.MustCompile(`\b0x[0-9a-fA-F]{40}\b`)
+
+// discoverAddresses returns the unnamed addresses mentioned by the terms. An address term
+// contributes itself and, if it's monitored, every address in the transactions it appears in.
+// A file contributes every address found in it (for example, the output of chifra export).
+func (opts *NamesOptions) discoverAddresses(known map[base.Address]types.Name) ([]base.Address, error) {
+	chain := opts.Globals.Chain
+
+	seen := make(map[base.Address]bool)
+	ret := make([]base.Address, 0)
+	add := func(addr base.Address) {
+		if addr.IsZero() || seen[addr] {
+			return
+		}
+		seen[addr] = true
+		if _, ok := known[addr]; !ok {
+			ret = append(ret, addr)
+		}
+	}
+
+	for _, term := range opts.Terms {
+		if !base.IsValidAddress(term) {
+			contents, err := os.ReadFile(term)
+			if err != nil {
+				return nil, err
+			}
+			for _, match := range addressRegex.FindAllString(string(contents), -1) {
+				add(base.HexToAddress(match))
+			}
+			continue
+		}
+
+		addr := base.HexToAddress(term)
+		add(addr)
+		if !file.FileExists(monitor.PathToMonitorFile(chain, addr)) {
+			continue
+		}
+
+		mon, err := monitor.NewMonitor(chain, addr, false)
+		if err != nil {
+			return nil, err
+		}
+		apps, _, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), false)
+		mon.Close()
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Gathering the addresses in", len(apps), "appearances of", addr.Hex())
+
+		procFunc := func(app *types.Appearance) error {
+			add(app.Address)
+			return nil
+		}
+		for _, app := range apps {
+			app := app
+			trans, err := opts.Conn.GetTransactionByAppearance(&app, true)
+			if err != nil {
+				return nil, err
+			}
+			ts := opts.Conn.GetBlockTimestamp(base.Blknum(app.BlockNumber))
+			if err = uniq.GetUniqAddressesInTransaction(chain, procFunc, "", trans, ts, make(uniq.AddressBooleanMap), opts.Conn); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Hex() < ret[j].Hex()
+	})
+	return ret, nil
+}
+
+// discoverer names addresses from each of its sources in turn
+type discoverer struct {
+	chain     string
+	conn      *rpc.Connection
+	sources   []string
+	tokens    map[base.Address]types.Name
+	known     map[base.Address]types.Name
+	contracts map[base.Address]bool
+}
+
+func (opts *NamesOptions) newDiscoverer(known map[base.Address]types.Name) (*discoverer, error) {
+	chain := opts.Globals.Chain
+
+	d := &discoverer{
+		chain:     chain,
+		conn:      opts.Conn,
+		known:     known,
+		tokens:    make(map[base.Address]types.Name),
+		contracts: make(map[base.Address]bool),
+	}
+
+	seen := make(map[string]bool)
+	for _, source := range opts.Sources {
+		list := []string{source}
+		if source == "all" {
+			list = discoverSources
+		}
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				d.sources = append(d.sources, s)
+			}
+		}
+	}
+	if len(d.sources) == 0 {
+		d.sources = discoverSources
+	}
+
+	if len(opts.TokenList) > 0 {
+		list, err := names.LoadTokenList(opts.TokenList)
+		if err != nil {
+			return nil, err
+		}
+		d.tokens = list.Names(base.MustParseUint64(config.GetChain(chain).ChainId))
+		logger.Info("Loaded", len(d.tokens), "tokens from", opts.TokenList)
+	}
+
+	return d, nil
+}
+
+// discover returns the name of an address from the first source that knows it or nil if none do
+func (d *discoverer) discover(addr base.Address) *types.Name {
+	for _, source := range d.sources {
+		var name *types.Name
+		switch source {
+		case "tokens":
+			if n, ok := d.tokens[addr]; ok {
+				name = &n
+			}
+		case "erc20":
+			name = d.fromErc20(addr)
+		case "ens":
+			name = d.fromEns(addr)
+		case "abis":
+			name = d.fromAbis(addr)
+		case "creation":
+			name = d.fromCreation(addr)
+		}
+		if name != nil {
+			return name
+		}
+	}
+	return nil
+}
+
+func (d *discoverer) isContract(addr base.Address) bool {
+	if is, ok := d.contracts[addr]; ok {
+		return is
+	}
+	is := d.conn.IsContractAtLatest(addr) == nil
+	d.contracts[addr] = is
+	return is
+}
+
+// fromErc20 names tokens from their on-chain name and symbol
+func (d *discoverer) fromErc20(addr base.Address) *types.Name {
+	if !d.isContract(addr) {
+		return nil
+	}
+	name := &types.Name{Address: addr, Source: "On chain"}
+	if _, err := cleanName(d.chain, name); err != nil {
+		return nil
+	}
+	if (!name.IsErc20 && !name.IsErc721) || len(name.Name) == 0 {
+		return nil
+	}
+	return name
+}
+
+// fromEns names addresses from their reverse ENS record (on mainnet only)
+func (d *discoverer) fromEns(addr base.Address) *types.Name {
+	if d.chain != "mainnet" {
+		return nil
+	}
+	ensName, ok := d.conn.GetEnsName(addr.Hex())
+	if !ok {
+		return nil
+	}
+	return &types.Name{
+		Address:    addr,
+		Name:       ensName,
+		Tags:       "66-ENS",
+		Source:     "ENS",
+		IsContract: d.isContract(addr),
+	}
+}
+
+// fromAbis names contracts by the kind of contract their cached ABI describes
+func (d *discoverer) fromAbis(addr base.Address) *types.Name {
+	if !d.isContract(addr) {
+		return nil
+	}
+	functions, err := abi.LoadCachedAbi(d.chain, addr)
+	if err != nil {
+		return nil
+	}
+	kind := classifyAbi(functions)
+	if kind == nil {
+		return nil
+	}
+	return &types.Name{
+		Address:    addr,
+		Name:       kind.name,
+		Tags:       kind.tags,
+		Source:     "Abis",
+		IsContract: true,
+	}
+}
+
+// fromCreation names contracts after the factory that created them or the account that deployed them
+func (d *discoverer) fromCreation(addr base.Address) *types.Name {
+	if !d.isContract(addr) {
+		return nil
+	}
+	deployer, factory, _, err := d.conn.GetContractCreator(addr)
+	if err != nil || deployer.IsZero() {
+		return nil
+	}
+	name := "Deployed by " + d.label(deployer)
+	if !factory.IsZero() {
+		name = "Created by " + d.label(factory)
+	}
+	return &types.Name{
+		Address:    addr,
+		Name:       name,
+		Tags:       "30-Contracts",
+		Source:     "Creation",
+		IsContract: true,
+	}
+}
+
+func (d *discoverer) label(addr base.Address) string {
+	if name, ok := d.known[addr]; ok && len(name.Name) > 0 {
+		return name.Name
+	}
+	return addr.Hex()
+}
+
+// abiKind is a kind of contract recognized by the functions in its ABI
+type abiKind struct {
+	name  string
+	tags  string
+	needs []string
+}
+
+// abiKinds are checked in order, so more specific kinds come first
+var abiKinds = []abiKind{
+	{"Safe Multisig", "30-Contracts:Multisig", []string{"getOwners", "getThreshold", "execTransaction"}},
+	{"Uniswap V2 Pair", "55-Defi:Pair", []string{"token0", "token1", "getReserves"}},
+	{"ERC-1155 Contract", "50-Tokens:ERC1155", []string{"balanceOfBatch", "safeBatchTransferFrom"}},
+	{"ERC-721 Contract", "50-Tokens:ERC721", []string{"ownerOf", "safeTransferFrom", "balanceOf"}},
+	{"ERC-20 Contract", "50-Tokens:ERC20", []string{"transfer", "transferFrom", "balanceOf", "totalSupply"}},
+	{"Upgradeable Proxy", "30-Contracts:Proxy", []string{"upgradeTo"}},
+}
+
+// classifyAbi returns the kind of contract an ABI describes or nil if it's not recognized
+func classifyAbi(functions []types.Function) *abiKind {
+	has := make(map[string]bool, len(functions))
+	for _, f := range functions {
+		if f.FunctionType == "function" {
+			has[f.Name] = true
+		}
+	}
+	for i := range abiKinds {
+		kind := &abiKinds[i]
+		found := true
+		for _, need := range kind.needs {
+			if !has[need] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package namesPkg
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func Test_classifyAbi(t *testing.T) {
+	abi := func(names ...string) []types.Function {
+		ret := make([]types.Function, 0, len(names))
+		for _, name := range names {
+			ret = append(ret, types.Function{Name: name, FunctionType: "function"})
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name      string
+		functions []types.Function
+		expected  string
+	}{
+		{"erc20", abi("transfer", "transferFrom", "balanceOf", "totalSupply", "approve"), "ERC-20 Contract"},
+		{"erc721", abi("transferFrom", "balanceOf", "ownerOf", "safeTransferFrom", "totalSupply"), "ERC-721 Contract"},
+		{"safe", abi("getOwners", "getThreshold", "execTransaction", "upgradeTo"), "Safe Multisig"},
+		{"pair", abi("token0", "token1", "getReserves", "transfer", "transferFrom", "balanceOf", "totalSupply"), "Uniswap V2 Pair"},
+		{"proxy", abi("upgradeTo", "implementation"), "Upgradeable Proxy"},
+		{"unknown", abi("deposit", "withdraw"), ""},
+		{"events do not count", []types.Function{{Name: "upgradeTo", FunctionType: "event"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if kind := classifyAbi(tt.functions); kind != nil {
+				got = kind.name
+			}
+			if got != tt.expected {
+				t.Errorf("classifyAbi() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package namesPkg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// HandleExport writes every custom name (including deleted names, so deletions are shared)
+// along with the provenance of its fields to a bundle signed with the local signing key.
+func (opts *NamesOptions) HandleExport(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+
+	key, err := names.SigningKey()
+	if err != nil {
+		return err
+	}
+
+	parts := types.Custom | (opts.getType() & types.Testing)
+	namesMap, err := names.LoadNamesMap(chain, parts, nil)
+	if err != nil {
+		return err
+	}
+
+	prov, err := names.LoadProvenance(chain)
+	if err != nil {
+		return err
+	}
+
+	author := names.LocalAuthor()
+	list := make([]names.BundleName, 0, len(namesMap))
+	for _, name := range namesMap {
+		list = append(list, names.NewBundleName(&name, prov[name.Address], author))
+	}
+
+	bundle := names.NewBundle(chain, author, base.Timestamp(time.Now().Unix()), list)
+	if err := bundle.Sign(key); err != nil {
+		return err
+	}
+	if err := names.WriteBundle(opts.Export, bundle); err != nil {
+		return err
+	}
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		modelChan <- &types.Message{
+			Msg: fmt.Sprintf("Exported %d names signed by %s to %s", len(list), bundle.Author.Hex(), opts.Export),
+			Num: int64(len(list)),
+		}
+	}
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
@@ -0,0 +1,162 @@
+package namesPkg
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/crud"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
+)
+
+// HandleImport merges a signed bundle of names into the custom names. Changes are applied
+// through the same create, delete and undelete paths as the crud options. Conflicts are
+// reported whether or not they were resolved.
+func (opts *NamesOptions) HandleImport(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		bundle, err := readBundle(chain, opts.Import)
+		if err != nil {
+			errorChan <- err
+			rCtx.Cancel()
+			return
+		}
+
+		parts := types.Custom | (opts.getType() & types.Testing)
+		namesMap, err := names.LoadNamesMap(chain, parts, nil)
+		if err != nil {
+			errorChan <- err
+			rCtx.Cancel()
+			return
+		}
+
+		prov, err := names.LoadProvenance(chain)
+		if err != nil {
+			errorChan <- err
+			rCtx.Cancel()
+			return
+		}
+
+		author := names.LocalAuthor()
+		ours := make(map[base.Address]names.BundleName, len(namesMap))
+		for addr, name := range namesMap {
+			ours[addr] = names.NewBundleName(&name, prov[addr], author)
+		}
+
+		mergeBase, err := names.LoadMergeBase(chain, bundle.Author)
+		if err != nil {
+			errorChan <- err
+			rCtx.Cancel()
+			return
+		}
+
+		result := names.Merge(ours, mergeBase, bundle, names.Strategy(opts.Strategy))
+
+		nCreated, nUpdated, nResolved := 0, 0, 0
+		for _, c := range result.Conflicts {
+			if len(c.Resolution) > 0 {
+				nResolved++
+			}
+		}
+		for _, merged := range result.Changed {
+			// the merge base is only saved once every change is written, so an import that
+			// stops early is repeated in full next time
+			if rCtx.WasCanceled() {
+				return
+			}
+			if merged.Created {
+				nCreated++
+			} else {
+				nUpdated++
+			}
+			if opts.DryRun {
+				logger.Info("Would", createOrUpdate(merged.Created), merged.Name.Address.Hex(), merged.Name.Name)
+				continue
+			}
+			if err := applyMerged(chain, &merged); err != nil {
+				errorChan <- err
+				rCtx.Cancel()
+				return
+			}
+		}
+
+		if !opts.DryRun {
+			if err := names.SaveMergeBase(chain, bundle.Author, result.Base); err != nil {
+				errorChan <- err
+				rCtx.Cancel()
+				return
+			}
+		}
+
+		if !utils.IsFuzzing() {
+			logger.Info(fmt.Sprintf(
+				"Imported %d names from %s: %d created, %d updated, %d conflicts (%d resolved)",
+				len(bundle.Names),
+				bundle.Author.Hex(),
+				nCreated,
+				nUpdated,
+				len(result.Conflicts),
+				nResolved,
+			))
+		}
+
+		for _, conflict := range result.Conflicts {
+			conflict := conflict
+			modelChan <- &conflict
+		}
+	}
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
+
+// readBundle reads a bundle and returns an error unless it's for chain and was signed by one
+// of the trusted authors
+func readBundle(chain, path string) (*names.Bundle, error) {
+	bundle, err := names.ReadBundle(path)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.Chain != chain {
+		return nil, fmt.Errorf("the bundle is for chain %s, not %s", bundle.Chain, chain)
+	}
+	if err := bundle.CheckAuthor(names.TrustedAuthors()); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// applyMerged writes a merged name to the custom names database and records where the
+// fields taken from the bundle came from
+func applyMerged(chain string, merged *names.MergedName) error {
+	name := &merged.Name
+	data := &crud.NameCrud{
+		Address:  crud.Field[base.Address]{Value: name.Address, Updated: true},
+		Name:     crud.Field[string]{Value: name.Name, Updated: true},
+		Tags:     crud.Field[string]{Value: name.Tags, Updated: true},
+		Source:   crud.Field[string]{Value: name.Source, Updated: true},
+		Symbol:   crud.Field[string]{Value: name.Symbol, Updated: true},
+		Decimals: crud.Field[string]{Value: fmt.Sprintf("%d", name.Decimals), Updated: true},
+	}
+
+	// handleCreate always leaves the name undeleted
+	if _, err := handleCreate(chain, data); err != nil {
+		return err
+	}
+	if name.Deleted {
+		if _, err := handleDelete(chain, data); err != nil {
+			return err
+		}
+	}
+
+	return names.SetProvenance(chain, name.Address, merged.Provenance)
+}
+
+func createOrUpdate(created bool) string {
+	if created {
+		return "create"
+	}
+	return "update"
+}
@@ -0,0 +1,66 @@
+package namesPkg
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func Test_readBundle(t *testing.T) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23"
+	teammate := "0x0000000000000000000000000000000000000b0b"
+
+	name := types.Name{Address: base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f"), Name: "Dai", Source: "Test"}
+	b := names.NewBundle("mainnet", base.ZeroAddr, 1700000000, []names.BundleName{
+		names.NewBundleName(&name, nil, base.HexToAddress(teammate)),
+	})
+	if err := b.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	if err := names.WriteBundle(path, b); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.GetRootConfig()
+	saved := cfg.Settings.NamesAuthors
+	t.Cleanup(func() { cfg.Settings.NamesAuthors = saved })
+
+	tests := []struct {
+		name    string
+		authors []string
+		chain   string
+		wantErr bool
+	}{
+		{"no authors", nil, "mainnet", true},
+		{"unknown signer", []string{teammate}, "mainnet", true},
+		{"trusted signer", []string{teammate, signer}, "mainnet", false},
+		{"wrong chain", []string{signer}, "sepolia", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.Settings.NamesAuthors = tt.authors
+			bundle, err := readBundle(tt.chain, path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.name == "unknown signer" && !errors.Is(err, names.ErrUntrustedAuthor) {
+				t.Errorf("expected an untrusted author error, got %v", err)
+			}
+			if err == nil && bundle.Author.Hex() != signer {
+				t.Errorf("unexpected author %s", bundle.Author.Hex())
+			}
+		})
+	}
+}
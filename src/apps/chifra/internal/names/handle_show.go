@@ -12,7 +12,11 @@ import (
 func (opts *NamesOptions) HandleShow(rCtx *output.RenderCtx) error {
 	chain := opts.Globals.Chain
 	testMode := opts.Globals.TestMode
-	namesArray, err := loadNamesArray(chain, opts.getType(), types.SortByAddress, opts.Terms)
+	sortBy := types.SortByAddress
+	if opts.Rank {
+		sortBy = types.SortByRank
+	}
+	namesArray, err := loadNamesArray(chain, opts.getType(), sortBy, opts.Terms)
 	if err != nil {
 		return err
 	}
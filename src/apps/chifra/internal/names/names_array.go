@@ -11,22 +11,34 @@ import (
 
 // loadNamesArray loads the names from the cache and returns an array of names
 func loadNamesArray(chain string, parts types.Parts, sortBy types.SortBy, terms []string) ([]types.Name, error) {
-	var ret []types.Name
-	if namesMap, err := names.LoadNamesMap(chain, parts, terms); err != nil {
+	var found []types.Name
+	if sortBy == types.SortByRank {
+		var err error
+		if found, err = names.SearchNames(chain, parts, terms); err != nil {
+			return nil, err
+		}
+	} else if namesMap, err := names.LoadNamesMap(chain, parts, terms); err != nil {
 		return nil, err
 	} else {
 		for _, name := range namesMap {
-			// Custom names with Individual tag or tags under 30 are private during testing
-			isTesting := parts&types.Testing != 0
-			isPrivate := strings.Contains(name.Tags, "Individual") || (name.IsCustom && name.Tags < "3")
-			if !isTesting || !isPrivate {
-				ret = append(ret, name)
-			}
+			found = append(found, name)
+		}
+	}
+
+	var ret []types.Name
+	for _, name := range found {
+		// Custom names with Individual tag or tags under 30 are private during testing
+		isTesting := parts&types.Testing != 0
+		isPrivate := strings.Contains(name.Tags, "Individual") || (name.IsCustom && name.Tags < "3")
+		if !isTesting || !isPrivate {
+			ret = append(ret, name)
 		}
 	}
 
-	sort.Slice(ret, func(i, j int) bool {
+	sort.SliceStable(ret, func(i, j int) bool {
 		switch sortBy {
+		case types.SortByRank:
+			return false // already in order of relevance
 		case types.SortByTags:
 			return ret[i].Tags < ret[j].Tags
 		case types.SortByAddress:
@@ -38,9 +38,17 @@ type NamesOptions struct {
 	Addr      bool                  `json:"addr,omitempty"`      // Display only addresses in the results (useful for scripting, assumes --no_header)
 	Tags      bool                  `json:"tags,omitempty"`      // Export the list of tags and subtags only
 	Clean     bool                  `json:"clean,omitempty"`     // Clean the data (addrs to lower case, sort by addr)
-	Regular   bool                  `json:"regular,omitempty"`   // Only available with --clean, cleans regular names database
-	DryRun    bool                  `json:"dryRun,omitempty"`    // Only available with --clean or --autoname, outputs changes to stdout instead of updating databases
+	Regular   bool                  `json:"regular,omitempty"`   // Only available with --clean or --discover, cleans or adds to the regular names database
+	DryRun    bool                  `json:"dryRun,omitempty"`    // Only available with --clean, --autoname, --discover, or --import, outputs changes to stdout instead of updating databases
 	Autoname  string                `json:"autoname,omitempty"`  // An address assumed to be a token, added automatically to names database if true
+	Regex     bool                  `json:"regex,omitempty"`     // Treat each term as a regular expression (the search semantics of earlier versions)
+	Rank      bool                  `json:"rank,omitempty"`      // Order the results by relevance to the search terms rather than by address
+	Export    string                `json:"export,omitempty"`    // Export the custom names to a signed bundle at this path
+	Import    string                `json:"import,omitempty"`    // Merge a signed bundle of names exported by a teammate into the custom names
+	Strategy  string                `json:"strategy,omitempty"`  // For the --import option only, resolve conflicts by keeping ours, taking theirs, or taking the newer value
+	Discover  bool                  `json:"discover,omitempty"`  // Name every unnamed address in the terms, in the appearances of monitored terms, or in files given as terms
+	Sources   []string              `json:"sources,omitempty"`   // For the --discover option only, the sources to name addresses from (in order of preference)
+	TokenList string                `json:"tokenList,omitempty"` // For the --discover option only, the path or URL of a token list in the Uniswap token list format
 	Create    bool                  `json:"create,omitempty"`    // Create a new name record
 	Update    bool                  `json:"update,omitempty"`    // Edit an existing name
 	Delete    bool                  `json:"delete,omitempty"`    // Delete a name, but do not remove it
@@ -72,6 +80,14 @@ func (opts *NamesOptions) testLog() {
 	logger.TestLog(opts.Regular, "Regular: ", opts.Regular)
 	logger.TestLog(opts.DryRun, "DryRun: ", opts.DryRun)
 	logger.TestLog(len(opts.Autoname) > 0, "Autoname: ", opts.Autoname)
+	logger.TestLog(opts.Regex, "Regex: ", opts.Regex)
+	logger.TestLog(opts.Rank, "Rank: ", opts.Rank)
+	logger.TestLog(len(opts.Export) > 0, "Export: ", opts.Export)
+	logger.TestLog(len(opts.Import) > 0, "Import: ", opts.Import)
+	logger.TestLog(len(opts.Strategy) > 0, "Strategy: ", opts.Strategy)
+	logger.TestLog(opts.Discover, "Discover: ", opts.Discover)
+	logger.TestLog(len(opts.Sources) > 0, "Sources: ", opts.Sources)
+	logger.TestLog(len(opts.TokenList) > 0, "TokenList: ", opts.TokenList)
 	logger.TestLog(opts.Create, "Create: ", opts.Create)
 	logger.TestLog(opts.Update, "Update: ", opts.Update)
 	logger.TestLog(opts.Delete, "Delete: ", opts.Delete)
@@ -129,6 +145,25 @@ func NamesFinishParseInternal(w io.Writer, values url.Values) *NamesOptions {
 			opts.DryRun = true
 		case "autoname":
 			opts.Autoname = value[0]
+		case "regex":
+			opts.Regex = true
+		case "rank":
+			opts.Rank = true
+		case "export":
+			opts.Export = value[0]
+		case "import":
+			opts.Import = value[0]
+		case "strategy":
+			opts.Strategy = value[0]
+		case "discover":
+			opts.Discover = true
+		case "sources":
+			for _, val := range value {
+				s := strings.Split(val, " ") // may contain space separated items
+				opts.Sources = append(opts.Sources, s...)
+			}
+		case "tokenList":
+			opts.TokenList = value[0]
 		case "create":
 			opts.Create = true
 		case "update":
@@ -38,7 +38,7 @@ func RunNames(cmd *cobra.Command, args []string) error {
 // ServeNames handles the names command for the API. Returns an error.
 func ServeNames(w http.ResponseWriter, r *http.Request) error {
 	opts := namesFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	var err1 error
 	if err1 = opts.LoadCrudDataIfNeeded(r); err1 != nil {
@@ -62,8 +62,14 @@ func (opts *NamesOptions) NamesInternal(rCtx *output.RenderCtx) error {
 	msg := "chifra names"
 	// EXISTING_CODE
 	// EXISTING_CODE
-	if len(opts.Autoname) > 0 {
+	if opts.Discover {
+		err = opts.HandleDiscover(rCtx)
+	} else if len(opts.Autoname) > 0 {
 		err = opts.HandleAutoname(rCtx)
+	} else if len(opts.Export) > 0 {
+		err = opts.HandleExport(rCtx)
+	} else if len(opts.Import) > 0 {
+		err = opts.HandleImport(rCtx)
 	} else if opts.Clean {
 		err = opts.HandleClean(rCtx)
 	} else if opts.Tags {
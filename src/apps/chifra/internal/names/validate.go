@@ -5,8 +5,12 @@
 package namesPkg
 
 import (
+	"strings"
+
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/names"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
@@ -34,9 +38,83 @@ func (opts *NamesOptions) validateNames() error {
 		}
 	}
 
-	isDryRunnable := opts.Clean || len(opts.Autoname) > 0
+	isDryRunnable := opts.Clean || len(opts.Autoname) > 0 || opts.Discover || len(opts.Import) > 0
 	if opts.DryRun && !isDryRunnable {
-		return validate.Usage("The {0} option is only available with the {1} options.", "--dry_run", "--clean or --autoname")
+		return validate.Usage("The {0} option is only available with the {1} options.", "--dry_run", "--clean, --autoname, --discover, or --import")
+	}
+
+	if err := validate.ValidateEnumSlice("--sources", opts.Sources, "[tokens|erc20|ens|abis|creation|all]"); err != nil {
+		return err
+	}
+
+	if !opts.Discover {
+		if len(opts.Sources) > 0 {
+			return validate.Usage("The {0} option requires {1}.", "--sources", "the --discover option")
+		}
+		if len(opts.TokenList) > 0 {
+			return validate.Usage("The {0} option requires {1}.", "--token_list", "the --discover option")
+		}
+	} else {
+		if len(opts.Terms) == 0 {
+			return validate.Usage("The {0} option requires at least one {1}.", "--discover", "address or file")
+		}
+		if opts.Clean || len(opts.Autoname) > 0 || opts.anyCrud() || opts.Prefund || opts.Tags || opts.Addr || opts.Rank || opts.Regex {
+			return validate.Usage("The {0} option must be used alone.", "--discover")
+		}
+		for _, term := range opts.Terms {
+			if !base.IsValidAddress(term) && opts.Globals.IsApiMode() {
+				return validate.Usage("The {0} option requires each term to be an {1}.", "--discover", "address in api mode")
+			}
+			if !base.IsValidAddress(term) && !file.FileExists(term) {
+				return validate.Usage("The {0} option requires each term to be an {1}.", "--discover", "address or an existing file")
+			}
+		}
+		if len(opts.TokenList) == 0 {
+			for _, source := range opts.Sources {
+				if source == "tokens" {
+					return validate.Usage("The {0} option requires {1}.", "--sources tokens", "the --token_list option")
+				}
+			}
+		}
+		if len(opts.TokenList) > 0 && !strings.HasPrefix(opts.TokenList, "http") && opts.Globals.IsApiMode() {
+			return validate.Usage("The {0} option must be a {1}.", "--token_list", "url in api mode")
+		}
+		if len(opts.TokenList) > 0 && !strings.HasPrefix(opts.TokenList, "http") && !file.FileExists(opts.TokenList) {
+			return validate.Usage("The {0} option ({1}) must {2}", "--token_list", opts.TokenList, "exist")
+		}
+	}
+
+	if err := validate.ValidateEnum("--strategy", opts.Strategy, "[ours|theirs|newest]"); err != nil {
+		return err
+	}
+
+	if len(opts.Strategy) > 0 && len(opts.Import) == 0 {
+		return validate.Usage("The {0} option requires {1}.", "--strategy", "the --import option")
+	}
+
+	if len(opts.Export) > 0 || len(opts.Import) > 0 {
+		which := "--export"
+		if len(opts.Import) > 0 {
+			which = "--import"
+		}
+		if opts.Globals.IsApiMode() {
+			// both name a file on the daemon's machine
+			return validate.Usage("The {0} option is not available{1}.", which, " in api mode")
+		}
+		if len(opts.Export) > 0 && len(opts.Import) > 0 {
+			return validate.Usage("The {0} option is not available{1}.", "--export", " with the --import option")
+		}
+		if len(opts.Terms) > 0 || opts.Clean || len(opts.Autoname) > 0 || opts.Discover || opts.anyCrud() || opts.Prefund || opts.Tags || opts.Addr {
+			return validate.Usage("The {0} option must be used alone.", which)
+		}
+		if len(opts.Import) > 0 && !file.FileExists(opts.Import) {
+			return validate.Usage("The {0} option ({1}) must {2}", "import", opts.Import, "exist")
+		}
+		if len(opts.Export) > 0 {
+			if _, err := names.SigningKey(); err != nil {
+				return validate.Usage("The {0} option requires {1}.", "--export", "a signing key in the [keys.names] section of the configuration file")
+			}
+		}
 	}
 
 	if opts.Tags {
@@ -67,6 +145,18 @@ func (opts *NamesOptions) validateNames() error {
 		return validate.Usage("The {0} option requires at least one {1}.", "--match_case", "term")
 	}
 
+	if opts.Rank {
+		if len(opts.Terms) == 0 {
+			return validate.Usage("The {0} option requires at least one {1}.", "--rank", "term")
+		}
+		if opts.Regex {
+			return validate.Usage("The {0} option is not available{1}.", "--rank", " with the --regex option")
+		}
+		if opts.Tags {
+			return validate.Usage("The {0} option is not available{1}.", "--rank", " with the --tags option")
+		}
+	}
+
 	if opts.Prefund {
 		if opts.Clean || len(opts.Autoname) > 0 || opts.anyCrud() {
 			return validate.Usage("You may not use the {0} option when editing names.", "--prefund")
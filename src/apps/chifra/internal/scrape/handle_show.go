@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/colors"
@@ -16,6 +17,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/sigintTrap"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/tslib"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
@@ -88,6 +90,12 @@ func (opts *ScrapeOptions) HandleScrape(rCtx *output.RenderCtx) error {
 	trapChannel := sigintTrap.Enable(sigintCtx, cancel, cleanOnQuit)
 	defer sigintTrap.Disable(trapChannel)
 
+	// With --subscribe, we wake as soon as a new block arrives rather than sleeping
+	var follower *rpc.HeadFollower
+	if opts.Subscribe {
+		follower = opts.Conn.FollowHeads(sigintCtx, time.Duration(opts.Sleep*float64(time.Second)))
+	}
+
 	var blocks = make([]base.Blknum, 0, opts.BlockCnt)
 	var err error
 
@@ -233,7 +241,7 @@ func (opts *ScrapeOptions) HandleScrape(rCtx *output.RenderCtx) error {
 		if bm.meta != nil { // it may be nil if the node died
 			distanceFromHead = bm.meta.ChainHeight() - bm.meta.StageHeight()
 		}
-		opts.pause(sigintCtx, distanceFromHead)
+		opts.pause(sigintCtx, distanceFromHead, follower)
 		if sigintCtx.Err() != nil {
 			return nil
 		}
@@ -41,6 +41,7 @@ type ScrapeOptions struct {
 	Touch     base.Blknum                `json:"touch,omitempty"`     // First block to visit when scraping (snapped back to most recent snap_to_grid mark)
 	RunCount  uint64                     `json:"runCount,omitempty"`  // Run the scraper this many times, then quit
 	DryRun    bool                       `json:"dryRun,omitempty"`    // Show the configuration that would be applied if run,no changes are made
+	Subscribe bool                       `json:"subscribe,omitempty"` // Wake as soon as a block arrives from a newHeads subscription rather than sleeping between passes
 	Notify    bool                       `json:"notify,omitempty"`    // Enable the notify feature
 	Settings  configtypes.ScrapeSettings `json:"settings,omitempty"`  // Configuration items for the scrape
 	Globals   globals.GlobalOptions      `json:"globals,omitempty"`   // The global options
@@ -64,6 +65,7 @@ func (opts *ScrapeOptions) testLog() {
 	logger.TestLog(opts.Touch != 0, "Touch: ", opts.Touch)
 	logger.TestLog(opts.RunCount != 0, "RunCount: ", opts.RunCount)
 	logger.TestLog(opts.DryRun, "DryRun: ", opts.DryRun)
+	logger.TestLog(opts.Subscribe, "Subscribe: ", opts.Subscribe)
 	logger.TestLog(opts.Notify, "Notify: ", opts.Notify)
 	opts.Settings.TestLog(opts.Globals.Chain, opts.Globals.TestMode)
 	opts.Conn.TestLog(opts.getCaches())
@@ -111,6 +113,8 @@ func ScrapeFinishParseInternal(w io.Writer, values url.Values) *ScrapeOptions {
 			opts.RunCount = base.MustParseUint64(value[0])
 		case "dryRun":
 			opts.DryRun = true
+		case "subscribe":
+			opts.Subscribe = true
 		case "notify":
 			opts.Notify = true
 		case "appsPerChunk":
@@ -9,6 +9,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/colors"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
 )
 
 // Report prints out a report of the progress of the scraper.
@@ -43,8 +44,9 @@ func (bm *BlazeManager) report(nBlocks, perChunk, nChunks, nAppsNow, nAppsFound,
 	logger.Info(colors.Colored(msg))
 }
 
-// Pause goes to sleep for a period of time based on the settings.
-func (opts *ScrapeOptions) pause(ctx context.Context, dist base.Blknum) {
+// Pause goes to sleep for a period of time based on the settings. If following the chain's
+// head, it wakes early when a new block arrives.
+func (opts *ScrapeOptions) pause(ctx context.Context, dist base.Blknum, follower *rpc.HeadFollower) {
 	// sleepOrCancel is a helper that waits ms milliseconds unless the context has been cancelled.
 	sleepOrCancel := func(ms time.Duration) (ok bool) {
 		// We need to create a new timer explicitly, so we can cleanup the memory.
@@ -70,6 +72,11 @@ func (opts *ScrapeOptions) pause(ctx context.Context, dist base.Blknum) {
 	}
 	isDefaultSleep := opts.Sleep >= 13 && opts.Sleep <= 14
 	shouldSleep := !isDefaultSleep || dist <= base.Blknum(2*config.GetScrape(opts.Globals.Chain).UnripeDist)
+	if shouldSleep && follower != nil {
+		logger.Progress(opts.Sleep > 1, "Waiting for the next block -", dist, "away from head.")
+		follower.Wait(ctx, time.Duration(opts.Sleep*float64(time.Second)))
+		return
+	}
 	if shouldSleep {
 		sleep := opts.Sleep // this value may change elsewhere allow us to break out of sleeping????
 		logger.Progress(sleep > 1, "Sleeping for", sleep, "seconds -", dist, "away from head.")
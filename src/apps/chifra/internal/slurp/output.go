@@ -34,7 +34,7 @@ func RunSlurp(cmd *cobra.Command, args []string) error {
 // ServeSlurp handles the slurp command for the API. Returns an error.
 func ServeSlurp(w http.ResponseWriter, r *http.Request) error {
 	opts := slurpFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("slurp", w, &opts.Globals)
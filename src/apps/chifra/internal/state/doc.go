@@ -14,4 +14,13 @@
 //
 // You may also query to see if an address is a smart contract as well as retrieve a contract's
 // byte code.
+//
+// With --storage, the tool reads a contract's raw storage slots at the given blocks. If you
+// provide the storage layout produced by solc --storage-layout with --layout, you may instead
+// name variables, mapping keys, array elements and struct members (for example,
+// balances[0x...] or owners[1].name). Their slots are computed and their values decoded.
+//
+// With --proof, the tool fetches a Merkle proof of each account's state (and of any raw --storage
+// slots) and verifies it against the block's state root, so the results need not be taken on trust
+// from the node.
 package statePkg
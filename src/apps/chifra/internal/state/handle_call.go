@@ -5,7 +5,6 @@
 package statePkg
 
 import (
-	"context"
 	"fmt"
 	"sort"
 
@@ -15,8 +14,8 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
 )
 
 func (opts *StateOptions) HandleCall(rCtx *output.RenderCtx) error {
@@ -27,6 +26,7 @@ func (opts *StateOptions) HandleCall(rCtx *output.RenderCtx) error {
 	artFunc := func(str string, function *types.Function) error {
 		return articulate.ArticulateFunction(function, "", str[2:])
 	}
+	abiCache := articulate.NewAbiCache(opts.Conn, false)
 
 	callAddress := opts.GetCallAddress()
 	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
@@ -48,48 +48,68 @@ func (opts *StateOptions) HandleCall(rCtx *output.RenderCtx) error {
 			showProgress := opts.Globals.ShowProgress()
 			bar := logger.NewBar(logger.BarOptions{
 				Enabled: showProgress,
+				Tracker: rCtx.Progress,
 				Total:   int64(cnt),
 			})
 
+			contractCalls := make([]*call.ContractCall, 0, len(opts.Calls))
+			for _, c := range opts.Calls {
+				if contractCall, _, err := call.NewContractCall(opts.Conn, callAddress, c); err != nil {
+					errorChan <- fmt.Errorf("the --call value provided (%s) was not found: %s", c, err)
+					rCtx.Cancel()
+					return
+				} else {
+					contractCalls = append(contractCalls, contractCall)
+				}
+			}
+
 			for _, thisMap := range sliceOfMaps {
 				if rCtx.WasCanceled() {
 					return
 				}
 
+				// Every call at every block in this group is made together. We remember
+				// which appearance each call belongs to so results land where they did
+				// when each call was made on its own.
+				apps := make([]types.Appearance, 0, len(thisMap))
 				for app := range thisMap {
 					thisMap[app] = new([]types.Result)
+					apps = append(apps, app)
 				}
+				sort.Slice(apps, func(i, j int) bool {
+					return apps[i].BlockNumber < apps[j].BlockNumber
+				})
 
-				iterFunc := func(app types.Appearance, value *[]types.Result) error {
-					bn := base.Blknum(app.BlockNumber)
-					for _, c := range opts.Calls {
-						if contractCall, _, err := call.NewContractCall(opts.Conn, callAddress, c); err != nil {
-							delete(thisMap, app)
-							return fmt.Errorf("the --call value provided (%s) was not found: %s", c, err)
-
-						} else {
-							contractCall.BlockNumber = bn
-							results, err := contractCall.Call(artFunc)
-							if err != nil {
-								delete(thisMap, app)
-								return err
-							} else {
-								bar.Tick()
-								*value = append(*value, *results)
-							}
-						}
+				calls := make([]*call.ContractCall, 0, len(apps)*len(contractCalls))
+				owners := make([]types.Appearance, 0, cap(calls))
+				for _, app := range apps {
+					for _, contractCall := range contractCalls {
+						theCall := *contractCall
+						theCall.BlockNumber = base.Blknum(app.BlockNumber)
+						calls = append(calls, &theCall)
+						owners = append(owners, app)
 					}
-					return nil
 				}
 
-				iterErrorChan := make(chan error)
-				iterCtx, iterCancel := context.WithCancel(context.Background())
-				defer iterCancel()
-				go utils.IterateOverMap(iterCtx, iterErrorChan, thisMap, iterFunc)
-				for err := range iterErrorChan {
-					if !testMode || nErrors == 0 {
-						errorChan <- err
-						nErrors++
+				results, errs := call.CallAll(calls, artFunc)
+				for index, err := range errs {
+					app := owners[index]
+					if revertData, ok := query.RevertData(err); ok {
+						results[index] = calls[index].Reverted(revertData)
+						results[index].RevertReason = abiCache.ArticulateRevert(callAddress, revertData)
+						err = nil
+					}
+					if value, ok := thisMap[app]; !ok {
+						continue
+					} else if err != nil {
+						delete(thisMap, app)
+						if !testMode || nErrors == 0 {
+							errorChan <- err
+							nErrors++
+						}
+					} else {
+						bar.Tick()
+						*value = append(*value, *results[index])
 					}
 				}
 
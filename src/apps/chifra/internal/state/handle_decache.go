@@ -12,6 +12,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/call"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/decache"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/storage"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/walk"
 )
@@ -70,6 +71,48 @@ func (opts *StateOptions) getItemsToRemove() ([]cache.Locator, error) {
 				}
 			}
 		}
+
+		if len(opts.Slots) > 0 {
+			slots, err := opts.getSlotHashes()
+			if err != nil {
+				return []cache.Locator{}, err
+			}
+			itemsToRemove, err := decache.LocationsFromAddressAndSlots(opts.Conn, address, slots, opts.BlockIds)
+			if err != nil {
+				return []cache.Locator{}, err
+			}
+			allItems = append(allItems, itemsToRemove...)
+		}
 	}
 	return allItems, nil
 }
+
+// getSlotHashes returns the slots named by the --storage option. For variables, only the
+// first slot the variable occupies is returned.
+func (opts *StateOptions) getSlotHashes() ([]base.Hash, error) {
+	var layout *storage.Layout
+	if len(opts.Layout) > 0 {
+		var err error
+		if layout, err = storage.LoadLayout(opts.Layout); err != nil {
+			return nil, err
+		}
+	}
+
+	slots := make([]base.Hash, 0, len(opts.Slots))
+	for _, s := range opts.Slots {
+		if layout != nil {
+			loc, err := layout.Locate(s)
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, loc.Hash())
+		} else {
+			n, err := storage.ParseSlot(s)
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, storage.SlotToHash(n))
+		}
+	}
+	return slots, nil
+}
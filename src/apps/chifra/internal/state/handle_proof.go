@@ -0,0 +1,99 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package statePkg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/proof"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/storage"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func (opts *StateOptions) HandleProof(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+	testMode := opts.Globals.TestMode
+	nErrors := 0
+
+	slots := make([]base.Hash, 0, len(opts.Slots))
+	for _, s := range opts.Slots {
+		if n, err := storage.ParseSlot(s); err != nil {
+			return err
+		} else {
+			slots = append(slots, storage.SlotToHash(n))
+		}
+	}
+
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		apps, _, err := identifiers.IdsToApps(chain, opts.BlockIds)
+		if err != nil {
+			errorChan <- err
+			rCtx.Cancel()
+			return
+		}
+
+		if len(apps) == 0 {
+			errorChan <- fmt.Errorf("no blocks found for the query")
+			rCtx.Cancel()
+			return
+		}
+
+		// a state root belongs to one block
+		if len(opts.Root) > 0 && len(apps) > 1 {
+			errorChan <- fmt.Errorf("the --root option requires a single block, found %d", len(apps))
+			rCtx.Cancel()
+			return
+		}
+
+		sort.Slice(apps, func(i, j int) bool {
+			return apps[i].BlockNumber < apps[j].BlockNumber
+		})
+
+		bar := logger.NewBar(logger.BarOptions{
+			Enabled: opts.Globals.ShowProgress(),
+			Tracker: rCtx.Progress,
+			Total:   int64(len(apps) * len(opts.Addrs)),
+		})
+
+		for _, app := range apps {
+			for _, addr := range opts.Addrs {
+				if rCtx.WasCanceled() {
+					return
+				}
+
+				p, err := opts.Conn.GetProof(base.HexToAddress(addr), slots, base.Blknum(app.BlockNumber))
+				if err != nil {
+					if !testMode || nErrors == 0 {
+						errorChan <- err
+						nErrors++
+					}
+					continue
+				}
+
+				// A proof that fails to check out is still reported (with consistent and verified
+				// set to false) so the caller can see what the node claimed. The node's state root
+				// proves nothing about the node, so only a root given with --root verifies it.
+				if err := proof.Verify(p); err != nil {
+					logger.Warn("proof is not consistent with the node's state root:", err)
+				} else if len(opts.Root) > 0 {
+					if err := proof.VerifyRoot(p, base.HexToHash(opts.Root)); err != nil {
+						logger.Warn("proof failed to verify:", err)
+					}
+				}
+
+				bar.Tick()
+				modelChan <- p
+			}
+		}
+		bar.Finish(true /* newLine */)
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOpts())
+}
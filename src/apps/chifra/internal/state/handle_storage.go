@@ -0,0 +1,142 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package statePkg
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/identifiers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/storage"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func (opts *StateOptions) HandleStorage(rCtx *output.RenderCtx) error {
+	chain := opts.Globals.Chain
+	testMode := opts.Globals.TestMode
+	nErrors := 0
+
+	var layout *storage.Layout
+	if len(opts.Layout) > 0 {
+		var err error
+		if layout, err = storage.LoadLayout(opts.Layout); err != nil {
+			return err
+		}
+	}
+
+	// Note that the validator precludes the possibility of having more than one address
+	// if the storage option is present.
+	address := base.HexToAddress(opts.Addrs[0])
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		apps, _, err := identifiers.IdsToApps(chain, opts.BlockIds)
+		if err != nil {
+			errorChan <- err
+			rCtx.Cancel()
+			return
+		}
+
+		if len(apps) == 0 {
+			errorChan <- fmt.Errorf("no blocks found for the query")
+			rCtx.Cancel()
+			return
+		}
+
+		sort.Slice(apps, func(i, j int) bool {
+			return apps[i].BlockNumber < apps[j].BlockNumber
+		})
+
+		bar := logger.NewBar(logger.BarOptions{
+			Enabled: opts.Globals.ShowProgress(),
+			Tracker: rCtx.Progress,
+			Total:   int64(len(apps) * len(opts.Slots)),
+		})
+
+		for _, app := range apps {
+			bn := base.Blknum(app.BlockNumber)
+			for _, s := range opts.Slots {
+				if rCtx.WasCanceled() {
+					return
+				}
+
+				var item *types.Slot
+				if layout == nil {
+					item, err = opts.readSlot(address, s, bn)
+				} else {
+					item, err = opts.readVariable(layout, address, s, bn)
+				}
+
+				if err != nil {
+					if !testMode || nErrors == 0 {
+						errorChan <- err
+						nErrors++
+					}
+					continue
+				}
+
+				bar.Tick()
+				modelChan <- item
+			}
+		}
+		bar.Finish(true /* newLine */)
+	}
+
+	extraOpts := map[string]any{
+		"layout": layout != nil,
+	}
+
+	return output.StreamMany(rCtx, fetchData, opts.Globals.OutputOptsWithExtra(extraOpts))
+}
+
+// readSlot reads a single raw storage slot
+func (opts *StateOptions) readSlot(address base.Address, slot string, bn base.Blknum) (*types.Slot, error) {
+	n, err := storage.ParseSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	return opts.Conn.GetStorageAt(address, storage.SlotToHash(n), bn)
+}
+
+// readVariable locates a variable using the storage layout then reads and decodes it. The
+// raw value reported is the contents of the first slot the variable occupies.
+func (opts *StateOptions) readVariable(layout *storage.Layout, address base.Address, path string, bn base.Blknum) (*types.Slot, error) {
+	loc, err := layout.Locate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	read := func(slot *big.Int) ([]byte, error) {
+		if raw, err := opts.Conn.GetStorageAt(address, storage.SlotToHash(slot), bn); err != nil {
+			return nil, err
+		} else {
+			return base.Hex2Bytes(raw.Value[2:]), nil
+		}
+	}
+
+	first, err := opts.Conn.GetStorageAt(address, loc.Hash(), bn)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := layout.Decode(loc, read)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Slot{
+		BlockNumber: first.BlockNumber,
+		Timestamp:   first.Timestamp,
+		Address:     address,
+		Slot:        first.Slot,
+		Offset:      loc.Offset,
+		Variable:    loc.Path,
+		SlotType:    layout.TypeLabel(loc),
+		Value:       first.Value,
+		Decoded:     decoded,
+	}, nil
+}
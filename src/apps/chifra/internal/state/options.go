@@ -38,11 +38,16 @@ type StateOptions struct {
 	Call       string                   `json:"call,omitempty"`       // Call a smart contract with one or more solidity calls, four-byte plus parameters, or encoded call data strings
 	Articulate bool                     `json:"articulate,omitempty"` // For the --call option only, articulate the retrieved data if ABIs can be found
 	ProxyFor   string                   `json:"proxyFor,omitempty"`   // For the --call option only, redirects calls to this implementation
+	Storage    string                   `json:"storage,omitempty"`    // Read one or more storage slots (or, with --layout, named variables) from a smart contract
+	Layout     string                   `json:"layout,omitempty"`     // For the --storage option only, a solc storage layout JSON file used to locate and decode named variables
+	Proof      bool                     `json:"proof,omitempty"`      // Fetch a Merkle proof of each account (and any --storage slots) and check it against the block's state root
+	Root       string                   `json:"root,omitempty"`       // For the --proof option only, a state root from a source you trust against which the proof of a single block is verified
 	Globals    globals.GlobalOptions    `json:"globals,omitempty"`    // The global options
 	Conn       *rpc.Connection          `json:"conn,omitempty"`       // The connection to the RPC server
 	BadFlag    error                    `json:"badFlag,omitempty"`    // An error flag if needed
 	// EXISTING_CODE
 	Calls []string `json:"-"`
+	Slots []string `json:"-"`
 	// EXISTING_CODE
 }
 
@@ -58,6 +63,10 @@ func (opts *StateOptions) testLog() {
 	logger.TestLog(len(opts.Call) > 0, "Call: ", opts.Call)
 	logger.TestLog(opts.Articulate, "Articulate: ", opts.Articulate)
 	logger.TestLog(len(opts.ProxyFor) > 0, "ProxyFor: ", opts.ProxyFor)
+	logger.TestLog(len(opts.Storage) > 0, "Storage: ", opts.Storage)
+	logger.TestLog(len(opts.Layout) > 0, "Layout: ", opts.Layout)
+	logger.TestLog(opts.Proof, "Proof: ", opts.Proof)
+	logger.TestLog(len(opts.Root) > 0, "Root: ", opts.Root)
 	opts.Conn.TestLog(opts.getCaches())
 	opts.Globals.TestLog()
 }
@@ -108,6 +117,14 @@ func StateFinishParseInternal(w io.Writer, values url.Values) *StateOptions {
 			opts.Articulate = true
 		case "proxyFor":
 			opts.ProxyFor = value[0]
+		case "storage":
+			opts.Storage = value[0]
+		case "layout":
+			opts.Layout = value[0]
+		case "proof":
+			opts.Proof = true
+		case "root":
+			opts.Root = value[0]
 		default:
 			if !copy.Globals.Caps.HasKey(key) {
 				err := validate.Usage("Invalid key ({0}) in {1} route.", key, "state")
@@ -122,6 +139,9 @@ func StateFinishParseInternal(w io.Writer, values url.Values) *StateOptions {
 	// EXISTING_CODE
 	opts.Call = strings.Replace(strings.Trim(opts.Call, "'"), "'", "\"", -1)
 	opts.Calls = strings.Split(opts.Call, ":")
+	if len(opts.Storage) > 0 {
+		opts.Slots = strings.Split(opts.Storage, ":")
+	}
 	if len(opts.Blocks) == 0 {
 		if opts.Globals.TestMode {
 			opts.Blocks = []string{"17000000"}
@@ -165,6 +185,9 @@ func stateFinishParse(args []string) *StateOptions {
 	}
 	opts.Call = strings.Replace(strings.Trim(opts.Call, "'"), "'", "\"", -1)
 	opts.Calls = strings.Split(opts.Call, ":")
+	if len(opts.Storage) > 0 {
+		opts.Slots = strings.Split(opts.Storage, ":")
+	}
 	if len(opts.Blocks) == 0 {
 		if opts.Globals.TestMode {
 			opts.Blocks = []string{"17000000"}
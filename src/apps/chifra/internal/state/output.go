@@ -34,7 +34,7 @@ func RunState(cmd *cobra.Command, args []string) error {
 // ServeState handles the state command for the API. Returns an error.
 func ServeState(w http.ResponseWriter, r *http.Request) error {
 	opts := stateFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("state", w, &opts.Globals)
@@ -58,6 +58,10 @@ func (opts *StateOptions) StateInternal(rCtx *output.RenderCtx) error {
 		err = opts.HandleDecache(rCtx)
 	} else if len(opts.Call) > 0 {
 		err = opts.HandleCall(rCtx)
+	} else if opts.Proof {
+		err = opts.HandleProof(rCtx)
+	} else if len(opts.Storage) > 0 {
+		err = opts.HandleStorage(rCtx)
 	} else {
 		err = opts.HandleShow(rCtx)
 	}
@@ -11,7 +11,9 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/call"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/storage"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
 )
 
@@ -37,7 +39,121 @@ func (opts *StateOptions) validateState() error {
 		// do nothing for now
 
 	} else {
-		if len(opts.Call) > 0 {
+		if len(opts.Root) > 0 && !opts.Proof {
+			return validate.Usage("The {0} option is only available with the {1} option.", "--root", "--proof")
+		}
+
+		if opts.Proof {
+			if len(opts.Root) > 0 && !validate.IsBlockHash(opts.Root) {
+				return validate.Usage("The --root value provided ({0}) is not a valid hash.", opts.Root)
+			}
+
+			if len(opts.Call) > 0 {
+				return validate.Usage("Please choose only one of {0}.", "--call or --proof")
+			}
+
+			if len(opts.Parts) > 0 {
+				return validate.Usage("The {0} option is not available{1}.", "--parts", " with the --proof option")
+			}
+
+			if opts.Changes {
+				return validate.Usage("The {0} option is not available{1}.", "--changes", " with the --proof option")
+			}
+
+			if opts.NoZero {
+				return validate.Usage("The {0} option is not available{1}.", "--no_zero", " with the --proof option")
+			}
+
+			if len(opts.Layout) > 0 {
+				return validate.Usage("The {0} option is not available{1}.", "--layout", " with the --proof option")
+			}
+
+			if opts.Articulate {
+				return validate.Usage("The {0} option is only available with the {1} option.", "--articulate", "--call")
+			}
+
+			proxy := base.HexToAddress(opts.ProxyFor)
+			if !proxy.IsZero() {
+				return validate.Usage("The {0} option is only available with the {1} option.", "--proxy_for", "--call")
+			}
+
+			if err := validate.ValidateAtLeastOneAddr(opts.Addrs); err != nil {
+				return err
+			}
+
+			if err := validate.ValidateAddresses(opts.Addrs); err != nil {
+				return err
+			}
+
+			for _, s := range opts.Slots {
+				if _, err := storage.ParseSlot(s); err != nil {
+					return validate.Usage("The --storage value provided ({0}) is not a valid slot.", s)
+				}
+			}
+
+		} else if len(opts.Storage) > 0 {
+			if len(opts.Call) > 0 {
+				return validate.Usage("Please choose only one of {0}.", "--call or --storage")
+			}
+
+			if len(opts.Parts) > 0 {
+				return validate.Usage("The {0} option is not available{1}.", "--parts", " with the --storage option")
+			}
+
+			if opts.Changes {
+				return validate.Usage("The {0} option is not available{1}.", "--changes", " with the --storage option")
+			}
+
+			if opts.NoZero {
+				return validate.Usage("The {0} option is not available{1}.", "--no_zero", " with the --storage option")
+			}
+
+			if opts.Articulate {
+				return validate.Usage("The {0} option is only available with the {1} option.", "--articulate", "--call")
+			}
+
+			proxy := base.HexToAddress(opts.ProxyFor)
+			if !proxy.IsZero() {
+				return validate.Usage("The {0} option is only available with the {1} option.", "--proxy_for", "--call")
+			}
+
+			if len(opts.Addrs) != 1 {
+				return validate.Usage("Exactly one address is required for the {0} option.", "--storage")
+			}
+
+			if err := validate.ValidateAddresses(opts.Addrs); err != nil {
+				return err
+			}
+
+			if len(opts.Layout) > 0 {
+				if opts.Globals.IsApiMode() {
+					// the layout names a file on the daemon's machine
+					return validate.Usage("The {0} option is not available{1}.", "--layout", " in api mode")
+				}
+				if !file.FileExists(opts.Layout) {
+					return validate.Usage("The {0} option ({1}) must {2}", "layout", opts.Layout, "exist")
+				}
+				layout, err := storage.LoadLayout(opts.Layout)
+				if err != nil {
+					return err
+				}
+				for _, s := range opts.Slots {
+					if _, err := layout.Locate(s); err != nil {
+						return validate.Usage("The --storage value provided ({0}) is invalid: {1}.", s, err.Error())
+					}
+				}
+			} else {
+				for _, s := range opts.Slots {
+					if _, err := storage.ParseSlot(s); err != nil {
+						return validate.Usage("The --storage value provided ({0}) is not a valid slot. Use --layout to read named variables.", s)
+					}
+				}
+			}
+
+		} else if len(opts.Layout) > 0 {
+			return validate.Usage("The {0} option is only available with the {1} option.", "--layout", "--storage")
+
+		} else if len(opts.Call) > 0 {
 			if len(opts.Parts) > 0 {
 				return validate.Usage("The {0} option is not available{1}.", "--parts", " with the --call option")
 			}
@@ -11,6 +11,7 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/tslib"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/validate"
@@ -100,6 +101,22 @@ func (opts *StatusOptions) GetStatus(diagnose bool) (*types.Status, error) {
 		// Ripe:      meta.Latest - meta.Ripe,
 	}
 
+	// Only the daemon serves enough requests for the endpoints' counts to mean anything
+	if s.IsApi {
+		for _, ep := range query.Endpoints(chain) {
+			s.Endpoints = append(s.Endpoints, types.RpcEndpoint{
+				Url:       ep.Url,
+				Weight:    ep.Weight,
+				RateLimit: ep.RateLimit,
+				State:     ep.State,
+				Served:    ep.Served,
+				Failures:  ep.Failures,
+				Latency:   float64(ep.Latency.Microseconds()) / 1000.,
+				LastError: ep.LastError,
+			})
+		}
+	}
+
 	if testMode {
 		s.ClientVersion = "Client version"
 		s.Version = "GHC-TrueBlocks//vers-beta--git-hash---git-ts-"
@@ -110,6 +127,7 @@ func (opts *StatusOptions) GetStatus(diagnose bool) (*types.Status, error) {
 		s.IndexPath = "--paths--"
 		s.Progress = "--client--, --final--, --staging--, --unripe-- ts: --ts--"
 		s.HasPinKey = false // the test machine doesn't have a key
+		s.Endpoints = nil
 	}
 
 	return s, nil
@@ -204,7 +222,8 @@ INFO Chain Config Path: {{.ChainConfig}}
 INFO Cache Path:        {{.CachePath}}
 INFO Index Path:        {{.IndexPath}}
 INFO Progress:[PROGRESS]
-`
+{{range .Endpoints}}INFO RPC Endpoint:      {{.Url}} ({{.State}}, weight {{.Weight}}) served {{.Served}}, failed {{.Failures}}, {{printf "%.1f" .Latency}}ms
+{{end}}`
 
 /*
 TODO: Better diagnostics (see #3209)
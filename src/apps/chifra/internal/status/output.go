@@ -34,7 +34,7 @@ func RunStatus(cmd *cobra.Command, args []string) error {
 // ServeStatus handles the status command for the API. Returns an error.
 func ServeStatus(w http.ResponseWriter, r *http.Request) error {
 	opts := statusFinishParseApi(w, r)
-	rCtx := output.NewRenderContext()
+	rCtx := output.RenderContextFrom(r.Context())
 	// EXISTING_CODE
 	// EXISTING_CODE
 	outputHelpers.InitJsonWriterApi("status", w, &opts.Globals)
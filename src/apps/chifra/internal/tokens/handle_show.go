@@ -2,15 +2,19 @@ package tokensPkg
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/call"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/tslib"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 	"github.com/ethereum/go-ethereum"
 )
 
+// balanceOf is the four-byte of ERC-20's balanceOf(address)
+const balanceOf = "0x70a08231"
+
 func (opts *TokensOptions) HandleShow(rCtx *output.RenderCtx) error {
 	chain := opts.Globals.Chain
 	tokenAddr := base.HexToAddress(opts.Addrs[0])
@@ -20,6 +24,9 @@ func (opts *TokensOptions) HandleShow(rCtx *output.RenderCtx) error {
 			addr := base.HexToAddress(address)
 			currentBn := base.Blknum(0)
 			currentTs := base.Timestamp(0)
+
+			// We query every block for this holder together (see call.CallMany)
+			requests := make([]call.Request, 0)
 			for _, br := range opts.BlockIds {
 				blockNums, err := br.ResolveBlocks(chain)
 				if err != nil {
@@ -32,25 +39,43 @@ func (opts *TokensOptions) HandleShow(rCtx *output.RenderCtx) error {
 				}
 
 				for _, bn := range blockNums {
-					if balance, err := opts.Conn.GetBalanceAtToken(tokenAddr, addr, fmt.Sprintf("0x%x", bn)); balance == nil {
-						errorChan <- err
-					} else {
-						if opts.Globals.Verbose {
-							if bn == 0 || bn != currentBn {
-								currentTs, _ = tslib.FromBnToTs(chain, bn)
-							}
-							currentBn = bn
-						}
-						s := &types.Token{
-							Holder:      addr,
-							Address:     tokenAddr,
-							Balance:     *balance,
-							BlockNumber: bn,
-							Timestamp:   currentTs,
-							TokenType:   types.TokenErc20,
+					requests = append(requests, call.Request{
+						To:          tokenAddr,
+						Data:        balanceOf + addr.Pad32(),
+						BlockNumber: bn,
+					})
+				}
+			}
+
+			responses := call.CallMany(opts.Conn, requests)
+			for index, response := range responses {
+				if rCtx.WasCanceled() {
+					return
+				}
+
+				// As before batching, a call the node rejects (for example, a holder's balance
+				// on a contract that isn't a token) reports a zero balance. Only transport
+				// failures are errors.
+				var rpcErr *query.RpcError
+				bn := requests[index].BlockNumber
+				if response.Err != nil && !errors.As(response.Err, &rpcErr) {
+					errorChan <- response.Err
+				} else {
+					if opts.Globals.Verbose {
+						if bn == 0 || bn != currentBn {
+							currentTs, _ = tslib.FromBnToTs(chain, bn)
 						}
-						modelChan <- s
+						currentBn = bn
+					}
+					s := &types.Token{
+						Holder:      addr,
+						Address:     tokenAddr,
+						Balance:     *base.HexToWei(response.Data),
+						BlockNumber: bn,
+						Timestamp:   currentTs,
+						TokenType:   types.TokenErc20,
 					}
+					modelChan <- s
 				}
 			}
 		}
@@ -19,6 +19,7 @@ func (opts *TransactionsOptions) HandleUniq(rCtx *output.RenderCtx) (err error)
 		bar := logger.NewBar(logger.BarOptions{
 			Type:    logger.Expanding,
 			Enabled: showProgress,
+			Tracker: rCtx.Progress,
 			Total:   250, // estimate since we have no idea how many there are
 		})
 		procFunc := func(s *types.Appearance) error {
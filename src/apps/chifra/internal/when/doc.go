@@ -19,4 +19,44 @@
 // optional, and if omitted, default to zero in each case. Block numbers may be specified as either
 // integers or hexadecimal number or block hashes. You may specify any number of dates and/or blocks
 // per invocation.
+//
+// ### Periods and calendars
+//
+// A block range may end with a period (hourly, daily, weekly, monthly, quarterly,
+// annually, or fiscal) to report the first block of each period in the range, or with the same
+// period ending in end (monthend, quarterend, yearend, fiscalend) to report the last block
+// of each period. Sub-day steps such as :15m or :4h start over at the beginning of each day.
+//
+// Periods are measured in UTC unless a calendar is named after an @. A calendar may be an IANA
+// time zone (:monthend@America/Chicago), one of the built-in calendars utc, ny, or nyclose
+// (each day ends at the 16:00 New York close and reports the first block after it), or a calendar
+// from the [calendars] section of trueBlocks.toml:
+//
+// toml
+// [calendars.fy]
+// timeZone = "Europe/London"
+// dayEnds = "17:30"        # days (and so all longer periods) end at this time
+// fiscalYearStart = 4      # quarters and fiscal years start in April
+// snap = "after"           # report the first block after each boundary
+//
+// For example, chifra when 2023-01-01-2024-01-01:monthend@ny reports the last block of each
+// month in New York. The same ranges work anywhere blocks are accepted, and single identifiers such
+// as 2023-06-15:monthend@ny work for chifra export --first_block and --last_block.
+//
+// ### Timestamps on L2 chains
+//
+// By default, --timestamps --check reports any block whose timestamp is not later than the block
+// before it. On many L2 chains, several blocks are produced each second and consecutive blocks share
+// a timestamp. For such chains, set the chain's timestamp rules in trueBlocks.toml:
+//
+// toml
+// [chains.arbitrum.timestamps]
+// allowDuplicates = true   # consecutive blocks may share a timestamp
+// resolve = "first"        # a shared timestamp resolves to its first block (defaults to the last)
+// l1Origin = true          # also store the L1 block each block derives from
+//
+// With allowDuplicates, --check accepts repeated timestamps (but still reports timestamps that go
+// backwards) and blocks past the end of the database are estimated from the chain's recent block
+// time. With l1Origin, --update also stores each block's L1 origin (for nodes that report
+// l1BlockNumber with each block), --check verifies it, and --repair re-queries it.
 package whenPkg
@@ -16,6 +16,7 @@ import (
 // HandleTimestampsCheck handles chifra when --timestamps --check
 func (opts *WhenOptions) HandleTimestampsCheck(rCtx *output.RenderCtx) error {
 	chain := opts.Globals.Chain
+	opts.rules = tslib.GetRules(chain)
 
 	cnt, err := tslib.NTimestamps(chain)
 	if err != nil {
@@ -84,16 +85,10 @@ func (opts *WhenOptions) checkOneBlock(scanBar *progress.ScanBar, prev *types.Na
 	if prev.Timestamp != base.NOPOSI {
 		status := "Okay"
 
-		bnSequential := prev.BlockNumber < onDisc.BlockNumber
-		if !bnSequential {
-			msg := fmt.Sprintf("At block %d, block number %d is not one plus %d.%s", bn, onDisc.BlockNumber, prev.BlockNumber, clear)
-			logger.Error(msg)
-			status = "Error"
-		}
-
-		tsSequential := prev.Timestamp < onDisc.Timestamp
-		if !tsSequential {
-			msg := fmt.Sprintf("At block %d, timestamp %d does not increase over previous %d%s", bn, onDisc.Timestamp, prev.Timestamp, clear)
+		// The chain's rules say whether blocks may share a timestamp (as on many L2s)
+		prevRecord := tslib.TimestampRecord{Bn: uint32(prev.BlockNumber), Ts: uint32(prev.Timestamp)}
+		for _, problem := range opts.rules.Check(prevRecord, *itemOnDisc) {
+			msg := fmt.Sprintf("At block %d, %s.%s", bn, problem, clear)
 			logger.Error(msg)
 			status = "Error"
 		}
@@ -115,6 +110,13 @@ func (opts *WhenOptions) checkOneBlock(scanBar *progress.ScanBar, prev *types.Na
 			status = "Error"
 		}
 
+		if opts.rules.L1Origin && bn < tslib.NL1Origins(chain) {
+			if err := opts.checkL1Origin(bn); err != nil {
+				logger.Error(fmt.Sprintf("At block %d, %s%s", bn, err, clear))
+				status = "Error"
+			}
+		}
+
 		if status == "Okay" {
 			scanBar.Report(opts.Globals.Writer, status, fmt.Sprintf(" bn: %d ts: %d", expected.BlockNumber, expected.Timestamp))
 		}
@@ -124,5 +126,32 @@ func (opts *WhenOptions) checkOneBlock(scanBar *progress.ScanBar, prev *types.Na
 	return nil
 }
 
+// checkL1Origin checks that the block's L1 origin does not go backwards and, if we're going deep,
+// that it agrees with the chain
+func (opts *WhenOptions) checkL1Origin(bn base.Blknum) error {
+	chain := opts.Globals.Chain
+
+	origin, err := tslib.FromBnToL1(chain, bn)
+	if err != nil {
+		return err
+	}
+
+	if bn > 0 {
+		if prev, err := tslib.FromBnToL1(chain, bn-1); err == nil && origin < prev {
+			return fmt.Errorf("L1 origin %d is earlier than previous %d", origin, prev)
+		}
+	}
+
+	if opts.Deep {
+		if expected, err := opts.Conn.GetL1OriginBlock(bn); err != nil {
+			return err
+		} else if origin != expected {
+			return fmt.Errorf("L1 origin on disc %d does not agree with on chain %d", origin, expected)
+		}
+	}
+
+	return nil
+}
+
 // TODO: There's got to be a better way
 var clear = strings.Repeat(" ", 60)
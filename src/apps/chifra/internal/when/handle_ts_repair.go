@@ -41,6 +41,17 @@ func (opts *WhenOptions) HandleTimestampsRepair(rCtx *output.RenderCtx) error {
 
 			ts, _ := tslib.FromBnToTs(chain, bn)
 			logger.Info("The timestamp at block", bn, "was reset to", ts, "from on chain.")
+
+			if tslib.GetRules(chain).L1Origin && bn < tslib.NL1Origins(chain) {
+				origin, err := opts.Conn.GetL1OriginBlock(bn)
+				if err != nil {
+					return err
+				}
+				if err := tslib.RepairL1(chain, bn, origin); err != nil {
+					return err
+				}
+				logger.Info("The L1 origin at block", bn, "was reset to", origin, "from on chain.")
+			}
 		}
 	}
 
@@ -33,7 +33,7 @@ func (opts *WhenOptions) HandleTimestampsUpdate(rCtx *output.RenderCtx) error {
 
 	if cnt >= meta.Latest {
 		logger.Info("Timestamp file is up to date.")
-		return nil
+		return opts.updateL1Origins()
 	}
 
 	timestamps := make([]tslib.TimestampRecord, 0, meta.Latest-cnt+2)
@@ -56,5 +56,47 @@ func (opts *WhenOptions) HandleTimestampsUpdate(rCtx *output.RenderCtx) error {
 		_ = tslib.Append(chain, timestamps)
 	}
 
+	return opts.updateL1Origins()
+}
+
+// updateL1Origins brings the L1 origins database forward to the end of the timestamps database
+// for chains whose rules store L1 origins
+func (opts *WhenOptions) updateL1Origins() error {
+	chain := opts.Globals.Chain
+	if !tslib.GetRules(chain).L1Origin {
+		return nil
+	}
+
+	cnt, err := tslib.NTimestamps(chain)
+	if err != nil {
+		return err
+	}
+
+	start := tslib.NL1Origins(chain)
+	if start >= cnt {
+		logger.Info("L1 origins file is up to date.")
+		return nil
+	}
+
+	logger.Info("Updating L1 origins file from", start, "to", cnt, fmt.Sprintf("(%d blocks)", (cnt-start)))
+	origins := make([]uint32, 0, 1000)
+	for bn := start; bn < cnt; bn++ {
+		origin, err := opts.Conn.GetL1OriginBlock(bn)
+		if err != nil {
+			return err
+		}
+		origins = append(origins, uint32(origin))
+		logger.Progress(bn%23 == 0, "Adding block", bn, "of", cnt, "to L1 origins array")
+		if len(origins) == cap(origins) {
+			if err := tslib.AppendL1(chain, origins); err != nil {
+				return err
+			}
+			origins = origins[:0]
+		}
+	}
+
+	if len(origins) > 0 {
+		return tslib.AppendL1(chain, origins)
+	}
 	return nil
 }
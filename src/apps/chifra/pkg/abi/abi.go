@@ -10,6 +10,9 @@ import (
 
 type SelectorSyncMap struct {
 	sync.Map
+	// errors holds custom errors separately because their four-byte selectors share a
+	// namespace with functions and must not shadow them during articulation
+	errors sync.Map
 }
 
 func (abiMap *SelectorSyncMap) GetValue(encoding string) *types.Function {
@@ -21,9 +24,32 @@ func (abiMap *SelectorSyncMap) GetValue(encoding string) *types.Function {
 }
 
 func (abiMap *SelectorSyncMap) SetValue(encoding string, function *types.Function) {
+	if function.FunctionType == "error" {
+		abiMap.errors.Store(encoding, function)
+		return
+	}
 	abiMap.Store(encoding, function)
 }
 
+func (abiMap *SelectorSyncMap) GetError(encoding string) *types.Function {
+	if function, ok := abiMap.errors.Load(encoding); !ok {
+		return nil
+	} else {
+		return function.(*types.Function)
+	}
+}
+
+func (abiMap *SelectorSyncMap) ErrorValues() []types.Function {
+	ret := make([]types.Function, 0)
+	visit := func(k any, b any) bool {
+		function, _ := b.(*types.Function)
+		ret = append(ret, *function)
+		return true
+	}
+	abiMap.errors.Range(visit)
+	return ret
+}
+
 func (abiMap *SelectorSyncMap) Count() int64 {
 	var cnt atomic.Int64
 	countFunc := func(k any, b any) bool {
@@ -68,6 +68,11 @@ func fromJson(reader io.Reader, abiMap *SelectorSyncMap) (err error) {
 		abiMap.SetValue(event.Encoding, event)
 	}
 
+	for _, ethError := range loadedAbi.Errors {
+		abiError := FunctionFromAbiError(&ethError)
+		abiMap.SetValue(abiError.Encoding, abiError)
+	}
+
 	return
 }
 
@@ -680,7 +685,7 @@ func (abiMap *SelectorSyncMap) LoadKnownAbis(chain string) (err error) {
 		}
 	}
 
-	toCache := abiMap.Values()
+	toCache := append(abiMap.Values(), abiMap.ErrorValues()...)
 	return setAbis(chain, toCache)
 }
 
@@ -763,6 +768,12 @@ func insertAbi(chain string, address base.Address, inputReader io.Reader) error
 	}
 }
 
+// LoadCachedAbi returns the functions, events and errors of the ABI of an address if it's in
+// the abis cache. Unlike LoadAbi, it never downloads.
+func LoadCachedAbi(chain string, address base.Address) ([]types.Function, error) {
+	return getAbi(chain, address)
+}
+
 // getAbi returns single ABI per address. ABI-per-address are stored as JSON, not binary.
 func getAbi(chain string, address base.Address) (simpleAbis []types.Function, err error) {
 	filePath := filepath.Join(walk.CacheTypeToFolder[walk.Cache_Abis], address.Hex()+".json")
@@ -788,6 +799,12 @@ func getAbi(chain string, address base.Address) (simpleAbis []types.Function, er
 		events = append(events, *types.FunctionFromAbiEvent(&event))
 	}
 
+	abiErrors := make([]types.Function, 0, len(ethAbi.Errors))
+	for _, ethError := range ethAbi.Errors {
+		abiErrors = append(abiErrors, *FunctionFromAbiError(&ethError))
+	}
+
 	simpleAbis = append(functions, events...)
+	simpleAbis = append(simpleAbis, abiErrors...)
 	return
 }
@@ -0,0 +1,145 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// FunctionFromSignature builds a Function (or event or error) from a canonical text signature
+// such as `transfer(address,uint256)`. Signatures carry no parameter names or indexing
+// information, so parameters are named positionally and event parameters are not indexed.
+func FunctionFromSignature(signature, functionType string) (*types.Function, error) {
+	signature = strings.ReplaceAll(strings.TrimSpace(signature), " ", "")
+	open := strings.Index(signature, "(")
+	if open < 1 || !strings.HasSuffix(signature, ")") {
+		return nil, fmt.Errorf("invalid signature %s", signature)
+	}
+
+	name := signature[:open]
+	argTypes, err := splitSignatureArgs(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature %s: %w", signature, err)
+	}
+
+	args := make(abi.Arguments, 0, len(argTypes))
+	for index, argType := range argTypes {
+		marshaling, err := argumentFromSignature(fmt.Sprintf("arg%d", index), argType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %s: %w", signature, err)
+		}
+		t, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %s: %w", signature, err)
+		}
+		args = append(args, abi.Argument{Name: marshaling.Name, Type: t})
+	}
+
+	switch functionType {
+	case "event":
+		event := abi.NewEvent(name, name, false, args)
+		return types.FunctionFromAbiEvent(&event), nil
+	case "error":
+		abiError := abi.NewError(name, args)
+		return FunctionFromAbiError(&abiError), nil
+	default:
+		method := abi.NewMethod(name, name, abi.Function, "nonpayable", false, false, args, nil)
+		return types.FunctionFromAbiMethod(&method), nil
+	}
+}
+
+// FunctionFromAbiError converts go-ethereum's abi.Error to our Function. Errors are encoded
+// exactly like functions, so we carry the error's arguments in an equivalent abi.Method.
+func FunctionFromAbiError(abiError *abi.Error) *types.Function {
+	method := abi.NewMethod(abiError.Name, abiError.Name, abi.Function, "nonpayable", false, false, abiError.Inputs, nil)
+	function := types.FunctionFromAbiMethod(&method)
+	function.FunctionType = "error"
+	return function
+}
+
+// argumentFromSignature converts a single canonical type (possibly a tuple or an array of
+// tuples) into the marshaling structure go-ethereum needs to build an abi.Type.
+func argumentFromSignature(name, argType string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(argType, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: argType}, nil
+	}
+
+	close := matchingParen(argType)
+	if close < 0 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unbalanced tuple %s", argType)
+	}
+
+	componentTypes, err := splitSignatureArgs(argType[1:close])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+
+	components := make([]abi.ArgumentMarshaling, 0, len(componentTypes))
+	for index, componentType := range componentTypes {
+		component, err := argumentFromSignature(fmt.Sprintf("field%d", index), componentType)
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		components = append(components, component)
+	}
+
+	return abi.ArgumentMarshaling{
+		Name:       name,
+		Type:       "tuple" + argType[close+1:],
+		Components: components,
+	}, nil
+}
+
+// splitSignatureArgs splits a comma-separated argument list at the top level only, leaving
+// the contents of nested tuples intact.
+func splitSignatureArgs(args string) ([]string, error) {
+	ret := []string{}
+	if len(args) == 0 {
+		return ret, nil
+	}
+
+	depth, start := 0, 0
+	for i, c := range args {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parenthesis")
+			}
+		case ',':
+			if depth == 0 {
+				if i == start {
+					return nil, fmt.Errorf("empty argument")
+				}
+				ret = append(ret, args[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 || start == len(args) {
+		return nil, fmt.Errorf("unbalanced parenthesis or empty argument")
+	}
+
+	return append(ret, args[start:]), nil
+}
+
+func matchingParen(str string) int {
+	depth := 0
+	for i, c := range str {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
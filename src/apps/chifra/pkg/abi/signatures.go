@@ -0,0 +1,475 @@
+package abi
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/walk"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SigSource records where a signature came from. Signatures found in real ABI files are
+// preferred over signatures imported from public lists when ranking collisions.
+type SigSource string
+
+const (
+	SigFromAbi    SigSource = "abi"
+	SigFromImport SigSource = "import"
+)
+
+// SigRecord is a single entry in the signature database.
+type SigRecord struct {
+	Encoding  string
+	Type      string
+	Signature string
+	Source    SigSource
+}
+
+// SignatureDb is a searchable store of function, event and error signatures keyed by their
+// four-byte or 32-byte encoding. Unlike SelectorSyncMap, it keeps every candidate for an
+// encoding so that colliding selectors can be disambiguated.
+type SignatureDb struct {
+	mutex   sync.RWMutex
+	records map[string][]SigRecord
+	parsed  map[string]*types.Function
+}
+
+const (
+	sigDbFilename     = "signatures.tab"
+	sigImportFilename = "imported.tab"
+)
+
+// NewSignatureDb returns an empty signature database.
+func NewSignatureDb() *SignatureDb {
+	return &SignatureDb{
+		records: make(map[string][]SigRecord),
+		parsed:  make(map[string]*types.Function),
+	}
+}
+
+// Add inserts a record into the database if it is not already present. A record seen in an
+// ABI file upgrades the source of the same signature previously imported from a list.
+func (db *SignatureDb) Add(rec SigRecord) bool {
+	rec.Encoding = strings.ToLower(rec.Encoding)
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	existing := db.records[rec.Encoding]
+	for i := range existing {
+		if existing[i].Signature == rec.Signature && existing[i].Type == rec.Type {
+			if rec.Source == SigFromAbi {
+				existing[i].Source = SigFromAbi
+			}
+			return false
+		}
+	}
+	db.records[rec.Encoding] = append(existing, rec)
+	return true
+}
+
+// AddFunction inserts the signature of an already-parsed function, event or error.
+func (db *SignatureDb) AddFunction(function *types.Function, source SigSource) bool {
+	if len(function.Signature) == 0 || len(function.Encoding) == 0 {
+		return false
+	}
+	return db.Add(SigRecord{
+		Encoding:  function.Encoding,
+		Type:      function.FunctionType,
+		Signature: function.Signature,
+		Source:    source,
+	})
+}
+
+// Count returns the number of distinct signatures in the database.
+func (db *SignatureDb) Count() int {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	cnt := 0
+	for _, recs := range db.records {
+		cnt += len(recs)
+	}
+	return cnt
+}
+
+// Records returns every record for the given encoding. Four-byte encodings match functions and
+// errors, 32-byte encodings match events.
+func (db *SignatureDb) Records(encoding string) []SigRecord {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	recs := db.records[strings.ToLower(encoding)]
+	ret := make([]SigRecord, len(recs))
+	copy(ret, recs)
+	return ret
+}
+
+// Lookup returns every candidate for the given encoding as a Function, ordered by source and
+// then by signature. Use Rank to order the candidates against actual calldata.
+func (db *SignatureDb) Lookup(encoding string) []types.Function {
+	recs := db.Records(encoding)
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Source != recs[j].Source {
+			return recs[i].Source == SigFromAbi
+		}
+		return recs[i].Signature < recs[j].Signature
+	})
+
+	ret := make([]types.Function, 0, len(recs))
+	for _, rec := range recs {
+		if function := db.parse(rec); function != nil {
+			ret = append(ret, *function.Clone())
+		}
+	}
+	return ret
+}
+
+func (db *SignatureDb) parse(rec SigRecord) *types.Function {
+	key := rec.Type + ":" + rec.Signature
+	db.mutex.RLock()
+	function, ok := db.parsed[key]
+	db.mutex.RUnlock()
+	if ok {
+		return function
+	}
+
+	function, err := FunctionFromSignature(rec.Signature, rec.Type)
+	if err != nil {
+		logger.Warn("skipping signature", rec.Signature, "error", err)
+		function = nil
+	}
+	db.mutex.Lock()
+	db.parsed[key] = function
+	db.mutex.Unlock()
+	return function
+}
+
+// Ranked is a candidate function together with how well it decoded the calldata.
+type Ranked struct {
+	Function types.Function
+	Score    int
+}
+
+const (
+	RankFails   = 0 // the calldata does not decode with this candidate
+	RankDecodes = 1 // the calldata decodes, but re-encoding does not reproduce it
+	RankExact   = 2 // the calldata decodes and re-encodes byte-for-byte
+)
+
+// Rank orders candidates for a four-byte selector by how well they decode the given calldata
+// (with or without the leading selector). Candidates that decode the data exactly are ranked
+// first, followed by those that merely decode, followed by those that fail. The sort is stable,
+// so ties keep the order returned by Lookup (signatures from real ABIs first).
+func Rank(candidates []types.Function, calldata string) []Ranked {
+	data := strings.TrimPrefix(strings.ToLower(calldata), "0x")
+	if len(data) >= 8 && len(candidates) > 0 && candidates[0].Encoding == "0x"+data[:8] {
+		data = data[8:]
+	}
+	dataBytes := base.Hex2Bytes(data)
+
+	ret := make([]Ranked, 0, len(candidates))
+	for _, candidate := range candidates {
+		ret = append(ret, Ranked{Function: candidate, Score: scoreCandidate(&candidate, dataBytes)})
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Score > ret[j].Score
+	})
+	return ret
+}
+
+func scoreCandidate(function *types.Function, data []byte) int {
+	if !function.IsMethod() {
+		return RankFails
+	}
+
+	method, err := function.GetAbiMethod()
+	if err != nil {
+		return RankFails
+	}
+
+	if len(method.Inputs) == 0 {
+		if len(data) == 0 {
+			return RankExact
+		}
+		return RankFails
+	}
+
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return RankFails
+	}
+
+	if packed, err := method.Inputs.Pack(values...); err == nil && string(packed) == string(data) {
+		return RankExact
+	}
+	return RankDecodes
+}
+
+// Save writes the database to the given file as tab-separated lines.
+func (db *SignatureDb) Save(path string) error {
+	db.mutex.RLock()
+	keys := make([]string, 0, len(db.records))
+	for key := range db.records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, rec := range db.records[key] {
+			lines = append(lines, strings.Join([]string{rec.Encoding, rec.Type, rec.Signature, string(rec.Source)}, "\t"))
+		}
+	}
+	db.mutex.RUnlock()
+	return file.LinesToAsciiFile(path, lines)
+}
+
+// readTab reads a database previously written with Save.
+func (db *SignatureDb) readTab(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		parts := strings.Split(scanner.Text(), "\t")
+		if len(parts) != 4 {
+			continue
+		}
+		db.Add(SigRecord{Encoding: parts[0], Type: parts[1], Signature: parts[2], Source: SigSource(parts[3])})
+	}
+	return scanner.Err()
+}
+
+// AddFromAbiJson adds every function, event and error found in an ABI json file.
+func (db *SignatureDb) AddFromAbiJson(reader io.Reader) (int, error) {
+	loadedAbi, err := abi.JSON(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	cnt := 0
+	for _, method := range loadedAbi.Methods {
+		if db.AddFunction(types.FunctionFromAbiMethod(&method), SigFromAbi) {
+			cnt++
+		}
+	}
+	for _, event := range loadedAbi.Events {
+		if db.AddFunction(types.FunctionFromAbiEvent(&event), SigFromAbi) {
+			cnt++
+		}
+	}
+	for _, abiError := range loadedAbi.Errors {
+		if db.AddFunction(FunctionFromAbiError(&abiError), SigFromAbi) {
+			cnt++
+		}
+	}
+	return cnt, nil
+}
+
+// ImportSignatures reads a public signature list and adds its signatures to the database. The
+// list may be a JSON object mapping encodings to one or more signatures, a 4byte.directory style
+// JSON export (`results` with `hex_signature` and `text_signature`), or text with one signature per
+// line optionally preceded by its encoding and a comma or tab. Encodings are always recomputed
+// from the signature, so any encoding in the list is ignored. Returns the number of signatures added.
+func (db *SignatureDb) ImportSignatures(reader io.Reader) (int, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	sigs := []string{}
+	trimmed := strings.TrimSpace(string(contents))
+	if strings.HasPrefix(trimmed, "{") {
+		if sigs, err = signaturesFromJson([]byte(trimmed)); err != nil {
+			return 0, err
+		}
+	} else {
+		for _, line := range strings.Split(trimmed, "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if idx := strings.IndexAny(line, ",\t"); idx >= 0 && strings.HasPrefix(line, "0x") {
+				line = strings.TrimSpace(line[idx+1:])
+			}
+			sigs = append(sigs, line)
+		}
+	}
+
+	cnt := 0
+	for _, sig := range sigs {
+		if db.importOne(sig) {
+			cnt++
+		}
+	}
+	return cnt, nil
+}
+
+// importOne adds a signature as a function (or error) and as an event since public lists rarely
+// say which it is. The encodings differ in length, so lookups only ever return the right kind.
+func (db *SignatureDb) importOne(sig string) bool {
+	sig = strings.ReplaceAll(strings.TrimSpace(sig), " ", "")
+	if _, err := FunctionFromSignature(sig, "function"); err != nil {
+		return false
+	}
+	hash := crypto.Keccak256([]byte(sig))
+	added := db.Add(SigRecord{Encoding: "0x" + base.Bytes2Hex(hash[:4]), Type: "function", Signature: sig, Source: SigFromImport})
+	if db.Add(SigRecord{Encoding: "0x" + base.Bytes2Hex(hash), Type: "event", Signature: sig, Source: SigFromImport}) {
+		added = true
+	}
+	return added
+}
+
+func signaturesFromJson(contents []byte) ([]string, error) {
+	fourByte := struct {
+		Results []struct {
+			HexSignature  string `json:"hex_signature"`
+			TextSignature string `json:"text_signature"`
+		} `json:"results"`
+	}{}
+	if err := json.Unmarshal(contents, &fourByte); err == nil && len(fourByte.Results) > 0 {
+		ret := make([]string, 0, len(fourByte.Results))
+		for _, result := range fourByte.Results {
+			ret = append(ret, result.TextSignature)
+		}
+		return ret, nil
+	}
+
+	asMap := map[string]any{}
+	if err := json.Unmarshal(contents, &asMap); err != nil {
+		return nil, fmt.Errorf("unrecognized signature list: %w", err)
+	}
+
+	ret := []string{}
+	for _, value := range asMap {
+		switch v := value.(type) {
+		case string:
+			ret = append(ret, v)
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					ret = append(ret, s)
+				}
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// PathToSignatureDb returns the path to the signature database for the given chain.
+func PathToSignatureDb(chain string) string {
+	return filepath.Join(config.PathToCache(chain), walk.CacheTypeToFolder[walk.Cache_Abis], sigDbFilename)
+}
+
+// PathToImportedSignatures returns the path to the file that accumulates imported signature lists.
+func PathToImportedSignatures(chain string) string {
+	return filepath.Join(config.PathToCache(chain), walk.CacheTypeToFolder[walk.Cache_Abis], sigImportFilename)
+}
+
+// ImportSignatureFile imports a public signature list into the persistent store for the chain.
+// The imported signatures are appended to the import file, so they survive rebuilds of the
+// database. Returns the number of new signatures.
+func ImportSignatureFile(chain, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	imported := NewSignatureDb()
+	_ = imported.readTab(PathToImportedSignatures(chain))
+	cnt, err := imported.ImportSignatures(f)
+	if err != nil {
+		return 0, err
+	}
+
+	return cnt, imported.Save(PathToImportedSignatures(chain))
+}
+
+// LoadSignatureDb returns the signature database for the chain, rebuilding it from the known
+// ABIs, the downloaded ABIs and any imported signature lists if any of those are newer than
+// the stored database.
+func LoadSignatureDb(chain string) (*SignatureDb, error) {
+	dbPath := PathToSignatureDb(chain)
+	knownDir := filepath.Join(config.PathToRootConfig(), "abis")
+	cacheDir := filepath.Join(config.PathToCache(chain), walk.CacheTypeToFolder[walk.Cache_Abis])
+
+	isUpToDate := func() bool {
+		dbTime, err := file.GetModTime(dbPath)
+		if err != nil {
+			return false
+		}
+		for _, dir := range []string{knownDir, cacheDir} {
+			if newest, err := file.GetNewestInDirectory(dir); err == nil && newest != nil {
+				if newest.ModTime().After(dbTime) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	db := NewSignatureDb()
+	if isUpToDate() {
+		if err := db.readTab(dbPath); err == nil {
+			return db, nil
+		}
+		db = NewSignatureDb()
+	}
+
+	if err := db.build(knownDir, cacheDir, chain); err != nil {
+		return nil, err
+	}
+
+	if err := file.EstablishFolder(cacheDir); err != nil {
+		return nil, err
+	}
+	return db, db.Save(dbPath)
+}
+
+func (db *SignatureDb) build(knownDir, cacheDir, chain string) error {
+	addFolder := func(folder string) {
+		_ = filepath.WalkDir(folder, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+				return nil
+			}
+			if f, err := os.Open(path); err == nil {
+				defer f.Close()
+				if _, err := db.AddFromAbiJson(f); err != nil {
+					logger.Warn("skipping abi file", path, "error", err)
+				}
+			}
+			return nil
+		})
+	}
+
+	if file.FolderExists(knownDir) {
+		addFolder(knownDir)
+	}
+	if file.FolderExists(cacheDir) {
+		addFolder(cacheDir)
+	}
+
+	importPath := PathToImportedSignatures(chain)
+	if file.FileExists(importPath) {
+		if err := db.readTab(importPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
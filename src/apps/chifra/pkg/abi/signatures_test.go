@@ -0,0 +1,146 @@
+package abi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFunctionFromSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		fnType    string
+		encoding  string
+		nInputs   int
+		wantErr   bool
+	}{
+		{"transfer(address,uint256)", "function", "0xa9059cbb", 2, false},
+		{"Transfer(address,address,uint256)", "event", "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", 3, false},
+		{"Error(string)", "error", "0x08c379a0", 1, false},
+		{"totalSupply()", "function", "0x18160ddd", 0, false},
+		{"multicall((address,bytes)[])", "function", "0x", 1, false},
+		{"swap((address,(uint256,bool))[2],bytes)", "function", "0x", 2, false},
+		{"transfer(address,", "function", "", 0, true},
+		{"transfer(address,,uint256)", "function", "", 0, true},
+		{"(address)", "function", "", 0, true},
+		{"foo(notatype)", "function", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		function, err := FunctionFromSignature(tt.signature, tt.fnType)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FunctionFromSignature(%s) error = %v, wantErr %v", tt.signature, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !strings.HasPrefix(function.Encoding, tt.encoding) {
+			t.Errorf("FunctionFromSignature(%s) encoding = %s, want %s", tt.signature, function.Encoding, tt.encoding)
+		}
+		if function.Signature != tt.signature {
+			t.Errorf("FunctionFromSignature(%s) signature = %s", tt.signature, function.Signature)
+		}
+		if len(function.Inputs) != tt.nInputs {
+			t.Errorf("FunctionFromSignature(%s) has %d inputs, want %d", tt.signature, len(function.Inputs), tt.nInputs)
+		}
+		if function.FunctionType != tt.fnType {
+			t.Errorf("FunctionFromSignature(%s) type = %s, want %s", tt.signature, function.FunctionType, tt.fnType)
+		}
+	}
+}
+
+func TestImportSignatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     int
+	}{
+		{"text", "transfer(address,uint256)\n# comment\n\napprove(address,uint256)\n", 2},
+		{"with encodings", "0xa9059cbb,transfer(address,uint256)\n0x095ea7b3\tapprove(address,uint256)\n", 2},
+		{"4byte", `{"results":[{"hex_signature":"0xa9059cbb","text_signature":"transfer(address,uint256)"}]}`, 1},
+		{"map", `{"0xa9059cbb":["transfer(address,uint256)"],"0x18160ddd":"totalSupply()"}`, 2},
+		{"junk", "not a signature\nfoo(\n", 0},
+	}
+
+	for _, tt := range tests {
+		db := NewSignatureDb()
+		cnt, err := db.ImportSignatures(strings.NewReader(tt.contents))
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if cnt != tt.want {
+			t.Errorf("%s: imported %d, want %d", tt.name, cnt, tt.want)
+		}
+	}
+
+	db := NewSignatureDb()
+	_, _ = db.ImportSignatures(strings.NewReader("transfer(address,uint256)"))
+	if got := db.Lookup("0xa9059cbb"); len(got) != 1 || got[0].FunctionType != "function" {
+		t.Errorf("expected a function candidate for the four-byte, got %v", got)
+	}
+	if got := db.Lookup("0x" + strings.Repeat("0", 64)); len(got) != 0 {
+		t.Errorf("expected no candidates for an unknown topic, got %v", got)
+	}
+}
+
+func TestRankCollisions(t *testing.T) {
+	// burn(uint256) and collate_propagate_storage(bytes16) share the selector 0x42966c68
+	db := NewSignatureDb()
+	_, _ = db.ImportSignatures(strings.NewReader("collate_propagate_storage(bytes16)\nburn(uint256)\n"))
+
+	candidates := db.Lookup("0x42966c68")
+	if len(candidates) != 2 {
+		t.Fatalf("expected two colliding candidates, got %d", len(candidates))
+	}
+	if candidates[0].Signature != "burn(uint256)" {
+		t.Errorf("expected candidates sorted by signature, got %s first", candidates[0].Signature)
+	}
+
+	calldata := "0x42966c68" + "00000000000000000000000000000000000000000000000000000000000003e8"
+	ranked := Rank(candidates, calldata)
+	if ranked[0].Function.Signature != "burn(uint256)" || ranked[0].Score != RankExact {
+		t.Errorf("expected burn(uint256) to decode exactly, got %s (%d)", ranked[0].Function.Signature, ranked[0].Score)
+	}
+	if ranked[1].Score == RankExact {
+		t.Errorf("expected %s not to decode exactly", ranked[1].Function.Signature)
+	}
+
+	calldata = "0x42966c68" + "0102030405060708090a0b0c0d0e0f1000000000000000000000000000000000"
+	ranked = Rank(candidates, calldata)
+	if ranked[0].Function.Signature != "burn(uint256)" {
+		t.Errorf("expected ties to keep lookup order, got %s first", ranked[0].Function.Signature)
+	}
+
+	ranked = Rank(candidates, "0x42966c68")
+	for _, r := range ranked {
+		if r.Score != RankFails {
+			t.Errorf("expected %s to fail on empty input", r.Function.Signature)
+		}
+	}
+}
+
+func TestSignatureDbSource(t *testing.T) {
+	const definition = `[{"inputs":[{"name":"amount","type":"uint256"}],"name":"burn","outputs":[],"type":"function"},{"inputs":[{"name":"needed","type":"uint256"}],"name":"Insufficient","type":"error"}]`
+
+	db := NewSignatureDb()
+	_, _ = db.ImportSignatures(strings.NewReader("collate_propagate_storage(bytes16)\nburn(uint256)\n"))
+	if _, err := db.AddFromAbiJson(strings.NewReader(definition)); err != nil {
+		t.Fatal(err)
+	}
+
+	recs := db.Records("0x42966c68")
+	if len(recs) != 2 {
+		t.Fatalf("expected two records, got %d", len(recs))
+	}
+	for _, rec := range recs {
+		if rec.Signature == "burn(uint256)" && rec.Source != SigFromAbi {
+			t.Errorf("expected signature found in an abi to be upgraded to source abi")
+		}
+	}
+
+	insufficient, _ := FunctionFromSignature("Insufficient(uint256)", "error")
+	if errs := db.Lookup(insufficient.Encoding); len(errs) != 1 || errs[0].FunctionType != "error" {
+		t.Errorf("expected custom error Insufficient(uint256) in the database, got %v", errs)
+	}
+}
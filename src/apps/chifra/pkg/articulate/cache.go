@@ -1,6 +1,8 @@
 package articulate
 
 import (
+	"sync"
+
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
@@ -12,6 +14,8 @@ type AbiCache struct {
 	AbiMap    abi.SelectorSyncMap
 	loadedMap abi.AddressSyncMap
 	skipMap   abi.AddressSyncMap
+	sigDb     *abi.SignatureDb
+	sigOnce   sync.Once
 }
 
 func NewAbiCache(conn *rpc.Connection, loadKnown bool) *AbiCache {
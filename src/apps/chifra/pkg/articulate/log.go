@@ -37,6 +37,10 @@ func (abiCache *AbiCache) ArticulateLog(log *types.Log) error {
 				return err
 			}
 		}
+
+		if log.ArticulatedLog == nil {
+			log.ArticulatedLog = abiCache.eventFromSignatures(log)
+		}
 		return nil
 	}
 }
@@ -5,13 +5,30 @@ import (
 )
 
 func (abiCache *AbiCache) ArticulateReceipt(receipt *types.Receipt) (err error) {
+	if receipt != nil {
+		if err = abiCache.articulateLogs(receipt); err != nil {
+			return err
+		}
+
+		if receipt.IsError && receipt.RevertReason == nil {
+			// failing to find a revert reason is not an error, many nodes cannot provide one
+			if revertData, err := abiCache.Conn.GetRevertDataByReceipt(receipt); err == nil {
+				receipt.RevertReason = abiCache.ArticulateRevert(receipt.To, revertData)
+			}
+		}
+	}
+
+	return nil
+}
+
+// articulateLogs articulates the receipt's logs (but not its revert reason)
+func (abiCache *AbiCache) articulateLogs(receipt *types.Receipt) error {
 	if receipt != nil {
 		for index := range receipt.Logs {
-			if err = abiCache.ArticulateLog(&receipt.Logs[index]); err != nil {
+			if err := abiCache.ArticulateLog(&receipt.Logs[index]); err != nil {
 				return err
 			}
 		}
 	}
-
 	return nil
 }
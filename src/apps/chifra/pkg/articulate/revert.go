@@ -0,0 +1,156 @@
+package articulate
+
+import (
+	"math/big"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	goEthAbi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Solidity's builtin errors. Every other revert selector is a custom error.
+const (
+	errorSelector = "0x08c379a0" // Error(string)
+	panicSelector = "0x4e487b71" // Panic(uint256)
+)
+
+// panicCodes are the codes the Solidity compiler inserts into Panic(uint256)
+var panicCodes = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "incorrectly encoded storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to zero-initialized function",
+}
+
+// ArticulateRevert decodes the bytes a call reverted with. Builtin errors are decoded
+// directly, custom errors are looked up in the ABI of the reverting contract (which is
+// loaded if needed) and then in the signature database. Returns nil if nothing decodes.
+func (abiCache *AbiCache) ArticulateRevert(address base.Address, revertData string) *types.Function {
+	if len(revertData) < 10 {
+		return nil
+	}
+
+	if found := DecodeRevert(revertData, &abiCache.AbiMap); found != nil {
+		return found
+	}
+
+	if !address.IsZero() && !abiCache.loadedMap.GetValue(address) && !abiCache.skipMap.GetValue(address) {
+		if err := abi.LoadAbi(abiCache.Conn, address, &abiCache.AbiMap); err != nil {
+			abiCache.skipMap.SetValue(address, true)
+		} else {
+			abiCache.loadedMap.SetValue(address, true)
+			if found := DecodeRevert(revertData, &abiCache.AbiMap); found != nil {
+				return found
+			}
+		}
+	}
+
+	return abiCache.errorFromSignatures(revertData)
+}
+
+// DecodeRevert decodes revert data using Solidity's builtin errors and the custom errors
+// found in abiMap. Returns nil if the selector is unknown or the data does not decode.
+func DecodeRevert(revertData string, abiMap *abi.SelectorSyncMap) *types.Function {
+	if len(revertData) < 10 {
+		return nil
+	}
+
+	selector := revertData[:10]
+	switch selector {
+	case errorSelector:
+		art := builtinError("Error", "message", "string")
+		if err := ArticulateFunction(art, revertData[10:], ""); err != nil {
+			return nil
+		}
+		if msg, ok := art.Inputs[0].Value.(string); ok {
+			art.Message = msg
+		}
+		return art
+
+	case panicSelector:
+		art := builtinError("Panic", "code", "uint256")
+		if err := ArticulateFunction(art, revertData[10:], ""); err != nil {
+			return nil
+		}
+		art.Message = panicMessage(revertData[10:])
+		return art
+	}
+
+	if found := abiMap.GetError(selector); found != nil {
+		art := found.Clone()
+		if err := ArticulateFunction(art, revertData[10:], ""); err == nil {
+			return art
+		}
+	}
+
+	return nil
+}
+
+// errorFromSignatures is the fallback for custom errors not found in any ABI. Imported
+// signatures do not say whether they are functions or errors, so any four-byte candidate
+// that decodes the revert data exactly is accepted.
+func (abiCache *AbiCache) errorFromSignatures(revertData string) *types.Function {
+	db := abiCache.signatureDb()
+	if db == nil {
+		return nil
+	}
+
+	for _, ranked := range abi.Rank(db.Lookup(revertData[:10]), revertData) {
+		if ranked.Score == abi.RankFails {
+			break
+		}
+		art := ranked.Function.Clone()
+		if err := ArticulateFunction(art, revertData[10:], ""); err == nil {
+			art.FunctionType = "error"
+			return art
+		}
+	}
+	return nil
+}
+
+// articulateTxRevert finds and decodes the revert reason of a failed transaction. The reason is
+// taken from the top-level trace if we have one and requested from the node otherwise. Failing to
+// find a reason is not an error because many nodes cannot provide one.
+func (abiCache *AbiCache) articulateTxRevert(tx *types.Transaction) {
+	if !tx.IsError || tx.RevertReason != nil {
+		return
+	}
+
+	var revertData string
+	if len(tx.Traces) > 0 && tx.Traces[0].Result != nil && len(tx.Traces[0].Result.Output) >= 10 {
+		revertData = tx.Traces[0].Result.Output
+	} else if data, err := abiCache.Conn.GetRevertData(tx); err != nil {
+		return
+	} else {
+		revertData = data
+	}
+
+	tx.RevertReason = abiCache.ArticulateRevert(tx.To, revertData)
+	if tx.Receipt != nil {
+		tx.Receipt.RevertReason = tx.RevertReason
+	}
+}
+
+func builtinError(name, argName, argType string) *types.Function {
+	t, _ := goEthAbi.NewType(argType, "", nil)
+	abiError := goEthAbi.NewError(name, goEthAbi.Arguments{{Name: argName, Type: t}})
+	return abi.FunctionFromAbiError(&abiError)
+}
+
+func panicMessage(data string) string {
+	code, ok := new(big.Int).SetString(data, 16)
+	if !ok || !code.IsUint64() {
+		return "unknown panic code"
+	}
+	if msg, ok := panicCodes[code.Uint64()]; ok {
+		return msg
+	}
+	return "unknown panic code"
+}
@@ -0,0 +1,70 @@
+package articulate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	encode := func(signature string, args ...any) string {
+		function, err := abi.FunctionFromSignature(signature, "error")
+		if err != nil {
+			t.Fatal(err)
+		}
+		packed, err := function.Pack(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "0x" + base.Bytes2Hex(packed)
+	}
+
+	custom, _ := abi.FunctionFromSignature("InsufficientBalance(uint256,uint256)", "error")
+	abiMap := &abi.SelectorSyncMap{}
+	abiMap.SetValue(custom.Encoding, custom)
+	if abiMap.GetValue(custom.Encoding) != nil {
+		t.Error("custom errors should not be visible as functions")
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		message string
+	}{
+		{"error string", encode("Error(string)", "insufficient balance"), "Error", "insufficient balance"},
+		{"panic overflow", encode("Panic(uint256)", big.NewInt(0x11)), "Panic", "arithmetic underflow or overflow"},
+		{"panic unknown", encode("Panic(uint256)", big.NewInt(0x99)), "Panic", "unknown panic code"},
+		{"custom error", encode("InsufficientBalance(uint256,uint256)", big.NewInt(10), big.NewInt(20)), "InsufficientBalance", ""},
+		{"unknown selector", encode("Unknown(uint256)", big.NewInt(1)), "", ""},
+		{"bad data", "0x08c379a0" + "00", "", ""},
+		{"too short", "0x", "", ""},
+	}
+
+	for _, tt := range tests {
+		got := DecodeRevert(tt.data, abiMap)
+		if tt.want == "" {
+			if got != nil {
+				t.Errorf("%s: expected no decoding, got %s", tt.name, got.Name)
+			}
+			continue
+		}
+		if got == nil {
+			t.Errorf("%s: expected %s, got nothing", tt.name, tt.want)
+			continue
+		}
+		if got.Name != tt.want || got.FunctionType != "error" {
+			t.Errorf("%s: got %s (%s), want %s (error)", tt.name, got.Name, got.FunctionType, tt.want)
+		}
+		if got.Message != tt.message {
+			t.Errorf("%s: got message %q, want %q", tt.name, got.Message, tt.message)
+		}
+	}
+
+	got := DecodeRevert(encode("InsufficientBalance(uint256,uint256)", big.NewInt(10), big.NewInt(20)), abiMap)
+	if len(got.Inputs) != 2 || got.Inputs[1].Value != "20" {
+		t.Errorf("expected custom error inputs to be decoded, got %v", got.Inputs)
+	}
+}
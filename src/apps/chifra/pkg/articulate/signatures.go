@@ -0,0 +1,83 @@
+package articulate
+
+import (
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/abi"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	goEthAbi "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// signatureDb returns the chain's signature database, loading it on first use. If it cannot
+// be loaded, we report the problem once and articulate without the fallback.
+func (abiCache *AbiCache) signatureDb() *abi.SignatureDb {
+	abiCache.sigOnce.Do(func() {
+		if db, err := abi.LoadSignatureDb(abiCache.Chain); err != nil {
+			logger.Warn("signature database not available", "error", err)
+		} else {
+			abiCache.sigDb = db
+		}
+	})
+	return abiCache.sigDb
+}
+
+// functionFromSignatures is the fallback used when no ABI provides the selector found at the
+// front of the input. Candidates are ranked against the calldata and the best candidate that
+// decodes is articulated. Returns nil if no candidate decodes.
+func (abiCache *AbiCache) functionFromSignatures(input, output string) *types.Function {
+	db := abiCache.signatureDb()
+	if db == nil || len(input) < 10 {
+		return nil
+	}
+
+	for _, ranked := range abi.Rank(db.Lookup(input[:10]), input) {
+		if ranked.Score == abi.RankFails {
+			break
+		}
+		art := ranked.Function.Clone()
+		if err := ArticulateFunction(art, input[10:], output); err == nil {
+			return art
+		}
+	}
+	return nil
+}
+
+// eventFromSignatures is the fallback used when no ABI provides the log's topic. Signatures do
+// not record which parameters are indexed, so we assume the leading parameters are indexed, one
+// per topic, and return the first candidate that decodes.
+func (abiCache *AbiCache) eventFromSignatures(log *types.Log) *types.Function {
+	db := abiCache.signatureDb()
+	if db == nil || len(log.Topics) < 1 {
+		return nil
+	}
+
+	data := log.Data
+	if len(data) > 1 {
+		data = data[2:]
+	}
+
+	nIndexed := len(log.Topics) - 1
+	for _, candidate := range db.Lookup(log.Topics[0].Hex()) {
+		if candidate.IsMethod() || len(candidate.Inputs) < nIndexed {
+			continue
+		}
+		abiEvent, err := candidate.GetAbiEvent()
+		if err != nil {
+			continue
+		}
+
+		inputs := make(goEthAbi.Arguments, len(abiEvent.Inputs))
+		copy(inputs, abiEvent.Inputs)
+		art := candidate.Clone()
+		for i := 0; i < nIndexed; i++ {
+			inputs[i].Indexed = true
+			art.Inputs[i].Indexed = true
+		}
+		event := goEthAbi.NewEvent(abiEvent.Name, abiEvent.RawName, abiEvent.Anonymous, inputs)
+		art.SetAbiEvent(&event)
+
+		if err := articulateArguments(event.Inputs, data, log.Topics, art.Inputs); err == nil {
+			return art
+		}
+	}
+	return nil
+}
@@ -10,6 +10,10 @@ import (
 )
 
 func (abiCache *AbiCache) ArticulateTrace(trace *types.Trace) (err error) {
+	if trace.Error != "" && trace.Result != nil && trace.RevertReason == nil {
+		trace.RevertReason = abiCache.ArticulateRevert(trace.Action.To, trace.Result.Output)
+	}
+
 	found, err := articulateTrace(trace, &abiCache.AbiMap)
 	if err != nil {
 		return err
@@ -38,6 +42,14 @@ func (abiCache *AbiCache) ArticulateTrace(trace *types.Trace) (err error) {
 			}
 		}
 
+		if trace.ArticulatedTrace == nil {
+			var outputData string
+			if trace.Result != nil && len(trace.Result.Output) > 2 && trace.Error == "" {
+				outputData = trace.Result.Output[2:]
+			}
+			trace.ArticulatedTrace = abiCache.functionFromSignatures(trace.Action.Input, outputData)
+		}
+
 		return nil
 	}
 }
@@ -77,7 +89,8 @@ func articulateTrace(trace *types.Trace, abiMap *abi.SelectorSyncMap) (articulat
 	if err != nil {
 		return nil, err
 	}
-	if len(trace.Result.Output) >= 2 {
+	// a reverted trace's output holds the revert reason, not the function's outputs
+	if len(trace.Result.Output) >= 2 && trace.Error == "" {
 		err = articulateArguments(
 			abiMethod.Outputs,
 			trace.Result.Output[2:],
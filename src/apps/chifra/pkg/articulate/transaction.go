@@ -46,7 +46,18 @@ func (abiCache *AbiCache) ArticulateTransaction(tx *types.Transaction) error {
 	}
 	// }
 
-	if err = abiCache.ArticulateReceipt(tx.Receipt); err != nil {
+	if tx.ArticulatedTx == nil && len(tx.Message) == 0 {
+		var outputData string
+		if len(tx.Traces) > 0 && tx.Traces[0].Result != nil && len(tx.Traces[0].Result.Output) > 2 && tx.Traces[0].Error == "" {
+			outputData = tx.Traces[0].Result.Output[2:]
+		}
+		tx.ArticulatedTx = abiCache.functionFromSignatures(tx.Input, outputData)
+	}
+
+	abiCache.articulateTxRevert(tx)
+
+	// the revert reason was sought (once) with the transaction's, so only the logs remain
+	if err = abiCache.articulateLogs(tx.Receipt); err != nil {
 		return err
 	}
 
@@ -66,7 +77,7 @@ func articulateTx(tx *types.Transaction, abiMap *abi.SelectorSyncMap) (*types.Fu
 	var selector string
 	var input = tx.Input
 	var outputData string
-	if len(tx.Traces) > 0 && tx.Traces[0].Result != nil && len(tx.Traces[0].Result.Output) > 2 {
+	if len(tx.Traces) > 0 && tx.Traces[0].Result != nil && len(tx.Traces[0].Result.Output) > 2 && tx.Traces[0].Error == "" {
 		outputData = tx.Traces[0].Result.Output[2:]
 	}
 
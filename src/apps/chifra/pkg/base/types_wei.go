@@ -126,6 +126,16 @@ func (w *Wei) UnmarshalText(text []byte) error {
 	return (*big.Int)(w).UnmarshalText(text)
 }
 
+// GobEncode and GobDecode let structures holding a Wei (such as a Name) be gob encoded, which
+// does not fall back to the text marshaler
+func (w *Wei) GobEncode() ([]byte, error) {
+	return (*big.Int)(w).GobEncode()
+}
+
+func (w *Wei) GobDecode(buf []byte) error {
+	return (*big.Int)(w).GobDecode(buf)
+}
+
 // TODO: BOGUS - THIS NAME SUCKS
 
 func (w *Wei) ToEtherStr(decimals int) string {
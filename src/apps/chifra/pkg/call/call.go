@@ -187,39 +187,19 @@ func (call *ContractCall) forceEncoding(encoding string) {
 }
 
 func (call *ContractCall) Call(artFunc func(string, *types.Function) error) (results *types.Result, err error) {
-	blockTs := base.Timestamp(0)
-	if call.Conn.StoreReadable() {
-		// walk.Cache_Results
-		results = &types.Result{
-			BlockNumber: call.BlockNumber,
-			Address:     call.Address,
-			Encoding:    call.Method.Encoding,
-		}
-		if err := call.Conn.Store.Read(results, nil); err == nil {
-			return results, nil
-		}
-		blockTs = call.Conn.GetBlockTimestamp(call.BlockNumber)
+	results, blockTs := call.readCache()
+	if results != nil {
+		return results, nil
 	}
 
 	if artFunc == nil {
 		logger.Fatal("should not happen ==> implementation error: artFunc is nil")
 	}
 
-	var packed []byte
 	blockNumberHex := fmt.Sprintf("0x%x", call.BlockNumber)
-	if call.encoded != "" {
-		packed = base.Hex2Bytes(call.encoded[2:])
-	} else {
-		packed, err = call.Method.Pack(call.Arguments)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	packedHex := "0x" + base.Bytes2Hex(packed)
-	encodedArguments := ""
-	if len(packedHex) > 10 {
-		encodedArguments = packedHex[10:]
+	packedHex, err := call.pack()
+	if err != nil {
+		return nil, err
 	}
 
 	method := "eth_call"
@@ -236,16 +216,87 @@ func (call *ContractCall) Call(artFunc func(string, *types.Function) error) (res
 		return nil, err
 	}
 
+	return call.finish(packedHex, *theBytes, blockTs, artFunc)
+}
+
+// CallAll makes each of the calls and returns their results (or errors) in the same order.
+// Cached results are used if available, the remaining calls are made together (see CallMany)
+// and their results are cached exactly as Call would cache them.
+func CallAll(calls []*ContractCall, artFunc func(string, *types.Function) error) ([]*types.Result, []error) {
+	results := make([]*types.Result, len(calls))
+	errs := make([]error, len(calls))
+	if len(calls) == 0 {
+		return results, errs
+	}
+
+	if artFunc == nil {
+		logger.Fatal("should not happen ==> implementation error: artFunc is nil")
+	}
+
+	timestamps := make([]base.Timestamp, len(calls))
+	packed := make([]string, len(calls))
+	pending := make([]int, 0, len(calls))
+	requests := make([]Request, 0, len(calls))
+	for index, call := range calls {
+		if results[index], timestamps[index] = call.readCache(); results[index] != nil {
+			continue
+		}
+		if packed[index], errs[index] = call.pack(); errs[index] != nil {
+			continue
+		}
+		pending = append(pending, index)
+		requests = append(requests, Request{
+			To:          call.Address,
+			Data:        packed[index],
+			BlockNumber: call.BlockNumber,
+		})
+	}
+
+	responses := CallMany(calls[0].Conn, requests)
+	for i, index := range pending {
+		if responses[i].Err != nil {
+			errs[index] = responses[i].Err
+			continue
+		}
+		results[index], errs[index] = calls[index].finish(packed[index], responses[i].Data, timestamps[index], artFunc)
+	}
+
+	return results, errs
+}
+
+// readCache returns the cached result of the call, if any. If there is no cached result, it
+// returns the block's timestamp (but only if the cache is readable, as Call always has).
+func (call *ContractCall) readCache() (*types.Result, base.Timestamp) {
+	blockTs := base.Timestamp(0)
+	if call.Conn.StoreReadable() {
+		// walk.Cache_Results
+		results := &types.Result{
+			BlockNumber: call.BlockNumber,
+			Address:     call.Address,
+			Encoding:    call.Method.Encoding,
+		}
+		if err := call.Conn.Store.Read(results, nil); err == nil {
+			return results, blockTs
+		}
+		blockTs = call.Conn.GetBlockTimestamp(call.BlockNumber)
+	}
+	return nil, blockTs
+}
+
+// finish articulates the bytes returned by the call, builds the result and caches it if final
+func (call *ContractCall) finish(packedHex, returned string, blockTs base.Timestamp, artFunc func(string, *types.Function) error) (*types.Result, error) {
+	encodedArguments := ""
+	if len(packedHex) > 10 {
+		encodedArguments = packedHex[10:]
+	}
+
 	function := call.Method.Clone()
 	// articulate it if possible
-	if theBytes != nil {
-		str := *theBytes
-		if err = artFunc(str, function); err != nil {
-			return nil, err
-		}
+	if err := artFunc(returned, function); err != nil {
+		return nil, err
 	}
 
-	results = &types.Result{
+	results := &types.Result{
 		BlockNumber:      call.BlockNumber,
 		Timestamp:        blockTs,
 		Address:          call.Address,
@@ -253,7 +304,7 @@ func (call *ContractCall) Call(artFunc func(string, *types.Function) error) (res
 		Encoding:         call.Method.Encoding,
 		Signature:        call.Method.Signature,
 		EncodedArguments: encodedArguments,
-		ReturnedBytes:    *theBytes,
+		ReturnedBytes:    returned,
 		ArticulatedOut:   function,
 	}
 	results.Values = make(map[string]string)
@@ -269,3 +320,37 @@ func (call *ContractCall) Call(artFunc func(string, *types.Function) error) (res
 
 	return results, nil
 }
+
+// pack returns the hex encoded call data (selector followed by the encoded arguments)
+func (call *ContractCall) pack() (string, error) {
+	if call.encoded != "" {
+		return call.encoded, nil
+	}
+	packed, err := call.Method.Pack(call.Arguments)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + base.Bytes2Hex(packed), nil
+}
+
+// Reverted builds the result of a call that reverted with the given data. The caller is
+// expected to articulate the revert reason. Reverted results are never cached.
+func (call *ContractCall) Reverted(revertData string) *types.Result {
+	encodedArguments := ""
+	if packedHex, err := call.pack(); err == nil && len(packedHex) > 10 {
+		encodedArguments = packedHex[10:]
+	}
+
+	return &types.Result{
+		BlockNumber:      call.BlockNumber,
+		Timestamp:        call.Conn.GetBlockTimestamp(call.BlockNumber),
+		Address:          call.Address,
+		Name:             call.Method.Name,
+		Encoding:         call.Method.Encoding,
+		Signature:        call.Method.Signature,
+		EncodedArguments: encodedArguments,
+		ReturnedBytes:    revertData,
+		ArticulatedOut:   call.Method.Clone(),
+		Values:           map[string]string{},
+	}
+}
@@ -0,0 +1,264 @@
+package call
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3 is deployed at the same address on nearly every EVM chain. See multicall3.com.
+var multicall3 = base.HexToAddress("0xca11bde05977b3631167028862be2a173976ca11")
+
+// multicall3Deployed records the block at which Multicall3 was deployed on chains we know about.
+// On other chains we search for the deployment block the first time we need it.
+var multicall3Deployed = map[string]base.Blknum{
+	"mainnet": 14353601,
+	"sepolia": 751532,
+	"gnosis":  21022491,
+}
+
+const multicall3Abi = `[{"inputs":[{"components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}],"name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+const (
+	// callsPerMulticall is the number of calls we aggregate into a single Multicall3 call. It's
+	// small enough to stay well below the gas cap nodes apply to eth_call.
+	callsPerMulticall = 100
+	// payloadsPerBatch is the number of eth_calls we send in a single JSON-RPC batch
+	payloadsPerBatch = 50
+)
+
+// Request is a single eth_call to be made as part of a batch.
+type Request struct {
+	To          base.Address
+	Data        string
+	BlockNumber base.Blknum
+}
+
+// Response is the outcome of a single Request. If the call reverted, Err carries the revert
+// data (see query.RevertData).
+type Response struct {
+	Data string
+	Err  error
+}
+
+type aggregate3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type aggregate3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+var aggregate3 abi.Method
+var deployedMap sync.Map
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		panic(err)
+	}
+	aggregate3 = parsed.Methods["aggregate3"]
+}
+
+// CallMany makes all the requests and returns a response for each one in the same order.
+// Requests for blocks at which Multicall3 is deployed are aggregated through it, the rest
+// (and any aggregate that fails as a whole) are sent as JSON-RPC batches of plain eth_calls.
+// Failures are reported per request.
+func CallMany(conn *rpc.Connection, requests []Request) []Response {
+	responses := make([]Response, len(requests))
+	if len(requests) == 0 {
+		return responses
+	}
+
+	deployed := multicallDeployedAt(conn)
+
+	// group requests by block, keeping their original positions
+	byBlock := make(map[base.Blknum][]int)
+	blocks := make([]base.Blknum, 0)
+	direct := make([]int, 0)
+	for index, req := range requests {
+		if req.BlockNumber < deployed {
+			direct = append(direct, index)
+			continue
+		}
+		if _, ok := byBlock[req.BlockNumber]; !ok {
+			blocks = append(blocks, req.BlockNumber)
+		}
+		byBlock[req.BlockNumber] = append(byBlock[req.BlockNumber], index)
+	}
+
+	groups := make([][]int, 0)
+	for _, bn := range blocks {
+		indices := byBlock[bn]
+		for start := 0; start < len(indices); start += callsPerMulticall {
+			end := min(start+callsPerMulticall, len(indices))
+			groups = append(groups, indices[start:end])
+		}
+	}
+
+	failedGroups := callAggregated(conn, requests, groups, responses)
+	for _, group := range failedGroups {
+		direct = append(direct, group...)
+	}
+	callDirect(conn, requests, direct, responses)
+
+	return responses
+}
+
+// callAggregated sends each group of requests (all at the same block) as one aggregate3 call.
+// It returns the groups whose aggregate call failed as a whole so they may be retried.
+func callAggregated(conn *rpc.Connection, requests []Request, groups [][]int, responses []Response) [][]int {
+	failed := make([][]int, 0)
+	for start := 0; start < len(groups); start += payloadsPerBatch {
+		end := min(start+payloadsPerBatch, len(groups))
+		payloads := make([]query.BatchPayload, 0, end-start)
+		for g := start; g < end; g++ {
+			calls := make([]aggregate3Call, 0, len(groups[g]))
+			for _, index := range groups[g] {
+				calls = append(calls, aggregate3Call{
+					Target:       requests[index].To.Common(),
+					AllowFailure: true,
+					CallData:     base.Hex2Bytes(strings.TrimPrefix(requests[index].Data, "0x")),
+				})
+			}
+			packed, err := aggregate3.Inputs.Pack(calls)
+			if err != nil {
+				failed = append(failed, groups[g])
+				continue
+			}
+			data := "0x" + base.Bytes2Hex(aggregate3.ID) + base.Bytes2Hex(packed)
+			payloads = append(payloads, ethCallPayload(fmt.Sprintf("%d", g), multicall3, data, requests[groups[g][0]].BlockNumber))
+		}
+
+		if len(payloads) == 0 {
+			continue
+		}
+
+		results, errs, err := query.QueryBatchEach[string](conn.Chain, payloads)
+		for _, payload := range payloads {
+			g := int(base.MustParseUint64(payload.Key))
+			if err != nil || errs[payload.Key] != nil || results[payload.Key] == nil {
+				failed = append(failed, groups[g])
+				continue
+			}
+			decoded, err := decodeAggregate3(*results[payload.Key], len(groups[g]))
+			if err != nil {
+				failed = append(failed, groups[g])
+				continue
+			}
+			for i, index := range groups[g] {
+				responses[index] = decoded[i]
+			}
+		}
+	}
+	return failed
+}
+
+// decodeAggregate3 decodes the value returned by aggregate3 into one response per call. A call
+// that failed inside the aggregate carries its revert data in the same way eth_call would.
+func decodeAggregate3(returned string, nCalls int) ([]Response, error) {
+	unpacked, err := aggregate3.Outputs.Unpack(base.Hex2Bytes(strings.TrimPrefix(returned, "0x")))
+	if err != nil {
+		return nil, err
+	}
+	if len(unpacked) != 1 {
+		return nil, fmt.Errorf("unexpected aggregate3 output")
+	}
+
+	results := *abi.ConvertType(unpacked[0], new([]aggregate3Result)).(*[]aggregate3Result)
+	if len(results) != nCalls {
+		return nil, fmt.Errorf("aggregate3 returned %d results for %d calls", len(results), nCalls)
+	}
+
+	responses := make([]Response, 0, nCalls)
+	for _, result := range results {
+		returnData := "0x" + base.Bytes2Hex(result.ReturnData)
+		if result.Success {
+			responses = append(responses, Response{Data: returnData})
+		} else {
+			responses = append(responses, Response{Err: &query.RpcError{Code: 3, Message: "execution reverted", Data: returnData}})
+		}
+	}
+	return responses, nil
+}
+
+// callDirect sends the given requests as plain eth_calls in JSON-RPC batches
+func callDirect(conn *rpc.Connection, requests []Request, indices []int, responses []Response) {
+	for start := 0; start < len(indices); start += payloadsPerBatch {
+		end := min(start+payloadsPerBatch, len(indices))
+		payloads := make([]query.BatchPayload, 0, end-start)
+		for _, index := range indices[start:end] {
+			req := requests[index]
+			payloads = append(payloads, ethCallPayload(fmt.Sprintf("%d", index), req.To, req.Data, req.BlockNumber))
+		}
+
+		results, errs, err := query.QueryBatchEach[string](conn.Chain, payloads)
+		for _, index := range indices[start:end] {
+			key := fmt.Sprintf("%d", index)
+			switch {
+			case err != nil:
+				responses[index] = Response{Err: err}
+			case errs[key] != nil:
+				responses[index] = Response{Err: errs[key]}
+			case results[key] == nil:
+				responses[index] = Response{Data: "0x"}
+			default:
+				responses[index] = Response{Data: *results[key]}
+			}
+		}
+	}
+}
+
+func ethCallPayload(key string, to base.Address, data string, bn base.Blknum) query.BatchPayload {
+	return query.BatchPayload{
+		Key: key,
+		Payload: &query.Payload{
+			Method: "eth_call",
+			Params: query.Params{
+				map[string]any{
+					"to":   to.Hex(),
+					"data": data,
+				},
+				fmt.Sprintf("0x%x", bn),
+			},
+		},
+	}
+}
+
+// multicallDeployedAt returns the first block at which Multicall3 exists on the connection's
+// chain (or base.NOPOSN if it doesn't). The answer is looked up once per chain.
+func multicallDeployedAt(conn *rpc.Connection) base.Blknum {
+	if bn, ok := deployedMap.Load(conn.Chain); ok {
+		return bn.(base.Blknum)
+	}
+
+	bn, known := multicall3Deployed[conn.Chain]
+	if !known {
+		bn = base.NOPOSN
+		if latest := conn.GetLatestBlockNumber(); conn.IsContractAt(multicall3, latest) == nil {
+			// find the first block with code by bisection
+			lo, hi := base.Blknum(0), latest
+			for lo < hi {
+				mid := lo + (hi-lo)/2
+				if conn.IsContractAt(multicall3, mid) == nil {
+					hi = mid
+				} else {
+					lo = mid + 1
+				}
+			}
+			bn = lo
+		}
+	}
+
+	deployedMap.Store(conn.Chain, bn)
+	return bn
+}
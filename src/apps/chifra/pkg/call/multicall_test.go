@@ -0,0 +1,52 @@
+package call
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
+)
+
+func TestDecodeAggregate3(t *testing.T) {
+	revert := base.Hex2Bytes("08c379a0")
+	packed, err := aggregate3.Outputs.Pack([]aggregate3Result{
+		{Success: true, ReturnData: base.Hex2Bytes("00000000000000000000000000000000000000000000000000000000000003e8")},
+		{Success: false, ReturnData: revert},
+		{Success: true, ReturnData: []byte{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	returned := "0x" + base.Bytes2Hex(packed)
+
+	responses, err := decodeAggregate3(returned, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if responses[0].Err != nil || base.HexToWei(responses[0].Data).Uint64() != 1000 {
+		t.Errorf("expected first call to return 1000, got %v", responses[0])
+	}
+	if data, ok := query.RevertData(responses[1].Err); !ok || data != "0x08c379a0" {
+		t.Errorf("expected second call to carry its revert data, got %v", responses[1])
+	}
+	if responses[2].Err != nil || responses[2].Data != "0x" {
+		t.Errorf("expected third call to return empty data, got %v", responses[2])
+	}
+
+	if _, err := decodeAggregate3(returned, 2); err == nil {
+		t.Error("expected a mismatched number of results to fail")
+	}
+	if _, err := decodeAggregate3("0x1234", 1); err == nil {
+		t.Error("expected garbage to fail")
+	}
+}
+
+func TestAggregate3Encoding(t *testing.T) {
+	// aggregate3((address,bool,bytes)[])
+	if got := base.Bytes2Hex(aggregate3.ID); got != "82ad56cb" {
+		t.Errorf("unexpected aggregate3 selector %s", got)
+	}
+	if _, err := aggregate3.Inputs.Pack([]aggregate3Call{{Target: multicall3.Common(), AllowFailure: true, CallData: []byte{1, 2, 3, 4}}}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,262 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Heuristic names a way of grouping addresses
+type Heuristic string
+
+const (
+	// Funder groups addresses first funded by the same account
+	Funder Heuristic = "funder"
+	// Sweep groups deposit addresses that empty themselves into the same account
+	Sweep Heuristic = "sweep"
+	// Deployer groups contracts deployed directly by the same account
+	Deployer Heuristic = "deployer"
+	// Create2 groups contracts created by the same CREATE2 factory
+	Create2 Heuristic = "create2"
+)
+
+// AllHeuristics are the heuristics in the order clusters are built
+var AllHeuristics = []Heuristic{Funder, Sweep, Deployer, Create2}
+
+// Funding records the first value an address ever received
+type Funding struct {
+	Funder      base.Address
+	Funded      base.Address
+	BlockNumber base.Blknum
+}
+
+// Transfer records an address sending value to another address that left it (nearly) empty
+type Transfer struct {
+	From        base.Address
+	To          base.Address
+	BlockNumber base.Blknum
+}
+
+// Creation records the creation of a contract. Factory is zero if the contract was deployed
+// directly by a transaction.
+type Creation struct {
+	Contract    base.Address
+	Deployer    base.Address
+	Factory     base.Address
+	Create2     bool
+	BlockNumber base.Blknum
+}
+
+// Observations are the facts the heuristics work from
+type Observations struct {
+	Fundings  []Funding
+	Sweeps    []Transfer
+	Creations []Creation
+}
+
+// AddFunding records a funding unless the funded address already has one
+func (o *Observations) AddFunding(f Funding) {
+	for _, existing := range o.Fundings {
+		if existing.Funded == f.Funded {
+			return
+		}
+	}
+	o.Fundings = append(o.Fundings, f)
+}
+
+// AddCreation records a creation unless the contract was already recorded
+func (o *Observations) AddCreation(c Creation) {
+	for _, existing := range o.Creations {
+		if existing.Contract == c.Contract {
+			return
+		}
+	}
+	o.Creations = append(o.Creations, c)
+}
+
+// Find applies the heuristics to the observations and returns the clusters with at least
+// minConfidence, most confident first
+func Find(obs *Observations, heuristics []Heuristic, minConfidence float64) []types.Cluster {
+	ret := make([]types.Cluster, 0)
+	for _, h := range heuristics {
+		var found []types.Cluster
+		switch h {
+		case Funder:
+			found = byFunder(obs)
+		case Sweep:
+			found = bySweep(obs)
+		case Deployer:
+			found = byDeployer(obs)
+		case Create2:
+			found = byCreate2(obs)
+		}
+		for _, c := range found {
+			if c.Confidence >= minConfidence {
+				ret = append(ret, c)
+			}
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Confidence == ret[j].Confidence {
+			return ret[i].Id < ret[j].Id
+		}
+		return ret[i].Confidence > ret[j].Confidence
+	})
+	return ret
+}
+
+// byFunder clusters addresses that share a first funder. A funder that funded many of the
+// addresses is likely a public service (an exchange or a faucet), so confidence falls with
+// the number of addresses it funded.
+func byFunder(obs *Observations) []types.Cluster {
+	groups := newGroups()
+	for _, f := range obs.Fundings {
+		groups.add(f.Funder, f.Funded)
+	}
+
+	ret := make([]types.Cluster, 0)
+	for _, anchor := range groups.anchors() {
+		members := groups.members(anchor)
+		if len(members) < 2 {
+			continue
+		}
+		confidence := 0.8
+		if len(members) > 5 {
+			confidence = math.Max(0.05, 0.8*5/float64(len(members)))
+		}
+		ret = append(ret, newCluster(Funder, anchor, members, confidence,
+			fmt.Sprintf("%d addresses first funded by %s", len(members), anchor.Hex())))
+	}
+	return ret
+}
+
+// bySweep clusters deposit addresses that empty themselves into the same account along with
+// that account. Each sweep adds to the confidence.
+func bySweep(obs *Observations) []types.Cluster {
+	groups := newGroups()
+	count := make(map[base.Address]int)
+	for _, s := range obs.Sweeps {
+		groups.add(s.To, s.From)
+		count[s.To]++
+	}
+
+	ret := make([]types.Cluster, 0)
+	for _, anchor := range groups.anchors() {
+		deposits := groups.members(anchor)
+		members := append([]base.Address{anchor}, deposits...)
+		confidence := math.Min(0.95, 0.5+0.1*float64(count[anchor])+0.05*float64(len(deposits)-1))
+		ret = append(ret, newCluster(Sweep, anchor, members, confidence,
+			fmt.Sprintf("%d deposit addresses swept %d times into %s", len(deposits), count[anchor], anchor.Hex())))
+	}
+	return ret
+}
+
+// byDeployer clusters the contracts an account deployed directly along with the account
+func byDeployer(obs *Observations) []types.Cluster {
+	groups := newGroups()
+	for _, c := range obs.Creations {
+		if c.Factory.IsZero() && !c.Deployer.IsZero() {
+			groups.add(c.Deployer, c.Contract)
+		}
+	}
+
+	ret := make([]types.Cluster, 0)
+	for _, anchor := range groups.anchors() {
+		contracts := groups.members(anchor)
+		members := append([]base.Address{anchor}, contracts...)
+		ret = append(ret, newCluster(Deployer, anchor, members, 0.9,
+			fmt.Sprintf("%d contracts deployed by %s", len(contracts), anchor.Hex())))
+	}
+	return ret
+}
+
+// byCreate2 clusters the contracts a CREATE2 factory created. Public factories create
+// contracts for anyone, so confidence falls with the number of accounts that used the factory.
+func byCreate2(obs *Observations) []types.Cluster {
+	groups := newGroups()
+	senders := make(map[base.Address]map[base.Address]bool)
+	for _, c := range obs.Creations {
+		if !c.Create2 || c.Factory.IsZero() {
+			continue
+		}
+		groups.add(c.Factory, c.Contract)
+		if senders[c.Factory] == nil {
+			senders[c.Factory] = make(map[base.Address]bool)
+		}
+		senders[c.Factory][c.Deployer] = true
+	}
+
+	ret := make([]types.Cluster, 0)
+	for _, anchor := range groups.anchors() {
+		children := groups.members(anchor)
+		if len(children) < 2 {
+			continue
+		}
+		nSenders := len(senders[anchor])
+		confidence := math.Max(0.05, 0.9/float64(nSenders))
+		ret = append(ret, newCluster(Create2, anchor, children, confidence,
+			fmt.Sprintf("%d contracts created by CREATE2 factory %s for %d senders", len(children), anchor.Hex(), nSenders)))
+	}
+	return ret
+}
+
+// Id returns the identifier of the cluster of a heuristic around an anchor. It's also the
+// subtag written to the names database when a cluster is accepted.
+func Id(h Heuristic, anchor base.Address) string {
+	return string(h) + "-" + anchor.Hex()
+}
+
+func newCluster(h Heuristic, anchor base.Address, members []base.Address, confidence float64, reason string) types.Cluster {
+	return types.Cluster{
+		Id:         Id(h, anchor),
+		Heuristic:  string(h),
+		Anchor:     anchor,
+		Members:    members,
+		Confidence: math.Round(confidence*100) / 100,
+		Reason:     reason,
+	}
+}
+
+// groups collects distinct members by anchor in the order they were first seen
+type groups struct {
+	order []base.Address
+	seen  map[base.Address]map[base.Address]bool
+	list  map[base.Address][]base.Address
+}
+
+func newGroups() *groups {
+	return &groups{
+		seen: make(map[base.Address]map[base.Address]bool),
+		list: make(map[base.Address][]base.Address),
+	}
+}
+
+func (g *groups) add(anchor, member base.Address) {
+	if anchor == member {
+		return
+	}
+	if g.seen[anchor] == nil {
+		g.seen[anchor] = make(map[base.Address]bool)
+		g.order = append(g.order, anchor)
+	}
+	if !g.seen[anchor][member] {
+		g.seen[anchor][member] = true
+		g.list[anchor] = append(g.list[anchor], member)
+	}
+}
+
+func (g *groups) anchors() []base.Address {
+	return g.order
+}
+
+func (g *groups) members(anchor base.Address) []base.Address {
+	ret := append([]base.Address{}, g.list[anchor]...)
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Hex() < ret[j].Hex()
+	})
+	return ret
+}
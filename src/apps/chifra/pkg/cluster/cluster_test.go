@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func addr(n int) base.Address {
+	return base.HexToAddress(fmt.Sprintf("0x%040x", n))
+}
+
+func findOne(t *testing.T, clusters []types.Cluster, id string) types.Cluster {
+	t.Helper()
+	for _, c := range clusters {
+		if c.Id == id {
+			return c
+		}
+	}
+	t.Fatalf("cluster %s not found in %v", id, clusters)
+	return types.Cluster{}
+}
+
+func TestFunder(t *testing.T) {
+	obs := &Observations{}
+	obs.AddFunding(Funding{Funder: addr(1), Funded: addr(10)})
+	obs.AddFunding(Funding{Funder: addr(1), Funded: addr(11)})
+	obs.AddFunding(Funding{Funder: addr(2), Funded: addr(11)}) // not the first funding
+	obs.AddFunding(Funding{Funder: addr(2), Funded: addr(12)}) // only one address
+
+	// a busy funder (an exchange, say) funds many addresses
+	for i := 100; i < 120; i++ {
+		obs.AddFunding(Funding{Funder: addr(3), Funded: addr(i)})
+	}
+
+	clusters := Find(obs, []Heuristic{Funder}, 0)
+	if len(clusters) != 2 {
+		t.Fatalf("expected two clusters, got %d", len(clusters))
+	}
+	c := findOne(t, clusters, Id(Funder, addr(1)))
+	if len(c.Members) != 2 || c.Confidence != 0.8 {
+		t.Errorf("unexpected cluster %+v", c)
+	}
+	busy := findOne(t, clusters, Id(Funder, addr(3)))
+	if busy.Confidence != 0.2 {
+		t.Errorf("expected a busy funder to have a low confidence, got %f", busy.Confidence)
+	}
+	if clusters[0].Id != c.Id {
+		t.Error("expected the most confident cluster first")
+	}
+
+	if got := Find(obs, []Heuristic{Funder}, 0.5); len(got) != 1 {
+		t.Errorf("expected min confidence to drop the busy funder, got %d clusters", len(got))
+	}
+}
+
+func TestSweep(t *testing.T) {
+	obs := &Observations{
+		Sweeps: []Transfer{
+			{From: addr(10), To: addr(1)},
+			{From: addr(10), To: addr(1)},
+			{From: addr(11), To: addr(1)},
+		},
+	}
+	clusters := Find(obs, []Heuristic{Sweep}, 0)
+	if len(clusters) != 1 {
+		t.Fatalf("expected one cluster, got %d", len(clusters))
+	}
+	c := clusters[0]
+	if len(c.Members) != 3 || c.Members[0] != addr(1) {
+		t.Errorf("expected the destination and both deposit addresses, got %v", c.Members)
+	}
+	if c.Confidence != 0.85 {
+		t.Errorf("unexpected confidence %f", c.Confidence)
+	}
+}
+
+func TestCreations(t *testing.T) {
+	obs := &Observations{}
+	obs.AddCreation(Creation{Contract: addr(20), Deployer: addr(1)})
+	obs.AddCreation(Creation{Contract: addr(21), Deployer: addr(1)})
+	obs.AddCreation(Creation{Contract: addr(21), Deployer: addr(2)}) // duplicate
+	obs.AddCreation(Creation{Contract: addr(30), Deployer: addr(1), Factory: addr(5), Create2: true})
+	obs.AddCreation(Creation{Contract: addr(31), Deployer: addr(1), Factory: addr(5), Create2: true})
+	obs.AddCreation(Creation{Contract: addr(40), Deployer: addr(1), Factory: addr(6), Create2: true})
+	obs.AddCreation(Creation{Contract: addr(41), Deployer: addr(2), Factory: addr(6), Create2: true})
+	obs.AddCreation(Creation{Contract: addr(42), Deployer: addr(3), Factory: addr(6), Create2: true})
+	obs.AddCreation(Creation{Contract: addr(50), Deployer: addr(1), Factory: addr(7)}) // plain CREATE
+
+	clusters := Find(obs, AllHeuristics, 0)
+	if len(clusters) != 3 {
+		t.Fatalf("expected three clusters, got %v", clusters)
+	}
+
+	deployed := findOne(t, clusters, Id(Deployer, addr(1)))
+	if len(deployed.Members) != 3 || deployed.Confidence != 0.9 {
+		t.Errorf("unexpected deployer cluster %+v", deployed)
+	}
+	private := findOne(t, clusters, Id(Create2, addr(5)))
+	if len(private.Members) != 2 || private.Confidence != 0.9 {
+		t.Errorf("unexpected create2 cluster %+v", private)
+	}
+	public := findOne(t, clusters, Id(Create2, addr(6)))
+	if len(public.Members) != 3 || public.Confidence != 0.3 {
+		t.Errorf("expected a factory used by three senders to have a low confidence, got %+v", public)
+	}
+}
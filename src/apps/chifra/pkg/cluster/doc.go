@@ -0,0 +1,3 @@
+// Package cluster groups addresses into entities using patterns in their transactions. The
+// caller gathers Observations from the chain and Find applies the heuristics to them.
+package cluster
@@ -0,0 +1,13 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package config
+
+import "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+
+// GetCalendar returns the named calendar from the configuration file, if there is one
+func GetCalendar(name string) (configtypes.CalendarGroup, bool) {
+	cal, ok := GetRootConfig().Calendars[name]
+	return cal, ok
+}
@@ -23,5 +23,6 @@ func GetChains() []configtypes.ChainGroup {
 
 // IsChainConfigured returns true if the chain is configured in the config file.
 func IsChainConfigured(needle string) bool {
-	return GetRootConfig().Chains != nil && GetRootConfig().Chains[needle] != configtypes.ChainGroup{}
+	_, ok := GetRootConfig().Chains[needle]
+	return ok
 }
@@ -121,10 +121,16 @@ func GetRootConfig() *configtypes.Config {
 		ch.IpfsGateway = strings.Replace(ch.IpfsGateway, "[{CHAIN}]", "ipfs", -1)
 		ch.LocalExplorer = clean(ch.LocalExplorer)
 		ch.RemoteExplorer = clean(ch.RemoteExplorer)
-		ch.RpcProvider = strings.Trim(clean(ch.RpcProvider), "/") // Infura, for example, doesn't like the trailing slash
+		if len(ch.RpcProvider) == 0 && len(ch.RpcProviders) > 0 {
+			ch.RpcProvider = ch.RpcProviders[0].Url
+		}
+		ch.RpcProvider = cleanRpcProvider(ch.RpcProvider)
 		if err := validateRpcEndpoint(ch.Chain, ch.RpcProvider); err != nil {
 			logger.Fatal(err)
 		}
+		for i := range ch.RpcProviders {
+			ch.RpcProviders[i].Url = cleanRpcProvider(ch.RpcProviders[i].Url)
+		}
 		ch.IpfsGateway = clean(ch.IpfsGateway)
 		if ch.Scrape.AppsPerChunk == 0 {
 			settings := configtypes.ScrapeSettings{
@@ -208,11 +214,17 @@ func validateRpcEndpoint(chain, provider string) error {
 		return usage.Usage(rpcWarning, chain, provider, problem)
 	}
 
-	if !strings.HasPrefix(provider, "http") {
-		problem := `Invalid rpcProvider found (must be a url).`
+	kind, _ := RpcTransport(provider)
+	if kind == "http" && !strings.HasPrefix(provider, "http") {
+		problem := `Invalid rpcProvider found (must be a url or the path of an IPC socket).`
 		return usage.Usage(rpcWarning, chain, provider, problem)
 	}
 
+	if kind != "http" {
+		// the check below is made over http. Websocket and IPC providers are checked when first used.
+		return nil
+	}
+
 	if chain == "mainnet" {
 		// TODO: Eventually this will be parameterized, for example, when we start publishing to Optimism
 		deployed := uint64(14957097) // block where the unchained index was deployed to mainnet
@@ -231,6 +243,10 @@ func checkUnchainedProvider(chain string, deployed uint64) error {
 		logger.Info("Skipping rpcProvider check")
 		return nil
 	}
+	if mode, _ := RpcFixtures(); mode == "replay" {
+		// there may be no node at all when replaying recorded responses
+		return nil
+	}
 	url := trueBlocksConfig.Chains[chain].RpcProvider
 	str := `{ "jsonrpc": "2.0", "method": "eth_getBlockByNumber", "params": [ "{0}", true ], "id": 1 }`
 	payLoad := []byte(strings.Replace(str, "{0}", fmt.Sprintf("0x%x", deployed), -1))
@@ -43,3 +43,29 @@ func Test_ReadTrueBlocks(t *testing.T) {
 		t.Error("DefaultChain is empty.")
 	}
 }
+
+func Test_RpcTransport(t *testing.T) {
+	tests := []struct {
+		provider string
+		cleaned  string
+		kind     string
+		addr     string
+	}{
+		{"localhost:8545/", "https://localhost:8545", "http", "https://localhost:8545"},
+		{"http://localhost:8545", "http://localhost:8545", "http", "http://localhost:8545"},
+		{"ws://localhost:8546/", "ws://localhost:8546", "ws", "ws://localhost:8546"},
+		{"wss://example.com/ws", "wss://example.com/ws", "ws", "wss://example.com/ws"},
+		{"ipc:///data/reth.ipc", "ipc:///data/reth.ipc", "ipc", "/data/reth.ipc"},
+		{"/data/geth/geth.ipc", "/data/geth/geth.ipc", "ipc", "/data/geth/geth.ipc"},
+		{"geth.ipc", "geth.ipc", "ipc", "geth.ipc"},
+	}
+	for _, test := range tests {
+		cleaned := cleanRpcProvider(test.provider)
+		if cleaned != test.cleaned {
+			t.Error("Mismatched cleaned provider for", test.provider, ":", cleaned)
+		}
+		if kind, addr := RpcTransport(cleaned); kind != test.kind || addr != test.addr {
+			t.Error("Mismatched transport for", cleaned, ":", kind, addr)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+)
+
+// GetDaemon returns the API server's settings
+func GetDaemon() configtypes.DaemonGroup {
+	return GetRootConfig().Daemon
+}
+
+// PathToAuditLog returns the file to which the API server logs mutating requests
+func PathToAuditLog() string {
+	if path := GetDaemon().AuditLog; len(path) > 0 {
+		return path
+	}
+	return filepath.Join(PathToRootConfig(), "audit.log")
+}
+
+// JobRetention returns how long the API server keeps the results of finished jobs
+func JobRetention() time.Duration {
+	if secs := GetDaemon().JobRetention; secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return time.Hour
+}
+
+// MaxJobs returns how many jobs one API key may have running at once
+func MaxJobs() int {
+	if n := GetDaemon().MaxJobs; n > 0 {
+		return int(n)
+	}
+	return 4
+}
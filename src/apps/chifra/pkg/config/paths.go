@@ -42,6 +42,11 @@ func PathToTimestamps(chain string) string {
 	return filepath.Join(PathToIndex(chain), "ts.bin")
 }
 
+// PathToL1Origins returns the path to the L1 origins database (which parallels the timestamps database) per chain
+func PathToL1Origins(chain string) string {
+	return filepath.Join(PathToIndex(chain), "ts_l1.bin")
+}
+
 // PathToIndex returns the one and only indexPath
 func PathToIndex(chain string) string {
 	// We need the index path from either XDG which dominates or the config file
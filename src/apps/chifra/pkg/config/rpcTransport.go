@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// RpcTransport returns how to reach an rpcProvider ("http", "ws" or "ipc") and the address to
+// dial. An rpcProvider may be an http(s):// or ws(s):// url, an ipc:// url, or the path of the
+// node's IPC socket.
+func RpcTransport(provider string) (string, string) {
+	switch {
+	case strings.HasPrefix(provider, "ipc://"):
+		return "ipc", strings.TrimPrefix(provider, "ipc://")
+	case strings.HasPrefix(provider, "ws://"), strings.HasPrefix(provider, "wss://"):
+		return "ws", provider
+	case isSocketPath(provider):
+		return "ipc", provider
+	default:
+		return "http", provider
+	}
+}
+
+func isSocketPath(provider string) bool {
+	return strings.HasPrefix(provider, "/") || strings.HasPrefix(provider, "./") || strings.HasSuffix(provider, ".ipc")
+}
+
+// cleanRpcProvider adds the scheme to an rpcProvider given without one and removes any trailing
+// slash (Infura, for example, doesn't like the trailing slash). Socket paths are left alone.
+func cleanRpcProvider(provider string) string {
+	kind, _ := RpcTransport(provider)
+	if kind == "ipc" {
+		return provider
+	}
+	if kind != "ws" && !strings.HasPrefix(provider, "http") {
+		provider = "https://" + provider
+	}
+	return strings.Trim(provider+"/", "/")
+}
+
+// RpcFixtures returns "record" and the fixture folder if chifra is recording its RPC requests
+// and the node's responses (TB_RPC_RECORD=<folder>), "replay" and the folder if it's answering
+// its RPC requests from those recordings without a node (TB_RPC_REPLAY=<folder>), or two empty
+// strings otherwise.
+func RpcFixtures() (string, string) {
+	if folder := os.Getenv("TB_RPC_REPLAY"); len(folder) > 0 {
+		return "replay", folder
+	}
+	if folder := os.Getenv("TB_RPC_RECORD"); len(folder) > 0 {
+		return "record", folder
+	}
+	return "", ""
+}
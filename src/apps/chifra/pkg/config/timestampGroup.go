@@ -0,0 +1,12 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package config
+
+import "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+
+// GetTimestamps returns the timestamp database settings per chain
+func GetTimestamps(chain string) configtypes.TimestampSettings {
+	return GetRootConfig().Chains[chain].Timestamps
+}
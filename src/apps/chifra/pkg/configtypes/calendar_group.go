@@ -0,0 +1,15 @@
+package configtypes
+
+import "encoding/json"
+
+type CalendarGroup struct {
+	TimeZone        string `json:"timeZone,omitempty" toml:"timeZone" comment:"An IANA time zone such as America/New_York (defaults to UTC)"`
+	DayEnds         string `json:"dayEnds,omitempty" toml:"dayEnds,omitempty" comment:"The local time (HH:MM) at which each day ends and the next begins (defaults to midnight)"`
+	FiscalYearStart int    `json:"fiscalYearStart,omitempty" toml:"fiscalYearStart,omitempty" comment:"The month (1-12) in which fiscal years and quarters start (defaults to January)"`
+	Snap            string `json:"snap,omitempty" toml:"snap,omitempty" comment:"Set to 'after' to report the first block after each period starts (defaults to the block in effect)"`
+}
+
+func (s *CalendarGroup) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
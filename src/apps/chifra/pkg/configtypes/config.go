@@ -11,12 +11,14 @@ import (
 )
 
 type Config struct {
-	Version   VersionGroup          `json:"version" toml:"version"`
-	Settings  SettingsGroup         `json:"settings" toml:"settings"`
-	Keys      map[string]KeyGroup   `json:"keys" toml:"keys"`
-	Pinning   PinningGroup          `json:"pinning" toml:"pinning"`
-	Unchained UnchainedGroup        `json:"unchained" toml:"unchained,omitempty" comment:"Do not edit these values unless instructed to do so."`
-	Chains    map[string]ChainGroup `json:"chains" toml:"chains"`
+	Version   VersionGroup             `json:"version" toml:"version"`
+	Settings  SettingsGroup            `json:"settings" toml:"settings"`
+	Keys      map[string]KeyGroup      `json:"keys" toml:"keys"`
+	Pinning   PinningGroup             `json:"pinning" toml:"pinning"`
+	Unchained UnchainedGroup           `json:"unchained" toml:"unchained,omitempty" comment:"Do not edit these values unless instructed to do so."`
+	Chains    map[string]ChainGroup    `json:"chains" toml:"chains"`
+	Calendars map[string]CalendarGroup `json:"calendars,omitempty" toml:"calendars,omitempty"`
+	Daemon    DaemonGroup              `json:"daemon,omitempty" toml:"daemon,omitempty"`
 }
 
 func (s *Config) String() string {
@@ -0,0 +1,22 @@
+package configtypes
+
+import "encoding/json"
+
+type DaemonGroup struct {
+	ApiKeys      []ApiKeyGroup `json:"apiKeys,omitempty" toml:"apiKeys,omitempty" comment:"If any keys are present, every request to the API server must carry one"`
+	CorsOrigins  []string      `json:"corsOrigins,omitempty" toml:"corsOrigins,omitempty" comment:"The origins allowed to call the API server from a browser (all if empty)"`
+	AuditLog     string        `json:"auditLog,omitempty" toml:"auditLog,omitempty" comment:"The file to which mutating requests are logged"`
+	JobRetention uint64        `json:"jobRetention,omitempty" toml:"jobRetention,omitempty" comment:"The number of seconds the results of finished jobs are kept (default 3600)"`
+	MaxJobs      uint64        `json:"maxJobs,omitempty" toml:"maxJobs,omitempty" comment:"The most jobs one API key may have running at once (default 4)"`
+}
+
+type ApiKeyGroup struct {
+	Name   string   `json:"name" toml:"name"`
+	Key    string   `json:"key" toml:"key"`
+	Scopes []string `json:"scopes" toml:"scopes"`
+}
+
+func (s *DaemonGroup) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
@@ -0,0 +1,14 @@
+package configtypes
+
+import "encoding/json"
+
+type RpcEndpointGroup struct {
+	Url       string  `json:"url" toml:"url"`
+	Weight    uint64  `json:"weight,omitempty" toml:"weight,omitempty" comment:"The relative share of requests this endpoint should serve (defaults to 1)"`
+	RateLimit float64 `json:"rateLimit,omitempty" toml:"rateLimit,omitempty" comment:"The most requests per second to send to this endpoint (zero for no limit)"`
+}
+
+func (s *RpcEndpointGroup) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
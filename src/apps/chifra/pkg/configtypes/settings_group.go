@@ -7,6 +7,9 @@ type SettingsGroup struct {
 	IndexPath      string      `json:"indexPath" toml:"indexPath" comment:"The location of the per chain unchained indexes"`
 	DefaultChain   string      `json:"defaultChain" toml:"defaultChain" comment:"The default chain to use if none is provided"`
 	DefaultGateway string      `json:"defaultGateway" toml:"defaultGateway,omitempty"`
+	NamesIndex     string      `json:"namesIndex,omitempty" toml:"namesIndex,omitempty" comment:"Set to 'memory' to rebuild the names search index on each run instead of keeping it in the cache"`
+	MonitorFormat  string      `json:"monitorFormat,omitempty" toml:"monitorFormat,omitempty" comment:"Set to 'v2' to create new monitors in the compressed format"`
+	NamesAuthors   []string    `json:"namesAuthors,omitempty" toml:"namesAuthors,omitempty" comment:"The addresses of the teammates whose bundles of names may be imported"`
 	Notify         NotifyGroup `json:"notify" toml:"notify"`
 }
 
@@ -0,0 +1,14 @@
+package configtypes
+
+import "encoding/json"
+
+type TimestampSettings struct {
+	AllowDuplicates bool   `json:"allowDuplicates,omitempty" toml:"allowDuplicates,omitempty" comment:"Set to true for chains (usually L2s) on which consecutive blocks may share a timestamp"`
+	Resolve         string `json:"resolve,omitempty" toml:"resolve,omitempty" comment:"Which of the blocks sharing a timestamp that timestamp resolves to: 'first' or 'last' (the default)"`
+	L1Origin        bool   `json:"l1Origin,omitempty" toml:"l1Origin,omitempty" comment:"Set to true to store the L1 block each block derives from alongside its timestamp"`
+}
+
+func (s *TimestampSettings) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
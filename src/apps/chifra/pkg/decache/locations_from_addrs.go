@@ -26,3 +26,24 @@ func LocationsFromAddressAndEncodings(conn *rpc.Connection, address base.Address
 	}
 	return locations, nil
 }
+
+func LocationsFromAddressAndSlots(conn *rpc.Connection, address base.Address, slots []base.Hash, ids []identifiers.Identifier) ([]cache.Locator, error) {
+	locations := make([]cache.Locator, 0)
+	for _, br := range ids {
+		blockNums, err := br.ResolveBlocks(conn.Chain)
+		if err != nil {
+			return nil, err
+		}
+		for _, bn := range blockNums {
+			for _, slot := range slots {
+				// walk.Cache_State
+				locations = append(locations, &types.Slot{
+					BlockNumber: bn,
+					Address:     address,
+					Slot:        slot,
+				})
+			}
+		}
+	}
+	return locations, nil
+}
@@ -57,6 +57,10 @@ func (f *AppearanceFilter) SetSort(sortBy AppearanceSort) {
 	f.sortBy = sortBy
 }
 
+func (f *AppearanceFilter) SortOrder() AppearanceSort {
+	return f.sortBy
+}
+
 func (f *AppearanceFilter) Reset() {
 	f.currentBn = uint32(0)
 	f.currentTs = int64(0)
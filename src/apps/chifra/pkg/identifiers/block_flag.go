@@ -0,0 +1,99 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package identifiers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// flagValue is the part of a command line flag's value that a BlockFlag wraps
+type flagValue interface {
+	Set(string) error
+	String() string
+	Type() string
+}
+
+// BlockFlag wraps the value of a block number flag so the flag also accepts any single block
+// identifier (a date, a timestamp, a special block, or any of those with a period such as
+// 2023-06-15:monthend@ny). Block numbers are set immediately. Other identifiers wait until the
+// chain is known and ResolveBlockFlags is called.
+type BlockFlag struct {
+	flagValue
+	target *base.Blknum
+}
+
+// pendingBlockFlags holds the identifiers given to block flags that have not yet been resolved
+var pendingBlockFlags = map[*base.Blknum]string{}
+
+// NewBlockFlag returns a flag value that sets the target block from any single block identifier
+func NewBlockFlag(value flagValue, target *base.Blknum) *BlockFlag {
+	return &BlockFlag{flagValue: value, target: target}
+}
+
+// Set sets the block if the value is a block number and otherwise holds the identifier
+func (b *BlockFlag) Set(value string) error {
+	delete(pendingBlockFlags, b.target)
+	if _, err := strconv.ParseUint(value, 0, 64); err == nil {
+		return b.flagValue.Set(value)
+	}
+
+	if _, err := NewBlockRange(value); err != nil {
+		return err
+	}
+	pendingBlockFlags[b.target] = value
+	return nil
+}
+
+// String returns the identifier if it has not been resolved and otherwise the block
+func (b *BlockFlag) String() string {
+	if value, ok := pendingBlockFlags[b.target]; ok {
+		return value
+	}
+	return b.flagValue.String()
+}
+
+// ResolveBlockFlags resolves the identifiers held for the given targets on the chain
+func ResolveBlockFlags(chain string, targets ...*base.Blknum) error {
+	for _, target := range targets {
+		if value, ok := pendingBlockFlags[target]; ok {
+			delete(pendingBlockFlags, target)
+			bn, err := ParseBlockId(chain, value)
+			if err != nil {
+				return err
+			}
+			*target = bn
+		}
+	}
+	return nil
+}
+
+// ParseBlockId returns the block number of a single block identifier. If the identifier has a
+// period, it's the first block reported for that period (for example, the last block of the
+// month for 2023-06-15:monthend@ny).
+func ParseBlockId(chain, value string) (base.Blknum, error) {
+	if bn, err := strconv.ParseUint(value, 0, 64); err == nil {
+		return base.Blknum(bn), nil
+	}
+
+	id, err := NewBlockRange(value)
+	if err != nil {
+		return 0, err
+	}
+	if id.EndType != NotDefined {
+		return 0, fmt.Errorf("a single block is required, not a range: %s", value)
+	}
+
+	blocks, err := id.ResolveBlocks(chain)
+	if err != nil {
+		return 0, err
+	}
+	if len(blocks) == 0 {
+		return 0, fmt.Errorf("the block identifier %s resolves to no block", value)
+	}
+	return blocks[0], nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package identifiers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // so named time zones work on machines without a zoneinfo database
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+)
+
+// A Calendar decides where the boundaries of periods fall. Days start at midnight (or at the
+// end of the previous day, if the calendar's days end at some other time) in the calendar's time
+// zone. Weeks start on Sunday. Quarters and fiscal years start in the calendar's fiscal month.
+//
+// A calendar is named in a modifier after an @. It may be one of the built-in calendars (utc,
+// ny, or nyclose), a calendar from the [calendars] section of the configuration file, or an IANA
+// time zone such as America/New_York.
+type Calendar struct {
+	Name        string
+	Location    *time.Location
+	DayEnds     time.Duration
+	FiscalStart time.Month
+	SnapAfter   bool
+}
+
+var builtinCalendars = map[string]configtypes.CalendarGroup{
+	"utc":     {TimeZone: "UTC"},
+	"ny":      {TimeZone: "America/New_York"},
+	"nyclose": {TimeZone: "America/New_York", DayEnds: "16:00", Snap: "after"},
+}
+
+// LookupCalendar returns the named calendar. An empty name is the UTC calendar.
+func LookupCalendar(name string) (*Calendar, error) {
+	if len(name) == 0 {
+		name = "utc"
+	}
+
+	group, ok := config.GetCalendar(name)
+	if !ok {
+		group, ok = builtinCalendars[name]
+	}
+	if !ok {
+		if !strings.Contains(name, "/") && name != "UTC" {
+			return nil, fmt.Errorf("unknown calendar: %s", name)
+		}
+		group = configtypes.CalendarGroup{TimeZone: name}
+	}
+
+	return newCalendar(name, group)
+}
+
+func newCalendar(name string, group configtypes.CalendarGroup) (*Calendar, error) {
+	cal := &Calendar{Name: name, Location: time.UTC, FiscalStart: time.January}
+
+	if len(group.TimeZone) > 0 {
+		loc, err := time.LoadLocation(group.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s has an invalid time zone: %w", name, err)
+		}
+		cal.Location = loc
+	}
+
+	if len(group.DayEnds) > 0 {
+		t, err := time.Parse("15:04", group.DayEnds)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s has an invalid dayEnds (expected HH:MM): %s", name, group.DayEnds)
+		}
+		cal.DayEnds = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	if group.FiscalYearStart != 0 {
+		if group.FiscalYearStart < 1 || group.FiscalYearStart > 12 {
+			return nil, fmt.Errorf("calendar %s has an invalid fiscalYearStart (expected 1-12): %d", name, group.FiscalYearStart)
+		}
+		cal.FiscalStart = time.Month(group.FiscalYearStart)
+	}
+
+	switch group.Snap {
+	case "", "before":
+	case "after":
+		cal.SnapAfter = true
+	default:
+		return nil, fmt.Errorf("calendar %s has an invalid snap (expected before or after): %s", name, group.Snap)
+	}
+
+	return cal, nil
+}
+
+// IsPeriodEnd returns true if the period reports the last block of each period rather than the
+// block at its start
+func IsPeriodEnd(period string) bool {
+	return strings.HasSuffix(period, "end")
+}
+
+// shift is how far ahead of the wall clock the calendar's days run. A calendar whose days end at
+// 16:00 is eight hours ahead (the day after starts at 16:00).
+func (c *Calendar) shift() time.Duration {
+	if c.DayEnds == 0 {
+		return 0
+	}
+	return 24*time.Hour - c.DayEnds
+}
+
+// dayStart returns the time at which the calendar's day y-m-d starts
+func (c *Calendar) dayStart(y int, m time.Month, d int) time.Time {
+	if c.DayEnds == 0 {
+		return time.Date(y, m, d, 0, 0, 0, 0, c.Location)
+	}
+	end := int(c.DayEnds / time.Minute)
+	return time.Date(y, m, d-1, end/60, end%60, 0, 0, c.Location)
+}
+
+// fiscalMonth returns the first month of the quarter (months == 3) or year (months == 12)
+// containing the month, counting from the calendar's fiscal start
+func (c *Calendar) fiscalMonth(y int, m time.Month, months int) (int, time.Month) {
+	offset := (int(m) - int(c.FiscalStart) + 12) % 12
+	first := int(m) - offset%months
+	if first < 1 {
+		return y - 1, time.Month(first + 12)
+	}
+	return y, time.Month(first)
+}
+
+// Floor returns the start of the period (or sub-day step) containing t
+func (c *Calendar) Floor(t time.Time, period string, step uint, unit string) time.Time {
+	l := t.In(c.Location).Add(c.shift())
+	y, m, d := l.Date()
+
+	if len(unit) > 0 {
+		size := stepSize(step, unit)
+		start := c.dayStart(y, m, d)
+		return start.Add(t.Sub(start) / size * size)
+	}
+
+	switch strings.TrimSuffix(period, "end") {
+	case "hourly":
+		return time.Date(y, m, d, l.Hour(), 0, 0, 0, c.Location).Add(-c.shift())
+	case "daily":
+		return c.dayStart(y, m, d)
+	case "weekly":
+		return c.dayStart(y, m, d-int(l.Weekday()))
+	case "monthly", "month":
+		return c.dayStart(y, m, 1)
+	case "quarterly", "quarter":
+		y, m = c.fiscalMonth(y, m, 3)
+		return c.dayStart(y, m, 1)
+	case "annually", "year":
+		return c.dayStart(y, time.January, 1)
+	case "fiscal":
+		y, m = c.fiscalMonth(y, m, 12)
+		return c.dayStart(y, m, 1)
+	}
+	return t
+}
+
+// Next returns the start of the period (or sub-day step) following the one containing t
+func (c *Calendar) Next(t time.Time, period string, step uint, unit string) time.Time {
+	start := c.Floor(t, period, step, unit)
+	l := start.In(c.Location).Add(c.shift())
+	y, m, d := l.Date()
+
+	if len(unit) > 0 {
+		// steps start over each day
+		next, tomorrow := start.Add(stepSize(step, unit)), c.dayStart(y, m, d+1)
+		if next.After(tomorrow) {
+			return tomorrow
+		}
+		return next
+	}
+
+	switch strings.TrimSuffix(period, "end") {
+	case "hourly":
+		return start.Add(time.Hour)
+	case "daily":
+		return c.dayStart(y, m, d+1)
+	case "weekly":
+		return c.dayStart(y, m, d+7)
+	case "monthly", "month":
+		return c.dayStart(y, m+1, 1)
+	case "quarterly", "quarter":
+		return c.dayStart(y, m+3, 1)
+	case "annually", "year", "fiscal":
+		return c.dayStart(y+1, m, 1)
+	}
+	return t
+}
+
+func stepSize(step uint, unit string) time.Duration {
+	if step == 0 {
+		step = 1
+	}
+	if unit == "h" {
+		return time.Duration(step) * time.Hour
+	}
+	return time.Duration(step) * time.Minute
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package identifiers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+)
+
+func mustCalendar(t *testing.T, name string) *Calendar {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	cal, err := LookupCalendar(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cal
+}
+
+func TestCalendarMonthEndNewYork(t *testing.T) {
+	cal := mustCalendar(t, "ny")
+	ny := cal.Location
+
+	// 2023-01-31 23:30 in New York is already February in UTC
+	at := time.Date(2023, 1, 31, 23, 30, 0, 0, ny)
+	if got := cal.Floor(at, "monthend", 0, ""); !got.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, ny)) {
+		t.Error("Mismatched start of month:", got)
+	}
+	if got := cal.Next(at, "monthend", 0, ""); !got.Equal(time.Date(2023, 2, 1, 0, 0, 0, 0, ny)) {
+		t.Error("Mismatched start of next month:", got)
+	}
+	if got := mustCalendar(t, "").Floor(at, "monthly", 0, ""); got.Month() != time.February {
+		t.Error("Expected February in UTC, got", got)
+	}
+}
+
+func TestCalendarNewYorkClose(t *testing.T) {
+	cal := mustCalendar(t, "nyclose")
+	ny := cal.Location
+	if !cal.SnapAfter {
+		t.Error("Expected nyclose to snap after")
+	}
+
+	before := time.Date(2023, 3, 14, 15, 59, 0, 0, ny)
+	if got := cal.Floor(before, "daily", 0, ""); !got.Equal(time.Date(2023, 3, 13, 16, 0, 0, 0, ny)) {
+		t.Error("Mismatched day start before the close:", got)
+	}
+	if got := cal.Next(before, "daily", 0, ""); !got.Equal(time.Date(2023, 3, 14, 16, 0, 0, 0, ny)) {
+		t.Error("Mismatched next day start before the close:", got)
+	}
+
+	after := time.Date(2023, 3, 14, 16, 0, 0, 0, ny)
+	if got := cal.Floor(after, "daily", 0, ""); !got.Equal(after) {
+		t.Error("Mismatched day start at the close:", got)
+	}
+
+	// The close stays at 16:00 local time across the change to daylight time (2023-03-12)
+	if got := cal.Next(time.Date(2023, 3, 11, 17, 0, 0, 0, ny), "daily", 0, ""); !got.Equal(time.Date(2023, 3, 12, 16, 0, 0, 0, ny)) {
+		t.Error("Mismatched close across daylight time:", got)
+	}
+}
+
+func TestCalendarFiscal(t *testing.T) {
+	cal, err := newCalendar("fy", configtypes.CalendarGroup{TimeZone: "UTC", FiscalYearStart: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2023, 2, 10, 0, 0, 0, 0, time.UTC)
+	if got := cal.Floor(at, "fiscal", 0, ""); !got.Equal(time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched fiscal year start:", got)
+	}
+	if got := cal.Next(at, "fiscalend", 0, ""); !got.Equal(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched next fiscal year start:", got)
+	}
+	if got := cal.Floor(at, "quarterend", 0, ""); !got.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched fiscal quarter start:", got)
+	}
+
+	cal.FiscalStart = time.February
+	if got := cal.Floor(at, "quarterly", 0, ""); !got.Equal(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched quarter start:", got)
+	}
+	if got := cal.Next(at, "quarterly", 0, ""); !got.Equal(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched next quarter start:", got)
+	}
+	if got := cal.Floor(at, "annually", 0, ""); got.Month() != time.January {
+		t.Error("Expected calendar years to start in January, got", got)
+	}
+}
+
+func TestCalendarSubDaySteps(t *testing.T) {
+	cal := mustCalendar(t, "")
+
+	at := time.Date(2023, 5, 1, 10, 37, 12, 0, time.UTC)
+	if got := cal.Floor(at, "", 15, "m"); !got.Equal(time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)) {
+		t.Error("Mismatched 15m start:", got)
+	}
+	if got := cal.Next(at, "", 15, "m"); !got.Equal(time.Date(2023, 5, 1, 10, 45, 0, 0, time.UTC)) {
+		t.Error("Mismatched next 15m start:", got)
+	}
+
+	// steps that don't divide the day start over at midnight
+	late := time.Date(2023, 5, 1, 22, 0, 0, 0, time.UTC)
+	if got := cal.Floor(late, "", 7, "h"); !got.Equal(time.Date(2023, 5, 1, 21, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched 7h start:", got)
+	}
+	if got := cal.Next(late, "", 7, "h"); !got.Equal(time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Mismatched next 7h start:", got)
+	}
+}
+
+func TestCalendarLookup(t *testing.T) {
+	if cal := mustCalendar(t, "Europe/Berlin"); cal.Location.String() != "Europe/Berlin" {
+		t.Error("Mismatched location:", cal.Location)
+	}
+
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	for _, name := range []string{"bogus", "Not/AZone"} {
+		if _, err := LookupCalendar(name); err == nil {
+			t.Error("Passes for unknown calendar", name)
+		}
+	}
+
+	if _, err := newCalendar("bad", configtypes.CalendarGroup{DayEnds: "25:00"}); err == nil {
+		t.Error("Passes for an invalid dayEnds")
+	}
+
+	if _, err := NewBlockRange("10-1000:next@ny"); err == nil {
+		t.Error("Passes for a calendar without a period")
+	}
+
+	if _, err := NewBlockRange("10-1000:daily@bogus"); err == nil {
+		t.Error("Passes for an unknown calendar")
+	}
+}
+
+func TestCountPeriods(t *testing.T) {
+	cal := mustCalendar(t, "")
+	from := time.Date(2022, 12, 30, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC)
+
+	for _, m := range []Modifier{
+		{Period: "hourly"},
+		{Period: "daily"},
+		{Period: "weekly"},
+		{Period: "monthend"},
+		{Period: "quarterly"},
+		{Period: "annually"},
+		{Step: 7, Unit: "h"},
+		{Step: 15, Unit: "m"},
+	} {
+		actual := uint64(0)
+		for start := cal.Floor(from, m.Period, m.Step, m.Unit); start.Before(to); start = cal.Next(start, m.Period, m.Step, m.Unit) {
+			actual++
+		}
+		if got := countPeriods(to.Sub(from), &m); got < actual || got > actual+actual/20+2 {
+			t.Errorf("%+v: expected about %d periods, got %d", m, actual, got)
+		}
+	}
+}
@@ -50,6 +50,16 @@ package identifiers
 // 2021-10-03T10:30:59:100
 // 2021-10-03T10:30:59-1000:100
 
+// A period may be followed by a calendar (see ./calendar.go), which sets the time zone, the time
+// of day at which days end, and the month in which fiscal years start. Sub-day steps are given in
+// minutes or hours:
+//
+// 2023-01-01-2024-01-01:monthend@ny
+// 2023-01-01-2024-01-01:quarterend@America/Chicago
+// 2023-01-01-2023-02-01:daily@nyclose
+// 2023-01-01-2023-01-02:15m
+// 2023-01-01-2023-01-08:4h@ny
+
 import (
 	"encoding/json"
 
@@ -60,12 +70,14 @@ import (
 // Define "tokens" for our lexer
 var rangeLexer = lexer.MustSimple([]lexer.SimpleRule{
 	{Name: `Date`, Pattern: `\d{4}-\d{2}-\d{2}(T[\d]{2}(:[\d]{2})?(:[\d]{2})?(UTC)?)?`},
+	{Name: `Zone`, Pattern: `[A-Z][A-Za-z_]*(/[A-Za-z_]+)*`},
 	{Name: `Special`, Pattern: `[a-z_]+[0-9]*`},
 	{Name: `Hash`, Pattern: `0x[a-f0-9]{64}`},
 	{Name: `Hex`, Pattern: `0x[a-f0-9]+`},
 	{Name: `Unsigned`, Pattern: `^[0-9]+`},
 	{Name: `PointSeparator`, Pattern: `-`},
 	{Name: `ModifierSeparator`, Pattern: `:`},
+	{Name: `CalendarSeparator`, Pattern: `@`},
 })
 
 // A Point carries information about when a range starts or ends. It can be
@@ -86,10 +98,13 @@ func (p Point) String() string {
 // Modifier changes the meaning of the given range. For example, if step of
 // 10 is provided, the range does not mean "each block from start to end"
 // anymore, but instead "every 10th block from start to end". Similarly
-// a period can be provided to get only blocks based on frequency (e.g. weekly)
+// a period can be provided to get only blocks based on frequency (e.g. weekly). A step
+// with a unit (e.g. 15m or 4h) and any period may be followed by a calendar (e.g. @ny)
 type Modifier struct {
-	Step   uint   `parser:"@Unsigned" json:"step,omitempty"`
-	Period string `parser:"| @('hourly'|'daily'|'weekly'|'monthly'|'quarterly'|'annually'|'next'|'prev'|'all')" json:"period,omitempty"`
+	Step     uint   `parser:"( @Unsigned" json:"step,omitempty"`
+	Unit     string `parser:"  @('m'|'h')?" json:"unit,omitempty"`
+	Period   string `parser:"| @('hourly'|'daily'|'weekly'|'monthly'|'monthend'|'quarterly'|'quarterend'|'annually'|'yearend'|'fiscal'|'fiscalend'|'next'|'prev'|'all') )" json:"period,omitempty"`
+	Calendar string `parser:"( '@' @(Special|Zone) )?" json:"calendar,omitempty"`
 }
 
 // Range is uses after having defined both Point and Modifier, we can construct
@@ -464,3 +464,59 @@ func TestParseInvalidPeriod(t *testing.T) {
 		t.Error("Passes for invalid period")
 	}
 }
+
+func TestParsePeriodCalendar(t *testing.T) {
+	out, err := Parse("2023-01-01-2024-01-01:monthend@ny")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if out.Modifier.Period != "monthend" {
+		t.Error("Mismatched period:", out.Modifier.Period)
+	}
+
+	if out.Modifier.Calendar != "ny" {
+		t.Error("Mismatched calendar:", out.Modifier.Calendar)
+	}
+
+	out, err = Parse("17000000:quarterend@America/Chicago")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if out.Modifier.Calendar != "America/Chicago" {
+		t.Error("Mismatched calendar:", out.Modifier.Calendar)
+	}
+}
+
+func TestParseSubDayStep(t *testing.T) {
+	out, err := Parse("2023-01-01-2023-01-02:4h@ny")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if out.Modifier.Step != 4 || out.Modifier.Unit != "h" {
+		t.Error("Mismatched step:", out.Modifier.Step, out.Modifier.Unit)
+	}
+
+	if out.Modifier.Calendar != "ny" {
+		t.Error("Mismatched calendar:", out.Modifier.Calendar)
+	}
+
+	out, err = Parse("2023-01-01-2023-01-02:15m")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if out.Modifier.Step != 15 || out.Modifier.Unit != "m" {
+		t.Error("Mismatched step:", out.Modifier.Step, out.Modifier.Unit)
+	}
+}
+
+func TestParseInvalidCalendar(t *testing.T) {
+	if _, err := Parse("10-1000:daily@"); err == nil {
+		t.Error("Passes for a missing calendar")
+	}
+}
@@ -68,6 +68,9 @@ func NewBlockRange(rangeStr string) (*Identifier, error) {
 	if parsed.Modifier == nil {
 		newRange.ModifierType = NotDefined
 	} else {
+		if err := validateCalendar(parsed.Modifier); err != nil {
+			return nil, err
+		}
 		newRange.ModifierType = getModifierType(parsed.Modifier)
 		newRange.Modifier = *parsed.Modifier
 	}
@@ -166,13 +169,30 @@ func getModifierType(m *Modifier) IdentifierType {
 		return Step
 	}
 
-	if m.Period != "" {
+	if m.Period != "" || m.Unit != "" {
 		return Period
 	}
 
 	return Step
 }
 
+// isNavigation returns true for the periods that move through blocks or transactions one at a time
+func isNavigation(period string) bool {
+	return period == "next" || period == "prev" || period == "all"
+}
+
+// validateCalendar returns an error if the modifier names a calendar it can't use
+func validateCalendar(m *Modifier) error {
+	if m == nil || len(m.Calendar) == 0 {
+		return nil
+	}
+	if len(m.Unit) == 0 && (len(m.Period) == 0 || isNavigation(m.Period)) {
+		return fmt.Errorf("a calendar may only be used with a period or a step in minutes or hours: %s", m.Calendar)
+	}
+	_, err := LookupCalendar(m.Calendar)
+	return err
+}
+
 type WrongModifierError struct {
 	Token string
 }
@@ -174,3 +174,27 @@ func TestToString(t *testing.T) {
 		t.Errorf("String printer for blockRange not equal to expected:\n%s\n%s", got, expected)
 	}
 }
+
+func TestCountBlocks(t *testing.T) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	tests := []struct {
+		id       string
+		expected uint64
+	}{
+		{"100-200", 100},
+		{"100-200:10", 10},
+		{"100-205:10", 11},
+		{"0-20000000:1", 20000000},
+		{"0-20000000:1000", 20000},
+		{"200-100", 0},
+	}
+	for _, tt := range tests {
+		id, err := NewBlockRange(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := id.CountBlocks("mainnet"); err != nil || got != tt.expected {
+			t.Errorf("%s: expected %d blocks, got %d (%v)", tt.id, tt.expected, got, err)
+		}
+	}
+}
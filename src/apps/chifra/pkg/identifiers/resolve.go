@@ -7,13 +7,14 @@ package identifiers
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/tslib"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
-	"github.com/bykof/gostradamus"
 )
 
 // ResolveBlocks resolves a list of identifiers to a list of blocks (excluding the last block)
@@ -23,18 +24,83 @@ func (id *Identifier) ResolveBlocks(chain string) ([]base.Blknum, error) {
 		return []base.Blknum{}, err
 	}
 
+	if id.isCalendarPeriod() {
+		return id.resolvePeriods(chain, bound.Last)
+	}
+
 	blocks := []base.Blknum{}
 	current, end := bound.First, bound.Last
 	for current < end {
 		blocks = append(blocks, current)
-		current, err = id.nextBlock(chain, current)
-		if err != nil {
-			return []base.Blknum{}, err
+		if id.ModifierType == Step {
+			current += base.Blknum(id.Modifier.Step)
+		} else {
+			current++
 		}
 	}
 	return blocks, nil
 }
 
+// CountBlocks returns how many blocks ResolveBlocks would return without resolving them. For
+// calendar periods, it's an estimate (never too low) made from the timestamps of the bounds.
+func (id *Identifier) CountBlocks(chain string) (uint64, error) {
+	bound, err := id.getBounds(chain)
+	if err != nil {
+		return 0, err
+	}
+	if bound.Last <= bound.First {
+		return 0, nil
+	}
+	span := uint64(bound.Last - bound.First)
+
+	switch {
+	case id.isCalendarPeriod():
+		first, err := tslib.FromBnToTs(chain, bound.First)
+		if err != nil {
+			return 0, err
+		}
+		last, err := tslib.FromBnToTs(chain, bound.Last)
+		if err != nil && !errors.Is(err, tslib.ErrInTheFuture) {
+			return 0, err
+		}
+		return countPeriods(time.Duration(max(last-first, 0))*time.Second, &id.Modifier), nil
+	case id.ModifierType == Step:
+		step := uint64(max(id.Modifier.Step, 1))
+		return (span + step - 1) / step, nil
+	default:
+		return span, nil
+	}
+}
+
+// countPeriods returns how many of the modifier's periods start in a span of time, at most. The
+// span touches at most two more days than it covers whole, even if the clocks change.
+func countPeriods(span time.Duration, m *Modifier) uint64 {
+	day := 24 * time.Hour
+	days := uint64(span/day) + 2
+	if len(m.Unit) > 0 {
+		// steps start over each day
+		size := stepSize(m.Step, m.Unit)
+		return days * uint64((day+size-1)/size)
+	}
+
+	var minDays uint64
+	switch strings.TrimSuffix(m.Period, "end") {
+	case "hourly":
+		return uint64(span/time.Hour) + 2
+	case "daily":
+		minDays = 1
+	case "weekly":
+		minDays = 7
+	case "monthly", "month":
+		minDays = 28
+	case "quarterly", "quarter":
+		minDays = 89
+	default:
+		minDays = 365
+	}
+	return days/minDays + 2
+}
+
 // GetBounds returns the earliest and latest blocks for an array of identifiers
 func GetBounds(chain string, ids *[]Identifier) (ret base.BlockRange, err error) {
 	ret = base.BlockRange{
@@ -57,9 +123,13 @@ func GetBounds(chain string, ids *[]Identifier) (ret base.BlockRange, err error)
 // getBounds returns the earliest and latest blocks for the identifier
 func (id *Identifier) getBounds(chain string) (ret base.BlockRange, err error) {
 	ret.First = id.Start.resolvePoint(chain)
-	switch id.ModifierType {
-	case Period:
-		ret.First, _ = snapBnToPeriod(ret.First, chain, id.Modifier.Period)
+	switch {
+	case id.isCalendarPeriod():
+		if cal, start, err := id.periodStart(chain, ret.First); err != nil {
+			return ret, err
+		} else {
+			ret.First, _ = cal.blockAt(chain, start)
+		}
 	default:
 		// do nothing
 	}
@@ -71,112 +141,92 @@ func (id *Identifier) getBounds(chain string) (ret base.BlockRange, err error) {
 	return ret, nil
 }
 
-func snapBnToPeriod(bn base.Blknum, chain, period string) (base.Blknum, error) {
-	conn := rpc.TempConnection(chain)
+// isCalendarPeriod returns true if the identifier steps through periods of time (and not next, prev, or all)
+func (id *Identifier) isCalendarPeriod() bool {
+	if id.ModifierType != Period {
+		return false
+	}
+	return len(id.Modifier.Unit) > 0 || !isNavigation(id.Modifier.Period)
+}
 
-	dt, err := tslib.FromBnToDate(chain, bn)
+// periodStart returns the identifier's calendar and the start of the period containing the block
+func (id *Identifier) periodStart(chain string, bn base.Blknum) (*Calendar, time.Time, error) {
+	cal, err := LookupCalendar(id.Modifier.Calendar)
 	if err != nil {
-		return bn, err
+		return nil, time.Time{}, err
 	}
 
-	// within five minutes of the period, snap to the future, otherwise snap to the past
-	switch period {
-	case "hourly":
-		dt = dt.ShiftMinutes(5)
-		dt = dt.FloorHour()
-	case "daily":
-		dt = dt.ShiftMinutes(5)
-		dt = dt.FloorDay()
-	case "weekly":
-		dt = dt.ShiftMinutes(5)
-		dt = dt.FloorWeek()
-		dt = dt.ShiftDays(-1).FloorDay() // returns Monday -- we want Sunday
-	case "monthly":
-		dt = dt.ShiftMinutes(5)
-		dt = dt.FloorMonth()
-	case "quarterly": // we assume here that the data is already on the quarter
-		dt = dt.ShiftMinutes(5)
-		dt = dt.FloorMonth()
-		for {
-			if dt.Month() == 1 || dt.Month() == 4 || dt.Month() == 7 || dt.Month() == 10 {
-				break
-			}
-			dt = dt.ShiftMonths(1)
-			dt = dt.FloorMonth()
-		}
-	case "annually":
-		dt = dt.ShiftMinutes(5)
-		dt = dt.FloorYear()
+	ts, err := tslib.FromBnToTs(chain, bn)
+	if err != nil {
+		return nil, time.Time{}, err
 	}
 
-	zeroTs := conn.GetBlockTimestamp(0)
-	firstDate := gostradamus.FromUnixTimestamp(zeroTs.Int64())
-	if dt.Time().Before(firstDate.Time()) {
-		dt = firstDate
+	// within five minutes of the period, snap to the future, otherwise snap to the past
+	t := time.Unix(int64(ts), 0)
+	if len(id.Modifier.Unit) == 0 {
+		t = t.Add(5 * time.Minute)
 	}
+	start := cal.Floor(t, id.Modifier.Period, id.Modifier.Step, id.Modifier.Unit)
 
-	ts := dt.UnixTimestamp()
-	return tslib.FromTsToBn(chain, base.Timestamp(ts))
+	conn := rpc.TempConnection(chain)
+	if first := time.Unix(int64(conn.GetBlockTimestamp(0)), 0); start.Before(first) {
+		start = first
+	}
+	return cal, start, nil
 }
 
-func (id *Identifier) nextBlock(chain string, current base.Blknum) (base.Blknum, error) {
-	bn := current
-
-	if id.ModifierType == Step {
-		bn = bn + base.Blknum(id.Modifier.Step)
+// resolvePeriods returns a block for each period from the one containing the identifier's start
+// up to (but not including) the one that starts at the last block. It's the block in effect when
+// the period starts, the first block after the period starts (for calendars that snap after), or
+// the last block before the period ends (for the *end periods).
+func (id *Identifier) resolvePeriods(chain string, last base.Blknum) ([]base.Blknum, error) {
+	cal, start, err := id.periodStart(chain, id.Start.resolvePoint(chain))
+	if err != nil {
+		return []base.Blknum{}, err
+	}
 
-	} else if id.ModifierType == Period {
-		dt, err := tslib.FromBnToDate(chain, bn)
-		if err != nil {
-			return bn, err
-		} else {
-			switch id.Modifier.Period {
-			case "hourly":
-				dt = dt.ShiftMinutes(5)
-				dt = dt.ShiftHours(1)
-				dt = dt.FloorHour()
-			case "daily":
-				dt = dt.ShiftMinutes(5)
-				dt = dt.ShiftDays(1)
-				dt = dt.FloorDay()
-			case "weekly":
-				dt = dt.ShiftDays(1)
-				dt = dt.ShiftMinutes(5)
-				dt = dt.ShiftWeeks(1)
-				dt = dt.FloorWeek() // returns Monday -- we want Sunday
-				dt = dt.ShiftDays(-1).FloorDay()
-			case "monthly":
-				dt = dt.ShiftMinutes(5)
-				dt = dt.ShiftMonths(1)
-				dt = dt.FloorMonth()
-			case "quarterly":
-				dt = dt.ShiftMinutes(5)
-				dt = dt.ShiftMonths(3)
-				dt = dt.FloorMonth()
-			case "annually":
-				dt = dt.ShiftMinutes(5)
-				dt = dt.ShiftYears(1)
-				dt = dt.FloorYear()
-			default:
-				// should not happen
-			}
+	m := id.Modifier
+	blocks := []base.Blknum{}
+	for {
+		bn, err := cal.blockAt(chain, start)
+		if err != nil && !errors.Is(err, tslib.ErrInTheFuture) {
+			return []base.Blknum{}, err
+		}
+		if bn >= last {
+			break
+		}
 
-			ts := dt.UnixTimestamp()
-			bn, err = tslib.FromTsToBn(chain, base.Timestamp(ts))
-			if err != nil {
-				return bn, err
-			}
-			if bn == current {
-				// might happen if the block spans the period
-				bn++ // ensure at least one block's advancement
+		next := cal.Next(start, m.Period, m.Step, m.Unit)
+		if IsPeriodEnd(m.Period) {
+			if bn, err = tslib.FromTsToBn(chain, base.Timestamp(next.Unix()-1)); err != nil {
+				// the period has not ended
+				break
 			}
+		} else if cal.SnapAfter {
+			bn = cal.blockAfter(chain, start, bn)
 		}
 
-	} else {
-		bn = bn + 1
+		if len(blocks) > 0 && bn <= blocks[len(blocks)-1] {
+			// might happen if the block spans the period
+			bn = blocks[len(blocks)-1] + 1 // ensure at least one block's advancement
+		}
+		blocks = append(blocks, bn)
+		start = next
 	}
+	return blocks, nil
+}
 
-	return bn, nil
+// blockAt returns the block in effect at the given time
+func (c *Calendar) blockAt(chain string, t time.Time) (base.Blknum, error) {
+	return tslib.FromTsToBn(chain, base.Timestamp(t.Unix()))
+}
+
+// blockAfter returns the first block at or after the given time given the block in effect then
+func (c *Calendar) blockAfter(chain string, t time.Time, bn base.Blknum) base.Blknum {
+	if ts, err := tslib.FromBnToTs(chain, bn); err == nil && ts < base.Timestamp(t.Unix()) {
+		return bn + 1
+	}
+	return bn
 }
 
 func (p *Point) resolvePoint(chain string) base.Blknum {
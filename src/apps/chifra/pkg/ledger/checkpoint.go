@@ -0,0 +1,259 @@
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// AssetBalance is the balance of an asset at the end of the last reconciled appearance
+type AssetBalance struct {
+	Asset   base.Address `json:"asset"`
+	Balance base.Wei     `json:"balance"`
+}
+
+// MarshalJSON writes the balance as a decimal string (base.Wei's MarshalText does not)
+func (a AssetBalance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Asset   base.Address `json:"asset"`
+		Balance string       `json:"balance"`
+	}{a.Asset, a.Balance.String()})
+}
+
+// Checkpoint records how far the statements of a monitor have been reconciled so that a later
+// export need only reconcile the monitor's new appearances. A checkpoint is only good for the
+// appearances, names, and options it was made with. If any of those change, Resume ignores it
+// and the ledger starts again from the first appearance. Prices are not part of it because they
+// are found after a statement reconciles and don't change its balances.
+type Checkpoint struct {
+	Address          base.Address   `json:"address"`
+	BlockNumber      base.Blknum    `json:"blockNumber"`
+	TransactionIndex base.Txnum     `json:"transactionIndex"`
+	NApps            int            `json:"nApps"`
+	AppsHash         string         `json:"appsHash"`
+	NamesHash        string         `json:"namesHash"`
+	OptionsHash      string         `json:"optionsHash"`
+	Balances         []AssetBalance `json:"balances"`
+}
+
+// PathToCheckpoint returns the path to the checkpoint of an address's monitor
+func PathToCheckpoint(chain string, addr base.Address) string {
+	return filepath.Join(config.PathToCache(chain), "monitors", "checkpoints", addr.Hex()+".json")
+}
+
+// LoadCheckpoint reads the checkpoint of an address's monitor. It returns nil (and no error) if
+// there isn't one.
+func LoadCheckpoint(chain string, addr base.Address) (*Checkpoint, error) {
+	return loadCheckpoint(PathToCheckpoint(chain, addr))
+}
+
+func loadCheckpoint(path string) (*Checkpoint, error) {
+	if !file.FileExists(path) {
+		return nil, nil
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := new(Checkpoint)
+	if err := json.Unmarshal(bytes, cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
+
+// Save writes the checkpoint, replacing any previous one
+func (cp *Checkpoint) Save(chain string) error {
+	return cp.save(PathToCheckpoint(chain, cp.Address))
+}
+
+func (cp *Checkpoint) save(path string) error {
+	if err := file.EstablishFolder(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, bytes, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// RemoveCheckpoint removes the checkpoint of an address's monitor if there is one
+func RemoveCheckpoint(chain string, addr base.Address) {
+	path := PathToCheckpoint(chain, addr)
+	if file.FileExists(path) {
+		_ = os.Remove(path)
+	}
+}
+
+// Resume checks a checkpoint against all of a monitor's appearances (sorted) and the ledger's
+// names and options. If it's still good, the ledger continues from the checkpoint and
+// Resume returns the number of appearances already reconciled. Otherwise, the ledger starts from
+// the first appearance and Resume returns zero and the reason the checkpoint was not used.
+func (l *Ledger) Resume(cp *Checkpoint, apps []types.Appearance) (int, string) {
+	l.checkpoint = &Checkpoint{
+		Address:     l.AccountFor,
+		NamesHash:   l.namesHash(),
+		OptionsHash: l.optionsHash(),
+		Balances:    []AssetBalance{},
+	}
+	l.advancing = true
+
+	reason := ""
+	switch {
+	case cp == nil:
+		reason = "there is no checkpoint"
+	case cp.Address != l.AccountFor:
+		reason = "the checkpoint is for a different address"
+	case cp.NApps == 0 || cp.NApps > len(apps):
+		reason = "the monitor has fewer appearances than the checkpoint"
+	case cp.AppsHash != hashAppearances(apps[:cp.NApps]):
+		reason = "the monitor's earlier appearances have changed"
+	case cp.NamesHash != l.checkpoint.NamesHash:
+		reason = "the names have changed"
+	case cp.OptionsHash != l.checkpoint.OptionsHash:
+		reason = "the checkpoint was made with different options"
+	}
+	if len(reason) > 0 {
+		return 0, reason
+	}
+
+	l.checkpoint.BlockNumber = cp.BlockNumber
+	l.checkpoint.TransactionIndex = cp.TransactionIndex
+	l.checkpoint.NApps = cp.NApps
+	l.checkpoint.Balances = append(l.checkpoint.Balances, cp.Balances...)
+	return cp.NApps, ""
+}
+
+// Checkpoint returns the ledger's checkpoint advanced past every appearance that reconciled (in
+// order, stopping at the first that did not), or nil if Resume was not called. The appearances
+// must be the same ones given to Resume.
+func (l *Ledger) Checkpoint(apps []types.Appearance) *Checkpoint {
+	if l.checkpoint == nil {
+		return nil
+	}
+	cp := *l.checkpoint
+	if cp.NApps > len(apps) {
+		cp.NApps = len(apps)
+	}
+	cp.AppsHash = hashAppearances(apps[:cp.NApps])
+	sort.Slice(cp.Balances, func(i, j int) bool {
+		return cp.Balances[i].Asset.Hex() < cp.Balances[j].Asset.Hex()
+	})
+	return &cp
+}
+
+// resumesAt returns the block of the last reconciled appearance if the ledger is continuing from
+// a checkpoint (and nothing since has failed to reconcile)
+func (l *Ledger) resumesAt() (base.Blknum, bool) {
+	if l.checkpoint == nil || !l.advancing || l.checkpoint.NApps == 0 {
+		return 0, false
+	}
+	return l.checkpoint.BlockNumber, true
+}
+
+// checkpointBalance returns the checkpoint's balance of an asset if the block is the checkpoint's
+// block. Otherwise, or if the checkpoint has no balance for the asset, it returns nil.
+func (l *Ledger) checkpointBalance(asset base.Address, bn base.Blknum) *base.Wei {
+	if at, ok := l.resumesAt(); !ok || at != bn {
+		return nil
+	}
+	for _, bal := range l.checkpoint.Balances {
+		if bal.Asset == asset {
+			return new(base.Wei).Add(&bal.Balance, new(base.Wei))
+		}
+	}
+	return nil
+}
+
+// advance moves the checkpoint past a transaction if all of its statements reconciled. Once a
+// transaction fails to reconcile, the checkpoint stops advancing.
+func (l *Ledger) advance(trans *types.Transaction, statements []types.Statement, reconciled bool) {
+	if l.checkpoint == nil || !l.advancing {
+		return
+	}
+
+	for i := range statements {
+		reconciled = reconciled && statements[i].Reconciled()
+	}
+	if !reconciled {
+		l.advancing = false
+		return
+	}
+
+	l.checkpoint.BlockNumber = trans.BlockNumber
+	l.checkpoint.TransactionIndex = trans.TransactionIndex
+	l.checkpoint.NApps++
+	for _, s := range statements {
+		found := false
+		for i := range l.checkpoint.Balances {
+			if l.checkpoint.Balances[i].Asset == s.AssetAddr {
+				l.checkpoint.Balances[i].Balance = s.EndBal
+				found = true
+				break
+			}
+		}
+		if !found {
+			l.checkpoint.Balances = append(l.checkpoint.Balances, AssetBalance{Asset: s.AssetAddr, Balance: s.EndBal})
+		}
+	}
+}
+
+// hashAppearances returns a hash of the block numbers and transaction indexes of appearances
+func hashAppearances(apps []types.Appearance) string {
+	h := sha256.New()
+	b := make([]byte, 8)
+	for _, app := range apps {
+		binary.LittleEndian.PutUint32(b, app.BlockNumber)
+		binary.LittleEndian.PutUint32(b[4:], app.TransactionIndex)
+		h.Write(b)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+// namesHash returns a hash of the names the ledger uses to describe assets
+func (l *Ledger) namesHash() string {
+	keys := make([]base.Address, 0, len(l.Names))
+	for addr := range l.Names {
+		keys = append(keys, addr)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Hex() < keys[j].Hex()
+	})
+
+	h := sha256.New()
+	for _, addr := range keys {
+		n := l.Names[addr]
+		fmt.Fprintf(h, "%s\t%s\t%s\t%d\n", addr.Hex(), n.Name, n.Symbol, n.Decimals)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+// optionsHash returns a hash of the options that change the statements of a ledger
+func (l *Ledger) optionsHash() string {
+	assets := make([]string, 0, len(l.assetFilter))
+	for _, asset := range l.assetFilter {
+		assets = append(assets, asset.Hex())
+	}
+	sort.Strings(assets)
+	opts := fmt.Sprintf("traces=%t;assets=%s", l.UseTraces, strings.Join(assets, ","))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(opts)))[:16]
+}
@@ -0,0 +1,132 @@
+package ledger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+var testCheckpointAddr = base.HexToAddress("0x00000000000000000000000000000000c4ec4001")
+
+var testCheckpointApps = []types.Appearance{
+	{BlockNumber: 100, TransactionIndex: 1},
+	{BlockNumber: 100, TransactionIndex: 5},
+	{BlockNumber: 200, TransactionIndex: 0},
+	{BlockNumber: 300, TransactionIndex: 2},
+}
+
+func newTestLedger() *Ledger {
+	return &Ledger{
+		Chain:       "mainnet",
+		AccountFor:  testCheckpointAddr,
+		Contexts:    make(map[ledgerContextKey]*ledgerContext),
+		assetFilter: []base.Address{},
+		Names: map[base.Address]types.Name{
+			base.FAKE_ETH_ADDRESS: {Address: base.FAKE_ETH_ADDRESS, Name: "Ether", Symbol: "ETH", Decimals: 18},
+		},
+	}
+}
+
+func testStatement(app types.Appearance, beg, in, end int64) types.Statement {
+	s := types.Statement{
+		AccountedFor:     testCheckpointAddr,
+		BlockNumber:      base.Blknum(app.BlockNumber),
+		TransactionIndex: base.Txnum(app.TransactionIndex),
+		AssetAddr:        base.FAKE_ETH_ADDRESS,
+	}
+	s.PrevBal.SetInt64(beg)
+	s.BegBal.SetInt64(beg)
+	s.AmountIn.SetInt64(in)
+	s.EndBal.SetInt64(end)
+	return s
+}
+
+func testTransaction(app types.Appearance) *types.Transaction {
+	return &types.Transaction{
+		BlockNumber:      base.Blknum(app.BlockNumber),
+		TransactionIndex: base.Txnum(app.TransactionIndex),
+	}
+}
+
+func TestCheckpointAdvance(t *testing.T) {
+	l := newTestLedger()
+	if done, reason := l.Resume(nil, testCheckpointApps); done != 0 || len(reason) == 0 {
+		t.Fatal("Expected to start from the first appearance without a checkpoint, got", done, reason)
+	}
+
+	apps := testCheckpointApps
+	l.advance(testTransaction(apps[0]), []types.Statement{testStatement(apps[0], 0, 10, 10)}, true)
+	l.advance(testTransaction(apps[1]), []types.Statement{testStatement(apps[1], 10, 5, 15)}, true)
+	l.advance(testTransaction(apps[2]), []types.Statement{testStatement(apps[2], 15, 5, 19)}, true) // does not reconcile
+	l.advance(testTransaction(apps[3]), []types.Statement{testStatement(apps[3], 20, 5, 25)}, true)
+
+	cp := l.Checkpoint(apps)
+	if cp.NApps != 2 || cp.BlockNumber != 100 || cp.TransactionIndex != 5 {
+		t.Fatal("Expected the checkpoint to stop before the unreconciled appearance, got", cp.NApps, cp.BlockNumber, cp.TransactionIndex)
+	}
+	if len(cp.Balances) != 1 || cp.Balances[0].Balance.Uint64() != 15 {
+		t.Error("Expected an ending balance of 15, got", cp.Balances)
+	}
+}
+
+func TestCheckpointResume(t *testing.T) {
+	l := newTestLedger()
+	l.Resume(nil, testCheckpointApps)
+	for i, app := range testCheckpointApps[:3] {
+		bal := int64(i * 10)
+		l.advance(testTransaction(app), []types.Statement{testStatement(app, bal, 10, bal+10)}, true)
+	}
+	cp := l.Checkpoint(testCheckpointApps)
+
+	path := filepath.Join(t.TempDir(), "checkpoints", testCheckpointAddr.Hex()+".json")
+	if missing, err := loadCheckpoint(path); missing != nil || err != nil {
+		t.Fatal("Expected no checkpoint before one is saved, got", missing, err)
+	}
+	if err := cp.save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadCheckpoint(path)
+	if err != nil || loaded == nil || loaded.AppsHash != cp.AppsHash || loaded.Balances[0].Balance.Uint64() != 30 {
+		t.Fatal("Expected to load the saved checkpoint, got", loaded, err)
+	}
+
+	// A good checkpoint continues from the last reconciled appearance
+	l = newTestLedger()
+	if done, reason := l.Resume(loaded, testCheckpointApps); done != 3 || len(reason) > 0 {
+		t.Fatal("Expected to resume after three appearances, got", done, reason)
+	}
+	_ = l.SetContexts("mainnet", testCheckpointApps[3:])
+	ctx := l.Contexts[l.ctxKey(300, 2)]
+	if ctx.PrevBlock != 200 || ctx.ReconType&types.First != 0 {
+		t.Error("Expected the resumed context to follow block 200, got", ctx.PrevBlock, ctx.ReconType)
+	}
+	if bal := l.checkpointBalance(base.FAKE_ETH_ADDRESS, 200); bal == nil || bal.Uint64() != 30 {
+		t.Error("Expected the checkpoint balance at block 200, got", bal)
+	}
+
+	// Changes to the names, the earlier appearances, or the options invalidate the checkpoint
+	l = newTestLedger()
+	l.Names[testCheckpointAddr] = types.Name{Address: testCheckpointAddr, Name: "Renamed"}
+	if done, _ := l.Resume(loaded, testCheckpointApps); done != 0 {
+		t.Error("Expected a change of names to invalidate the checkpoint")
+	}
+
+	changed := append([]types.Appearance{{BlockNumber: 50, TransactionIndex: 0}}, testCheckpointApps...)
+	if done, _ := newTestLedger().Resume(loaded, changed); done != 0 {
+		t.Error("Expected a change of earlier appearances to invalidate the checkpoint")
+	}
+
+	l = newTestLedger()
+	l.UseTraces = true
+	if done, _ := l.Resume(loaded, testCheckpointApps); done != 0 {
+		t.Error("Expected a change of options to invalidate the checkpoint")
+	}
+
+	// New appearances alone do not
+	more := append(testCheckpointApps[:4:4], types.Appearance{BlockNumber: 400, TransactionIndex: 1})
+	if done, _ := newTestLedger().Resume(loaded, more); done != 3 {
+		t.Error("Expected new appearances to leave the checkpoint good")
+	}
+}
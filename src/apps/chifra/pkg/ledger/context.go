@@ -91,12 +91,19 @@ const maxTestingBlock = 17000000
 // appearance's and if they are the same or different. Because balances are only available per block,
 // we must know this information to be able to calculate the correct post-tx balance.
 func (l *Ledger) SetContexts(chain string, apps []types.Appearance) error {
+	resumeAt, resuming := l.resumesAt()
 	for i := 0; i < len(apps); i++ {
 		cur := base.Blknum(apps[i].BlockNumber)
 		prev := base.Blknum(apps[base.Max(1, i)-1].BlockNumber)
 		next := base.Blknum(apps[base.Min(i+1, len(apps)-1)].BlockNumber)
+		isFirst := i == 0
+		if isFirst && resuming && resumeAt <= cur {
+			// continuing from a checkpoint, the previous appearance is the last one reconciled
+			prev = resumeAt
+			isFirst = false
+		}
 		key := l.ctxKey(base.Blknum(apps[i].BlockNumber), base.Txnum(apps[i].TransactionIndex))
-		l.Contexts[key] = newLedgerContext(base.Blknum(prev), base.Blknum(cur), base.Blknum(next), i == 0, i == (len(apps)-1), l.Reversed)
+		l.Contexts[key] = newLedgerContext(base.Blknum(prev), base.Blknum(cur), base.Blknum(next), isFirst, i == (len(apps)-1), l.Reversed)
 	}
 	l.debugContext()
 	return nil
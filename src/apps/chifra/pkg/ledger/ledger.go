@@ -27,6 +27,8 @@ type Ledger struct {
 	Conn        *rpc.Connection
 	assetFilter []base.Address
 	theTx       *types.Transaction
+	checkpoint  *Checkpoint
+	advancing   bool
 }
 
 // NewLedger returns a new empty Ledger struct
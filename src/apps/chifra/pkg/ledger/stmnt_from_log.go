@@ -88,9 +88,11 @@ func (l *Ledger) getStatementsFromLog(conn *rpc.Connection, logIn *types.Log) (t
 
 		if ofInterest {
 			var err error
-			pBal := new(base.Wei)
-			if pBal, err = conn.GetBalanceAtToken(log.Address, l.AccountFor, fmt.Sprintf("0x%x", ctx.PrevBlock)); pBal == nil {
-				return s, err
+			pBal := l.checkpointBalance(log.Address, ctx.PrevBlock)
+			if pBal == nil {
+				if pBal, err = conn.GetBalanceAtToken(log.Address, l.AccountFor, fmt.Sprintf("0x%x", ctx.PrevBlock)); pBal == nil {
+					return s, err
+				}
 			}
 			s.PrevBal = *pBal
 
@@ -32,6 +32,8 @@ func (l *Ledger) GetStatements(conn *rpc.Connection, filter *filter.AppearanceFi
 
 	// make room for our results
 	statements := make([]types.Statement, 0, 20) // a high estimate of the number of statements we'll need
+	reconciled := true
+	var ethBalance *types.Statement
 
 	key := l.ctxKey(trans.BlockNumber, trans.TransactionIndex)
 	ctx := l.Contexts[key]
@@ -39,7 +41,10 @@ func (l *Ledger) GetStatements(conn *rpc.Connection, filter *filter.AppearanceFi
 	if l.assetOfInterest(base.FAKE_ETH_ADDRESS) {
 		// TODO: We ignore errors in the next few lines, but we should not
 		// TODO: BOGUS PERF - This greatly increases the number of times we call into eth_getBalance which is quite slow
-		prevBal, _ := conn.GetBalanceAt(l.AccountFor, ctx.PrevBlock)
+		prevBal := l.checkpointBalance(base.FAKE_ETH_ADDRESS, ctx.PrevBlock)
+		if prevBal == nil {
+			prevBal, _ = conn.GetBalanceAt(l.AccountFor, ctx.PrevBlock)
+		}
 		if trans.BlockNumber == 0 {
 			prevBal = new(base.Wei)
 		}
@@ -109,6 +114,7 @@ func (l *Ledger) GetStatements(conn *rpc.Connection, filter *filter.AppearanceFi
 				statements = append(statements, ret)
 			} else {
 				logger.TestLog(true, "Tx reconciled with a zero value net amount. It's okay.")
+				ethBalance = &ret
 			}
 		} else {
 			if !l.UseTraces {
@@ -118,6 +124,7 @@ func (l *Ledger) GetStatements(conn *rpc.Connection, filter *filter.AppearanceFi
 				if !utils.IsFuzzing() {
 					logger.Warn(colors.Yellow+"Statement at ", fmt.Sprintf("%d.%d", trans.BlockNumber, trans.TransactionIndex), " does not reconcile."+colors.Off)
 				}
+				reconciled = false
 			} else {
 				statements = append(statements, traceStatements...)
 			}
@@ -126,10 +133,18 @@ func (l *Ledger) GetStatements(conn *rpc.Connection, filter *filter.AppearanceFi
 
 	if receiptStatements, err := l.getStatementsFromReceipt(conn, filter, trans.Receipt); err != nil {
 		logger.Warn(l.TestMode, "Error getting statement from receipt")
+		reconciled = false
 	} else {
 		statements = append(statements, receiptStatements...)
 	}
 
+	if ethBalance != nil {
+		// not reported, but its ending balance belongs in the checkpoint
+		l.advance(trans, append([]types.Statement{*ethBalance}, statements...), reconciled)
+	} else {
+		l.advance(trans, statements, reconciled)
+	}
+
 	isFinal := base.IsFinal(conn.LatestBlockTimestamp, trans.Timestamp)
 	if false && isFinal && conn.StoreWritable() && conn.EnabledMap[walk.Cache_Statements] {
 		statementGroup := &types.StatementGroup{
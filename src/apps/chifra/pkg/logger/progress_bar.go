@@ -23,6 +23,12 @@ type BarOptions struct {
 	Start       int64   // the starting value (defaults to zero)
 	Total       int64   // the total (possibly estimated for Expanding) number of objects
 	PrefixColor string  // the color of the prefix
+	Tracker     Tracker // if not nil, told of the bar's progress even if the bar is not enabled
+}
+
+// Tracker is told of a progress bar's progress
+type Tracker interface {
+	Track(done, total int64)
 }
 
 type ProgressBar struct {
@@ -45,6 +51,7 @@ func NewBar(opts BarOptions) (bar *ProgressBar) {
 	bar.Type = opts.Type
 	bar.Start = opts.Start
 	bar.Total = opts.Total
+	bar.Tracker = opts.Tracker
 
 	bar.cur = opts.Start
 	bar.percent = int64(float32(bar.cur) * 100 / float32(bar.Total))
@@ -61,6 +68,7 @@ func NewBar(opts BarOptions) (bar *ProgressBar) {
 
 func (bar *ProgressBar) Bump() {
 	atomic.AddInt64(&bar.cur, 1)
+	bar.track()
 }
 
 func (bar *ProgressBar) Tick() {
@@ -74,10 +82,15 @@ func (bar *ProgressBar) Tick() {
 			bar.graphic += bar.Fill // initial progress position
 		}
 	}
+	bar.track()
 	bar.display()
 }
 
 func (bar *ProgressBar) Finish(newLine bool) time.Duration {
+	if bar.Tracker != nil {
+		cur := atomic.LoadInt64(&bar.cur)
+		bar.Tracker.Track(cur, cur)
+	}
 	if bar.Enabled && loggerWriter != nil {
 		atomic.StoreInt64(&bar.Total, bar.cur)
 		if bar.Type == Expanding {
@@ -96,6 +109,12 @@ func (bar *ProgressBar) Finish(newLine bool) time.Duration {
 	return time.Since(bar.startTime)
 }
 
+func (bar *ProgressBar) track() {
+	if bar.Tracker != nil {
+		bar.Tracker.Track(atomic.LoadInt64(&bar.cur), atomic.LoadInt64(&bar.Total))
+	}
+}
+
 func (bar *ProgressBar) display() {
 	if bar.Enabled && loggerWriter != nil {
 		last := bar.percent
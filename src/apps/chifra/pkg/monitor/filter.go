@@ -1,44 +1,14 @@
 package monitor
 
 import (
-	"encoding/binary"
-	"fmt"
-	"io"
-	"os"
-
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 func (mon *Monitor) ReadAndFilterAppearances(filt *filter.AppearanceFilter, withCount bool) (apps []types.Appearance, cnt int, err error) {
-	readAppearances := func(apps *[]types.AppRecord) (err error) {
-		if int64(len(*apps)) > mon.Count() {
-			err = fmt.Errorf("array is larger than the size of the file (%d,%d)", len(*apps), mon.Count())
-			return
-		}
-
-		if mon.ReadFp == nil {
-			path := mon.Path()
-			mon.ReadFp, err = os.OpenFile(path, os.O_RDONLY, 0644)
-			if err != nil {
-				return
-			}
-		}
-
-		// Seek past the header to get to the first record
-		_, err = mon.ReadFp.Seek(index.AppRecordWidth, io.SeekStart)
-		if err != nil {
-			return
-		}
-
-		err = binary.Read(mon.ReadFp, binary.LittleEndian, apps)
-		if err != nil {
-			return
-		}
-
-		return
+	if mon.IsGroup() {
+		return mon.readAndFilterGroupAppearances(filt, withCount)
 	}
 
 	filt.Reset()
@@ -48,8 +18,8 @@ func (mon *Monitor) ReadAndFilterAppearances(filt *filter.AppearanceFilter, with
 		return nil, 0, nil
 	}
 
-	fromDisc := make([]types.AppRecord, mon.Count())
-	if err := readAppearances(&fromDisc); err != nil {
+	fromDisc, details, err := mon.readAppRecords()
+	if err != nil {
 		mon.Close()
 		return nil, 0, err
 	} else if len(fromDisc) == 0 {
@@ -82,6 +52,9 @@ func (mon *Monitor) ReadAndFilterAppearances(filt *filter.AppearanceFilter, with
 				TransactionIndex: app.TransactionIndex,
 				Timestamp:        base.NOPOSI,
 			}
+			if rec, ok := details[app]; ok && rec.Timestamp != 0 {
+				s.Timestamp = rec.Timestamp
+			}
 			apps = append(apps, s)
 		}
 		prev = app
@@ -0,0 +1,291 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
+)
+
+// Group is a named set of monitored addresses that are freshened and exported together. A
+// group is stored alongside the monitors as a text file with one address per line, so the
+// file may also be used as a --watchlist.
+type Group struct {
+	Name  string         `json:"name"`
+	Addrs []base.Address `json:"addrs"`
+}
+
+// ErrGroupNotFound is returned when a named group does not exist
+var ErrGroupNotFound = errors.New("monitor group not found")
+
+var groupNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// IsValidGroupName returns true if the name may be used as the name of a group
+func IsValidGroupName(name string) bool {
+	return groupNameRegex.MatchString(name)
+}
+
+// PathToGroups returns the folder in which the groups of a chain are stored
+func PathToGroups(chain string) string {
+	return filepath.Join(config.PathToCache(chain), "monitors", "groups")
+}
+
+// PathToGroup returns the path to the file of a named group
+func PathToGroup(chain, name string) string {
+	return filepath.Join(PathToGroups(chain), name+".txt")
+}
+
+// LoadGroup reads a named group
+func LoadGroup(chain, name string) (*Group, error) {
+	if !IsValidGroupName(name) {
+		return nil, fmt.Errorf("invalid group name %q", name)
+	}
+	path := PathToGroup(chain, name)
+	if !file.FileExists(path) {
+		return nil, fmt.Errorf("%w: %s", ErrGroupNotFound, name)
+	}
+
+	g := &Group{Name: name, Addrs: make([]base.Address, 0)}
+	seen := make(map[base.Address]bool)
+	for _, line := range file.AsciiFileToLines(path) {
+		line = strings.TrimSpace(utils.StripComments(line))
+		if !base.IsValidAddress(line) {
+			continue
+		}
+		addr := base.HexToAddress(line)
+		if !addr.IsZero() && !seen[addr] {
+			seen[addr] = true
+			g.Addrs = append(g.Addrs, addr)
+		}
+	}
+	return g, nil
+}
+
+// ListGroups returns every group of a chain sorted by name
+func ListGroups(chain string) ([]Group, error) {
+	entries, err := os.ReadDir(PathToGroups(chain))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Group{}, nil
+		}
+		return nil, err
+	}
+
+	ret := make([]Group, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".txt")
+		if entry.IsDir() || name == entry.Name() || !IsValidGroupName(name) {
+			continue
+		}
+		g, err := LoadGroup(chain, name)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *g)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret, nil
+}
+
+// Save writes the group, creating its folder if needed
+func (g *Group) Save(chain string) error {
+	if !IsValidGroupName(g.Name) {
+		return fmt.Errorf("invalid group name %q", g.Name)
+	}
+	if err := file.EstablishFolder(PathToGroups(chain)); err != nil {
+		return err
+	}
+	lines := make([]string, 0, len(g.Addrs)+1)
+	lines = append(lines, "# monitor group "+g.Name)
+	for _, addr := range g.Addrs {
+		lines = append(lines, addr.Hex())
+	}
+	return file.LinesToAsciiFile(PathToGroup(chain, g.Name), lines)
+}
+
+// Add adds addresses to the group, ignoring those already present. It returns the number added.
+func (g *Group) Add(addrs []base.Address) int {
+	n := 0
+	for _, addr := range addrs {
+		if !g.Has(addr) {
+			g.Addrs = append(g.Addrs, addr)
+			n++
+		}
+	}
+	return n
+}
+
+// Remove removes addresses from the group. It returns the number removed.
+func (g *Group) Remove(addrs []base.Address) int {
+	drop := make(map[base.Address]bool, len(addrs))
+	for _, addr := range addrs {
+		drop[addr] = true
+	}
+	kept := make([]base.Address, 0, len(g.Addrs))
+	for _, addr := range g.Addrs {
+		if !drop[addr] {
+			kept = append(kept, addr)
+		}
+	}
+	n := len(g.Addrs) - len(kept)
+	g.Addrs = kept
+	return n
+}
+
+// Has returns true if the address is a member of the group
+func (g *Group) Has(addr base.Address) bool {
+	for _, a := range g.Addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings returns the group's addresses as hex strings
+func (g *Group) Strings() []string {
+	ret := make([]string, 0, len(g.Addrs))
+	for _, addr := range g.Addrs {
+		ret = append(ret, addr.Hex())
+	}
+	return ret
+}
+
+// RemoveGroup removes a group's file. The members' monitors are not touched.
+func RemoveGroup(chain, name string) error {
+	path := PathToGroup(chain, name)
+	if !file.FileExists(path) {
+		return fmt.Errorf("%w: %s", ErrGroupNotFound, name)
+	}
+	return os.Remove(path)
+}
+
+// NewGroupMonitor returns a monitor whose appearances are the combined appearances of the
+// members. The combined stream is in block order. If dedupe is true, an appearance of more
+// than one member in the same transaction is reported only once (for the first member in
+// address order), which is what one wants when exporting transactions.
+func NewGroupMonitor(chain, name string, members []Monitor, dedupe bool) Monitor {
+	return Monitor{
+		Chain:   chain,
+		Group:   name,
+		Header:  Header{Magic: file.SmallMagicNumber},
+		members: members,
+		dedupe:  dedupe,
+	}
+}
+
+// IsGroup returns true if the monitor combines the monitors of a group
+func (mon *Monitor) IsGroup() bool {
+	return len(mon.Group) > 0
+}
+
+// Addresses returns the address of the monitor or, for a group, the addresses of its members
+func (mon *Monitor) Addresses() []base.Address {
+	if !mon.IsGroup() {
+		return []base.Address{mon.Address}
+	}
+	ret := make([]base.Address, 0, len(mon.members))
+	for _, m := range mon.members {
+		ret = append(ret, m.Address)
+	}
+	return ret
+}
+
+// Label returns a short description of the monitor suitable for progress and error messages
+func (mon *Monitor) Label() string {
+	if mon.IsGroup() {
+		return "group " + mon.Group
+	}
+	return mon.Address.Hex()
+}
+
+// readAndFilterGroupAppearances merges the appearances of the members of a group and applies
+// the filter to the combined stream
+func (mon *Monitor) readAndFilterGroupAppearances(filt *filter.AppearanceFilter, withCount bool) ([]types.Appearance, int, error) {
+	filt.Reset()
+
+	combined := make([]types.Appearance, 0)
+	for i := range mon.members {
+		apps, _, err := mon.members[i].ReadAndFilterAppearances(filter.NewEmptyFilter(), false)
+		if err != nil {
+			return nil, 0, err
+		}
+		combined = append(combined, apps...)
+	}
+	combined = mergeAppearances(combined, mon.dedupe, filt.SortOrder() == filter.Reversed)
+	if len(combined) == 0 {
+		return nil, 0, nil
+	}
+
+	prev := combined[0]
+	apps := make([]types.Appearance, 0, len(combined))
+	for _, app := range combined {
+		rec := types.AppRecord{BlockNumber: app.BlockNumber, TransactionIndex: app.TransactionIndex}
+		var passes bool
+		var finished bool
+		if withCount {
+			passes, finished = filt.ApplyFilter(&rec)
+		} else {
+			passes, finished = filt.ApplyRangeFilter(&rec)
+		}
+
+		if finished {
+			return apps, len(apps), nil
+		} else if passes {
+			if len(apps) == 0 {
+				filt.OuterBounds.First = base.Blknum(prev.BlockNumber)
+			}
+			filt.OuterBounds.Last = base.Blknum(app.BlockNumber + 1)
+			app.Timestamp = base.NOPOSI
+			apps = append(apps, app)
+		}
+		prev = app
+	}
+
+	return apps, len(apps), nil
+}
+
+// mergeAppearances sorts appearances by block, transaction, and address and, if dedupe is
+// true, keeps only the first appearance of each transaction
+func mergeAppearances(apps []types.Appearance, dedupe, reversed bool) []types.Appearance {
+	sort.SliceStable(apps, func(i, j int) bool {
+		if apps[i].BlockNumber != apps[j].BlockNumber {
+			return apps[i].BlockNumber < apps[j].BlockNumber
+		}
+		if apps[i].TransactionIndex != apps[j].TransactionIndex {
+			return apps[i].TransactionIndex < apps[j].TransactionIndex
+		}
+		return apps[i].Address.Hex() < apps[j].Address.Hex()
+	})
+
+	ret := make([]types.Appearance, 0, len(apps))
+	for i, app := range apps {
+		if i > 0 {
+			last := ret[len(ret)-1]
+			same := last.BlockNumber == app.BlockNumber && last.TransactionIndex == app.TransactionIndex
+			if same && (dedupe || last.Address == app.Address) {
+				continue
+			}
+		}
+		ret = append(ret, app)
+	}
+
+	if reversed {
+		for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+			ret[i], ret[j] = ret[j], ret[i]
+		}
+	}
+	return ret
+}
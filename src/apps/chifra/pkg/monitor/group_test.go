@@ -0,0 +1,123 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func Test_Group_Merge(t *testing.T) {
+	a := base.HexToAddress("0x049029dd41661e58f99271a0112dfd34695f7000")
+	b := base.HexToAddress("0x049029dd41661e58f99271a0112dfd34695f7001")
+	apps := func() []types.Appearance {
+		return []types.Appearance{
+			{Address: b, BlockNumber: 1001002, TransactionIndex: 1},
+			{Address: a, BlockNumber: 1001003, TransactionIndex: 2},
+			{Address: a, BlockNumber: 1001002, TransactionIndex: 1},
+			{Address: b, BlockNumber: 1001001, TransactionIndex: 5},
+			{Address: b, BlockNumber: 1001002, TransactionIndex: 1},
+		}
+	}
+
+	merged := mergeAppearances(apps(), true, false)
+	expected := []types.Appearance{
+		{Address: b, BlockNumber: 1001001, TransactionIndex: 5},
+		{Address: a, BlockNumber: 1001002, TransactionIndex: 1},
+		{Address: a, BlockNumber: 1001003, TransactionIndex: 2},
+	}
+	if len(merged) != len(expected) {
+		t.Fatal("Expected", len(expected), "appearances, got", len(merged), merged)
+	}
+	for i := range expected {
+		if merged[i] != expected[i] {
+			t.Error("Appearance", i, "expected", expected[i], "got", merged[i])
+		}
+	}
+
+	merged = mergeAppearances(apps(), false, true)
+	if len(merged) != 4 {
+		t.Fatal("Expected 4 appearances without dedupe, got", len(merged), merged)
+	}
+	if merged[0].BlockNumber != 1001003 || merged[3].BlockNumber != 1001001 {
+		t.Error("Expected the merged appearances to be reversed, got", merged)
+	}
+}
+
+func Test_Group_Monitor(t *testing.T) {
+	monA := GetTestMonitor(t)
+	defer func() {
+		RemoveTestMonitor(&monA, t)
+	}()
+
+	monB := Monitor{
+		Address: base.HexToAddress("0x049029dd41661e58f99271a0112dfd34695f7001"),
+		Chain:   "mainnet",
+		Staged:  true,
+		Header:  Header{Magic: file.SmallMagicNumber},
+	}
+	file.Remove(monB.Path())
+	file.Touch(monB.Path())
+	defer file.Remove(monB.Path())
+	otherApps := []types.AppRecord{
+		{BlockNumber: 1001000, TransactionIndex: 3},
+		{BlockNumber: 1001002, TransactionIndex: 1},
+		{BlockNumber: 1001004, TransactionIndex: 0},
+	}
+	if err := monB.WriteAppearancesAppend(2002003, &otherApps); err != nil {
+		t.Fatal(err)
+	}
+
+	group := NewGroupMonitor("mainnet", "test", []Monitor{monA, monB}, true /* dedupe */)
+	if !group.IsGroup() || group.Label() != "group test" || len(group.Addresses()) != 2 {
+		t.Error("Unexpected group monitor", group.Label(), group.Addresses())
+	}
+
+	apps, cnt, err := group.ReadAndFilterAppearances(filter.NewEmptyFilter(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []base.Blknum{1001000, 1001001, 1001002, 1001003, 1001004}
+	if cnt != len(expected) {
+		t.Fatal("Expected", len(expected), "appearances, got", cnt, apps)
+	}
+	for i, bn := range expected {
+		if base.Blknum(apps[i].BlockNumber) != bn {
+			t.Error("Appearance", i, "expected block", bn, "got", apps[i].BlockNumber)
+		}
+	}
+
+	ranged := filter.NewFilter(false, false, []string{},
+		base.BlockRange{First: 1001002, Last: 1001003},
+		base.RecordRange{First: 0, Last: base.NOPOS},
+	)
+	if _, cnt, err = group.ReadAndFilterAppearances(ranged, false); err != nil {
+		t.Fatal(err)
+	} else if cnt != 2 {
+		t.Error("Expected 2 appearances in the block range, got", cnt)
+	}
+}
+
+func Test_Group_Members(t *testing.T) {
+	a := base.HexToAddress("0x049029dd41661e58f99271a0112dfd34695f7000")
+	b := base.HexToAddress("0x049029dd41661e58f99271a0112dfd34695f7001")
+
+	g := Group{Name: "dao"}
+	if n := g.Add([]base.Address{a, b, a}); n != 2 {
+		t.Error("Expected to add 2 addresses, added", n)
+	}
+	if !g.Has(a) || !g.Has(b) {
+		t.Error("Expected both addresses to be members", g.Addrs)
+	}
+	if n := g.Remove([]base.Address{a}); n != 1 || g.Has(a) || len(g.Addrs) != 1 {
+		t.Error("Expected to remove one address, removed", n, g.Addrs)
+	}
+
+	for name, valid := range map[string]bool{"dao": true, "my-dao.v2": true, "": false, "-dao": false, "a/b": false, "a b": false} {
+		if IsValidGroupName(name) != valid {
+			t.Error("IsValidGroupName", name, "expected", valid)
+		}
+	}
+}
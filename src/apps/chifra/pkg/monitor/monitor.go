@@ -16,16 +16,15 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/utils"
 )
 
 // Header is the header of the Monitor file. Note that it's the same width as an types.AppRecord
-// therefor one should not change its size
+// therefor one should not change its size. Version is zero for v1 files (see monitor_format.go).
 type Header struct {
 	Magic       uint16 `json:"-"`
-	Unused      bool   `json:"-"`
+	Version     uint8  `json:"-"`
 	Deleted     bool   `json:"deleted,omitempty"`
 	LastScanned uint32 `json:"lastScanned,omitempty"`
 }
@@ -36,7 +35,11 @@ type Monitor struct {
 	Staged  bool         `json:"-"`
 	Chain   string       `json:"-"`
 	ReadFp  *os.File     `json:"-"`
+	Group   string       `json:"-"`
 	Header
+	members []Monitor
+	dedupe  bool
+	cached  recordCache
 }
 
 const (
@@ -115,14 +118,10 @@ func (mon *Monitor) Reload(create bool) (int64, error) {
 	return mon.Count(), nil
 }
 
+// Count returns the number of appearances in the monitor's file regardless of its format
 func (mon *Monitor) Count() int64 {
-	if file.FileSize(mon.Path()) == 0 {
-		return 0
-	}
-	s := file.FileSize(mon.Path())
-	w := int64(index.AppRecordWidth)
-	n := s / w
-	return n - 1
+	_, n := readFormat(mon.Path())
+	return n
 }
 
 // IsOpen returns true if the underlying monitor file is opened.
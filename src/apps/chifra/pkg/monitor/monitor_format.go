@@ -0,0 +1,375 @@
+package monitor
+
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/tslib"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Monitor files come in two formats, both of which start with the eight-byte Header.
+//
+// In a v1 file, the Header (whose Version byte is zero) is followed by fixed-width AppRecords.
+//
+// In a v2 file, the Header is followed by the number of records (uint32) and a reserved uint32,
+// and then by one or more frames. A frame is a uvarint record count, a uvarint byte length, and
+// that many bytes of records. Each record is a byte of flags, the signed varint deltas of its
+// block number and transaction index (the latter relative to the previous record in the same
+// block), and then the optional fields named by the flags. Deltas start from zero in each frame,
+// so appending to a file never rewrites what's already there.
+const (
+	FormatV1 uint8 = 1
+	FormatV2 uint8 = 2
+)
+
+// headerV2Width is the width of a v2 file's Header plus the record count and reserved field
+const headerV2Width = index.AppRecordWidth + 8
+
+const (
+	flagTimestamp = 1 << iota
+	flagReconciled
+)
+
+// ErrCorruptMonitor is returned when a v2 monitor file can't be decoded
+var ErrCorruptMonitor = errors.New("corrupt monitor file")
+
+// Record is an appearance as stored in a monitor file. Timestamp and Reconciled are optional
+// and are only kept by v2 files. A zero Timestamp means the timestamp is not stored. Reconciled
+// is set once the statements exported for the appearance have all reconciled.
+type Record struct {
+	BlockNumber      uint32         `json:"blockNumber"`
+	TransactionIndex uint32         `json:"transactionIndex"`
+	Timestamp        base.Timestamp `json:"timestamp,omitempty"`
+	Reconciled       bool           `json:"reconciled,omitempty"`
+}
+
+// AppRecord returns the record's block number and transaction index
+func (r *Record) AppRecord() types.AppRecord {
+	return types.AppRecord{BlockNumber: r.BlockNumber, TransactionIndex: r.TransactionIndex}
+}
+
+func (r *Record) flags() byte {
+	var flags byte
+	if r.Timestamp != 0 {
+		flags |= flagTimestamp
+	}
+	if r.Reconciled {
+		flags |= flagReconciled
+	}
+	return flags
+}
+
+func recordsFromApps(apps []types.AppRecord) []Record {
+	recs := make([]Record, 0, len(apps))
+	for _, app := range apps {
+		recs = append(recs, Record{BlockNumber: app.BlockNumber, TransactionIndex: app.TransactionIndex})
+	}
+	return recs
+}
+
+// Format returns the format of a file with this header
+func (h *Header) Format() uint8 {
+	if h.Version < FormatV2 {
+		return FormatV1
+	}
+	return h.Version
+}
+
+func (h *Header) setFormat(format uint8) {
+	if format == FormatV2 {
+		h.Version = FormatV2
+	} else {
+		h.Version = 0
+	}
+}
+
+// ParseFormat converts the name of a format (v1 or v2) into its value
+func ParseFormat(name string) (uint8, error) {
+	switch strings.ToLower(name) {
+	case "v1", "1":
+		return FormatV1, nil
+	case "v2", "2":
+		return FormatV2, nil
+	}
+	return 0, fmt.Errorf("unknown monitor format %q", name)
+}
+
+// DefaultFormat returns the format in which new monitor files are created. That's v1 unless
+// the settings in the configuration file set monitorFormat to v2.
+func DefaultFormat() uint8 {
+	if format, err := ParseFormat(config.GetSettings().MonitorFormat); err == nil {
+		return format
+	}
+	return FormatV1
+}
+
+// Format returns the format of the monitor's file or, if the file is empty, the format in which
+// it will be created
+func (mon *Monitor) Format() uint8 {
+	format, _ := readFormat(mon.Path())
+	return format
+}
+
+// readFormat returns the format of a monitor file and the number of records it holds without
+// reading the records
+func readFormat(path string) (uint8, int64) {
+	size := file.FileSize(path)
+	if size < index.AppRecordWidth {
+		return DefaultFormat(), 0
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return DefaultFormat(), 0
+	}
+	defer f.Close()
+
+	var b [headerV2Width]byte
+	n, _ := f.Read(b[:])
+	if b[2] < FormatV2 {
+		return FormatV1, size/index.AppRecordWidth - 1
+	}
+	if n < headerV2Width {
+		return FormatV2, 0
+	}
+	return FormatV2, int64(binary.LittleEndian.Uint32(b[index.AppRecordWidth:]))
+}
+
+// encodeFrame returns the v2 encoding of a frame holding the records
+func encodeFrame(recs []Record) []byte {
+	payload := make([]byte, 0, len(recs)*4)
+	var prev Record
+	for _, r := range recs {
+		flags := r.flags()
+		payload = append(payload, flags)
+		payload = binary.AppendVarint(payload, int64(r.BlockNumber)-int64(prev.BlockNumber))
+		prevTx := int64(0)
+		if r.BlockNumber == prev.BlockNumber {
+			prevTx = int64(prev.TransactionIndex)
+		}
+		payload = binary.AppendVarint(payload, int64(r.TransactionIndex)-prevTx)
+		if flags&flagTimestamp != 0 {
+			payload = binary.AppendVarint(payload, int64(r.Timestamp-prev.Timestamp))
+		}
+		ts := prev.Timestamp
+		prev = r
+		if flags&flagTimestamp == 0 {
+			prev.Timestamp = ts
+		}
+	}
+
+	frame := binary.AppendUvarint(make([]byte, 0, len(payload)+8), uint64(len(recs)))
+	frame = binary.AppendUvarint(frame, uint64(len(payload)))
+	return append(frame, payload...)
+}
+
+// frameReader decodes the varints of a v2 file, remembering the first error
+type frameReader struct {
+	buf []byte
+	err error
+}
+
+func (r *frameReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = ErrCorruptMonitor
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *frameReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = ErrCorruptMonitor
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *frameReader) bytes(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if uint64(len(r.buf)) < n {
+		r.err = ErrCorruptMonitor
+		return nil
+	}
+	ret := r.buf[:n]
+	r.buf = r.buf[n:]
+	return ret
+}
+
+// decodeFrames decodes the frames of a v2 file (everything following its header)
+func decodeFrames(data []byte, count int64) ([]Record, error) {
+	recs := make([]Record, 0, count)
+	r := frameReader{buf: data}
+	for len(r.buf) > 0 && r.err == nil {
+		n := r.uvarint()
+		fr := frameReader{buf: r.bytes(r.uvarint())}
+		if r.err != nil {
+			break
+		}
+
+		var prev Record
+		for i := uint64(0); i < n && fr.err == nil; i++ {
+			flags := fr.bytes(1)
+			if fr.err != nil {
+				break
+			}
+			rec := Record{Timestamp: prev.Timestamp}
+			rec.BlockNumber = uint32(int64(prev.BlockNumber) + fr.varint())
+			prevTx := int64(0)
+			if rec.BlockNumber == prev.BlockNumber {
+				prevTx = int64(prev.TransactionIndex)
+			}
+			rec.TransactionIndex = uint32(prevTx + fr.varint())
+			if flags[0]&flagTimestamp != 0 {
+				rec.Timestamp += base.Timestamp(fr.varint())
+			}
+			rec.Reconciled = flags[0]&flagReconciled != 0
+			prev = rec
+			if flags[0]&flagTimestamp == 0 {
+				rec.Timestamp = 0
+			}
+			recs = append(recs, rec)
+		}
+		if fr.err == nil && len(fr.buf) > 0 {
+			fr.err = ErrCorruptMonitor
+		}
+		r.err = fr.err
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	if int64(len(recs)) != count {
+		return nil, fmt.Errorf("%w: expected %d records, found %d", ErrCorruptMonitor, count, len(recs))
+	}
+	return recs, nil
+}
+
+// stampTimestamps attaches a timestamp to each record that doesn't have one. Records whose
+// timestamp can't be found are left alone.
+func stampTimestamps(chain string, recs []Record) {
+	for i := range recs {
+		if recs[i].Timestamp == 0 {
+			if ts, err := tslib.FromBnToTs(chain, base.Blknum(recs[i].BlockNumber)); err == nil {
+				recs[i].Timestamp = ts
+			}
+		}
+	}
+}
+
+// Migrate rewrites the monitor's file in the given format. The new file is written alongside the
+// old one and then moved into place. Timestamps are added to the records when migrating to v2 and
+// the optional fields are dropped when migrating to v1. It returns false if the file was already
+// in the given format.
+func (mon *Monitor) Migrate(format uint8) (bool, error) {
+	if format != FormatV1 && format != FormatV2 {
+		return false, fmt.Errorf("unknown monitor format %d", format)
+	}
+
+	mon.Close()
+	path := mon.Path()
+	if !file.FileExists(path) || file.FileSize(path) < index.AppRecordWidth {
+		return false, nil
+	}
+
+	if err := mon.ReadMonitorHeader(); err != nil {
+		mon.Close()
+		return false, err
+	}
+	mon.Close()
+	if mon.Header.Format() == format {
+		return false, nil
+	}
+
+	recs, err := mon.ReadRecords()
+	if err != nil {
+		return false, err
+	}
+	if format == FormatV2 {
+		stampTimestamps(mon.Chain, recs)
+	}
+
+	monitorMutex.Lock()
+	defer monitorMutex.Unlock()
+
+	tmpPath := path + ".tmp"
+	if err := mon.writeFile(tmpPath, format, recs); err != nil {
+		os.Remove(tmpPath)
+		return false, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return false, err
+	}
+	return true, nil
+}
+
+// MarkReconciled records whether each of the given appearances reconciled. Only v2 files keep
+// this, so it does nothing to a v1 file. Like Migrate, the new file is written alongside the old
+// one and then moved into place. It returns the number of records that changed.
+func (mon *Monitor) MarkReconciled(reconciled map[types.AppRecord]bool) (int, error) {
+	if len(reconciled) == 0 || mon.Format() != FormatV2 {
+		return 0, nil
+	}
+
+	monitorMutex.Lock()
+	defer monitorMutex.Unlock()
+
+	recs, err := mon.ReadRecords()
+	if err != nil {
+		return 0, err
+	}
+
+	nChanged := 0
+	for i := range recs {
+		if value, ok := reconciled[recs[i].AppRecord()]; ok && recs[i].Reconciled != value {
+			recs[i].Reconciled = value
+			nChanged++
+		}
+	}
+	if nChanged == 0 {
+		return 0, nil
+	}
+
+	if err := mon.ReadMonitorHeader(); err != nil {
+		mon.Close()
+		return 0, err
+	}
+	mon.Close()
+
+	path := mon.Path()
+	tmpPath := path + ".tmp"
+	if err := mon.writeFile(tmpPath, FormatV2, recs); err != nil {
+		os.Remove(tmpPath)
+		return 0, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return 0, err
+	}
+	return nChanged, nil
+}
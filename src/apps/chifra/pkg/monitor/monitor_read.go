@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
@@ -39,6 +40,21 @@ func (mon *Monitor) ReadAppearanceAt(idx int64, app *types.AppRecord) (err error
 		return
 	}
 
+	if mon.Format() == FormatV2 {
+		// Records in a v2 file are not fixed width, so they are decoded once and kept until
+		// the file changes
+		var recs []Record
+		if recs, err = mon.cachedRecords(); err != nil {
+			return
+		}
+		if idx > int64(len(recs)) {
+			err = fmt.Errorf("index out of range in ReadAppearanceAt[%d]", idx)
+			return
+		}
+		*app = recs[idx-1].AppRecord()
+		return
+	}
+
 	if mon.ReadFp == nil {
 		path := mon.Path()
 		mon.ReadFp, err = os.OpenFile(path, os.O_RDONLY, 0644)
@@ -61,3 +77,114 @@ func (mon *Monitor) ReadAppearanceAt(idx int64, app *types.AppRecord) (err error
 	err = binary.Read(mon.ReadFp, binary.LittleEndian, &app.TransactionIndex)
 	return
 }
+
+// ReadRecords returns the records in the monitor's file, in the order they were written,
+// regardless of the file's format. Records read from a v1 file have no optional fields.
+func (mon *Monitor) ReadRecords() ([]Record, error) {
+	format, count := readFormat(mon.Path())
+	if count == 0 {
+		return []Record{}, nil
+	}
+
+	if format == FormatV1 {
+		apps, err := mon.readV1(count)
+		if err != nil {
+			return nil, err
+		}
+		return recordsFromApps(apps), nil
+	}
+
+	data, err := os.ReadFile(mon.Path())
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < headerV2Width {
+		return nil, fmt.Errorf("%w: %s", ErrCorruptMonitor, mon.Path())
+	}
+	recs, err := decodeFrames(data[headerV2Width:], count)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", mon.Path(), err)
+	}
+	return recs, nil
+}
+
+// recordCache holds the decoded records of a v2 file along with the size and modification
+// time of the file they were decoded from
+type recordCache struct {
+	recs    []Record
+	size    int64
+	modTime time.Time
+}
+
+// cachedRecords returns the records in the monitor's file, decoding them only if the file has
+// changed since they were last decoded
+func (mon *Monitor) cachedRecords() ([]Record, error) {
+	info, err := os.Stat(mon.Path())
+	if err != nil {
+		return nil, err
+	}
+	if mon.cached.recs != nil && mon.cached.size == info.Size() && mon.cached.modTime.Equal(info.ModTime()) {
+		return mon.cached.recs, nil
+	}
+
+	recs, err := mon.ReadRecords()
+	if err != nil {
+		mon.cached = recordCache{}
+		return nil, err
+	}
+	mon.cached = recordCache{recs: recs, size: info.Size(), modTime: info.ModTime()}
+	return recs, nil
+}
+
+// readAppRecords returns the appearances in the monitor's file. For a v2 file, it also returns
+// the records carrying optional fields, keyed by appearance. A v1 file remains open.
+func (mon *Monitor) readAppRecords() ([]types.AppRecord, map[types.AppRecord]*Record, error) {
+	format, count := readFormat(mon.Path())
+	if count == 0 {
+		return []types.AppRecord{}, nil, nil
+	}
+
+	if format == FormatV1 {
+		apps, err := mon.readV1(count)
+		return apps, nil, err
+	}
+
+	recs, err := mon.ReadRecords()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var details map[types.AppRecord]*Record
+	apps := make([]types.AppRecord, 0, len(recs))
+	for i := range recs {
+		app := recs[i].AppRecord()
+		apps = append(apps, app)
+		if recs[i].flags() != 0 {
+			if details == nil {
+				details = make(map[types.AppRecord]*Record)
+			}
+			details[app] = &recs[i]
+		}
+	}
+	return apps, details, nil
+}
+
+// readV1 reads the fixed-width records of a v1 file. The file remains open.
+func (mon *Monitor) readV1(count int64) (apps []types.AppRecord, err error) {
+	if mon.ReadFp == nil {
+		mon.ReadFp, err = os.OpenFile(mon.Path(), os.O_RDONLY, 0644)
+		if err != nil {
+			return
+		}
+	}
+
+	// Seek past the header to get to the first record
+	_, err = mon.ReadFp.Seek(index.AppRecordWidth, io.SeekStart)
+	if err != nil {
+		return
+	}
+
+	apps = make([]types.AppRecord, count)
+	err = binary.Read(mon.ReadFp, binary.LittleEndian, &apps)
+	return
+}
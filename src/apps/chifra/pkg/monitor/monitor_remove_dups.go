@@ -5,8 +5,7 @@
 package monitor
 
 import (
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"sort"
 )
 
 func (mon *Monitor) RemoveDups() (int64, int64, error) {
@@ -15,34 +14,34 @@ func (mon *Monitor) RemoveDups() (int64, int64, error) {
 	}
 	defer mon.Close()
 
-	if apps, cnt, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), true /* withCount */); err != nil {
+	if recs, err := mon.ReadRecords(); err != nil {
 		return mon.Count(), mon.Count(), err
 
-	} else if cnt == 0 {
+	} else if len(recs) == 0 {
 		return mon.Count(), mon.Count(), nil
 
 	} else {
 		cntBefore := mon.Count()
 		cntAfter := cntBefore
 
-		var prev types.AppRecord
-		deDupped := make([]types.AppRecord, 0, mon.Count())
-		for i, app := range apps {
-			iApp := types.AppRecord{
-				BlockNumber:      app.BlockNumber,
-				TransactionIndex: app.TransactionIndex,
+		sortRecords(recs)
+		deDupped := make([]Record, 0, len(recs))
+		for i, rec := range recs {
+			if i > 0 {
+				prev := &deDupped[len(deDupped)-1]
+				if prev.BlockNumber == rec.BlockNumber && prev.TransactionIndex == rec.TransactionIndex {
+					mergeRecord(prev, &rec)
+					continue
+				}
 			}
-			if i == 0 || (prev.BlockNumber != iApp.BlockNumber || prev.TransactionIndex != iApp.TransactionIndex) {
-				deDupped = append(deDupped, iApp)
-			}
-			prev = iApp
+			deDupped = append(deDupped, rec)
 		}
 
-		if len(apps) != len(deDupped) {
+		if len(recs) != len(deDupped) {
 			mon.Close() // so when we open it, it gets replaced
 			// Very important to note - if you use false for append, the header gets overwritten
 			// so ordering matters here and we need to write the header afterwards
-			cntAfter, err = mon.WriteAppearances(deDupped, false /* append */)
+			cntAfter, err = mon.WriteRecords(deDupped, false /* append */)
 			if err != nil {
 				return cntBefore, cntAfter, err
 			}
@@ -52,3 +51,22 @@ func (mon *Monitor) RemoveDups() (int64, int64, error) {
 		return cntBefore, cntAfter, err
 	}
 }
+
+// sortRecords sorts records by block number and transaction index, keeping the file order of
+// duplicates
+func sortRecords(recs []Record) {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].BlockNumber != recs[j].BlockNumber {
+			return recs[i].BlockNumber < recs[j].BlockNumber
+		}
+		return recs[i].TransactionIndex < recs[j].TransactionIndex
+	})
+}
+
+// mergeRecord fills in any optional fields of a record from a duplicate of it
+func mergeRecord(rec, dup *Record) {
+	if rec.Timestamp == 0 {
+		rec.Timestamp = dup.Timestamp
+	}
+	rec.Reconciled = rec.Reconciled || dup.Reconciled
+}
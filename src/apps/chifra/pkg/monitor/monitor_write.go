@@ -5,10 +5,12 @@ package monitor
 // be found in the LICENSE file.
 
 import (
+	"bufio"
 	"encoding/binary"
 	"io"
 	"os"
 
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/index"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
@@ -16,8 +18,12 @@ import (
 
 // TODO: Protect against overwriting files on disc
 
-// WriteMonHeader writes the monitor's header
+// WriteMonHeader writes the monitor's header. An existing file keeps its format (only Migrate
+// changes it). A new file is created in the default format.
 func (mon *Monitor) WriteMonHeader(deleted bool, lastScanned uint32, force bool) (err error) {
+	format, count := readFormat(mon.Path())
+	size := file.FileSize(mon.Path())
+
 	f, err := os.OpenFile(mon.Path(), os.O_WRONLY|os.O_CREATE, 0644)
 	if err != nil {
 		return
@@ -28,9 +34,13 @@ func (mon *Monitor) WriteMonHeader(deleted bool, lastScanned uint32, force bool)
 	if force || lastScanned > mon.LastScanned {
 		mon.LastScanned = lastScanned
 	}
+	mon.Header.setFormat(format)
 
 	_, _ = f.Seek(0, io.SeekStart)
 	err = binary.Write(f, binary.LittleEndian, mon.Header)
+	if err == nil && format == FormatV2 && size < headerV2Width {
+		err = binary.Write(f, binary.LittleEndian, [2]uint32{uint32(count), 0})
+	}
 	return
 }
 
@@ -64,39 +74,140 @@ func (mon *Monitor) WriteAppearancesAppend(lastScanned uint32, apps *[]types.App
 
 // TODO: Protect against overwriting files on disc
 
-// WriteAppearances writes appearances to a Monitor
+// WriteAppearances writes appearances to a Monitor. A v2 file stores each appearance's
+// timestamp along with it.
 func (mon *Monitor) WriteAppearances(apps []types.AppRecord, append bool) (int64, error) {
-	var f *os.File
-	var err error
+	recs := recordsFromApps(apps)
+	if mon.Format() == FormatV2 {
+		stampTimestamps(mon.Chain, recs)
+	}
+	return mon.WriteRecords(recs, append)
+}
+
+// WriteRecords writes records to a Monitor in the format of its file. If append is false, the
+// records replace those in the file and the caller should rewrite the header afterwards.
+func (mon *Monitor) WriteRecords(recs []Record, append bool) (int64, error) {
 	path := mon.Path()
-	if append {
-		f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	format, count := readFormat(path)
+
+	var err error
+	if !append {
+		err = mon.writeFile(path, format, recs)
+	} else if format == FormatV1 {
+		err = appendV1(path, recs)
 	} else {
-		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		err = mon.appendV2(path, count, recs)
 	}
 	if err != nil {
 		return 0, err
 	}
 
+	_, _ = mon.Reload(false /* create */)
+	return mon.Count(), nil
+}
+
+func appendV1(path string, recs []Record) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
 	_, _ = f.Seek(index.AppRecordWidth, io.SeekStart)
 
 	b := make([]byte, 4)
-	for _, app := range apps {
-		binary.LittleEndian.PutUint32(b, app.BlockNumber)
+	for _, rec := range recs {
+		binary.LittleEndian.PutUint32(b, rec.BlockNumber)
 		_, err = f.Write(b)
 		if err != nil {
 			f.Close()
-			return 0, err
+			return err
 		}
-		binary.LittleEndian.PutUint32(b, app.TransactionIndex)
+		binary.LittleEndian.PutUint32(b, rec.TransactionIndex)
 		_, err = f.Write(b)
 		if err != nil {
 			f.Close()
-			return 0, err
+			return err
 		}
 	}
 
-	f.Close() // do not defer this, we need to close it so the fileSize is right
-	_, _ = mon.Reload(false /* create */)
-	return mon.Count(), nil
+	return f.Close() // do not defer this, we need to close it so the fileSize is right
+}
+
+// appendV2 writes the records as a new frame at the end of the file and updates the count
+func (mon *Monitor) appendV2(path string, count int64, recs []Record) error {
+	if len(recs) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	if file.FileSize(path) < headerV2Width {
+		hdr := mon.Header
+		hdr.setFormat(FormatV2)
+		if err = binary.Write(f, binary.LittleEndian, hdr); err == nil {
+			err = binary.Write(f, binary.LittleEndian, [2]uint32{0, 0})
+		}
+		count = 0
+	}
+
+	if err == nil {
+		if _, err = f.Seek(0, io.SeekEnd); err == nil {
+			_, err = f.Write(encodeFrame(recs))
+		}
+	}
+
+	if err == nil {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(count)+uint32(len(recs)))
+		_, err = f.WriteAt(b, index.AppRecordWidth)
+	}
+
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeFile writes the monitor's header and the records to the file at path in the given format,
+// replacing whatever was there
+func (mon *Monitor) writeFile(path string, format uint8, recs []Record) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	hdr := mon.Header
+	if hdr.Magic == 0 {
+		hdr.Magic = file.SmallMagicNumber
+	}
+	hdr.setFormat(format)
+
+	w := bufio.NewWriter(f)
+	err = binary.Write(w, binary.LittleEndian, hdr)
+	if err == nil {
+		if format == FormatV2 {
+			if err = binary.Write(w, binary.LittleEndian, [2]uint32{uint32(len(recs)), 0}); err == nil && len(recs) > 0 {
+				_, err = w.Write(encodeFrame(recs))
+			}
+		} else {
+			apps := make([]types.AppRecord, 0, len(recs))
+			for i := range recs {
+				apps = append(apps, recs[i].AppRecord())
+			}
+			err = binary.Write(w, binary.LittleEndian, apps)
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
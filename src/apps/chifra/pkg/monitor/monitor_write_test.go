@@ -0,0 +1,256 @@
+package monitor
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+var testRecordsAddr = base.HexToAddress("0x049029dd41661e58f99271a0112dfd34695f7002")
+
+var testRecords = []Record{
+	{BlockNumber: 1001001, TransactionIndex: 0, Timestamp: 1455404053},
+	{BlockNumber: 1001001, TransactionIndex: 7, Timestamp: 1455404053, Reconciled: true},
+	{BlockNumber: 1001003, TransactionIndex: 2},
+	{BlockNumber: 999000, TransactionIndex: 99999, Timestamp: 1455300000, Reconciled: true},
+	{BlockNumber: 0, TransactionIndex: 4},
+}
+
+func getTestMonitorV2(t *testing.T) Monitor {
+	mon := Monitor{
+		Address: testRecordsAddr,
+		Chain:   "mainnet",
+		Staged:  true,
+		Header:  Header{Magic: file.SmallMagicNumber, LastScanned: 2002003},
+	}
+	file.Remove(mon.Path())
+	if err := mon.writeFile(mon.Path(), FormatV2, nil); err != nil {
+		t.Fatal(err)
+	}
+	return mon
+}
+
+func Test_Monitor_RoundTripV2(t *testing.T) {
+	mon := getTestMonitorV2(t)
+	defer file.Remove(mon.Path())
+
+	if mon.Format() != FormatV2 || mon.Count() != 0 {
+		t.Fatal("Expected an empty v2 monitor, got format", mon.Format(), "count", mon.Count())
+	}
+
+	// Two appends make two frames
+	if n, err := mon.WriteRecords(testRecords[:2], true /* append */); err != nil || n != 2 {
+		t.Fatal("Expected 2 records, got", n, err)
+	}
+	if n, err := mon.WriteRecords(testRecords[2:], true /* append */); err != nil || n != int64(len(testRecords)) {
+		t.Fatal("Expected", len(testRecords), "records, got", n, err)
+	}
+
+	got, err := mon.ReadRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(testRecords) {
+		t.Fatal("Expected", len(testRecords), "records, got", len(got))
+	}
+	for i := range testRecords {
+		if got[i] != testRecords[i] {
+			t.Error("Record", i, "expected", testRecords[i], "got", got[i])
+		}
+	}
+
+	var app = testRecords[0].AppRecord()
+	if err := mon.ReadAppearanceAt(4, &app); err != nil || app != testRecords[3].AppRecord() {
+		t.Error("ReadAppearanceAt(4) expected", testRecords[3].AppRecord(), "got", app, err)
+	}
+
+	// Rewriting the header (here, by deleting the monitor) must not change the format
+	mon.Delete()
+	if err := mon.ReadMonitorHeader(); err != nil {
+		t.Fatal(err)
+	}
+	mon.Close()
+	if mon.Format() != FormatV2 || !mon.Deleted || mon.LastScanned != 2002003 || mon.Count() != int64(len(testRecords)) {
+		t.Error("Header rewrite changed the monitor", mon.Format(), mon.Deleted, mon.LastScanned, mon.Count())
+	}
+
+	// The optional fields reach the appearances
+	apps, cnt, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), false)
+	if err != nil || cnt != len(testRecords) {
+		t.Fatal("Expected", len(testRecords), "appearances, got", cnt, err)
+	}
+	if apps[0].BlockNumber != 0 || apps[0].Timestamp != base.NOPOSI {
+		t.Error("Expected the first appearance to have no timestamp, got", apps[0])
+	}
+	if apps[2].Timestamp != 1455404053 {
+		t.Error("Expected the timestamp of the third appearance, got", apps[2])
+	}
+}
+
+func Test_Monitor_RoundTripV1(t *testing.T) {
+	mon := GetTestMonitor(t)
+	defer func() {
+		RemoveTestMonitor(&mon, t)
+	}()
+
+	if mon.Format() != FormatV1 {
+		t.Fatal("Expected a v1 monitor")
+	}
+
+	if n, err := mon.WriteRecords(testRecords[:nTests], false /* append */); err != nil || n != nTests {
+		t.Fatal("Expected", nTests, "records, got", n, err)
+	}
+	if file.FileSize(mon.Path()) != (nTests+1)*8 {
+		t.Error("Expected a fixed-width file, got size", file.FileSize(mon.Path()))
+	}
+
+	got, err := mon.ReadRecords()
+	mon.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < nTests; i++ {
+		expected := Record{BlockNumber: testRecords[i].BlockNumber, TransactionIndex: testRecords[i].TransactionIndex}
+		if got[i] != expected {
+			t.Error("Record", i, "expected", expected, "got", got[i])
+		}
+	}
+}
+
+func Test_Monitor_Migrate(t *testing.T) {
+	mon := GetTestMonitor(t)
+	defer func() {
+		RemoveTestMonitor(&mon, t)
+	}()
+	sizeV1 := file.FileSize(mon.Path())
+
+	for _, format := range []uint8{FormatV2, FormatV1} {
+		if migrated, err := mon.Migrate(format); err != nil || !migrated {
+			t.Fatal("Expected to migrate to", format, err)
+		}
+		if migrated, err := mon.Migrate(format); err != nil || migrated {
+			t.Error("Expected a second migration to", format, "to do nothing", err)
+		}
+		if mon.Format() != format || mon.Count() != nTests || mon.LastScanned != 2002003 {
+			t.Error("Unexpected monitor after migrating to", format, mon.Format(), mon.Count(), mon.LastScanned)
+		}
+
+		got, err := mon.ReadRecords()
+		mon.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, app := range testApps {
+			if got[i].AppRecord() != app {
+				t.Error("Record", i, "expected", app, "got", got[i])
+			}
+		}
+	}
+
+	if file.FileSize(mon.Path()) != sizeV1 {
+		t.Error("Expected migrating back to v1 to restore the file's size", sizeV1, "got", file.FileSize(mon.Path()))
+	}
+	if file.FileExists(mon.Path() + ".tmp") {
+		t.Error("Migrate left a temporary file behind")
+	}
+}
+
+func Test_Monitor_RemoveDupsV2(t *testing.T) {
+	mon := getTestMonitorV2(t)
+	defer file.Remove(mon.Path())
+
+	recs := []Record{
+		{BlockNumber: 20, TransactionIndex: 1},
+		{BlockNumber: 10, TransactionIndex: 3},
+		{BlockNumber: 20, TransactionIndex: 1, Timestamp: 1600000000, Reconciled: true},
+	}
+	if _, err := mon.WriteRecords(recs, true /* append */); err != nil {
+		t.Fatal(err)
+	}
+
+	before, after, err := mon.RemoveDups()
+	if err != nil || before != 3 || after != 2 {
+		t.Fatal("Expected 3 records before and 2 after, got", before, after, err)
+	}
+
+	got, err := mon.ReadRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Record{
+		{BlockNumber: 10, TransactionIndex: 3},
+		{BlockNumber: 20, TransactionIndex: 1, Timestamp: 1600000000, Reconciled: true},
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Error("Record", i, "expected", expected[i], "got", got[i])
+		}
+	}
+	if mon.Format() != FormatV2 {
+		t.Error("Removing duplicates changed the format")
+	}
+}
+
+func Test_Monitor_MarkReconciled(t *testing.T) {
+	mon := getTestMonitorV2(t)
+	defer file.Remove(mon.Path())
+
+	if _, err := mon.WriteRecords(testRecords, true /* append */); err != nil {
+		t.Fatal(err)
+	}
+
+	var app types.AppRecord
+	if err := mon.ReadAppearanceAt(3, &app); err != nil || app != testRecords[2].AppRecord() {
+		t.Fatal("ReadAppearanceAt(3) expected", testRecords[2].AppRecord(), "got", app, err)
+	}
+
+	n, err := mon.MarkReconciled(map[types.AppRecord]bool{
+		testRecords[2].AppRecord(): true,
+		testRecords[3].AppRecord(): false,
+		testRecords[4].AppRecord(): false,
+	})
+	if err != nil || n != 2 {
+		t.Fatal("Expected 2 records to change, got", n, err)
+	}
+
+	got, err := mon.ReadRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []bool{false, true, true, false, false} {
+		if got[i].Reconciled != expected || got[i].Timestamp != testRecords[i].Timestamp {
+			t.Error("Record", i, "expected reconciled", expected, "got", got[i])
+		}
+	}
+	if mon.LastScanned != 2002003 || mon.Count() != int64(len(testRecords)) {
+		t.Error("Marking records changed the header", mon.LastScanned, mon.Count())
+	}
+
+	// the records decoded by ReadAppearanceAt are not reused once the file changes
+	if _, err := mon.WriteRecords(testRecords[:1], true /* append */); err != nil {
+		t.Fatal(err)
+	}
+	if err := mon.ReadAppearanceAt(int64(len(testRecords))+1, &app); err != nil || app != testRecords[0].AppRecord() {
+		t.Error("Expected the appended record, got", app, err)
+	}
+}
+
+func Test_Monitor_CorruptV2(t *testing.T) {
+	mon := getTestMonitorV2(t)
+	defer file.Remove(mon.Path())
+
+	if _, err := mon.WriteRecords(testRecords, true /* append */); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(mon.Path(), file.FileSize(mon.Path())-3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mon.ReadRecords(); !errors.Is(err, ErrCorruptMonitor) {
+		t.Error("Expected a corrupt monitor error, got", err)
+	}
+}
@@ -2,8 +2,6 @@ package monitor
 
 import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/filter"
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 func (mon *Monitor) TruncateTo(chain string, num uint32) (bool, error) {
@@ -12,20 +10,18 @@ func (mon *Monitor) TruncateTo(chain string, num uint32) (bool, error) {
 		return false, err
 	}
 
-	if apps, cnt, err := mon.ReadAndFilterAppearances(filter.NewEmptyFilter(), true /* withCount */); err != nil {
+	if recs, err := mon.ReadRecords(); err != nil {
 		return false, err
 
-	} else if cnt == 0 {
+	} else if len(recs) == 0 {
 		return false, nil
 
 	} else {
-		var keep []types.AppRecord
-		for _, app := range apps {
-			if app.BlockNumber <= num {
-				keep = append(keep, types.AppRecord{
-					BlockNumber:      app.BlockNumber,
-					TransactionIndex: app.TransactionIndex,
-				})
+		sortRecords(recs)
+		var keep []Record
+		for _, rec := range recs {
+			if rec.BlockNumber <= num {
+				keep = append(keep, rec)
 			}
 		}
 		lastScanned := base.Min(num, mon.Header.LastScanned)
@@ -33,13 +29,13 @@ func (mon *Monitor) TruncateTo(chain string, num uint32) (bool, error) {
 		mon.Close() // so when we open it, it gets replaced
 		// Very important to note - if you use false for append, the header gets overwritten
 		// so ordering matters here and we need to write the header afterwards
-		if _, err := mon.WriteAppearances(keep, false /* append */); err != nil {
+		if _, err := mon.WriteRecords(keep, false /* append */); err != nil {
 			mon.Close()
 			return false, err
 		}
 		_ = mon.WriteMonHeader(mon.Deleted, lastScanned, true /* force */)
 		mon.Close()
 
-		return len(apps)-len(keep) > 0, nil
+		return len(recs)-len(keep) > 0, nil
 	}
 }
@@ -0,0 +1,267 @@
+package names
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// bundleVersion changes whenever the layout of a bundle changes
+const bundleVersion = 1
+
+// BundleName is a custom name as it's shared in a bundle along with the provenance of each
+// of its fields
+type BundleName struct {
+	Address    base.Address   `json:"address"`
+	Name       string         `json:"name"`
+	Tags       string         `json:"tags,omitempty"`
+	Symbol     string         `json:"symbol,omitempty"`
+	Source     string         `json:"source,omitempty"`
+	Decimals   uint64         `json:"decimals,omitempty"`
+	Deleted    bool           `json:"deleted,omitempty"`
+	Provenance NameProvenance `json:"provenance,omitempty"`
+}
+
+// Bundle is a signed set of custom names exported by one author for others to import
+type Bundle struct {
+	Version   int            `json:"version"`
+	Chain     string         `json:"chain"`
+	Author    base.Address   `json:"author"`
+	Timestamp base.Timestamp `json:"timestamp"`
+	Names     []BundleName   `json:"names"`
+	Signature string         `json:"signature,omitempty"`
+}
+
+// ErrNoSigningKey is returned if there's no key to sign bundles with
+var ErrNoSigningKey = errors.New("no signing key in the [keys.names] section of the config")
+
+// ErrUntrustedAuthor is returned if a bundle's author is not in the `namesAuthors` setting
+var ErrUntrustedAuthor = errors.New("the bundle's author is not in the namesAuthors setting")
+
+// SigningKey returns the private key bundles are signed with. It's the `secret` of the
+// `names` key in the configuration file.
+func SigningKey() (*ecdsa.PrivateKey, error) {
+	secret := strings.TrimPrefix(config.GetKey("names").Secret, "0x")
+	if len(secret) == 0 {
+		return nil, ErrNoSigningKey
+	}
+	return crypto.HexToECDSA(secret)
+}
+
+// LocalAuthor returns the address of the signing key or the zero address if there's no key
+func LocalAuthor() base.Address {
+	if key, err := SigningKey(); err == nil {
+		return base.HexToAddress(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	}
+	return base.ZeroAddr
+}
+
+// NewBundleName combines a custom name with the provenance of its fields. Fields without
+// recorded provenance are attributed to author at an unknown time.
+func NewBundleName(name *types.Name, prov NameProvenance, author base.Address) BundleName {
+	ret := BundleName{
+		Address:    name.Address,
+		Name:       name.Name,
+		Tags:       name.Tags,
+		Symbol:     name.Symbol,
+		Source:     name.Source,
+		Decimals:   name.Decimals,
+		Deleted:    name.Deleted,
+		Provenance: make(NameProvenance, len(MergeFields)),
+	}
+	for _, field := range MergeFields {
+		if p, ok := prov[field]; ok {
+			ret.Provenance[field] = p
+		} else {
+			ret.Provenance[field] = Provenance{Source: name.Source, Author: author}
+		}
+	}
+	return ret
+}
+
+// ToName returns the bundled name as a custom name
+func (b *BundleName) ToName() types.Name {
+	return types.Name{
+		Address:  b.Address,
+		Name:     b.Name,
+		Tags:     b.Tags,
+		Symbol:   b.Symbol,
+		Source:   b.Source,
+		Decimals: b.Decimals,
+		Deleted:  b.Deleted,
+		IsCustom: true,
+	}
+}
+
+// NewBundle returns an unsigned bundle of the given names sorted by address
+func NewBundle(chain string, author base.Address, ts base.Timestamp, names []BundleName) *Bundle {
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].Address.Hex() < names[j].Address.Hex()
+	})
+	return &Bundle{
+		Version:   bundleVersion,
+		Chain:     chain,
+		Author:    author,
+		Timestamp: ts,
+		Names:     names,
+	}
+}
+
+// digest is the hash of the bundle without its signature
+func (b *Bundle) digest() ([]byte, error) {
+	unsigned := *b
+	unsigned.Signature = ""
+	bytes, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(bytes), nil
+}
+
+// Sign signs the bundle and sets its author to the address of the key
+func (b *Bundle) Sign(key *ecdsa.PrivateKey) error {
+	b.Author = base.HexToAddress(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	hash, err := b.digest()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return err
+	}
+	b.Signature = hexutil.Encode(sig)
+	return nil
+}
+
+// Verify returns an error if the bundle was not signed by its author or was changed after
+// it was signed
+func (b *Bundle) Verify() error {
+	if b.Version != bundleVersion {
+		return fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+	sig, err := hexutil.Decode(b.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid bundle signature: %w", err)
+	}
+	hash, err := b.digest()
+	if err != nil {
+		return err
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return fmt.Errorf("invalid bundle signature: %w", err)
+	}
+	signer := base.HexToAddress(crypto.PubkeyToAddress(*pub).Hex())
+	if signer != b.Author {
+		return fmt.Errorf("bundle claims to be from %s but was signed by %s", b.Author.Hex(), signer.Hex())
+	}
+	return nil
+}
+
+// TrustedAuthors returns the addresses in the `namesAuthors` setting of the configuration file.
+// Bundles from other authors are not imported.
+func TrustedAuthors() []base.Address {
+	authors := config.GetSettings().NamesAuthors
+	ret := make([]base.Address, 0, len(authors))
+	for _, author := range authors {
+		ret = append(ret, base.HexToAddress(author))
+	}
+	return ret
+}
+
+// CheckAuthor returns an error unless the bundle's author is one of trusted. A signature only
+// shows who signed the bundle, so call it after Verify.
+func (b *Bundle) CheckAuthor(trusted []base.Address) error {
+	for _, author := range trusted {
+		if author == b.Author {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUntrustedAuthor, b.Author.Hex())
+}
+
+// WriteBundle writes a bundle to a file
+func WriteBundle(path string, b *Bundle) error {
+	bytes, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.StringToAsciiFile(path, string(bytes)+"\n")
+}
+
+// ReadBundle reads a bundle from a file and verifies its signature
+func ReadBundle(path string) (*Bundle, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	if err := json.Unmarshal(bytes, &b); err != nil {
+		return nil, fmt.Errorf("could not read bundle %s: %w", path, err)
+	}
+	if err := b.Verify(); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// getMergeBasePath returns the path of the names as they were after the last import from author
+func getMergeBasePath(chain string, author base.Address) string {
+	return filepath.Join(config.MustGetPathToChainConfig(chain), "names_sync", author.Hex()+".json")
+}
+
+// LoadMergeBase returns the names as they were after the last import from author (the common
+// ancestor of a three-way merge). It's empty if nothing was imported from author before.
+func LoadMergeBase(chain string, author base.Address) (map[base.Address]BundleName, error) {
+	ret := make(map[base.Address]BundleName)
+	path := getMergeBasePath(chain, author)
+	if !file.FileExists(path) {
+		return ret, nil
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var names []BundleName
+	if err := json.Unmarshal(bytes, &names); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	for _, name := range names {
+		ret[name.Address] = name
+	}
+	return ret, nil
+}
+
+// SaveMergeBase records the names as they are after an import from author
+func SaveMergeBase(chain string, author base.Address, names map[base.Address]BundleName) error {
+	list := make([]BundleName, 0, len(names))
+	for _, name := range names {
+		list = append(list, name)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Address.Hex() < list[j].Address.Hex()
+	})
+
+	bytes, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := getMergeBasePath(chain, author)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return file.StringToAsciiFile(path, string(bytes)+"\n")
+}
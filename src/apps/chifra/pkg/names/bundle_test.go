@@ -0,0 +1,163 @@
+package names
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	addr1 = base.HexToAddress("0x1f9840a85d5af5bf1d1762f925bdaddc4201f984")
+	addr2 = base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f")
+	alice = base.HexToAddress("0x00000000000000000000000000000000000a11ce")
+	bob   = base.HexToAddress("0x0000000000000000000000000000000000000b0b")
+)
+
+func bundleName(addr base.Address, name, tags string, author base.Address, ts base.Timestamp) BundleName {
+	n := types.Name{Address: addr, Name: name, Tags: tags, Source: "Test"}
+	prov := make(NameProvenance)
+	for _, field := range MergeFields {
+		prov[field] = Provenance{Source: "Test", Author: author, Timestamp: ts}
+	}
+	return NewBundleName(&n, prov, author)
+}
+
+func nameMap(names ...BundleName) map[base.Address]BundleName {
+	ret := make(map[base.Address]BundleName)
+	for _, n := range names {
+		ret[n.Address] = n
+	}
+	return ret
+}
+
+func TestBundleSignAndVerify(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewBundle("mainnet", base.ZeroAddr, 1700000000, []BundleName{
+		bundleName(addr2, "Dai", "50-Tokens", bob, 10),
+		bundleName(addr1, "Uniswap", "50-Tokens", bob, 10),
+	})
+	if b.Names[0].Address != addr1 {
+		t.Error("expected the names to be sorted by address")
+	}
+	if err := b.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	if b.Author.Hex() != "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23" {
+		t.Errorf("unexpected author %s", b.Author.Hex())
+	}
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	if err := WriteBundle(path, b); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadBundle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Names) != 2 || read.Names[1].Provenance["name"].Author != bob {
+		t.Errorf("bundle did not round trip: %+v", read)
+	}
+
+	read.Names[0].Name = "Not Uniswap"
+	if err := read.Verify(); err == nil {
+		t.Error("expected a tampered bundle to fail verification")
+	}
+
+	b.Author = bob
+	if err := b.Verify(); err == nil {
+		t.Error("expected a bundle with the wrong author to fail verification")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base1 := bundleName(addr1, "Uniswap", "50-Tokens", bob, 10)
+
+	tests := []struct {
+		name      string
+		ours      BundleName
+		theirs    BundleName
+		hasBase   bool
+		strategy  Strategy
+		changed   string // the name after the merge or empty if unchanged
+		conflicts int
+		resolved  string
+	}{
+		{"unchanged", base1, base1, true, StrategyNone, "", 0, ""},
+		{"they changed", base1, bundleName(addr1, "Uniswap Token", "50-Tokens", bob, 20), true, StrategyNone, "Uniswap Token", 0, ""},
+		{"we changed", bundleName(addr1, "UNI", "50-Tokens", alice, 20), base1, true, StrategyNone, "", 0, ""},
+		{"both same", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "UNI", "50-Tokens", bob, 30), true, StrategyNone, "", 0, ""},
+		{"conflict", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "Uni", "50-Tokens", bob, 30), true, StrategyNone, "", 1, ""},
+		{"conflict ours", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "Uni", "50-Tokens", bob, 30), true, StrategyOurs, "", 1, "ours"},
+		{"conflict theirs", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "Uni", "50-Tokens", bob, 30), true, StrategyTheirs, "Uni", 1, "theirs"},
+		{"conflict newest theirs", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "Uni", "50-Tokens", bob, 30), true, StrategyNewest, "Uni", 1, "theirs"},
+		{"conflict newest ours", bundleName(addr1, "UNI", "50-Tokens", alice, 40), bundleName(addr1, "Uni", "50-Tokens", bob, 30), true, StrategyNewest, "", 1, "ours"},
+		{"no base", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "Uni", "50-Tokens", bob, 30), false, StrategyNone, "", 1, ""},
+		{"separate fields", bundleName(addr1, "UNI", "50-Tokens", alice, 20), bundleName(addr1, "Uniswap", "55-Defi", bob, 30), true, StrategyNone, "UNI", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mergeBase := nameMap()
+			if tt.hasBase {
+				mergeBase = nameMap(base1)
+			}
+			theirs := NewBundle("mainnet", bob, 100, []BundleName{tt.theirs})
+			result := Merge(nameMap(tt.ours), mergeBase, theirs, tt.strategy)
+
+			changed := ""
+			if len(result.Changed) > 0 {
+				changed = result.Changed[0].Name.Name
+			}
+			if changed != tt.changed {
+				t.Errorf("changed name %q, expected %q", changed, tt.changed)
+			}
+			if len(result.Conflicts) != tt.conflicts {
+				t.Fatalf("got %d conflicts, expected %d", len(result.Conflicts), tt.conflicts)
+			}
+			if tt.conflicts > 0 && result.Conflicts[0].Resolution != tt.resolved {
+				t.Errorf("resolution %q, expected %q", result.Conflicts[0].Resolution, tt.resolved)
+			}
+		})
+	}
+}
+
+func TestMergeRemembersConflicts(t *testing.T) {
+	base1 := bundleName(addr1, "Uniswap", "50-Tokens", bob, 10)
+	ours := nameMap(bundleName(addr1, "UNI", "50-Tokens", alice, 20))
+	theirs := NewBundle("mainnet", bob, 100, []BundleName{bundleName(addr1, "Uni", "50-Tokens", bob, 30)})
+
+	// an unresolved conflict is reported again on the next import
+	result := Merge(ours, nameMap(base1), theirs, StrategyNone)
+	again := Merge(ours, result.Base, theirs, StrategyNone)
+	if len(again.Conflicts) != 1 {
+		t.Errorf("expected the conflict to be reported again, got %d conflicts", len(again.Conflicts))
+	}
+
+	// a resolved conflict is not
+	result = Merge(ours, nameMap(base1), theirs, StrategyOurs)
+	again = Merge(ours, result.Base, theirs, StrategyNone)
+	if len(again.Conflicts) != 0 || len(again.Changed) != 0 {
+		t.Errorf("expected nothing to merge, got %+v", again)
+	}
+}
+
+func TestMergeCreates(t *testing.T) {
+	theirs := NewBundle("mainnet", bob, 100, []BundleName{bundleName(addr2, "Dai", "50-Tokens", bob, 30)})
+	result := Merge(nameMap(), nameMap(), theirs, StrategyNone)
+	if len(result.Changed) != 1 || !result.Changed[0].Created || !result.Changed[0].Name.IsCustom {
+		t.Fatalf("expected one created name, got %+v", result.Changed)
+	}
+
+	// a name we removed locally is not recreated unless they changed it
+	result = Merge(nameMap(), result.Base, theirs, StrategyNone)
+	if len(result.Changed) != 0 {
+		t.Errorf("expected a removed name to stay removed, got %+v", result.Changed)
+	}
+}
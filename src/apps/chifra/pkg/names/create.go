@@ -10,6 +10,8 @@ import (
 )
 
 func CreateName(dbType DatabaseType, chain string, name *types.Name) (err error) {
+	defer invalidateSearchIndex()
+
 	switch dbType {
 	case DatabaseCustom:
 		return customCreateName(chain, name)
@@ -64,3 +66,21 @@ func regularCreateName(name *types.Name) (err error) {
 	regularNames[name.Address] = *name
 	return
 }
+
+// AddName adds a name to the in-memory copy of a database without writing the database. Call
+// CustomWriteNames or RegularWriteNames to save the names added.
+func AddName(dbType DatabaseType, name *types.Name) {
+	defer invalidateSearchIndex()
+
+	switch dbType {
+	case DatabaseCustom:
+		customNamesMutex.Lock()
+		defer customNamesMutex.Unlock()
+		name.IsCustom = true
+		customNames[name.Address] = *name
+	case DatabaseRegular:
+		_ = regularCreateName(name)
+	default:
+		logger.Fatal("should not happen ==> unknown database type")
+	}
+}
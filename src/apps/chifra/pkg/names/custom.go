@@ -19,6 +19,7 @@ var customNamesMutex sync.Mutex
 
 func ClearCustomNames() {
 	customNamesLoaded = false
+	invalidateSearchIndex()
 }
 
 func loadCustomMap(chain string, terms []string, parts types.Parts, namesMap *map[base.Address]types.Name) (err error) {
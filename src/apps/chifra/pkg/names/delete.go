@@ -13,6 +13,8 @@ import (
 )
 
 func SetDeleted(dbType DatabaseType, chain string, address base.Address, deleted bool) (name *types.Name, err error) {
+	defer invalidateSearchIndex()
+
 	switch dbType {
 	case DatabaseCustom:
 		return customSetDeleted(chain, address, deleted)
@@ -0,0 +1,253 @@
+package names
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Fields of a name record that are indexed. The order is also the order of their weight
+// when ranking results.
+const (
+	fieldName uint8 = 1 << iota
+	fieldSymbol
+	fieldAddress
+	fieldTags
+	fieldSource
+)
+
+var fieldWeights = map[uint8]float64{
+	fieldName:    5,
+	fieldSymbol:  4,
+	fieldAddress: 3,
+	fieldTags:    2,
+	fieldSource:  1,
+}
+
+// posting records that a token appears in a field of a document
+type posting struct {
+	Doc   int32
+	Field uint8
+}
+
+// Index is an inverted index over the name, symbol, address, tags and source of a set of
+// names. Tokens are kept sorted so prefix queries are a binary search followed by a short scan.
+// A name appears once per database it was loaded from, which Sources records.
+type Index struct {
+	Docs     []types.Name
+	Sources  []types.Parts
+	Tokens   []string
+	Postings [][]posting
+}
+
+// NewIndex builds an index over the given names each of which came from the database
+// in the corresponding entry of sources
+func NewIndex(docs []types.Name, sources []types.Parts) *Index {
+	byToken := make(map[string][]posting)
+	for i := range docs {
+		doc := &docs[i]
+		fields := []struct {
+			field uint8
+			value string
+		}{
+			{fieldName, doc.Name},
+			{fieldSymbol, doc.Symbol},
+			{fieldAddress, doc.Address.Hex()},
+			{fieldTags, doc.Tags},
+			{fieldSource, doc.Source},
+		}
+		for _, f := range fields {
+			for _, token := range tokenize(f.value) {
+				list := byToken[token]
+				if n := len(list); n > 0 && list[n-1].Doc == int32(i) {
+					list[n-1].Field |= f.field
+				} else {
+					list = append(list, posting{Doc: int32(i), Field: f.field})
+				}
+				byToken[token] = list
+			}
+		}
+	}
+
+	idx := &Index{
+		Docs:     docs,
+		Sources:  sources,
+		Tokens:   make([]string, 0, len(byToken)),
+		Postings: make([][]posting, 0, len(byToken)),
+	}
+	for token := range byToken {
+		idx.Tokens = append(idx.Tokens, token)
+	}
+	sort.Strings(idx.Tokens)
+	for _, token := range idx.Tokens {
+		idx.Postings = append(idx.Postings, byToken[token])
+	}
+	return idx
+}
+
+// tokenize lower cases a value and splits it into words of letters and digits. Addresses
+// (and other hex strings) are single words.
+func tokenize(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Hit is a document that matched a query along with its relevance
+type Hit struct {
+	Doc   int
+	Score float64
+}
+
+// Search returns the documents matching every term of the query ordered by relevance (and
+// by address among equally relevant documents). Only documents from the databases in
+// wanted are returned.
+func (idx *Index) Search(q *Query, wanted types.Parts) []Hit {
+	var scores map[int32]float64
+	for _, term := range q.Terms {
+		termScores := idx.searchTerm(&term, q.Fields)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for doc, score := range scores {
+			if s, ok := termScores[doc]; ok {
+				scores[doc] = score + s
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for doc, score := range scores {
+		name := &idx.Docs[doc]
+		if idx.Sources[doc]&wanted == 0 {
+			continue
+		}
+		if !q.matchesFacets(name) || (q.MatchCase && !q.matchesCase(name)) {
+			continue
+		}
+		hits = append(hits, Hit{Doc: int(doc), Score: score})
+	}
+
+	// facet-only queries match every document in the wanted databases
+	if len(q.Terms) == 0 {
+		for i := range idx.Docs {
+			if idx.Sources[i]&wanted != 0 && q.matchesFacets(&idx.Docs[i]) {
+				hits = append(hits, Hit{Doc: i, Score: 1})
+			}
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return idx.Docs[hits[i].Doc].Address.Hex() < idx.Docs[hits[j].Doc].Address.Hex()
+	})
+	return hits
+}
+
+// searchTerm returns the best score of each document matching every word of a term
+func (idx *Index) searchTerm(term *Term, fields uint8) map[int32]float64 {
+	var scores map[int32]float64
+	for _, word := range term.Words {
+		wordScores := make(map[int32]float64)
+		add := func(i int, quality float64) {
+			for _, p := range idx.Postings[i] {
+				if p.Field&fields == 0 {
+					continue
+				}
+				if s := quality * bestWeight(p.Field&fields); s > wordScores[p.Doc] {
+					wordScores[p.Doc] = s
+				}
+			}
+		}
+
+		switch term.Kind {
+		case Fuzzy:
+			for i, token := range idx.Tokens {
+				if d := editDistance(word, token, term.Distance); d <= term.Distance {
+					add(i, 0.5/float64(d+1))
+				}
+			}
+		case Prefix:
+			start := sort.SearchStrings(idx.Tokens, word)
+			for i := start; i < len(idx.Tokens) && strings.HasPrefix(idx.Tokens[i], word); i++ {
+				if idx.Tokens[i] == word {
+					add(i, 1)
+				} else {
+					add(i, 0.6)
+				}
+			}
+		default:
+			for i, token := range idx.Tokens {
+				if token == word {
+					add(i, 1)
+				} else if strings.HasPrefix(token, word) {
+					add(i, 0.6)
+				} else if strings.Contains(token, word) {
+					add(i, 0.3)
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+		for doc, score := range scores {
+			if s, ok := wordScores[doc]; ok {
+				scores[doc] = score + s
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+	return scores
+}
+
+func bestWeight(fields uint8) float64 {
+	best := 0.0
+	for field, weight := range fieldWeights {
+		if fields&field != 0 && weight > best {
+			best = weight
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b or max+1 if it's larger
+// than max. Rows are abandoned as soon as every entry exceeds max.
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,209 @@
+package names
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+var inputIndex = `
+tags	address	name	symbol	source	decimals	deleted	isCustom	isPrefund	isContract	isErc20	isErc721
+55-Defi	0x000000000000000000000000000000000000dead	ENS: Burn Address		EtherScan.io		false	false	false	false	false	false
+50-Tokens:ERC20	0x1f9840a85d5af5bf1d1762f925bdaddc4201f984	Uniswap	UNI	On chain	18	false	false	false	true	true	false
+55-Defi	0x7a250d5630b4cf539739df2c5dacb4c659f2488d	Uniswap V2: Router 2		EtherScan.io		false	false	false	true	false	false
+55-Defi	0x5c69bee701ef814a2b6a3edd4b1652cb9cc5aa6f	Uniswap V2: Factory Contract		EtherScan.io		false	false	false	true	false	false
+50-Tokens:ERC20	0x6b175474e89094c44da98b954eedeac495271d0f	Dai Stablecoin	DAI	On chain	18	false	false	false	true	true	false
+30-Contracts	0x000000000000541e251335090ac5b47176af4f7e	dex.blue		EtherScan.io		false	false	false	true	false	false
+`
+
+func newTestIndex(t *testing.T) *Index {
+	r, err := NewNameReader(strings.NewReader(inputIndex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := make([]types.Name, 0)
+	sources := make([]types.Parts, 0)
+	for {
+		name, err := r.Read()
+		if err != nil {
+			break
+		}
+		docs = append(docs, name)
+		sources = append(sources, types.Regular)
+	}
+	return NewIndex(docs, sources)
+}
+
+func searchFor(t *testing.T, idx *Index, parts types.Parts, terms ...string) []string {
+	q, err := ParseQuery(terms, parts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := make([]string, 0)
+	for _, hit := range idx.Search(q, types.Regular) {
+		ret = append(ret, idx.Docs[hit.Doc].Name)
+	}
+	return ret
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := newTestIndex(t)
+	tests := []struct {
+		name     string
+		parts    types.Parts
+		terms    []string
+		expected []string
+	}{
+		{"exact word", 0, []string{"dai"}, []string{"Dai Stablecoin"}},
+		{"prefix of word", 0, []string{"stable"}, []string{"Dai Stablecoin"}},
+		{"inside a word", 0, []string{"tablecoin"}, []string{"Dai Stablecoin"}},
+		{"prefix only", 0, []string{"tablecoin*"}, []string{}},
+		{"all terms must match", 0, []string{"uniswap", "router"}, []string{"Uniswap V2: Router 2"}},
+		{"phrase", 0, []string{"uniswap v2"}, []string{"Uniswap V2: Factory Contract", "Uniswap V2: Router 2"}},
+		{"name ranks above tags", 0, []string{"uni*"}, []string{"Uniswap", "Uniswap V2: Factory Contract", "Uniswap V2: Router 2"}},
+		{"fuzzy", 0, []string{"uniswop~"}, []string{"Uniswap", "Uniswap V2: Factory Contract", "Uniswap V2: Router 2"}},
+		{"fuzzy distance zero", 0, []string{"uniswop~0"}, []string{}},
+		{"address prefix", 0, []string{"0x6b17"}, []string{"Dai Stablecoin"}},
+		{"facet", 0, []string{"tag:tokens"}, []string{"Uniswap", "Dai Stablecoin"}},
+		{"facet with number", 0, []string{"tag:55-defi", "factory"}, []string{"Uniswap V2: Factory Contract"}},
+		{"facet excludes", 0, []string{"tag:tokens", "router"}, []string{}},
+		{"source needs expand", 0, []string{"etherscan"}, []string{}},
+		{"expanded", types.Expanded, []string{"etherscan", "burn"}, []string{"ENS: Burn Address"}},
+		{"tags only", types.Tags, []string{"erc20"}, []string{"Uniswap", "Dai Stablecoin"}},
+		{"match case", types.MatchCase, []string{"uniswap"}, []string{}},
+		{"match case hit", types.MatchCase, []string{"Uniswap V2"}, []string{"Uniswap V2: Factory Contract", "Uniswap V2: Router 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchFor(t, idx, tt.parts, tt.terms...)
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIndexSources(t *testing.T) {
+	idx := newTestIndex(t)
+	q, _ := ParseQuery([]string{"dai"}, 0)
+	if hits := idx.Search(q, types.Custom); len(hits) != 0 {
+		t.Errorf("expected no hits from the custom database, got %d", len(hits))
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery([]string{"uni*", "dai~2", "Tag:Tokens", "foo bar"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Terms) != 3 || len(q.Facets) != 1 || q.Facets[0] != "tokens" {
+		t.Fatalf("unexpected query %+v", q)
+	}
+	if q.Terms[0].Kind != Prefix || q.Terms[1].Kind != Fuzzy || q.Terms[1].Distance != 2 {
+		t.Errorf("unexpected terms %+v", q.Terms)
+	}
+	if len(q.Terms[2].Words) != 2 {
+		t.Errorf("expected two words, got %v", q.Terms[2].Words)
+	}
+
+	for _, bad := range []string{"tag:", "dai~9", "***"} {
+		if _, err := ParseQuery([]string{bad}, 0); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		max      int
+		expected int
+	}{
+		{"uniswap", "uniswap", 2, 0},
+		{"uniswop", "uniswap", 2, 1},
+		{"unswap", "uniswap", 2, 1},
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 1, 2},
+		{"a", "abcdef", 2, 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, tt.max); got != tt.expected {
+			t.Errorf("editDistance(%s, %s, %d) = %d, expected %d", tt.a, tt.b, tt.max, got, tt.expected)
+		}
+	}
+}
+
+func TestIndexReadWrite(t *testing.T) {
+	idx := newTestIndex(t)
+	idx.Docs[0].Prefund = *base.NewWei(1000)
+
+	path := filepath.Join(t.TempDir(), "search.idx")
+	if err := writeIndex(path, "key", idx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readIndex(path, "other"); err == nil {
+		t.Error("expected an index with a different key to be stale")
+	}
+	got, err := readIndex(path, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Docs[0].Prefund.String() != "1000" {
+		t.Errorf("expected the prefund to survive, got %s", got.Docs[0].Prefund.String())
+	}
+	if expected, found := searchFor(t, idx, 0, "uniswap"), searchFor(t, got, 0, "uniswap"); strings.Join(expected, ",") != strings.Join(found, ",") {
+		t.Errorf("expected %v, got %v", expected, found)
+	}
+}
+
+// BenchmarkIndex500k compares building the index for a database the size of a large custom
+// names file with reading the same index back from the cache, which is what a one-shot
+// search does once the index is on disk.
+func BenchmarkIndex500k(b *testing.B) {
+	words := []string{"uniswap", "router", "factory", "token", "vault", "bridge", "pool", "dao", "wallet", "exchange"}
+	tags := []string{"50-Tokens:ERC20", "55-Defi", "30-Contracts", "90-Individuals"}
+	docs := make([]types.Name, 0, 500000)
+	sources := make([]types.Parts, 0, 500000)
+	for i := 0; i < 500000; i++ {
+		docs = append(docs, types.Name{
+			Address: base.HexToAddress(fmt.Sprintf("0x%040x", i+1)),
+			Name:    fmt.Sprintf("%s %s %d", words[i%len(words)], words[(i/len(words))%len(words)], i),
+			Symbol:  fmt.Sprintf("S%d", i%1000),
+			Tags:    tags[i%len(tags)],
+		})
+		sources = append(sources, types.Regular)
+	}
+
+	idx := NewIndex(docs, sources)
+	path := filepath.Join(b.TempDir(), "search.idx")
+	if err := writeIndex(path, "key", idx); err != nil {
+		b.Fatal(err)
+	}
+	q, err := ParseQuery([]string{"uniswap", "router"}, types.Regular)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("build", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = NewIndex(docs, sources)
+		}
+	})
+	b.Run("read", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := readIndex(path, "key"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = idx.Search(q, types.Regular)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package names
+
+import (
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Strategy says how to resolve a conflict during a merge
+type Strategy string
+
+const (
+	// StrategyNone leaves conflicts unresolved (the local value is kept and the conflict is reported)
+	StrategyNone Strategy = ""
+	// StrategyOurs keeps the local value
+	StrategyOurs Strategy = "ours"
+	// StrategyTheirs takes the imported value
+	StrategyTheirs Strategy = "theirs"
+	// StrategyNewest takes whichever value changed most recently (the local value if unknown)
+	StrategyNewest Strategy = "newest"
+)
+
+// MergedName is a custom name that changed during a merge
+type MergedName struct {
+	Name    types.Name
+	Created bool
+	// Provenance holds the provenance of the fields that were taken from the bundle
+	Provenance NameProvenance
+}
+
+// MergeResult is the result of merging a bundle into the custom names
+type MergeResult struct {
+	Changed   []MergedName
+	Conflicts []types.NameConflict
+	// Base is the merge base to use the next time a bundle from the same author is imported
+	Base map[base.Address]BundleName
+}
+
+// Merge does a three-way merge of the names in theirs into ours field by field using base
+// (the names as they were when last merged with the same author) as the common ancestor.
+// A field changed only in the bundle is taken, a field changed only locally is kept and a
+// field changed on both sides is a conflict which is resolved by strategy. Names missing
+// from the bundle are left alone.
+func Merge(ours, mergeBase map[base.Address]BundleName, theirs *Bundle, strategy Strategy) *MergeResult {
+	ret := &MergeResult{
+		Base: make(map[base.Address]BundleName, len(mergeBase)),
+	}
+	for addr, name := range mergeBase {
+		ret.Base[addr] = name
+	}
+
+	for _, t := range theirs.Names {
+		t := t
+		b, hasBase := mergeBase[t.Address]
+		o, hasOurs := ours[t.Address]
+		if !hasOurs {
+			if hasBase && sameFields(&b, &t) {
+				// we removed it locally and they did not change it since
+				continue
+			}
+			ret.Changed = append(ret.Changed, MergedName{
+				Name:       t.ToName(),
+				Created:    true,
+				Provenance: t.Provenance,
+			})
+			ret.Base[t.Address] = t
+			continue
+		}
+
+		merged := o.ToName()
+		taken := make(NameProvenance)
+		newBase := t
+		newBase.Provenance = nil
+		unresolved := false
+
+		for _, field := range MergeFields {
+			tv, ov := bundleValue(&t, field), bundleValue(&o, field)
+			if tv == ov {
+				continue
+			}
+
+			bv := ""
+			if hasBase {
+				bv = bundleValue(&b, field)
+			}
+
+			takeTheirs := false
+			switch {
+			case hasBase && bv == ov:
+				takeTheirs = true
+			case hasBase && bv == tv:
+				takeTheirs = false
+			default:
+				conflict := types.NameConflict{
+					Address:         t.Address,
+					Name:            o.Name,
+					Field:           field,
+					Base:            bv,
+					Ours:            ov,
+					Theirs:          tv,
+					OursAuthor:      o.Provenance[field].Author,
+					OursTimestamp:   o.Provenance[field].Timestamp,
+					TheirsAuthor:    t.Provenance[field].Author,
+					TheirsTimestamp: t.Provenance[field].Timestamp,
+				}
+				switch strategy {
+				case StrategyOurs:
+					conflict.Resolution = string(StrategyOurs)
+				case StrategyTheirs:
+					conflict.Resolution = string(StrategyTheirs)
+				case StrategyNewest:
+					conflict.Resolution = string(StrategyOurs)
+					if conflict.TheirsTimestamp > conflict.OursTimestamp {
+						conflict.Resolution = string(StrategyTheirs)
+					}
+				default:
+					// remember the old base so the conflict is reported again next time
+					unresolved = true
+					_ = setBundleValue(&newBase, field, bv)
+				}
+				takeTheirs = conflict.Resolution == string(StrategyTheirs)
+				ret.Conflicts = append(ret.Conflicts, conflict)
+			}
+
+			if takeTheirs {
+				_ = SetFieldValue(&merged, field, tv)
+				taken[field] = t.Provenance[field]
+			}
+		}
+
+		if len(taken) > 0 {
+			ret.Changed = append(ret.Changed, MergedName{
+				Name:       merged,
+				Provenance: taken,
+			})
+		}
+		if hasBase || !unresolved {
+			ret.Base[t.Address] = newBase
+		}
+	}
+
+	return ret
+}
+
+func bundleValue(b *BundleName, field string) string {
+	name := b.ToName()
+	return FieldValue(&name, field)
+}
+
+func setBundleValue(b *BundleName, field, value string) error {
+	name := b.ToName()
+	if err := SetFieldValue(&name, field, value); err != nil {
+		return err
+	}
+	b.Name, b.Tags, b.Symbol, b.Source = name.Name, name.Tags, name.Symbol, name.Source
+	b.Decimals, b.Deleted = name.Decimals, name.Deleted
+	return nil
+}
+
+func sameFields(a, b *BundleName) bool {
+	for _, field := range MergeFields {
+		if bundleValue(a, field) != bundleValue(b, field) {
+			return false
+		}
+	}
+	return true
+}
@@ -15,8 +15,15 @@ import (
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
-// LoadNamesMap loads the names from the cache and returns a map of names
+// LoadNamesMap loads the names from the cache and returns a map of names. If there are search
+// terms, the names are found with the search index (see ParseQuery) unless parts includes
+// types.Regex in which case each term is a regular expression matched against every name.
 func LoadNamesMap(chain string, parts types.Parts, terms []string) (map[base.Address]types.Name, error) {
+	if len(terms) > 0 && parts&types.Regex == 0 {
+		namesMap, _, err := searchNames(chain, parts, terms)
+		return namesMap, err
+	}
+
 	namesMap := map[base.Address]types.Name{}
 
 	// Load the prefund names first...
@@ -71,6 +78,7 @@ func ClearCache() {
 
 	regularNames = make(map[base.Address]types.Name)
 	customNames = make(map[base.Address]types.Name)
+	invalidateSearchIndex()
 }
 
 var requiredColumns = []string{
@@ -0,0 +1,147 @@
+package names
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Provenance records where the current value of a field of a custom name came from. A zero
+// timestamp means the time of the change is unknown (for example, names edited before
+// provenance was recorded).
+type Provenance struct {
+	Source    string         `json:"source,omitempty"`
+	Author    base.Address   `json:"author"`
+	Timestamp base.Timestamp `json:"timestamp"`
+}
+
+// NameProvenance maps the fields of a custom name to their provenance
+type NameProvenance map[string]Provenance
+
+// MergeFields are the fields of a custom name carried in bundles and merged on import
+var MergeFields = []string{"name", "tags", "symbol", "source", "decimals", "deleted"}
+
+var provenanceMutex sync.Mutex
+
+func getProvenancePath(chain string) string {
+	return filepath.Join(config.MustGetPathToChainConfig(chain), "names_custom_provenance.json")
+}
+
+// LoadProvenance returns the provenance of the fields of every custom name. Names without
+// recorded provenance are not in the map.
+func LoadProvenance(chain string) (map[base.Address]NameProvenance, error) {
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+	return loadProvenance(chain)
+}
+
+func loadProvenance(chain string) (map[base.Address]NameProvenance, error) {
+	ret := make(map[base.Address]NameProvenance)
+	path := getProvenancePath(chain)
+	if !file.FileExists(path) {
+		return ret, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, &ret); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	return ret, nil
+}
+
+// SetProvenance records the provenance of some of the fields of a custom name. Fields not
+// in fields keep the provenance they had.
+func SetProvenance(chain string, address base.Address, fields NameProvenance) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+
+	all, err := loadProvenance(chain)
+	if err != nil {
+		return err
+	}
+	existing := all[address]
+	if existing == nil {
+		existing = make(NameProvenance)
+	}
+	for field, prov := range fields {
+		existing[field] = prov
+	}
+	all[address] = existing
+
+	contents, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.StringToAsciiFile(getProvenancePath(chain), string(contents))
+}
+
+// StampProvenance records prov as the provenance of every merged field whose value differs
+// between prev (which may be nil for a new name) and next
+func StampProvenance(chain string, prev, next *types.Name, prov Provenance) error {
+	fields := make(NameProvenance)
+	for _, field := range MergeFields {
+		if prev == nil || FieldValue(prev, field) != FieldValue(next, field) {
+			fields[field] = prov
+		}
+	}
+	return SetProvenance(chain, next.Address, fields)
+}
+
+// FieldValue returns the value of one of the MergeFields of a name as a string
+func FieldValue(name *types.Name, field string) string {
+	switch field {
+	case "name":
+		return name.Name
+	case "tags":
+		return name.Tags
+	case "symbol":
+		return name.Symbol
+	case "source":
+		return name.Source
+	case "decimals":
+		return fmt.Sprintf("%d", name.Decimals)
+	case "deleted":
+		return fmt.Sprintf("%t", name.Deleted)
+	}
+	return ""
+}
+
+// SetFieldValue sets one of the MergeFields of a name from its string value
+func SetFieldValue(name *types.Name, field, value string) error {
+	switch field {
+	case "name":
+		name.Name = value
+	case "tags":
+		name.Tags = value
+	case "symbol":
+		name.Symbol = value
+	case "source":
+		name.Source = value
+	case "decimals":
+		decimals, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		name.Decimals = decimals
+	case "deleted":
+		name.Deleted = value == "true"
+	default:
+		return fmt.Errorf("unknown field %s", field)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package names
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// TermKind says how the words of a search term match indexed tokens
+type TermKind int
+
+const (
+	// Plain terms match any token containing them (whole words rank highest, then prefixes)
+	Plain TermKind = iota
+	// Prefix terms (`uni*`) match tokens by prefix
+	Prefix
+	// Fuzzy terms (`uniswop~` or `uniswop~2`) match tokens within an edit distance
+	Fuzzy
+)
+
+// Term is a single search term. A term may contain more than one word (e.g. "Uniswap V2"),
+// all of which must match.
+type Term struct {
+	Words    []string
+	Kind     TermKind
+	Distance int
+	original string
+}
+
+// Query is a parsed set of search terms. Every term and every facet must match.
+type Query struct {
+	Terms     []Term
+	Facets    []string
+	Fields    uint8
+	MatchCase bool
+}
+
+// ParseQuery parses search terms. Terms of the form `tag:value` (or `tags:value`) are facets
+// which restrict results to names with a matching tag. A trailing `*` makes a term a prefix
+// query and a trailing `~` (optionally followed by a distance) makes it a fuzzy query. The
+// fields searched depend on parts in the same way they do for regular expression searches.
+func ParseQuery(terms []string, parts types.Parts) (*Query, error) {
+	q := &Query{
+		Fields:    fieldName | fieldSymbol | fieldAddress | fieldTags,
+		MatchCase: parts&types.MatchCase != 0,
+	}
+	if parts&types.Expanded != 0 {
+		q.Fields |= fieldSource
+	}
+	if parts&types.Tags != 0 {
+		q.Fields = fieldTags
+	}
+
+	for _, t := range terms {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+
+		lower := strings.ToLower(t)
+		if strings.HasPrefix(lower, "tag:") || strings.HasPrefix(lower, "tags:") {
+			facet := strings.TrimSpace(lower[strings.Index(lower, ":")+1:])
+			if facet == "" {
+				return nil, fmt.Errorf("empty tag in search term %s", t)
+			}
+			q.Facets = append(q.Facets, facet)
+			continue
+		}
+
+		term := Term{Kind: Plain, original: t}
+		if pos := strings.LastIndex(t, "~"); pos > 0 {
+			term.Kind = Fuzzy
+			if pos < len(t)-1 {
+				d, err := strconv.Atoi(t[pos+1:])
+				if err != nil || d < 0 || d > 3 {
+					return nil, fmt.Errorf("invalid edit distance in search term %s", t)
+				}
+				term.Distance = d
+			}
+			t = t[:pos]
+		} else if strings.HasSuffix(t, "*") {
+			term.Kind = Prefix
+			t = strings.TrimSuffix(t, "*")
+		}
+
+		term.Words = tokenize(t)
+		if len(term.Words) == 0 {
+			return nil, fmt.Errorf("search term %s has nothing to search for", term.original)
+		}
+		if term.Kind == Fuzzy && term.Distance == 0 && !strings.HasSuffix(term.original, "~0") {
+			// default to one edit for short words and two for longer ones
+			term.Distance = 1
+			if len(t) > 5 {
+				term.Distance = 2
+			}
+		}
+		q.Terms = append(q.Terms, term)
+	}
+
+	return q, nil
+}
+
+// matchesFacets returns true if every facet matches one of the name's tags. Tags are split
+// on colons and a facet matches a tag with or without its numeric prefix (so `tag:tokens`
+// and `tag:50-tokens` both match `50-Tokens:ERC20`).
+func (q *Query) matchesFacets(name *types.Name) bool {
+	if len(q.Facets) == 0 {
+		return true
+	}
+	tags := strings.Split(strings.ToLower(name.Tags), ":")
+	for _, facet := range q.Facets {
+		found := false
+		for _, tag := range tags {
+			bare := tag
+			if dash := strings.Index(tag, "-"); dash > 0 && isDigits(tag[:dash]) {
+				bare = tag[dash+1:]
+			}
+			if strings.HasPrefix(tag, facet) || strings.HasPrefix(bare, facet) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesCase checks the exact case of every non-fuzzy term against the searched fields.
+// The index itself is case-insensitive.
+func (q *Query) matchesCase(name *types.Name) bool {
+	values := make([]string, 0, 5)
+	if q.Fields&fieldName != 0 {
+		values = append(values, name.Name)
+	}
+	if q.Fields&fieldSymbol != 0 {
+		values = append(values, name.Symbol)
+	}
+	if q.Fields&fieldAddress != 0 {
+		values = append(values, name.Address.Hex())
+	}
+	if q.Fields&fieldTags != 0 {
+		values = append(values, name.Tags)
+	}
+	if q.Fields&fieldSource != 0 {
+		values = append(values, name.Source)
+	}
+	searchStr := strings.Join(values, "\t")
+
+	for _, term := range q.Terms {
+		if term.Kind == Fuzzy {
+			continue
+		}
+		if !strings.Contains(searchStr, strings.TrimSuffix(term.original, "*")) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
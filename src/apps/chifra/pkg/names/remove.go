@@ -12,6 +12,8 @@ import (
 )
 
 func RemoveName(dbType DatabaseType, chain string, address base.Address) (name *types.Name, err error) {
+	defer invalidateSearchIndex()
+
 	switch dbType {
 	case DatabaseCustom:
 		return customRemoveName(chain, address)
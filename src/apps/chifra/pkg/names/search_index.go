@@ -0,0 +1,212 @@
+package names
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/prefunds"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// indexVersion changes whenever the layout of the index changes so older files on disk are rebuilt
+const indexVersion = 1
+
+// loadOrder is the order in which the databases overlay each other (later ones win)
+var loadOrder = []types.Parts{types.Prefund, types.Regular, types.Baddress, types.Custom}
+
+var searchIndex *Index
+var searchIndexKey string
+var searchIndexMutex sync.Mutex
+
+// invalidateSearchIndex forgets the in-memory index. Any change to the databases must call it.
+func invalidateSearchIndex() {
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+	searchIndex = nil
+	searchIndexKey = ""
+}
+
+// getSearchIndex returns an index over every name database. The index is kept in the cache
+// and reused until one of the databases changes, so a one-shot search doesn't pay to build
+// it. If the `namesIndex` setting is `memory`, it's instead built once per process.
+func getSearchIndex(chain string, testing bool) *Index {
+	key := indexKey(chain, testing)
+
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+	if searchIndex != nil && searchIndexKey == key {
+		return searchIndex
+	}
+
+	onDisk := config.GetSettings().NamesIndex != "memory" && !testing
+	indexPath := filepath.Join(config.PathToCache(chain), "names", "search.idx")
+	if onDisk {
+		if idx, err := readIndex(indexPath, key); err == nil {
+			searchIndex, searchIndexKey = idx, key
+			return idx
+		}
+	}
+
+	docs, sources := loadAllNames(chain, testing)
+	idx := NewIndex(docs, sources)
+	if onDisk {
+		_ = writeIndex(indexPath, key, idx)
+	}
+	searchIndex, searchIndexKey = idx, key
+	return idx
+}
+
+// loadAllNames loads every name from every database keeping one record per database
+func loadAllNames(chain string, testing bool) ([]types.Name, []types.Parts) {
+	extra := types.Parts(0)
+	if testing {
+		extra = types.Testing
+	}
+
+	docs := make([]types.Name, 0)
+	sources := make([]types.Parts, 0)
+	for _, source := range loadOrder {
+		namesMap := map[base.Address]types.Name{}
+		switch source {
+		case types.Prefund:
+			_ = loadPrefundMap(chain, nil, source|extra, &namesMap)
+		case types.Regular:
+			_ = loadRegularMap(chain, nil, source|extra, &namesMap)
+		case types.Baddress:
+			_ = loadKnownBadresses(chain, nil, source|extra, &namesMap)
+		case types.Custom:
+			_ = loadCustomMap(chain, nil, source|extra, &namesMap)
+		}
+
+		these := make([]types.Name, 0, len(namesMap))
+		for _, name := range namesMap {
+			these = append(these, name)
+		}
+		sort.Slice(these, func(i, j int) bool {
+			return these[i].Address.Hex() < these[j].Address.Hex()
+		})
+		for _, name := range these {
+			docs = append(docs, name)
+			sources = append(sources, source)
+		}
+	}
+	return docs, sources
+}
+
+// indexKey identifies the state of the databases an index was built from
+func indexKey(chain string, testing bool) string {
+	key := fmt.Sprintf("%d-%s-%t", indexVersion, chain, testing)
+	for _, path := range []string{
+		getDatabasePath(chain, DatabaseRegular),
+		getDatabasePath(chain, DatabaseCustom),
+		prefunds.GetPrefundPath(chain),
+	} {
+		if info, err := os.Stat(path); err == nil {
+			key += fmt.Sprintf("-%d-%d", info.Size(), info.ModTime().UnixNano())
+		} else {
+			key += "-0-0"
+		}
+	}
+	return key
+}
+
+type indexFile struct {
+	Key   string
+	Index *Index
+}
+
+func readIndex(path, key string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var contents indexFile
+	if err := gob.NewDecoder(f).Decode(&contents); err != nil {
+		return nil, err
+	}
+	if contents.Key != key || contents.Index == nil {
+		return nil, fmt.Errorf("names index is stale")
+	}
+	return contents.Index, nil
+}
+
+// writeIndex writes the index to a temporary file first so readers never see a partial index
+func writeIndex(path, key string, idx *Index) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(f).Encode(&indexFile{Key: key, Index: idx}); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// searchNames runs a query against the index and overlays the results in the same way
+// LoadNamesMap overlays entire databases. It returns the names along with their addresses
+// in order of relevance.
+func searchNames(chain string, parts types.Parts, terms []string) (map[base.Address]types.Name, []base.Address, error) {
+	q, err := ParseQuery(terms, parts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idx := getSearchIndex(chain, parts&types.Testing != 0)
+	hits := idx.Search(q, parts&types.All)
+
+	namesMap := map[base.Address]types.Name{}
+	for _, source := range loadOrder {
+		for _, hit := range hits {
+			if idx.Sources[hit.Doc] != source {
+				continue
+			}
+			name := idx.Docs[hit.Doc]
+			if existing, ok := namesMap[name.Address]; ok {
+				name.Parts |= existing.Parts
+			}
+			namesMap[name.Address] = name
+		}
+	}
+
+	order := make([]base.Address, 0, len(namesMap))
+	seen := make(map[base.Address]bool, len(namesMap))
+	for _, hit := range hits {
+		addr := idx.Docs[hit.Doc].Address
+		if !seen[addr] {
+			seen[addr] = true
+			order = append(order, addr)
+		}
+	}
+	return namesMap, order, nil
+}
+
+// SearchNames returns the names matching the search terms ordered by relevance. See ParseQuery
+// for the query syntax.
+func SearchNames(chain string, parts types.Parts, terms []string) ([]types.Name, error) {
+	namesMap, order, err := searchNames(chain, parts, terms)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]types.Name, 0, len(order))
+	for _, addr := range order {
+		ret = append(ret, namesMap[addr])
+	}
+	return ret, nil
+}
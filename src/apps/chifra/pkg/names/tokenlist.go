@@ -0,0 +1,89 @@
+package names
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// TokenList is a list of tokens in the Uniswap token list format (see https://tokenlists.org)
+type TokenList struct {
+	Name   string           `json:"name"`
+	Tokens []TokenListEntry `json:"tokens"`
+}
+
+// TokenListEntry is a single token in a TokenList
+type TokenListEntry struct {
+	ChainId  uint64   `json:"chainId"`
+	Address  string   `json:"address"`
+	Name     string   `json:"name"`
+	Symbol   string   `json:"symbol"`
+	Decimals uint64   `json:"decimals"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// ReadTokenList reads a token list
+func ReadTokenList(r io.Reader) (*TokenList, error) {
+	var list TokenList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("could not read token list: %w", err)
+	}
+	return &list, nil
+}
+
+// LoadTokenList reads a token list from a file or, if pathOrUrl starts with http, downloads it
+func LoadTokenList(pathOrUrl string) (*TokenList, error) {
+	if !strings.HasPrefix(pathOrUrl, "http") {
+		f, err := os.Open(pathOrUrl)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ReadTokenList(f)
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(pathOrUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download token list %s: %s", pathOrUrl, resp.Status)
+	}
+	return ReadTokenList(resp.Body)
+}
+
+// Names returns the tokens on the given chain as names keyed by address. Each name's source
+// is the name of the list.
+func (l *TokenList) Names(chainId uint64) map[base.Address]types.Name {
+	ret := make(map[base.Address]types.Name, len(l.Tokens))
+	source := "TokenList"
+	if len(l.Name) > 0 {
+		source += ": " + l.Name
+	}
+	for _, token := range l.Tokens {
+		if token.ChainId != chainId || !base.IsValidAddress(token.Address) {
+			continue
+		}
+		addr := base.HexToAddress(token.Address)
+		ret[addr] = types.Name{
+			Address:    addr,
+			Name:       strings.TrimSpace(token.Name),
+			Symbol:     strings.TrimSpace(token.Symbol),
+			Decimals:   token.Decimals,
+			Tags:       "50-Tokens:ERC20",
+			Source:     source,
+			IsContract: true,
+			IsErc20:    true,
+		}
+	}
+	return ret
+}
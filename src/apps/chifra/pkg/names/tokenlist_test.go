@@ -0,0 +1,48 @@
+package names
+
+import (
+	"strings"
+	"testing"
+)
+
+var inputTokenList = `{
+  "name": "Uniswap Labels Default",
+  "timestamp": "2024-01-01T00:00:00.000Z",
+  "version": { "major": 1, "minor": 0, "patch": 0 },
+  "tokens": [
+    { "chainId": 1, "address": "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984", "name": "Uniswap ", "symbol": "UNI", "decimals": 18 },
+    { "chainId": 1, "address": "0x6B175474E89094C44Da98b954EedeAC495271d0F", "name": "Dai Stablecoin", "symbol": "DAI", "decimals": 18, "tags": ["stablecoin"] },
+    { "chainId": 10, "address": "0xDA10009cBd5D07dd0CeCc66161FC93D7c9000da1", "name": "Dai Stablecoin", "symbol": "DAI", "decimals": 18 },
+    { "chainId": 1, "address": "not-an-address", "name": "Broken", "symbol": "BRK", "decimals": 0 }
+  ]
+}`
+
+func TestTokenList(t *testing.T) {
+	list, err := ReadTokenList(strings.NewReader(inputTokenList))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := list.Names(1)
+	if len(names) != 2 {
+		t.Fatalf("expected two names on mainnet, got %d", len(names))
+	}
+	uni, ok := names[addr1]
+	if !ok {
+		t.Fatal("expected to find Uniswap")
+	}
+	if uni.Name != "Uniswap" || uni.Symbol != "UNI" || uni.Decimals != 18 || !uni.IsErc20 {
+		t.Errorf("unexpected name %+v", uni)
+	}
+	if uni.Source != "TokenList: Uniswap Labels Default" {
+		t.Errorf("unexpected source %s", uni.Source)
+	}
+
+	if len(list.Names(10)) != 1 {
+		t.Error("expected one name on optimism")
+	}
+
+	if _, err := ReadTokenList(strings.NewReader("not json")); err == nil {
+		t.Error("expected an error for a malformed list")
+	}
+}
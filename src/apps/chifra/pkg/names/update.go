@@ -8,6 +8,8 @@ import (
 )
 
 func UpdateName(dbType DatabaseType, chain string, name *types.Name) (err error) {
+	defer invalidateSearchIndex()
+
 	switch dbType {
 	case DatabaseCustom:
 		return updateCustomName(name)
@@ -11,6 +11,8 @@ import (
 )
 
 func CustomWriteNames(chain string, dryRun bool) (err error) {
+	defer invalidateSearchIndex()
+
 	database := DatabaseCustom
 	if dryRun {
 		database = DatabaseDryRun
@@ -27,6 +29,8 @@ func CustomWriteNames(chain string, dryRun bool) (err error) {
 }
 
 func RegularWriteNames(chain string, dryRun bool) (err error) {
+	defer invalidateSearchIndex()
+
 	database := DatabaseRegular
 	if dryRun {
 		database = DatabaseDryRun
@@ -3,6 +3,7 @@ package output
 import (
 	"context"
 
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/progress"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
@@ -11,6 +12,10 @@ type RenderCtx struct {
 	Cancel    context.CancelFunc `json:"-"`
 	ModelChan chan types.Modeler `json:"-"`
 	ErrorChan chan error         `json:"-"`
+	Progress  *progress.Tracker  `json:"-"`
+	// Options are the output options of the command that sends models to ModelChan. They are
+	// set before the first model is sent so the receiver can render the models as the command would.
+	Options *OutputOptions `json:"-"`
 }
 
 func NewRenderContext() *RenderCtx {
@@ -37,3 +42,19 @@ func (r *RenderCtx) WasCanceled() bool {
 		return false
 	}
 }
+
+type renderCtxKey struct{}
+
+// WithRenderContext returns a copy of the context carrying the render context. The API server
+// uses it to hand a command the render context it will later cancel or report progress from.
+func WithRenderContext(ctx context.Context, rCtx *RenderCtx) context.Context {
+	return context.WithValue(ctx, renderCtxKey{}, rCtx)
+}
+
+// RenderContextFrom returns the render context carried by the context or a new one if there is none
+func RenderContextFrom(ctx context.Context) *RenderCtx {
+	if rCtx, ok := ctx.Value(renderCtxKey{}).(*RenderCtx); ok {
+		return rCtx
+	}
+	return NewRenderContext()
+}
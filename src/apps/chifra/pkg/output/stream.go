@@ -107,6 +107,7 @@ type fetchDataFunc func(modelChan chan types.Modeler, errorChan chan error)
 // StreamMany outputs models as they are acquired
 func StreamMany(rCtx *RenderCtx, fetchData fetchDataFunc, options OutputOptions) error {
 	if rCtx.ModelChan != nil {
+		rCtx.Options = &options
 		fetchData(rCtx.ModelChan, rCtx.ErrorChan)
 		return nil
 	}
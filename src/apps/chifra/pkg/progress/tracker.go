@@ -0,0 +1,36 @@
+// Copyright 2021 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+
+package progress
+
+import "sync"
+
+// Tracker keeps the latest progress of a long-running command so it may be reported elsewhere
+// (for example, by the API server's job API). It is safe for concurrent use. A nil Tracker
+// ignores updates.
+type Tracker struct {
+	mutex sync.Mutex
+	done  int64
+	total int64
+}
+
+// Track records the progress. Progress bars call it on every tick.
+func (t *Tracker) Track(done, total int64) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.done, t.total = done, total
+}
+
+// Progress returns the latest progress recorded
+func (t *Tracker) Progress() (done, total int64) {
+	if t == nil {
+		return 0, 0
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.done, t.total
+}
@@ -0,0 +1,2 @@
+// Package proof verifies Merkle-Patricia proofs of account state and storage (EIP-1186) against a block's state root
+package proof
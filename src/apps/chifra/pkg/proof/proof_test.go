@@ -0,0 +1,186 @@
+package proof
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// testdata/proofs.json holds eth_getProof responses for a contract (with two present and one
+// absent storage slot), an externally owned account, and an account that does not exist,
+// along with the state root of the block they were taken from.
+type fixture struct {
+	Block struct {
+		StateRoot base.Hash `json:"stateRoot"`
+	} `json:"block"`
+	Proofs []json.RawMessage `json:"proofs"`
+}
+
+func loadProofs(t *testing.T) []types.Proof {
+	bytes, err := os.ReadFile("testdata/proofs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f fixture
+	if err := json.Unmarshal(bytes, &f); err != nil {
+		t.Fatal(err)
+	}
+	ret := make([]types.Proof, 0, len(f.Proofs))
+	for _, data := range f.Proofs {
+		p, err := rpc.ParseProof(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.StateRoot = f.Block.StateRoot
+		ret = append(ret, *p)
+	}
+	return ret
+}
+
+func TestVerify(t *testing.T) {
+	proofs := loadProofs(t)
+	if len(proofs) != 3 {
+		t.Fatalf("expected three proofs, got %d", len(proofs))
+	}
+
+	for _, p := range proofs {
+		if err := Verify(&p); err != nil || !p.Consistent {
+			t.Errorf("%s: expected the proof to verify, got %v", p.Address.Hex(), err)
+		}
+		if p.Verified {
+			t.Errorf("%s: expected the proof to be unverified without a trusted root", p.Address.Hex())
+		}
+		for _, sp := range p.StorageProof {
+			if !sp.Verified {
+				t.Errorf("%s: expected slot %s to verify", p.Address.Hex(), sp.Key.Hex())
+			}
+		}
+	}
+
+	if len(proofs[0].StorageProof) != 3 || proofs[0].StorageProof[2].Value.BigInt().Sign() != 0 {
+		t.Error("expected the third storage proof to prove an empty slot")
+	}
+	if proofs[1].Nonce != 42 {
+		t.Errorf("expected a nonce of 42, got %d", proofs[1].Nonce)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(p []types.Proof) *types.Proof
+	}{
+		{"balance", func(p []types.Proof) *types.Proof {
+			p[1].Balance = *base.NewWei(1)
+			return &p[1]
+		}},
+		{"nonce", func(p []types.Proof) *types.Proof {
+			p[1].Nonce++
+			return &p[1]
+		}},
+		{"state root", func(p []types.Proof) *types.Proof {
+			p[1].StateRoot = base.HexToHash("0x01")
+			return &p[1]
+		}},
+		{"missing node", func(p []types.Proof) *types.Proof {
+			p[1].AccountProof = p[1].AccountProof[:len(p[1].AccountProof)-1]
+			return &p[1]
+		}},
+		{"altered node", func(p []types.Proof) *types.Proof {
+			last := len(p[1].AccountProof) - 1
+			node := p[1].AccountProof[last]
+			p[1].AccountProof[last] = node[:len(node)-2] + "00"
+			return &p[1]
+		}},
+		{"absent account with balance", func(p []types.Proof) *types.Proof {
+			p[2].Balance = *base.NewWei(1)
+			return &p[2]
+		}},
+		{"storage value", func(p []types.Proof) *types.Proof {
+			p[0].StorageProof[1].Value = *base.NewWei(99)
+			return &p[0]
+		}},
+		{"empty slot with value", func(p []types.Proof) *types.Proof {
+			p[0].StorageProof[2].Value = *base.NewWei(1)
+			return &p[0]
+		}},
+		{"storage hash", func(p []types.Proof) *types.Proof {
+			p[0].StorageHash = emptyRoot
+			return &p[0]
+		}},
+	}
+
+	for _, tt := range tests {
+		p := tt.tamper(loadProofs(t))
+		if err := Verify(p); err == nil || p.Consistent {
+			t.Errorf("%s: expected a tampered proof to fail", tt.name)
+		}
+	}
+
+	// a bad storage proof fails only that slot
+	proofs := loadProofs(t)
+	proofs[0].StorageProof[0].Value = *base.NewWei(1)
+	if err := Verify(&proofs[0]); err == nil {
+		t.Error("expected a tampered storage proof to fail")
+	}
+	if proofs[0].StorageProof[0].Verified || !proofs[0].StorageProof[1].Verified {
+		t.Error("expected only the tampered slot to be unverified")
+	}
+}
+
+func TestVerifyRoot(t *testing.T) {
+	proofs := loadProofs(t)
+	p := &proofs[1]
+	trusted := p.StateRoot
+	if err := Verify(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyRoot(p, trusted); err != nil || !p.Verified {
+		t.Errorf("expected the proof to verify against its own state root, got %v", err)
+	}
+
+	if err := VerifyRoot(p, base.HexToHash("0x01")); err == nil || p.Verified {
+		t.Error("expected the proof to fail against a different state root")
+	}
+
+	// a root that matches does not make an inconsistent proof verified
+	p.Nonce++
+	_ = Verify(p)
+	if err := VerifyRoot(p, trusted); err != nil || p.Verified {
+		t.Error("expected an inconsistent proof to stay unverified")
+	}
+}
+
+func TestDecodeCompact(t *testing.T) {
+	tests := []struct {
+		encoded []byte
+		nibbles []byte
+		isLeaf  bool
+	}{
+		{[]byte{0x00, 0x12}, []byte{1, 2}, false},
+		{[]byte{0x11, 0x23}, []byte{1, 2, 3}, false},
+		{[]byte{0x20, 0x0f}, []byte{0, 15}, true},
+		{[]byte{0x3f}, []byte{15}, true},
+	}
+	for _, tt := range tests {
+		nibbles, isLeaf := decodeCompact(tt.encoded)
+		if string(nibbles) != string(tt.nibbles) || isLeaf != tt.isLeaf {
+			t.Errorf("decodeCompact(%x) = %v, %v want %v, %v", tt.encoded, nibbles, isLeaf, tt.nibbles, tt.isLeaf)
+		}
+	}
+}
+
+func TestEmptyTrie(t *testing.T) {
+	if value, err := VerifyProof(emptyRoot, []byte{1}, nil); value != nil || err != nil {
+		t.Error("expected an empty trie to prove absence")
+	}
+	if _, err := VerifyProof(base.HexToHash("0x01"), []byte{1}, nil); !errors.Is(err, ErrInvalidProof) {
+		t.Error("expected a missing root to fail")
+	}
+}
@@ -0,0 +1,167 @@
+package proof
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// emptyRoot is the root hash of an empty trie, keccak(rlp(""))
+var emptyRoot = base.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// VerifyProof walks a Merkle-Patricia proof from root along the path of key (which is hashed
+// as it is in Ethereum's secure tries). It returns the value stored at key or nil if the proof
+// shows that key is absent from the trie. Every node visited must be in the proof and must hash
+// to the reference that led to it, so a node cannot lie about the value without the root changing.
+func VerifyProof(root base.Hash, key []byte, nodes []string) ([]byte, error) {
+	db := make(map[string][]byte, len(nodes))
+	for _, node := range nodes {
+		raw := base.Hex2Bytes(strings.TrimPrefix(node, "0x"))
+		db[string(crypto.Keccak256(raw))] = raw
+	}
+
+	if root == emptyRoot && len(nodes) == 0 {
+		return nil, nil
+	}
+
+	path := keyToNibbles(crypto.Keccak256(key))
+	node, ok := db[string(root.Bytes())]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing root node", ErrInvalidProof)
+	}
+
+	for {
+		items, err := splitNode(node)
+		if err != nil {
+			return nil, err
+		}
+
+		var child []byte
+		switch len(items) {
+		case 17:
+			if len(path) == 0 {
+				return stringContent(items[16])
+			}
+			child, path = items[path[0]], path[1:]
+
+		case 2:
+			encoded, err := stringContent(items[0])
+			if err != nil {
+				return nil, err
+			}
+			nibbles, isLeaf := decodeCompact(encoded)
+			if !hasPrefix(path, nibbles) {
+				// the path diverges, so the key is not in the trie
+				return nil, nil
+			}
+			path = path[len(nibbles):]
+			if isLeaf {
+				if len(path) != 0 {
+					return nil, nil
+				}
+				return stringContent(items[1])
+			}
+			child = items[1]
+
+		default:
+			return nil, fmt.Errorf("%w: node with %d items", ErrInvalidProof, len(items))
+		}
+
+		if node, err = resolve(child, db); err != nil || node == nil {
+			return nil, err
+		}
+	}
+}
+
+// resolve follows a reference to a child node. Nodes shorter than thirty-two bytes are
+// embedded in their parent, others are referenced by hash. An empty reference means the
+// key is absent.
+func resolve(child []byte, db map[string][]byte) ([]byte, error) {
+	kind, content, _, err := rlp.Split(child)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+
+	switch {
+	case kind == rlp.List:
+		return child, nil
+	case len(content) == 0:
+		return nil, nil
+	case len(content) == 32:
+		if node, ok := db[string(content)]; ok {
+			return node, nil
+		}
+		return nil, fmt.Errorf("%w: missing node %s", ErrInvalidProof, base.Bytes2Hex(content))
+	default:
+		return nil, fmt.Errorf("%w: bad reference", ErrInvalidProof)
+	}
+}
+
+// splitNode returns the raw RLP encoding of each item in a node
+func splitNode(node []byte) ([][]byte, error) {
+	content, _, err := rlp.SplitList(node)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	items := make([][]byte, 0, 17)
+	for len(content) > 0 {
+		_, _, rest, err := rlp.Split(content)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+		}
+		items = append(items, content[:len(content)-len(rest)])
+		content = rest
+	}
+	return items, nil
+}
+
+func stringContent(item []byte) ([]byte, error) {
+	content, _, err := rlp.SplitString(item)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProof, err)
+	}
+	if len(content) == 0 {
+		return nil, nil
+	}
+	return content, nil
+}
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+// decodeCompact decodes the hex-prefix encoding of a leaf or extension node's path. The
+// first nibble's flags say whether the node is a leaf and whether the path has odd length.
+func decodeCompact(encoded []byte) ([]byte, bool) {
+	if len(encoded) == 0 {
+		return nil, false
+	}
+	nibbles := keyToNibbles(encoded)
+	isLeaf := nibbles[0]&2 != 0
+	if nibbles[0]&1 != 0 {
+		return nibbles[1:], isLeaf
+	}
+	return nibbles[2:], isLeaf
+}
+
+func hasPrefix(path, prefix []byte) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
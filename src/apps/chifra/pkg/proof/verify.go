@@ -0,0 +1,108 @@
+package proof
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// emptyCodeHash is keccak(""), the code hash of accounts without code
+var emptyCodeHash = base.HexToHash("0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470")
+
+type account struct {
+	Nonce       uint64
+	Balance     *big.Int
+	StorageHash []byte
+	CodeHash    []byte
+}
+
+// Verify checks the account proof against the proof's state root and each storage proof
+// against the proven storage root. It sets the proof's Consistent flag and the storage proofs'
+// Verified flags and returns the first failure. The proof as a whole is consistent only if
+// every part of it is. Because the state root comes from the node that sent the proof, Verify
+// leaves the proof's Verified flag alone (see VerifyRoot).
+func Verify(p *types.Proof) error {
+	p.Consistent = false
+	for i := range p.StorageProof {
+		p.StorageProof[i].Verified = false
+	}
+
+	if err := verifyAccount(p); err != nil {
+		return err
+	}
+
+	var firstErr error
+	for i := range p.StorageProof {
+		if err := verifyStorage(p.StorageHash, &p.StorageProof[i]); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		p.StorageProof[i].Verified = true
+	}
+
+	p.Consistent = firstErr == nil
+	return firstErr
+}
+
+// VerifyRoot marks a consistent proof as verified if its state root is the trusted root.
+func VerifyRoot(p *types.Proof, root base.Hash) error {
+	p.Verified = false
+	if p.StateRoot != root {
+		return fmt.Errorf("%w: the node reported state root %s at block %d, not %s", ErrInvalidProof, p.StateRoot.Hex(), p.BlockNumber, root.Hex())
+	}
+	p.Verified = p.Consistent
+	return nil
+}
+
+func verifyAccount(p *types.Proof) error {
+	value, err := VerifyProof(p.StateRoot, p.Address.Bytes(), p.AccountProof)
+	if err != nil {
+		return fmt.Errorf("account %s: %w", p.Address.Hex(), err)
+	}
+
+	var acct account
+	if value == nil {
+		// the account does not exist so it must be reported as empty
+		acct = account{Balance: new(big.Int), StorageHash: emptyRoot.Bytes(), CodeHash: emptyCodeHash.Bytes()}
+	} else if err := rlp.DecodeBytes(value, &acct); err != nil {
+		return fmt.Errorf("account %s: %w: %s", p.Address.Hex(), ErrInvalidProof, err)
+	}
+
+	switch {
+	case uint64(p.Nonce) != acct.Nonce:
+		return fmt.Errorf("account %s: nonce %d does not match proven nonce %d", p.Address.Hex(), p.Nonce, acct.Nonce)
+	case p.Balance.BigInt().Cmp(acct.Balance) != 0:
+		return fmt.Errorf("account %s: balance %s does not match proven balance %s", p.Address.Hex(), p.Balance.String(), acct.Balance.String())
+	case p.StorageHash != base.BytesToHash(acct.StorageHash):
+		return fmt.Errorf("account %s: storage hash does not match the proven storage hash", p.Address.Hex())
+	case p.CodeHash != base.BytesToHash(acct.CodeHash):
+		return fmt.Errorf("account %s: code hash does not match the proven code hash", p.Address.Hex())
+	}
+	return nil
+}
+
+func verifyStorage(storageHash base.Hash, sp *types.StorageProof) error {
+	value, err := VerifyProof(storageHash, sp.Key.Bytes(), sp.Proof)
+	if err != nil {
+		return fmt.Errorf("slot %s: %w", sp.Key.Hex(), err)
+	}
+
+	proven := new(big.Int)
+	if value != nil {
+		var raw []byte
+		if err := rlp.DecodeBytes(value, &raw); err != nil {
+			return fmt.Errorf("slot %s: %w: %s", sp.Key.Hex(), ErrInvalidProof, err)
+		}
+		proven.SetBytes(raw)
+	}
+
+	if sp.Value.BigInt().Cmp(proven) != 0 {
+		return fmt.Errorf("slot %s: value %s does not match proven value %s", sp.Key.Hex(), sp.Value.String(), proven.String())
+	}
+	return nil
+}
@@ -53,6 +53,13 @@ func (conn *Connection) GetClientIDs() (uint64, uint64, error) {
 
 // GetLatestBlockNumber returns the block number at the front of the chain (i.e. latest)
 func (conn *Connection) GetLatestBlockNumber() base.Blknum {
+	if conn.heads != nil {
+		// following the chain's head, so there's no need to ask
+		if bn, ok := conn.heads.following(); ok {
+			return bn
+		}
+	}
+
 	if ec, err := conn.getClient(); err != nil {
 		logger.Error("Could not connect to RPC client", err)
 		return 0
@@ -93,7 +100,7 @@ func (conn *Connection) getClient() (*ethclient.Client, error) {
 	defer clientMutex.Unlock()
 
 	if perProviderClientMap[provider] == nil {
-		ec, err := ethclient.Dial(provider)
+		ec, err := query.DialClient(provider)
 		if err != nil || ec == nil {
 			logger.Error("Missdial("+provider+"):", err)
 			logger.Fatal("")
@@ -16,6 +16,7 @@ type Connection struct {
 	Store                *cache.Store // Cache Store to use for read/write. Write can be disabled by setting Store to read-only mode
 	LatestBlockTimestamp base.Timestamp
 	EnabledMap           map[walk.CacheType]bool
+	heads                *HeadFollower // set by FollowHeads, answers GetLatestBlockNumber
 }
 
 // settings allows every command has its own options type, we have to
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"sync"
 
@@ -137,3 +138,38 @@ func (conn *Connection) GetContractDeployBlock(address base.Address) (block base
 	deployedCache[address] = block
 	return
 }
+
+// GetContractCreator returns the account that sent the transaction that deployed a contract
+// (the deployer) and, if the contract was created by another contract, the creating contract
+// (the factory) along with the block of the deployment. It requires a node that serves traces.
+func (conn *Connection) GetContractCreator(address base.Address) (deployer, factory base.Address, bn base.Blknum, err error) {
+	if bn, err = conn.GetContractDeployBlock(address); err != nil {
+		return
+	}
+
+	traces, err := conn.GetTracesByBlockNumber(bn)
+	if err != nil {
+		return
+	}
+
+	for _, trace := range traces {
+		if trace.TraceType != "create" || trace.Action == nil || trace.Result == nil || trace.Result.Address != address {
+			continue
+		}
+		if len(trace.TraceAddress) == 0 {
+			deployer = trace.Action.From
+			return
+		}
+		factory = trace.Action.From
+		for _, t := range traces {
+			if t.TransactionIndex == trace.TransactionIndex && len(t.TraceAddress) == 0 && t.Action != nil {
+				deployer = t.Action.From
+				break
+			}
+		}
+		return
+	}
+
+	err = fmt.Errorf("no trace in block %d creates %s", bn, address.Hex())
+	return
+}
@@ -5,7 +5,10 @@
 package rpc
 
 import (
+	"fmt"
+
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/walk"
 )
 
@@ -49,3 +52,22 @@ func (conn *Connection) GetBlockHashByNumber(bn base.Blknum) (base.Hash, error)
 		return block.Hash, err
 	}
 }
+
+// GetL1OriginBlock returns the L1 block from which an L2 block derives. Only nodes that report
+// the l1BlockNumber of each block (Arbitrum, for example) support this.
+func (conn *Connection) GetL1OriginBlock(bn base.Blknum) (base.Blknum, error) {
+	type l1Header struct {
+		L1BlockNumber string `json:"l1BlockNumber"`
+	}
+
+	method := "eth_getBlockByNumber"
+	params := query.Params{fmt.Sprintf("0x%x", bn), false}
+
+	if header, err := query.Query[l1Header](conn.Chain, method, params); err != nil {
+		return 0, err
+	} else if len(header.L1BlockNumber) == 0 {
+		return 0, fmt.Errorf("the node does not report an L1 origin for block %d", bn)
+	} else {
+		return base.MustParseBlknum(header.L1BlockNumber), nil
+	}
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// GetProof returns the EIP-1186 proof of an account's state and of the given storage slots at
+// a block along with the block's state root (search: FromRpc). The proof is not verified here.
+func (conn *Connection) GetProof(address base.Address, slots []base.Hash, blockNumber base.Blknum) (*types.Proof, error) {
+	keys := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		keys = append(keys, slot.Hex())
+	}
+
+	payloads := []query.BatchPayload{
+		{
+			Key: "proof",
+			Payload: &query.Payload{
+				Method: "eth_getProof",
+				Params: query.Params{address, keys, fmt.Sprintf("0x%x", blockNumber)},
+			},
+		},
+		{
+			Key: "header",
+			Payload: &query.Payload{
+				Method: "eth_getBlockByNumber",
+				Params: query.Params{fmt.Sprintf("0x%x", blockNumber), false},
+			},
+		},
+	}
+
+	results, errs, err := query.QueryBatchEach[json.RawMessage](conn.Chain, payloads)
+	if err != nil {
+		return nil, err
+	}
+	// the node's own error (for example, pruned state) is more useful than an empty result
+	for _, key := range []string{"proof", "header"} {
+		if errs[key] != nil {
+			return nil, errs[key]
+		}
+	}
+	if results["proof"] == nil || results["header"] == nil || string(*results["header"]) == "null" {
+		return nil, fmt.Errorf("no proof found for %s at block %d", address.Hex(), blockNumber)
+	}
+
+	var header struct {
+		StateRoot base.Hash `json:"stateRoot"`
+		Timestamp string    `json:"timestamp"`
+	}
+	if err := json.Unmarshal(*results["header"], &header); err != nil {
+		return nil, err
+	}
+
+	proof, err := ParseProof(*results["proof"])
+	if err != nil {
+		return nil, err
+	}
+	proof.BlockNumber = blockNumber
+	proof.Timestamp = base.MustParseTimestamp(header.Timestamp)
+	proof.StateRoot = header.StateRoot
+	return proof, nil
+}
+
+// ParseProof parses the result of eth_getProof. Nodes return quantities and storage keys
+// as unpadded hex, so those fields need help.
+func ParseProof(data []byte) (*types.Proof, error) {
+	var response struct {
+		types.Proof
+		Nonce        string `json:"nonce"`
+		StorageProof []struct {
+			types.StorageProof
+			Key string `json:"key"`
+		} `json:"storageProof"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+
+	proof := response.Proof
+	proof.Nonce = base.MustParseValue(response.Nonce)
+	proof.StorageProof = make([]types.StorageProof, 0, len(response.StorageProof))
+	for _, sp := range response.StorageProof {
+		sp.StorageProof.Key = base.HexToHash(sp.Key)
+		proof.StorageProof = append(proof.StorageProof, sp.StorageProof)
+	}
+	return &proof, nil
+}
@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// GetRevertData returns the raw bytes a failed transaction reverted with (or an empty string
+// if the node cannot tell us). We first ask the node for a call trace of the top-level call and,
+// if that's not available, we replay the transaction with eth_call against the parent block.
+// The replay is an approximation since it ignores earlier transactions in the same block.
+func (conn *Connection) GetRevertData(tx *types.Transaction) (string, error) {
+	if tx == nil || tx.Hash.IsZero() {
+		return "", nil
+	}
+
+	method := "debug_traceTransaction"
+	params := query.Params{
+		tx.Hash.Hex(),
+		map[string]any{
+			"tracer":       "callTracer",
+			"tracerConfig": map[string]any{"onlyTopCall": true},
+		},
+	}
+	type callFrame struct {
+		Output string `json:"output"`
+	}
+	if frame, err := query.Query[callFrame](conn.Chain, method, params); err == nil && frame != nil {
+		if len(frame.Output) >= 10 {
+			return frame.Output, nil
+		}
+	}
+
+	if tx.BlockNumber == 0 {
+		return "", nil
+	}
+
+	call := map[string]any{
+		"from": tx.From.Hex(),
+		"data": tx.Input,
+	}
+	if !tx.To.IsZero() {
+		call["to"] = tx.To.Hex()
+	}
+	if !tx.Value.IsZero() {
+		call["value"] = "0x" + tx.Value.Text(16)
+	}
+	if tx.Gas > 0 {
+		call["gas"] = fmt.Sprintf("0x%x", tx.Gas)
+	}
+
+	method = "eth_call"
+	params = query.Params{call, fmt.Sprintf("0x%x", tx.BlockNumber-1)}
+	if _, err := query.Query[string](conn.Chain, method, params); err != nil {
+		if data, ok := query.RevertData(err); ok {
+			return data, nil
+		}
+		return "", err
+	}
+
+	return "", nil
+}
+
+// GetRevertDataByReceipt is like GetRevertData, but for callers that only hold a receipt.
+func (conn *Connection) GetRevertDataByReceipt(receipt *types.Receipt) (string, error) {
+	if receipt == nil || !receipt.IsError {
+		return "", nil
+	}
+
+	tx, err := conn.GetTransactionByNumberAndId(receipt.BlockNumber, receipt.TransactionIndex)
+	if err != nil {
+		return "", err
+	}
+	if tx.Hash.IsZero() {
+		tx.Hash = receipt.TransactionHash
+	}
+
+	return conn.GetRevertData(tx)
+}
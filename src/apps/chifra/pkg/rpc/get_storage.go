@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/walk"
+)
+
+// GetStorageAt returns the raw contents of a storage slot at a block (search: FromRpc)
+func (conn *Connection) GetStorageAt(address base.Address, slot base.Hash, blockNumber base.Blknum) (*types.Slot, error) {
+	if conn.StoreReadable() {
+		// walk.Cache_State
+		cached := &types.Slot{
+			BlockNumber: blockNumber,
+			Address:     address,
+			Slot:        slot,
+		}
+		if err := conn.Store.Read(cached, nil); err == nil {
+			return cached, nil
+		}
+	}
+
+	method := "eth_getStorageAt"
+	params := query.Params{
+		address,
+		slot.Hex(),
+		fmt.Sprintf("0x%x", blockNumber),
+	}
+
+	value, err := query.Query[string](conn.Chain, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &types.Slot{
+		BlockNumber: blockNumber,
+		Timestamp:   conn.GetBlockTimestamp(blockNumber),
+		Address:     address,
+		Slot:        slot,
+		Value:       padStorage(*value),
+	}
+
+	isFinal := base.IsFinal(conn.LatestBlockTimestamp, ret.Timestamp)
+	if isFinal && conn.StoreWritable() && conn.EnabledMap[walk.Cache_State] {
+		_ = conn.Store.Write(ret, nil)
+	}
+
+	return ret, nil
+}
+
+// padStorage normalizes a slot's contents to thirty-two bytes. Some nodes strip leading zeros.
+func padStorage(value string) string {
+	value = strings.TrimPrefix(value, "0x")
+	if len(value) < 64 {
+		value = strings.Repeat("0", 64-len(value)) + value
+	}
+	return "0x" + value
+}
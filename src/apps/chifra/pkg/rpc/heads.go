@@ -0,0 +1,233 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/rpc/query"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// resubscribeDelay is how long the follower polls after losing its subscription before it
+// tries to subscribe again
+const resubscribeDelay = 30 * time.Second
+
+// HeadFollower follows the front of the chain. It subscribes to newHeads on a websocket or IPC
+// endpoint and wakes its caller as soon as a block arrives. While the subscription is down, it
+// polls for the latest block.
+type HeadFollower struct {
+	url      string
+	interval time.Duration
+	latest   func() (base.Blknum, error)
+	wake     chan struct{}
+
+	mutex      sync.Mutex
+	head       base.Blknum
+	missed     uint64
+	subscribed bool
+	stopped    bool
+}
+
+// FollowHeads starts following the chain's head until the context is cancelled. While it does,
+// GetLatestBlockNumber answers from the follower instead of asking the node. If the subscription
+// drops, it asks for the latest block every interval until it can subscribe again. It returns nil
+// if the chain has no websocket or IPC endpoint, as following would only add to the polling
+// its caller already does.
+func (conn *Connection) FollowHeads(ctx context.Context, interval time.Duration) *HeadFollower {
+	url := subscriptionEndpoint(conn.Chain)
+	if len(url) == 0 {
+		logger.Warn("The chain has no websocket or IPC endpoint to subscribe to, so there is no head to follow.")
+		return nil
+	}
+
+	latest := func() (base.Blknum, error) {
+		bn, err := query.Query[string](conn.Chain, "eth_blockNumber", query.Params{})
+		if err != nil {
+			return 0, err
+		}
+		return base.MustParseBlknum(*bn), nil
+	}
+	f := newHeadFollower(url, interval, latest)
+	conn.heads = f
+	go f.run(ctx)
+	return f
+}
+
+func newHeadFollower(url string, interval time.Duration, latest func() (base.Blknum, error)) *HeadFollower {
+	return &HeadFollower{
+		url:      url,
+		interval: interval,
+		latest:   latest,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// subscriptionEndpoint returns the chain's rpcProvider if it can carry a subscription or else the
+// first of its rpcProviders that can, or an empty string if there is none
+func subscriptionEndpoint(chain string) string {
+	ch := config.GetChain(chain)
+	urls := []string{ch.RpcProvider}
+	for _, group := range ch.RpcProviders {
+		urls = append(urls, group.Url)
+	}
+	for _, url := range urls {
+		if kind, _ := config.RpcTransport(url); kind == "ws" || kind == "ipc" {
+			return url
+		}
+	}
+	return ""
+}
+
+// Wait waits until a new block arrives, the timeout passes, or the context is cancelled and
+// returns the latest block seen. It returns false if the context was cancelled.
+func (f *HeadFollower) Wait(ctx context.Context, timeout time.Duration) (base.Blknum, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return f.Head(), false
+	case <-f.wake:
+	case <-timer.C:
+	}
+	return f.Head(), true
+}
+
+// Head returns the latest block seen
+func (f *HeadFollower) Head() base.Blknum {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.head
+}
+
+// following returns the latest block seen if the follower is still following the chain
+func (f *HeadFollower) following() (base.Blknum, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.head, !f.stopped && f.head != 0
+}
+
+// Missed returns the number of blocks for which no head arrived (the subscription skipped them)
+func (f *HeadFollower) Missed() uint64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.missed
+}
+
+// IsSubscribed returns true if heads are arriving from a subscription rather than by polling
+func (f *HeadFollower) IsSubscribed() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.subscribed
+}
+
+func (f *HeadFollower) run(ctx context.Context) {
+	defer func() {
+		f.mutex.Lock()
+		f.stopped = true
+		f.mutex.Unlock()
+	}()
+
+	for ctx.Err() == nil {
+		if len(f.url) > 0 {
+			if err := f.subscribe(ctx); err != nil && ctx.Err() == nil {
+				logger.Warn("The newHeads subscription failed, polling instead:", err)
+			}
+		}
+		f.poll(ctx, resubscribeDelay)
+	}
+}
+
+// subscribe follows newHeads until the subscription fails or the context is cancelled
+func (f *HeadFollower) subscribe(ctx context.Context) error {
+	ec, err := query.DialClient(f.url)
+	if err != nil {
+		return err
+	}
+	defer ec.Close()
+
+	headers := make(chan *types.Header, 16)
+	sub, err := ec.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	f.setSubscribed(true)
+	defer f.setSubscribed(false)
+
+	// the subscription only reports blocks that arrive from now on
+	if bn, err := f.latest(); err == nil {
+		f.arrived(bn, false)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			if err == nil {
+				err = fmt.Errorf("subscription closed")
+			}
+			return err
+		case header := <-headers:
+			f.arrived(base.Blknum(header.Number.Uint64()), true)
+		}
+	}
+}
+
+// poll asks for the latest block every interval until the duration passes or the context is
+// cancelled
+func (f *HeadFollower) poll(ctx context.Context, duration time.Duration) {
+	until := time.Now().Add(duration)
+	for ctx.Err() == nil && time.Now().Before(until) {
+		if bn, err := f.latest(); err == nil {
+			f.arrived(bn, false)
+		}
+
+		timer := time.NewTimer(f.interval)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// arrived notes a new head and wakes the waiter. A subscription is expected to report every
+// block, so a gap means heads were missed. A head at or behind the last one is a re-org.
+func (f *HeadFollower) arrived(bn base.Blknum, fromSubscription bool) {
+	f.mutex.Lock()
+	prev := f.head
+	if bn <= prev {
+		f.mutex.Unlock()
+		if fromSubscription && bn < prev {
+			logger.Warn(fmt.Sprintf("Head went back from %d to %d (re-org)", prev, bn))
+		}
+		return
+	}
+
+	f.head = bn
+	if fromSubscription && prev != 0 && bn > prev+1 {
+		f.missed += uint64(bn - prev - 1)
+		f.mutex.Unlock()
+		logger.Warn(fmt.Sprintf("Missed %d head(s) between %d and %d", bn-prev-1, prev, bn))
+	} else {
+		f.mutex.Unlock()
+	}
+
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (f *HeadFollower) setSubscribed(subscribed bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.subscribed = subscribed
+}
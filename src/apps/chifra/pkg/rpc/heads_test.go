@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+)
+
+// newHeadsNode answers eth_subscribe and then sends a newHeads notification for each block it
+// receives on the channel. It drops the connection when the channel is closed.
+func newHeadsNode(t *testing.T, blocks chan uint64) string {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := conn.ReadJSON(&req); err != nil || req.Method != "eth_subscribe" {
+			return
+		}
+		_ = conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": "0xabc"})
+
+		for bn := range blocks {
+			header := &types.Header{Number: new(big.Int).SetUint64(bn), Difficulty: big.NewInt(0)}
+			_ = conn.WriteJSON(map[string]any{
+				"jsonrpc": "2.0",
+				"method":  "eth_subscription",
+				"params":  map[string]any{"subscription": "0xabc", "result": header},
+			})
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + server.URL[len("http"):]
+}
+
+func waitFor(t *testing.T, f *HeadFollower, want base.Blknum) {
+	ctx := context.Background()
+	deadline := time.Now().Add(5 * time.Second)
+	for f.Head() != want {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected head", want, "got", f.Head())
+		}
+		f.Wait(ctx, 100*time.Millisecond)
+	}
+}
+
+func TestHeadFollowerSubscription(t *testing.T) {
+	blocks := make(chan uint64)
+	url := newHeadsNode(t, blocks)
+
+	var polled atomic.Uint64
+	polled.Store(100)
+	latest := func() (base.Blknum, error) {
+		return base.Blknum(polled.Load()), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := newHeadFollower(url, 20*time.Millisecond, latest)
+	go f.run(ctx)
+
+	// the first head comes from asking for the latest block
+	waitFor(t, f, 100)
+	for !f.IsSubscribed() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	blocks <- 101
+	waitFor(t, f, 101)
+	if f.Missed() != 0 {
+		t.Error("Expected no missed heads, got", f.Missed())
+	}
+
+	// the node skips two blocks
+	blocks <- 104
+	waitFor(t, f, 104)
+	if f.Missed() != 2 {
+		t.Error("Expected two missed heads, got", f.Missed())
+	}
+
+	// a re-org doesn't move the head back
+	blocks <- 103
+	blocks <- 105
+	waitFor(t, f, 105)
+
+	// when the node drops the subscription, the follower polls
+	close(blocks)
+	polled.Store(110)
+	waitFor(t, f, 110)
+	if f.IsSubscribed() {
+		t.Error("Expected the follower to be polling")
+	}
+}
+
+func TestHeadFollowerPolling(t *testing.T) {
+	var polled atomic.Uint64
+	polled.Store(5)
+	latest := func() (base.Blknum, error) {
+		return base.Blknum(polled.Load()), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	f := newHeadFollower("", 10*time.Millisecond, latest)
+	go f.run(ctx)
+
+	waitFor(t, f, 5)
+	polled.Store(9)
+	waitFor(t, f, 9)
+	if f.Missed() != 0 {
+		t.Error("Expected polling not to report missed heads, got", f.Missed())
+	}
+
+	// a connection that follows the head doesn't ask the node for the latest block
+	conn := &Connection{Chain: "mainnet", heads: f}
+	if bn := conn.GetLatestBlockNumber(); bn != 9 {
+		t.Error("Expected the latest block to come from the follower, got", bn)
+	}
+
+	cancel()
+	if _, ok := f.Wait(ctx, time.Second); ok {
+		t.Error("Expected Wait to report the cancellation")
+	}
+}
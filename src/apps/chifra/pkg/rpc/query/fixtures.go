@@ -0,0 +1,222 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// When recording (TB_RPC_RECORD=<folder>), each request sent to the node and the node's
+// response are written to a fixture file keyed by the request's method and params. When
+// replaying (TB_RPC_REPLAY=<folder>), requests are answered from those files and no node is
+// needed. A request that was never recorded is an error.
+
+// fixture is the recorded response to one request
+type fixture struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+type fixtureRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type fixtureResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+// fixtureTransport records the requests sent through another transport or, when replaying,
+// answers them from the recordings
+type fixtureTransport struct {
+	folder string
+	replay bool
+	next   transport
+}
+
+// withFixtures wraps the transport if chifra is recording or replaying RPC requests
+func withFixtures(t transport) transport {
+	switch mode, folder := config.RpcFixtures(); mode {
+	case "record":
+		return &fixtureTransport{folder: folder, next: t}
+	case "replay":
+		return &fixtureTransport{folder: folder, replay: true}
+	default:
+		return t
+	}
+}
+
+func (t *fixtureTransport) send(headers map[string]string, payload []byte) ([]byte, error) {
+	requests, isBatch, err := splitRequests(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.replay {
+		responses := make([]fixtureResponse, 0, len(requests))
+		for _, req := range requests {
+			if response, err := t.load(req); err != nil {
+				return nil, err
+			} else {
+				responses = append(responses, response)
+			}
+		}
+		if isBatch {
+			return json.Marshal(responses)
+		}
+		return json.Marshal(responses[0])
+	}
+
+	theBytes, err := t.next.send(headers, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []fixtureResponse
+	if isBatch {
+		err = json.Unmarshal(theBytes, &responses)
+	} else {
+		responses = make([]fixtureResponse, 1)
+		err = json.Unmarshal(theBytes, &responses[0])
+	}
+	if err != nil {
+		// not something we can record, so just pass it along
+		return theBytes, nil
+	}
+
+	byId := make(map[string]fixtureResponse, len(responses))
+	for _, response := range responses {
+		byId[string(response.ID)] = response
+	}
+	for _, req := range requests {
+		if response, ok := byId[string(req.ID)]; ok {
+			if err := t.save(req, response); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return theBytes, nil
+}
+
+// load returns the recorded response to the request, answering with the request's id
+func (t *fixtureTransport) load(req fixtureRequest) (fixtureResponse, error) {
+	path, params := t.pathFor(req)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return fixtureResponse{}, fmt.Errorf("no recorded response for %s %s in %s", req.Method, params, t.folder)
+	}
+
+	var fix fixture
+	if err := json.Unmarshal(contents, &fix); err != nil {
+		return fixtureResponse{}, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return fixtureResponse{Jsonrpc: "2.0", ID: req.ID, Result: fix.Result, Error: fix.Error}, nil
+}
+
+// save writes the node's response to the request to the request's fixture file
+func (t *fixtureTransport) save(req fixtureRequest, response fixtureResponse) error {
+	path, params := t.pathFor(req)
+	if err := file.EstablishFolder(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	fix := fixture{Method: req.Method, Params: params, Result: response.Result, Error: response.Error}
+	contents, err := json.MarshalIndent(fix, "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.StringToAsciiFile(path, string(contents)+"\n")
+}
+
+// pathFor returns the path of the request's fixture file (named for a hash of its params in a
+// folder named for its method) and the params in the canonical form that was hashed
+func (t *fixtureTransport) pathFor(req fixtureRequest) (string, json.RawMessage) {
+	params := canonicalParams(req.Params)
+	sum := sha256.Sum256(params)
+	return filepath.Join(t.folder, req.Method, hex.EncodeToString(sum[:8])+".json"), params
+}
+
+// canonicalParams returns the params compacted and with the keys of any objects sorted so that
+// the same request always has the same key
+func canonicalParams(params json.RawMessage) json.RawMessage {
+	if len(bytes.TrimSpace(params)) == 0 {
+		return json.RawMessage("[]")
+	}
+	var value any
+	if err := json.Unmarshal(params, &value); err != nil {
+		return params
+	}
+	ret, _ := json.Marshal(value)
+	return ret
+}
+
+// splitRequests returns the requests in a payload that is a single request or a batch
+func splitRequests(payload []byte) ([]fixtureRequest, bool, error) {
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []fixtureRequest
+		err := json.Unmarshal(trimmed, &requests)
+		return requests, true, err
+	}
+	requests := make([]fixtureRequest, 1)
+	err := json.Unmarshal(trimmed, &requests[0])
+	return requests, false, err
+}
+
+// dialFixtures returns an ethclient whose requests go through the recording or replaying transport
+func dialFixtures(url string) (*ethclient.Client, error) {
+	httpClient := &http.Client{Transport: roundTripper{getTransport(url)}}
+	// the url is never dialed, the round tripper sends every request through the transport
+	c, err := gethrpc.DialOptions(context.Background(), "http://fixtures", gethrpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(c), nil
+}
+
+// roundTripper sends the body of an http request through a transport
+type roundTripper struct {
+	t transport
+}
+
+func (r roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	payload, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	theBytes, err := r.t.send(map[string]string{}, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(theBytes)),
+		ContentLength: int64(len(theBytes)),
+		Request:       request,
+	}, nil
+}
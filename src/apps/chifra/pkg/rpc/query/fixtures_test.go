@@ -0,0 +1,109 @@
+package query
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setTestTransport(url string, t transport) {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+	transports[url] = t
+}
+
+func TestFixturesRecordAndReplay(t *testing.T) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	folder := t.TempDir()
+
+	// record against a live node
+	url := newHttpNode(t)
+	recorder := &fixtureTransport{folder: folder, next: &httpTransport{url: url}}
+	setTestTransport(url, recorder)
+	setTestPool("test-record", newEndpoint(url, 1, 0))
+
+	if result, err := Query[string]("test-record", "eth_blockNumber", Params{}); err != nil || *result != "0x10" {
+		t.Fatal("Expected the request to be recorded, got", result, err)
+	}
+	if _, err := QueryBatch[string]("test-record", []BatchPayload{
+		{Key: "chain", Payload: &Payload{Method: "eth_chainId", Params: Params{}}},
+		{Key: "bogus", Payload: &Payload{Method: "eth_bogus", Params: Params{"0x1", map[string]any{"b": 1, "a": 2}}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(folder, "*", "*.json"))
+	if len(files) != 3 {
+		t.Fatal("Expected three fixtures, got", files)
+	}
+
+	// replay with no node at all
+	offline := "http://offline"
+	setTestTransport(offline, &fixtureTransport{folder: folder, replay: true})
+	setTestPool("test-replay", newEndpoint(offline, 1, 0))
+
+	if result, err := Query[string]("test-replay", "eth_blockNumber", Params{}); err != nil || *result != "0x10" {
+		t.Error("Expected the recorded response, got", result, err)
+	}
+
+	// the batch is answered in a different order, with the node's error for the bogus request
+	results, errs, err := QueryBatchEach[string]("test-replay", []BatchPayload{
+		{Key: "bogus", Payload: &Payload{Method: "eth_bogus", Params: Params{"0x1", map[string]any{"a": 2, "b": 1}}}},
+		{Key: "chain", Payload: &Payload{Method: "eth_chainId", Params: Params{}}},
+	})
+	if err != nil || *results["chain"] != "0x1" || errs["bogus"] == nil {
+		t.Error("Expected the recorded batch, got", results, errs, err)
+	}
+
+	// a request that was never recorded fails
+	if _, err := Query[string]("test-replay", "eth_blockNumber", Params{"extra"}); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Error("Expected an unrecorded request to fail, got", err)
+	}
+}
+
+func TestFixturesEthClient(t *testing.T) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	folder := t.TempDir()
+	url := newHttpNode(t)
+
+	t.Setenv("TB_RPC_RECORD", folder)
+	ec, err := DialClient(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bn, err := ec.BlockNumber(context.Background()); err != nil || bn != 16 {
+		t.Fatal("Expected the ethclient to record, got", bn, err)
+	}
+	ec.Close()
+
+	t.Setenv("TB_RPC_RECORD", "")
+	t.Setenv("TB_RPC_REPLAY", folder)
+	ec, err = DialClient("http://offline-ethclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ec.Close()
+	if bn, err := ec.BlockNumber(context.Background()); err != nil || bn != 16 {
+		t.Error("Expected the ethclient to replay, got", bn, err)
+	}
+	if _, err := ec.ChainID(context.Background()); err == nil {
+		t.Error("Expected an unrecorded request to fail")
+	}
+
+	if _, err := os.Stat(filepath.Join(folder, "eth_blockNumber")); err != nil {
+		t.Error("Expected a fixture folder for eth_blockNumber", err)
+	}
+}
+
+func TestCanonicalParams(t *testing.T) {
+	a := canonicalParams([]byte(`[ "0x1", {"b": 1, "a": 2} ]`))
+	b := canonicalParams([]byte(`["0x1",{"a":2,"b":1}]`))
+	if string(a) != string(b) {
+		t.Error("Expected the same key for the same params, got", string(a), string(b))
+	}
+	if string(canonicalParams(nil)) != "[]" {
+		t.Error("Expected missing params to be empty")
+	}
+}
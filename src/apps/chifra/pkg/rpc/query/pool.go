@@ -0,0 +1,381 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+	"github.com/gorilla/websocket"
+)
+
+// A chain may have several RPC endpoints (the rpcProviders of its configuration, or just its
+// rpcProvider). Each request goes to one of them chosen at random in proportion to its weight
+// divided by its average latency. A request that fails for a transient reason (429, 5xx, a
+// dropped connection) is retried with backoff, on a different endpoint if there is one. An
+// endpoint that fails too many times in a row is skipped (its circuit is open) for a while.
+const (
+	maxRetries       = 3
+	backoffBase      = 250 * time.Millisecond
+	backoffMax       = 4 * time.Second
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+	latencySmoothing = 0.2
+)
+
+// EndpointStats describes one of a chain's RPC endpoints and how it has served requests
+type EndpointStats struct {
+	Url       string
+	Weight    uint64
+	RateLimit float64
+	State     string
+	Served    uint64
+	Failures  uint64
+	Latency   time.Duration
+	LastError string
+}
+
+type endpoint struct {
+	url     string
+	weight  uint64
+	limiter *rateLimiter
+
+	mutex       sync.Mutex
+	latency     time.Duration
+	served      uint64
+	failures    uint64
+	consecutive int
+	openUntil   time.Time
+	lastError   string
+}
+
+type pool struct {
+	key       string
+	endpoints []*endpoint
+}
+
+var (
+	poolsMutex sync.Mutex
+	pools      = map[string]*pool{}
+)
+
+// getPool returns the pool of endpoints for the chain, building it from the configuration the
+// first time it's needed and again whenever the chain's endpoints are configured differently
+func getPool(chain string) *pool {
+	poolsMutex.Lock()
+	defer poolsMutex.Unlock()
+
+	ch := config.GetChain(chain)
+	key := poolKey(&ch)
+	if p, ok := pools[chain]; ok && p.key == key {
+		return p
+	}
+
+	p := &pool{key: key}
+	for _, group := range ch.RpcProviders {
+		p.endpoints = append(p.endpoints, newEndpoint(group.Url, group.Weight, group.RateLimit))
+	}
+	if len(p.endpoints) == 0 {
+		p.endpoints = append(p.endpoints, newEndpoint(ch.RpcProvider, 1, 0))
+	}
+	pools[chain] = p
+	return p
+}
+
+// poolKey describes the chain's endpoints and their settings. A pool built for one key is
+// not used for another.
+func poolKey(ch *configtypes.ChainGroup) string {
+	var sb strings.Builder
+	sb.WriteString(ch.RpcProvider)
+	for _, group := range ch.RpcProviders {
+		sb.WriteString(fmt.Sprintf("|%s,%d,%g", group.Url, group.Weight, group.RateLimit))
+	}
+	return sb.String()
+}
+
+func newEndpoint(url string, weight uint64, rateLimit float64) *endpoint {
+	if weight == 0 {
+		weight = 1
+	}
+	return &endpoint{url: url, weight: weight, limiter: newRateLimiter(rateLimit)}
+}
+
+// Endpoints returns the chain's RPC endpoints and how each has served requests so far
+func Endpoints(chain string) []EndpointStats {
+	p := getPool(chain)
+	now := time.Now()
+	ret := make([]EndpointStats, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		ep.mutex.Lock()
+		stats := EndpointStats{
+			Url:       ep.url,
+			Weight:    ep.weight,
+			State:     ep.state(now),
+			Served:    ep.served,
+			Failures:  ep.failures,
+			Latency:   ep.latency,
+			LastError: ep.lastError,
+		}
+		ep.mutex.Unlock()
+		if ep.limiter != nil {
+			stats.RateLimit = ep.limiter.rate
+		}
+		ret = append(ret, stats)
+	}
+	return ret
+}
+
+// do sends a request to one of the pool's endpoints, retrying transient failures
+func (p *pool) do(send func(url string) error) error {
+	tried := map[*endpoint]bool{}
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt))
+		}
+
+		ep := p.pick(tried)
+		ep.limiter.wait()
+
+		start := time.Now()
+		err = send(ep.url)
+		ep.record(time.Since(start), err)
+		if err == nil || !isTransient(err) {
+			return err
+		}
+
+		tried[ep] = true
+		if len(tried) == len(p.endpoints) {
+			if !isRetryable(err) {
+				// the endpoint isn't there, so trying it again won't help
+				return err
+			}
+			tried = map[*endpoint]bool{}
+		}
+	}
+	return err
+}
+
+// pick chooses an endpoint that hasn't been tried, preferring those whose circuits are closed
+// and which are not waiting on their rate limits
+func (p *pool) pick(tried map[*endpoint]bool) *endpoint {
+	if len(p.endpoints) == 1 {
+		return p.endpoints[0]
+	}
+
+	now := time.Now()
+	candidates := make([]*endpoint, 0, len(p.endpoints))
+	shares := make([]float64, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		if tried[ep] {
+			continue
+		}
+		if share := ep.share(now); share > 0 {
+			candidates = append(candidates, ep)
+			shares = append(shares, share)
+		}
+	}
+
+	if len(candidates) == 0 {
+		// every untried endpoint's circuit is open, so the one that opened first gets a trial request
+		var best *endpoint
+		for _, ep := range p.endpoints {
+			if !tried[ep] && (best == nil || ep.reopens().Before(best.reopens())) {
+				best = ep
+			}
+		}
+		if best == nil {
+			best = p.endpoints[0]
+		}
+		return best
+	}
+
+	total := 0.0
+	for _, share := range shares {
+		total += share
+	}
+	r := rand.Float64() * total
+	for i, share := range shares {
+		if r < share {
+			return candidates[i]
+		}
+		r -= share
+	}
+	return candidates[len(candidates)-1]
+}
+
+// share returns the endpoint's share of the requests: its weight divided by its latency (in
+// milliseconds), less if it must wait on its rate limit, or zero if its circuit is open
+func (ep *endpoint) share(now time.Time) float64 {
+	ep.mutex.Lock()
+	state, latency := ep.state(now), ep.latency
+	ep.mutex.Unlock()
+
+	if state == "open" {
+		return 0
+	}
+
+	ms := math.Max(float64(latency)/float64(time.Millisecond), 1)
+	if delay := ep.limiter.delay(); delay > 0 {
+		ms += float64(delay) / float64(time.Millisecond)
+	}
+	return float64(ep.weight) / ms
+}
+
+// state returns the state of the endpoint's circuit breaker. The mutex must be held.
+func (ep *endpoint) state(now time.Time) string {
+	if ep.consecutive < breakerThreshold {
+		return "closed"
+	}
+	if now.Before(ep.openUntil) {
+		return "open"
+	}
+	return "half-open"
+}
+
+func (ep *endpoint) reopens() time.Time {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	return ep.openUntil
+}
+
+// record notes the outcome of a request. Errors the node returns (reverts, for example) are
+// still requests served.
+func (ep *endpoint) record(elapsed time.Duration, err error) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	if err != nil && isTransient(err) {
+		ep.failures++
+		ep.consecutive++
+		ep.lastError = err.Error()
+		if ep.consecutive >= breakerThreshold {
+			ep.openUntil = time.Now().Add(breakerCooldown)
+		}
+		return
+	}
+
+	ep.served++
+	ep.consecutive = 0
+	if ep.latency == 0 {
+		ep.latency = elapsed
+	} else {
+		ep.latency = time.Duration(latencySmoothing*float64(elapsed) + (1-latencySmoothing)*float64(ep.latency))
+	}
+}
+
+// backoff returns how long to wait before the given retry (doubling each time, with jitter)
+func backoff(attempt int) time.Duration {
+	d := backoffBase << (attempt - 1)
+	if d > backoffMax {
+		d = backoffMax
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// HttpError is returned when the endpoint answers with a status other than 200
+type HttpError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HttpError) Error() string {
+	return fmt.Sprintf("%s: %d", e.Status, e.StatusCode)
+}
+
+// isTransient returns true if a different endpoint (or the same one, later) might succeed
+func isTransient(err error) bool {
+	// ENOENT is a missing IPC socket, which, like a refused connection, means there's no node there
+	return isRetryable(err) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ENOENT) || isDnsError(err)
+}
+
+// isRetryable returns true if the same endpoint might succeed if asked again
+func isRetryable(err error) bool {
+	var httpErr *HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	var rpcErr *RpcError
+	if errors.As(err, &rpcErr) {
+		// -32005 is the limit exceeded error of EIP-1474
+		return rpcErr.Code == -32005
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	// a websocket the endpoint closed is reopened by the next request
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isDnsError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// rateLimiter is a token bucket that holds up to one second's worth of requests
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{rate: rate, tokens: math.Max(rate, 1), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last refill. The mutex must be held.
+func (r *rateLimiter) refill(now time.Time) {
+	r.tokens = math.Min(r.tokens+now.Sub(r.last).Seconds()*r.rate, math.Max(r.rate, 1))
+	r.last = now
+}
+
+// delay returns how long a request would wait for a token
+func (r *rateLimiter) delay() time.Duration {
+	if r == nil {
+		return 0
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.refill(time.Now())
+	if r.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - r.tokens) / r.rate * float64(time.Second))
+}
+
+// wait takes a token, waiting for one if there are none
+func (r *rateLimiter) wait() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	r.refill(time.Now())
+	r.tokens--
+	var wait time.Duration
+	if r.tokens < 0 {
+		wait = time.Duration(-r.tokens / r.rate * float64(time.Second))
+	}
+	r.mutex.Unlock()
+	time.Sleep(wait)
+}
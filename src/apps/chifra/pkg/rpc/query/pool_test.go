@@ -0,0 +1,180 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/configtypes"
+)
+
+// newTestServer answers eth_blockNumber with 0x10 after failing (with the given status) the
+// first failures requests
+func newTestServer(failures int32, status int) (*httptest.Server, *int32) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	return server, &count
+}
+
+func setTestPool(chain string, endpoints ...*endpoint) {
+	poolsMutex.Lock()
+	defer poolsMutex.Unlock()
+	ch := config.GetChain(chain)
+	pools[chain] = &pool{key: poolKey(&ch), endpoints: endpoints}
+}
+
+func TestPoolRetries(t *testing.T) {
+	server, count := newTestServer(2, http.StatusTooManyRequests)
+	defer server.Close()
+	setTestPool("test-retries", newEndpoint(server.URL, 1, 0))
+
+	result, err := Query[string]("test-retries", "eth_blockNumber", Params{})
+	if err != nil || *result != "0x10" {
+		t.Fatal("Expected the request to succeed after retries, got", result, err)
+	}
+	if *count != 3 {
+		t.Error("Expected three requests, got", *count)
+	}
+
+	stats := Endpoints("test-retries")
+	if stats[0].Served != 1 || stats[0].Failures != 2 || stats[0].State != "closed" {
+		t.Error("Mismatched stats:", stats[0])
+	}
+}
+
+func TestPoolFailover(t *testing.T) {
+	bad, _ := newTestServer(1000, http.StatusBadGateway)
+	defer bad.Close()
+	good, _ := newTestServer(0, 0)
+	defer good.Close()
+	setTestPool("test-failover", newEndpoint(bad.URL, 1, 0), newEndpoint(good.URL, 1, 0))
+
+	for i := 0; i < 20; i++ {
+		if _, err := Query[string]("test-failover", "eth_blockNumber", Params{}); err != nil {
+			t.Fatal("Expected the request to fail over to the good endpoint, got", err)
+		}
+	}
+
+	stats := Endpoints("test-failover")
+	if stats[0].Served != 0 || stats[1].Served != 20 {
+		t.Error("Expected the good endpoint to serve every request, got", stats)
+	}
+	if stats[0].Failures >= 20 || stats[0].State != "open" {
+		t.Error("Expected the bad endpoint's circuit to open, got", stats[0])
+	}
+}
+
+func TestPoolWeights(t *testing.T) {
+	// with equal latencies, the shares follow the weights
+	heavyEp, lightEp := newEndpoint("http://heavy", 4, 0), newEndpoint("http://light", 1, 0)
+	heavyEp.latency, lightEp.latency = 10*time.Millisecond, 10*time.Millisecond
+	p := &pool{endpoints: []*endpoint{heavyEp, lightEp}}
+	for i := 0; i < 500; i++ {
+		p.pick(nil).served++
+	}
+	if heavyEp.served < 2*lightEp.served {
+		t.Error("Expected the heavier endpoint to serve most requests, got", heavyEp.served, lightEp.served)
+	}
+
+	// a much slower endpoint serves fewer requests despite its weight
+	heavyEp.latency, heavyEp.served, lightEp.served = 400*time.Millisecond, 0, 0
+	for i := 0; i < 500; i++ {
+		p.pick(nil).served++
+	}
+	if lightEp.served < 2*heavyEp.served {
+		t.Error("Expected the faster endpoint to serve most requests, got", heavyEp.served, lightEp.served)
+	}
+}
+
+func TestPoolFollowsConfig(t *testing.T) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+	chain := "test-config"
+	cfg := config.GetRootConfig()
+	saved, existed := cfg.Chains[chain]
+	t.Cleanup(func() {
+		if existed {
+			cfg.Chains[chain] = saved
+		} else {
+			delete(cfg.Chains, chain)
+		}
+	})
+
+	urls := func() []string {
+		ret := []string{}
+		for _, ep := range Endpoints(chain) {
+			ret = append(ret, fmt.Sprintf("%s:%d", ep.Url, ep.Weight))
+		}
+		return ret
+	}
+
+	cfg.Chains[chain] = configtypes.ChainGroup{Chain: chain, RpcProvider: "http://one"}
+	first := getPool(chain)
+	if got := urls(); !reflect.DeepEqual(got, []string{"http://one:1"}) {
+		t.Errorf("unexpected endpoints %v", got)
+	}
+	if getPool(chain) != first {
+		t.Error("expected the pool to be kept while the configuration is unchanged")
+	}
+
+	cfg.Chains[chain] = configtypes.ChainGroup{Chain: chain, RpcProvider: "http://one", RpcProviders: []configtypes.RpcEndpointGroup{
+		{Url: "http://two", Weight: 2},
+		{Url: "http://three"},
+	}}
+	if got := urls(); !reflect.DeepEqual(got, []string{"http://two:2", "http://three:1"}) {
+		t.Errorf("expected the pool to follow the new providers, got %v", got)
+	}
+
+	cfg.Chains[chain] = configtypes.ChainGroup{Chain: chain, RpcProvider: "http://one", RpcProviders: []configtypes.RpcEndpointGroup{
+		{Url: "http://two", Weight: 5},
+		{Url: "http://three"},
+	}}
+	if got := urls(); !reflect.DeepEqual(got, []string{"http://two:5", "http://three:1"}) {
+		t.Errorf("expected the pool to follow the new weights, got %v", got)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(20)
+	for i := 0; i < 20; i++ {
+		limiter.wait()
+	}
+	if delay := limiter.delay(); delay <= 0 || delay > 50*time.Millisecond {
+		t.Error("Expected to wait about one twentieth of a second, got", delay)
+	}
+
+	start := time.Now()
+	limiter.wait()
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Error("Expected the limiter to wait, got", elapsed)
+	}
+
+	if newRateLimiter(0).delay() != 0 {
+		t.Error("Expected no limit")
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	if !isTransient(&HttpError{StatusCode: 503}) || !isTransient(&HttpError{StatusCode: 429}) {
+		t.Error("Expected 5xx and 429 to be transient")
+	}
+	if isTransient(&HttpError{StatusCode: 404}) {
+		t.Error("Expected 404 not to be transient")
+	}
+	if isTransient(&RpcError{Code: 3, Message: "execution reverted"}) {
+		t.Error("Expected a revert not to be transient")
+	}
+	if !isTransient(&RpcError{Code: -32005, Message: "limit exceeded"}) {
+		t.Error("Expected limit exceeded to be transient")
+	}
+}
@@ -1,16 +1,14 @@
 package query
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"runtime"
 	"strings"
 	"sync/atomic"
 
-	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/debug"
 )
 
@@ -25,6 +23,7 @@ type Payload struct {
 }
 
 type rpcResponse[T any] struct {
+	ID     int           `json:"id"`
 	Result T             `json:"result"`
 	Error  *eip1474Error `json:"error"`
 }
@@ -32,6 +31,45 @@ type rpcResponse[T any] struct {
 type eip1474Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// RpcError is returned when the node responds with an error object. Data carries the
+// error's `data` field (for reverted calls, the raw revert bytes) if the node sent one.
+type RpcError struct {
+	Code    int
+	Message string
+	Data    string
+}
+
+func (e *RpcError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+func (e *eip1474Error) toError() *RpcError {
+	ret := &RpcError{
+		Code:    e.Code,
+		Message: e.Message,
+	}
+	switch data := e.Data.(type) {
+	case string:
+		ret.Data = data
+	case map[string]any:
+		// some nodes wrap the revert bytes in an object
+		if inner, ok := data["data"].(string); ok {
+			ret.Data = inner
+		}
+	}
+	return ret
+}
+
+// RevertData returns the revert bytes carried by an error returned from eth_call, if any.
+func RevertData(err error) (string, bool) {
+	var rpcErr *RpcError
+	if errors.As(err, &rpcErr) && strings.HasPrefix(rpcErr.Data, "0x") && len(rpcErr.Data) >= 10 {
+		return rpcErr.Data, true
+	}
+	return "", false
 }
 
 var rpcCounter uint32
@@ -52,8 +90,12 @@ type BatchPayload struct {
 
 // Query returns a single result for given method and params.
 func Query[T any](chain string, method string, params Params) (*T, error) {
-	url := config.GetChain(chain).RpcProvider
-	return QueryUrl[T](url, method, params)
+	var ret *T
+	err := getPool(chain).do(func(url string) (err error) {
+		ret, err = QueryUrl[T](url, method, params)
+		return err
+	})
+	return ret, err
 }
 
 // QueryUrl is just like Query, but it does not resolve chain to RPC provider URL
@@ -72,42 +114,24 @@ func QueryWithHeaders[T any](url string, headers map[string]string, method strin
 
 	debug.DebugCurl(rpcDebug{url: url, payload: payloadToSend, headers: headers})
 
-	if plBytes, err := json.Marshal(payloadToSend); err != nil {
+	plBytes, err := json.Marshal(payloadToSend)
+	if err != nil {
+		return nil, err
+	}
+
+	theBytes, err := getTransport(url).send(headers, plBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var result rpcResponse[T]
+	if err = json.Unmarshal(theBytes, &result); err != nil {
 		return nil, err
-	} else {
-		body := bytes.NewReader(plBytes)
-		if request, err := http.NewRequest("POST", url, body); err != nil {
-			return nil, err
-		} else {
-			request.Header.Set("Content-Type", "application/json")
-			for key, value := range headers {
-				request.Header.Set(key, value)
-			}
-
-			client := &http.Client{}
-			if response, err := client.Do(request); err != nil {
-				return nil, err
-			} else if response.StatusCode != 200 {
-				return nil, fmt.Errorf("%s: %d", response.Status, response.StatusCode)
-			} else {
-				defer response.Body.Close()
-
-				if theBytes, err := io.ReadAll(response.Body); err != nil {
-					return nil, err
-				} else {
-					var result rpcResponse[T]
-					if err = json.Unmarshal(theBytes, &result); err != nil {
-						return nil, err
-					} else {
-						if result.Error != nil {
-							return nil, fmt.Errorf("%d: %s", result.Error.Code, result.Error.Message)
-						}
-						return &result.Result, nil
-					}
-				}
-			}
-		}
 	}
+	if result.Error != nil {
+		return nil, result.Error.toError()
+	}
+	return &result.Result, nil
 }
 
 // QueryBatch batches requests to the node. Returned values are stored in map, with the same keys as defined
@@ -117,57 +141,116 @@ func QueryBatch[T any](chain string, batchPayload []BatchPayload) (map[string]*T
 }
 
 func QueryBatchWithHeaders[T any](chain string, headers map[string]string, batchPayload []BatchPayload) (map[string]*T, error) {
-	keys := make([]string, 0, len(batchPayload))
-	payloads := make([]Payload, 0, len(batchPayload))
-	for _, bpl := range batchPayload {
-		keys = append(keys, bpl.Key)
-		payloads = append(payloads, *bpl.Payload)
+	payloads, plBytes, err := buildBatch(batchPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []rpcResponse[T]
+	if theBytes, err := postBatch(chain, headers, payloads, plBytes); err != nil {
+		return nil, err
+	} else {
+		if err = json.Unmarshal(theBytes, &result); err != nil {
+			return nil, err
+		}
+		results := make(map[string]*T, len(batchPayload))
+		for index := range batchPayload {
+			results[batchPayload[index].Key] = &result[index].Result
+		}
+		return results, err
+	}
+}
+
+// QueryBatchEach is like QueryBatch, but it reports a failure for each request instead of
+// silently returning an empty result. Responses are matched to requests by id because nodes
+// are free to answer a batch in any order. A request that failed has no entry in the
+// results map and an entry in the errors map.
+func QueryBatchEach[T any](chain string, batchPayload []BatchPayload) (map[string]*T, map[string]error, error) {
+	payloads, plBytes, err := buildBatch(batchPayload)
+	if err != nil {
+		return nil, nil, err
+	}
+	idToKey := make(map[int]string, len(batchPayload))
+	for index := range payloads {
+		idToKey[payloads[index].ID] = batchPayload[index].Key
+	}
+
+	theBytes, err := postBatch(chain, map[string]string{}, payloads, plBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result []rpcResponse[T]
+	if err = json.Unmarshal(theBytes, &result); err != nil {
+		// a node that rejects the whole batch answers with a single error object
+		var single rpcResponse[T]
+		if json.Unmarshal(theBytes, &single) == nil && single.Error != nil {
+			return nil, nil, single.Error.toError()
+		}
+		return nil, nil, err
+	}
+
+	results := make(map[string]*T, len(batchPayload))
+	errs := make(map[string]error)
+	for index := range result {
+		key, ok := idToKey[result[index].ID]
+		if !ok {
+			continue
+		}
+		delete(idToKey, result[index].ID)
+		if result[index].Error != nil {
+			errs[key] = result[index].Error.toError()
+		} else {
+			results[key] = &result[index].Result
+		}
+	}
+	for _, key := range idToKey {
+		errs[key] = fmt.Errorf("no response for request %s", key)
 	}
 
-	url := config.GetChain(chain).RpcProvider
-	payloadToSend := make([]rpcPayload, 0, len(payloads))
+	return results, errs, nil
+}
 
-	for _, payload := range payloads {
-		theLoad := rpcPayload{
+// buildBatch returns the requests of a batch, in the same order as batchPayload, and their encoding
+func buildBatch(batchPayload []BatchPayload) ([]rpcPayload, []byte, error) {
+	payloadToSend := make([]rpcPayload, 0, len(batchPayload))
+	for _, bpl := range batchPayload {
+		payloadToSend = append(payloadToSend, rpcPayload{
 			Jsonrpc: "2.0",
-			Method:  payload.Method,
-			Params:  payload.Params,
+			Method:  bpl.Method,
+			Params:  bpl.Params,
 			ID:      int(atomic.AddUint32(&rpcCounter, 1)),
-		}
-		debug.DebugCurl(rpcDebug{
-			url:     url,
-			payload: theLoad,
-			headers: headers,
 		})
-		payloadToSend = append(payloadToSend, theLoad)
 	}
 
 	plBytes, err := json.Marshal(payloadToSend)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return payloadToSend, plBytes, nil
+}
 
-	var result []rpcResponse[T]
-	body := bytes.NewReader(plBytes)
-	if response, err := http.Post(url, "application/json", body); err != nil {
-		return nil, err
-	} else {
-		defer response.Body.Close()
-		if theBytes, err := io.ReadAll(response.Body); err != nil {
-			return nil, err
-		} else {
-			if err = json.Unmarshal(theBytes, &result); err != nil {
-				return nil, err
-			}
-			results := make(map[string]*T, len(batchPayload))
-			for index, key := range keys {
-				results[key] = &result[index].Result
-			}
-			return results, err
+// postBatch sends a batch of requests to one of the chain's endpoints and returns the response.
+// The requests are only used to report each one against the endpoint that was chosen.
+func postBatch(chain string, headers map[string]string, payloads []rpcPayload, plBytes []byte) ([]byte, error) {
+	var theBytes []byte
+	err := getPool(chain).do(func(url string) (err error) {
+		for _, payload := range payloads {
+			debug.DebugCurl(rpcDebug{
+				url:     url,
+				payload: payload,
+				headers: headers,
+			})
 		}
-	}
+		theBytes, err = getTransport(url).send(headers, plBytes)
+		return err
+	})
+	return theBytes, err
 }
 
+// client is shared by all requests so that connections to the endpoints are reused
+var client = &http.Client{}
+
 func init() {
 	// We need to increase MaxIdleConnsPerHost, otherwise chifra will keep trying to open too
 	// many ports. It can lead to bind errors.
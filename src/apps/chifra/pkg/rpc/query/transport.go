@@ -0,0 +1,193 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gorilla/websocket"
+)
+
+// transport sends a JSON-RPC payload (a single request or a batch) to an endpoint and returns
+// the response. Endpoints are reached over http(s), a websocket (ws:// or wss://) or the node's
+// IPC socket (ipc:// or the socket's path).
+type transport interface {
+	send(headers map[string]string, payload []byte) ([]byte, error)
+}
+
+var (
+	transportsMutex sync.Mutex
+	transports      = map[string]transport{}
+)
+
+// getTransport returns the transport for the url. Websocket and IPC connections are opened on
+// first use and shared by all requests to the endpoint.
+func getTransport(url string) transport {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	if t, ok := transports[url]; ok {
+		return t
+	}
+
+	var t transport
+	switch kind, addr := config.RpcTransport(url); kind {
+	case "ipc":
+		t = &ipcTransport{path: addr}
+	case "ws":
+		t = &wsTransport{url: addr}
+	default:
+		t = &httpTransport{url: addr}
+	}
+	t = withFixtures(t)
+	transports[url] = t
+	return t
+}
+
+// DialClient returns an ethclient connected to the endpoint over the same transport used by Query
+func DialClient(url string) (*ethclient.Client, error) {
+	if mode, _ := config.RpcFixtures(); mode != "" {
+		return dialFixtures(url)
+	}
+	_, addr := config.RpcTransport(url)
+	return ethclient.Dial(addr)
+}
+
+type httpTransport struct {
+	url string
+}
+
+func (t *httpTransport) send(headers map[string]string, payload []byte) ([]byte, error) {
+	request, err := http.NewRequest("POST", t.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, &HttpError{StatusCode: response.StatusCode, Status: response.Status}
+	}
+	return io.ReadAll(response.Body)
+}
+
+// ipcTransport sends requests one at a time over the node's unix socket. Headers don't apply.
+type ipcTransport struct {
+	path    string
+	mutex   sync.Mutex
+	conn    net.Conn
+	decoder *json.Decoder
+}
+
+func (t *ipcTransport) send(_ map[string]string, payload []byte) ([]byte, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.conn == nil {
+		conn, err := net.Dial("unix", t.path)
+		if err != nil {
+			return nil, err
+		}
+		t.conn, t.decoder = conn, json.NewDecoder(conn)
+	}
+
+	if _, err := t.conn.Write(payload); err != nil {
+		t.close()
+		return nil, err
+	}
+
+	for {
+		var msg json.RawMessage
+		if err := t.decoder.Decode(&msg); err != nil {
+			t.close()
+			return nil, err
+		}
+		if !isNotification(msg) {
+			return msg, nil
+		}
+	}
+}
+
+// close drops the connection so the next request reconnects. The mutex must be held.
+func (t *ipcTransport) close() {
+	t.conn.Close()
+	t.conn, t.decoder = nil, nil
+}
+
+// wsTransport sends requests one at a time over a websocket. Headers are sent with the
+// handshake that opens the connection.
+type wsTransport struct {
+	url   string
+	mutex sync.Mutex
+	conn  *websocket.Conn
+}
+
+func (t *wsTransport) send(headers map[string]string, payload []byte) ([]byte, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.conn == nil {
+		header := http.Header{}
+		for key, value := range headers {
+			header.Set(key, value)
+		}
+		conn, response, err := websocket.DefaultDialer.Dial(t.url, header)
+		if err != nil {
+			if response != nil && errors.Is(err, websocket.ErrBadHandshake) {
+				return nil, &HttpError{StatusCode: response.StatusCode, Status: response.Status}
+			}
+			return nil, err
+		}
+		t.conn = conn
+	}
+
+	if err := t.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+		t.close()
+		return nil, err
+	}
+
+	for {
+		_, msg, err := t.conn.ReadMessage()
+		if err != nil {
+			t.close()
+			return nil, err
+		}
+		if !isNotification(msg) {
+			return msg, nil
+		}
+	}
+}
+
+// close drops the connection so the next request reconnects. The mutex must be held.
+func (t *wsTransport) close() {
+	t.conn.Close()
+	t.conn = nil
+}
+
+// isNotification returns true if the message is a notification (for example, from a
+// subscription) rather than a response to a request
+func isNotification(msg []byte) bool {
+	var notification struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	msg = bytes.TrimSpace(msg)
+	if len(msg) == 0 || msg[0] != '{' || json.Unmarshal(msg, &notification) != nil {
+		return false
+	}
+	return notification.ID == nil && notification.Method != ""
+}
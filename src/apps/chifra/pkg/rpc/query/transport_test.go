@@ -0,0 +1,191 @@
+package query
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeNode answers eth_blockNumber and eth_chainId, singly or in a batch, the way a node does
+func fakeNode(request []byte) []byte {
+	answer := func(raw json.RawMessage) map[string]any {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		_ = json.Unmarshal(raw, &req)
+		ret := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_blockNumber":
+			ret["result"] = "0x10"
+		case "eth_chainId":
+			ret["result"] = "0x1"
+		default:
+			ret["error"] = map[string]any{"code": -32601, "message": "method not found"}
+		}
+		return ret
+	}
+
+	var response any
+	var batch []json.RawMessage
+	if json.Unmarshal(request, &batch) == nil {
+		answers := make([]map[string]any, 0, len(batch))
+		for _, raw := range batch {
+			answers = append(answers, answer(raw))
+		}
+		response = answers
+	} else {
+		response = answer(request)
+	}
+	ret, _ := json.Marshal(response)
+	return ret
+}
+
+// a notification the fake servers send ahead of each response, which the transports must skip
+var fakeNotification = []byte(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x1","result":{}}}`)
+
+func newHttpNode(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(fakeNode(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newWsNode(t *testing.T) string {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			_ = conn.WriteMessage(websocket.TextMessage, fakeNotification)
+			_ = conn.WriteMessage(websocket.TextMessage, fakeNode(msg))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + server.URL[len("http"):]
+}
+
+func newIpcNode(t *testing.T) string {
+	return newIpcNodeWithLimit(t, 0)
+}
+
+// newIpcNodeWithLimit starts a node on a unix socket that drops each connection after it has
+// answered limit requests (zero for no limit)
+func newIpcNodeWithLimit(t *testing.T, limit int) string {
+	path := filepath.Join(t.TempDir(), "node.ipc")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				decoder := json.NewDecoder(bufio.NewReader(conn))
+				for n := 1; ; n++ {
+					var msg json.RawMessage
+					if err := decoder.Decode(&msg); err != nil {
+						return
+					}
+					_, _ = conn.Write(fakeNotification)
+					_, _ = conn.Write(fakeNode(msg))
+					if n == limit {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return "ipc://" + path
+}
+
+func TestTransports(t *testing.T) {
+	t.Setenv("TB_NO_PROVIDER_CHECK", "true")
+
+	nodes := map[string]string{
+		"http": newHttpNode(t),
+		"ws":   newWsNode(t),
+		"ipc":  newIpcNode(t),
+	}
+
+	for kind, url := range nodes {
+		chain := "test-" + kind
+		setTestPool(chain, newEndpoint(url, 1, 0))
+
+		for i := 0; i < 3; i++ {
+			result, err := Query[string](chain, "eth_blockNumber", Params{})
+			if err != nil || *result != "0x10" {
+				t.Fatal("Query failed over", kind, result, err)
+			}
+		}
+
+		if _, err := Query[string](chain, "eth_bogus", Params{}); err == nil {
+			t.Error("Expected an error for an unknown method over", kind)
+		}
+
+		results, err := QueryBatch[string](chain, []BatchPayload{
+			{Key: "block", Payload: &Payload{Method: "eth_blockNumber", Params: Params{}}},
+			{Key: "chain", Payload: &Payload{Method: "eth_chainId", Params: Params{}}},
+		})
+		if err != nil || *results["block"] != "0x10" || *results["chain"] != "0x1" {
+			t.Error("QueryBatch failed over", kind, results, err)
+		}
+
+		ec, err := DialClient(url)
+		if err != nil {
+			t.Fatal("DialClient failed over", kind, err)
+		}
+		if bn, err := ec.BlockNumber(context.Background()); err != nil || bn != 16 {
+			t.Error("ethclient failed over", kind, bn, err)
+		}
+		ec.Close()
+	}
+}
+
+func TestTransportReconnects(t *testing.T) {
+	// the node drops the connection after each request, so the next request fails and the one
+	// after that opens a new connection
+	url := newIpcNodeWithLimit(t, 1)
+	if _, err := QueryUrl[string](url, "eth_blockNumber", Params{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := QueryUrl[string](url, "eth_blockNumber", Params{}); err == nil || !isRetryable(err) {
+		t.Error("Expected a retryable error from a closed connection, got", err)
+	}
+	if result, err := QueryUrl[string](url, "eth_blockNumber", Params{}); err != nil || *result != "0x10" {
+		t.Error("Expected the transport to reconnect, got", result, err)
+	}
+}
+
+func TestIsNotification(t *testing.T) {
+	if !isNotification(fakeNotification) {
+		t.Error("Expected a subscription message to be a notification")
+	}
+	if isNotification([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`)) || isNotification([]byte(`[{"id":1}]`)) {
+		t.Error("Expected responses not to be notifications")
+	}
+}
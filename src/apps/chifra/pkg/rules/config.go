@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+)
+
+// Kind is the kind of condition a rule tests
+type Kind string
+
+const (
+	// KindTransfer matches transfers of an asset (ETH or an ERC-20 token) of at least an amount
+	KindTransfer Kind = "transfer"
+	// KindSelector matches transactions calling a function selector
+	KindSelector Kind = "selector"
+	// KindBalance matches when the monitor's ETH balance falls below an amount
+	KindBalance Kind = "balance"
+)
+
+// Config is the contents of a rules file. The file is TOML with one [[rule]] table per rule
+// and one [[sink]] table per sink. If Record is set, every event evaluated is appended to that
+// file so the stream may later be replayed against other rules.
+type Config struct {
+	Record string       `toml:"record"`
+	Rules  []Rule       `toml:"rule"`
+	Sinks  []SinkConfig `toml:"sink"`
+}
+
+// Rule is a single condition evaluated against each new appearance
+type Rule struct {
+	Name      string   `toml:"name" json:"name"`
+	Kind      Kind     `toml:"kind" json:"kind"`
+	Monitors  []string `toml:"monitors" json:"monitors,omitempty"`
+	Asset     string   `toml:"asset" json:"asset,omitempty"`
+	Direction string   `toml:"direction" json:"direction,omitempty"`
+	Min       string   `toml:"min" json:"min,omitempty"`
+	Below     string   `toml:"below" json:"below,omitempty"`
+	Decimals  int      `toml:"decimals" json:"decimals,omitempty"`
+	Selector  string   `toml:"selector" json:"selector,omitempty"`
+
+	monitors map[base.Address]bool
+	asset    base.Address
+	amount   *base.Wei
+}
+
+// SinkConfig describes where matches are delivered. Kind is one of webhook, file, socket, or
+// command. Target is the url, path, socket path, or shell command respectively. If Rules is
+// not empty, only matches of the named rules are delivered to the sink.
+type SinkConfig struct {
+	Kind    string   `toml:"kind"`
+	Target  string   `toml:"target"`
+	Rules   []string `toml:"rules"`
+	Retries int      `toml:"retries"`
+	Timeout float64  `toml:"timeout"`
+}
+
+// Load reads and checks a rules file
+func Load(path string) (*Config, error) {
+	var cfg Config
+	if err := config.ReadToml(path, &cfg); err != nil {
+		return nil, fmt.Errorf("reading rules file %s: %w", path, err)
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("rules file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Check validates the configuration and prepares the rules for evaluation
+func (cfg *Config) Check() error {
+	if len(cfg.Rules) == 0 {
+		return fmt.Errorf("no rules found")
+	}
+	if len(cfg.Sinks) == 0 {
+		return fmt.Errorf("no sinks found")
+	}
+
+	names := make(map[string]bool, len(cfg.Rules))
+	for i := range cfg.Rules {
+		r := &cfg.Rules[i]
+		if len(r.Name) == 0 {
+			r.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("duplicate rule name %s", r.Name)
+		}
+		names[r.Name] = true
+		if err := r.prepare(); err != nil {
+			return fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+	}
+
+	for i, s := range cfg.Sinks {
+		switch s.Kind {
+		case "webhook", "file", "socket", "command":
+		default:
+			return fmt.Errorf("sink %d: unknown kind %q", i+1, s.Kind)
+		}
+		if len(s.Target) == 0 {
+			return fmt.Errorf("sink %d: a %s sink requires a target", i+1, s.Kind)
+		}
+		for _, name := range s.Rules {
+			if !names[name] {
+				return fmt.Errorf("sink %d: unknown rule %s", i+1, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Rule) prepare() error {
+	r.monitors = make(map[base.Address]bool, len(r.Monitors))
+	for _, m := range r.Monitors {
+		if !base.IsValidAddress(m) {
+			return fmt.Errorf("invalid monitor address %s", m)
+		}
+		r.monitors[base.HexToAddress(m)] = true
+	}
+
+	if r.Decimals == 0 {
+		r.Decimals = 18
+	}
+
+	var err error
+	switch r.Kind {
+	case KindTransfer:
+		switch strings.ToLower(r.Asset) {
+		case "", "eth":
+			r.asset = base.FAKE_ETH_ADDRESS
+		default:
+			if !base.IsValidAddress(r.Asset) {
+				return fmt.Errorf("invalid asset %s", r.Asset)
+			}
+			r.asset = base.HexToAddress(r.Asset)
+		}
+		switch r.Direction {
+		case "":
+			r.Direction = "any"
+		case "in", "out", "any":
+		default:
+			return fmt.Errorf("direction must be one of in, out, or any")
+		}
+		if len(r.Min) == 0 {
+			r.Min = "0"
+		}
+		r.amount, err = ParseUnits(r.Min, r.Decimals)
+	case KindSelector:
+		sel := strings.ToLower(r.Selector)
+		if len(sel) != 10 || !strings.HasPrefix(sel, "0x") || !base.IsHex(sel) {
+			return fmt.Errorf("selector must be four bytes (0x12345678)")
+		}
+		r.Selector = sel
+	case KindBalance:
+		if len(r.Below) == 0 {
+			return fmt.Errorf("a balance rule requires a below value")
+		}
+		r.amount, err = ParseUnits(r.Below, r.Decimals)
+	default:
+		return fmt.Errorf("unknown kind %q", r.Kind)
+	}
+	return err
+}
+
+// ParseUnits converts a decimal amount (for example 1.5) into its integer value with the
+// given number of decimals (for example 1500000000000000000 with 18 decimals)
+func ParseUnits(amount string, decimals int) (*base.Wei, error) {
+	rat, ok := new(big.Rat).SetString(amount)
+	if !ok || rat.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	rat.Mul(rat, new(big.Rat).SetInt(scale))
+	ret := new(base.Wei)
+	ret.SetString(new(big.Int).Quo(rat.Num(), rat.Denom()).String(), 10)
+	return ret, nil
+}
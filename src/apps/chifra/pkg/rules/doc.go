@@ -0,0 +1,4 @@
+// Package rules evaluates the new appearances of watched monitors against declarative rules
+// and delivers the matches to sinks (webhooks, files, unix sockets, or shell commands). The
+// engine works on Events, which may be built from the chain or replayed from a recording.
+package rules
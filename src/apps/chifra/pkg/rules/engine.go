@@ -0,0 +1,189 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
+)
+
+// Alert is delivered to the sinks each time a rule matches an event
+type Alert struct {
+	Rule     string    `json:"rule"`
+	Kind     Kind      `json:"kind"`
+	Reason   string    `json:"reason"`
+	Transfer *Transfer `json:"transfer,omitempty"`
+	Event    Event     `json:"event"`
+}
+
+// Engine evaluates events against the rules and delivers the resulting alerts to the sinks
+type Engine struct {
+	rules []Rule
+	sinks []sinkEntry
+	// below remembers, per rule and monitor, that a balance rule already fired so that it
+	// fires again only after the balance recovers
+	below map[string]bool
+}
+
+type sinkEntry struct {
+	sink  Sink
+	rules map[string]bool
+}
+
+// NewEngine returns an engine for a checked configuration that delivers to the configured sinks
+func NewEngine(cfg *Config) (*Engine, error) {
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		sink, err := NewSink(sc)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return NewEngineWithSinks(cfg, sinks), nil
+}
+
+// NewEngineWithSinks returns an engine that delivers to the given sinks, one per entry in
+// cfg.Sinks, instead of the configured ones. It's used for testing.
+func NewEngineWithSinks(cfg *Config, sinks []Sink) *Engine {
+	e := &Engine{
+		rules: cfg.Rules,
+		below: make(map[string]bool),
+	}
+	for i, sink := range sinks {
+		entry := sinkEntry{sink: sink}
+		if i < len(cfg.Sinks) && len(cfg.Sinks[i].Rules) > 0 {
+			entry.rules = make(map[string]bool)
+			for _, name := range cfg.Sinks[i].Rules {
+				entry.rules[name] = true
+			}
+		}
+		e.sinks = append(e.sinks, entry)
+	}
+	return e
+}
+
+// Evaluate returns the alerts an event raises without delivering them
+func (e *Engine) Evaluate(ev *Event) []Alert {
+	ret := make([]Alert, 0)
+	for i := range e.rules {
+		r := &e.rules[i]
+		if len(r.monitors) > 0 && !r.monitors[ev.Monitor] {
+			continue
+		}
+		switch r.Kind {
+		case KindTransfer:
+			for j := range ev.Transfers {
+				t := ev.Transfers[j]
+				if r.matchesTransfer(ev.Monitor, &t) {
+					ret = append(ret, Alert{
+						Rule:     r.Name,
+						Kind:     r.Kind,
+						Reason:   fmt.Sprintf("transfer of %s of %s from %s to %s", t.Amount.String(), t.Asset.Hex(), t.From.Hex(), t.To.Hex()),
+						Transfer: &t,
+						Event:    *ev,
+					})
+				}
+			}
+		case KindSelector:
+			if ev.From == ev.Monitor || ev.To == ev.Monitor {
+				if ev.Selector() == r.Selector {
+					ret = append(ret, Alert{Rule: r.Name, Kind: r.Kind, Reason: "call to " + r.Selector, Event: *ev})
+				}
+			}
+		case KindBalance:
+			if ev.Balance == nil {
+				continue
+			}
+			key := r.Name + ev.Monitor.Hex()
+			if ev.Balance.Cmp(r.amount) < 0 {
+				if !e.below[key] {
+					e.below[key] = true
+					ret = append(ret, Alert{
+						Rule:   r.Name,
+						Kind:   r.Kind,
+						Reason: fmt.Sprintf("balance %s is below %s", ev.Balance.String(), r.amount.String()),
+						Event:  *ev,
+					})
+				}
+			} else {
+				e.below[key] = false
+			}
+		}
+	}
+	return ret
+}
+
+func (r *Rule) matchesTransfer(mon base.Address, t *Transfer) bool {
+	if t.Asset != r.asset {
+		return false
+	}
+	switch r.Direction {
+	case "in":
+		if t.To != mon {
+			return false
+		}
+	case "out":
+		if t.From != mon {
+			return false
+		}
+	default:
+		if t.To != mon && t.From != mon {
+			return false
+		}
+	}
+	return t.Amount.Cmp(r.amount) >= 0
+}
+
+// Process evaluates an event and delivers its alerts to the sinks. A failing sink does not
+// prevent delivery to the others. It returns the alerts and the first delivery error.
+func (e *Engine) Process(ev *Event) ([]Alert, error) {
+	alerts := e.Evaluate(ev)
+	var firstErr error
+	for i := range alerts {
+		alert := &alerts[i]
+		for _, entry := range e.sinks {
+			if entry.rules != nil && !entry.rules[alert.Rule] {
+				continue
+			}
+			if err := entry.sink.Deliver(alert); err != nil {
+				logger.Warn("Delivering alert", alert.Rule, "failed:", err)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+	}
+	return alerts, firstErr
+}
+
+// Replay processes a recorded stream of events in order
+func (e *Engine) Replay(events []Event) ([]Alert, error) {
+	ret := make([]Alert, 0)
+	var firstErr error
+	for i := range events {
+		alerts, err := e.Process(&events[i])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		ret = append(ret, alerts...)
+	}
+	return ret, firstErr
+}
+
+// Close releases the resources held by the sinks
+func (e *Engine) Close() {
+	for _, entry := range e.sinks {
+		entry.sink.Close()
+	}
+}
+
+// NeedsBalances returns true if any rule needs the monitors' balances
+func (e *Engine) NeedsBalances() bool {
+	for _, r := range e.rules {
+		if r.Kind == KindBalance {
+			return true
+		}
+	}
+	return false
+}
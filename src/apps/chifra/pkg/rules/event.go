@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Event is a new appearance of a monitor along with everything the rules need to know about
+// it. Events are written one per line as JSON, so a stream of them may be recorded and
+// replayed against a set of rules.
+type Event struct {
+	Monitor          base.Address   `json:"monitor"`
+	BlockNumber      base.Blknum    `json:"blockNumber"`
+	TransactionIndex base.Txnum     `json:"transactionIndex"`
+	Timestamp        base.Timestamp `json:"timestamp"`
+	Hash             base.Hash      `json:"hash"`
+	From             base.Address   `json:"from"`
+	To               base.Address   `json:"to"`
+	Input            string         `json:"input,omitempty"`
+	Transfers        []Transfer     `json:"transfers,omitempty"`
+	Balance          *base.Wei      `json:"balance,omitempty"`
+}
+
+// Transfer is a movement of ETH (with Asset set to base.FAKE_ETH_ADDRESS) or of an ERC-20 token
+type Transfer struct {
+	Asset  base.Address `json:"asset"`
+	From   base.Address `json:"from"`
+	To     base.Address `json:"to"`
+	Amount base.Wei     `json:"amount"`
+}
+
+var transferTopic = base.HexToHash(
+	"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+)
+
+// NewEvent builds the event for a monitor's appearance in a transaction. The transaction's
+// receipt (for token transfers) and traces (for internal ETH transfers) are used if present.
+// The balance, which may be nil, is the monitor's ETH balance after the transaction's block.
+func NewEvent(mon base.Address, tx *types.Transaction, balance *base.Wei) Event {
+	ev := Event{
+		Monitor:          mon,
+		BlockNumber:      tx.BlockNumber,
+		TransactionIndex: tx.TransactionIndex,
+		Timestamp:        tx.Timestamp,
+		Hash:             tx.Hash,
+		From:             tx.From,
+		To:               tx.To,
+		Input:            tx.Input,
+		Balance:          balance,
+	}
+
+	if !tx.Value.IsZero() {
+		ev.Transfers = append(ev.Transfers, Transfer{Asset: base.FAKE_ETH_ADDRESS, From: tx.From, To: tx.To, Amount: tx.Value})
+	}
+
+	for _, trace := range tx.Traces {
+		if len(trace.TraceAddress) == 0 || trace.Action == nil || trace.Action.Value.IsZero() {
+			continue
+		}
+		to := trace.Action.To
+		if trace.Result != nil && !trace.Result.Address.IsZero() {
+			to = trace.Result.Address
+		}
+		ev.Transfers = append(ev.Transfers, Transfer{Asset: base.FAKE_ETH_ADDRESS, From: trace.Action.From, To: to, Amount: trace.Action.Value})
+	}
+
+	if tx.Receipt != nil {
+		for _, log := range tx.Receipt.Logs {
+			// ERC-721 transfers index the token id and carry no data, so they're skipped
+			if len(log.Topics) != 3 || log.Topics[0] != transferTopic || len(log.Data) < 3 {
+				continue
+			}
+			amount, ok := new(base.Wei).SetString(strings.TrimPrefix(log.Data, "0x"), 16)
+			if !ok {
+				continue
+			}
+			ev.Transfers = append(ev.Transfers, Transfer{
+				Asset:  log.Address,
+				From:   base.HexToAddress(log.Topics[1].Hex()),
+				To:     base.HexToAddress(log.Topics[2].Hex()),
+				Amount: *amount,
+			})
+		}
+	}
+
+	return ev
+}
+
+// Selector returns the four byte function selector the transaction called or an empty string
+func (ev *Event) Selector() string {
+	if len(ev.Input) < 10 {
+		return ""
+	}
+	return strings.ToLower(ev.Input[:10])
+}
+
+// ReadEvents reads a recorded stream of events, one JSON object per line. Blank lines are skipped.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	ret := make([]Event, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		ret = append(ret, ev)
+	}
+	return ret, scanner.Err()
+}
+
+// WriteEvent appends an event to a recorded stream
+func WriteEvent(w io.Writer, ev *Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"os"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// memorySink remembers the alerts delivered to it
+type memorySink struct {
+	alerts []Alert
+}
+
+func (s *memorySink) Deliver(alert *Alert) error {
+	s.alerts = append(s.alerts, *alert)
+	return nil
+}
+
+func (s *memorySink) Close() {}
+
+func loadStream(t *testing.T) []Event {
+	t.Helper()
+	f, err := os.Open("testdata/stream.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	events, err := ReadEvents(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestReplay(t *testing.T) {
+	cfg, err := Load("testdata/rules.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all, approvals := &memorySink{}, &memorySink{}
+	engine := NewEngineWithSinks(cfg, []Sink{all, approvals})
+	alerts, err := engine.Replay(loadStream(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		rule string
+		bn   base.Blknum
+	}{
+		{"big-eth-out", 100},
+		{"large-usdc", 102},
+		{"approve", 103},
+		{"low-balance", 103},
+		{"big-eth-out", 106},
+		{"low-balance", 106},
+	}
+	if len(alerts) != len(expected) {
+		t.Fatalf("expected %d alerts, got %d: %v", len(expected), len(alerts), alerts)
+	}
+	for i, e := range expected {
+		if alerts[i].Rule != e.rule || alerts[i].Event.BlockNumber != e.bn {
+			t.Errorf("alert %d: expected %s at %d, got %s at %d", i, e.rule, e.bn, alerts[i].Rule, alerts[i].Event.BlockNumber)
+		}
+	}
+
+	if len(all.alerts) != len(expected) {
+		t.Errorf("expected the first sink to receive %d alerts, got %d", len(expected), len(all.alerts))
+	}
+	if len(approvals.alerts) != 1 || approvals.alerts[0].Rule != "approve" {
+		t.Errorf("expected the second sink to receive only the approval, got %v", approvals.alerts)
+	}
+	if alerts[1].Transfer == nil || alerts[1].Transfer.Amount.String() != "2500000000" {
+		t.Errorf("expected the usdc alert to carry its transfer, got %v", alerts[1].Transfer)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	sinks := []SinkConfig{{Kind: "file", Target: "alerts.json"}}
+	tests := []struct {
+		name  string
+		rules []Rule
+		sinks []SinkConfig
+		ok    bool
+	}{
+		{"transfer", []Rule{{Kind: KindTransfer, Min: "1.5"}}, sinks, true},
+		{"no rules", nil, sinks, false},
+		{"no sinks", []Rule{{Kind: KindTransfer}}, nil, false},
+		{"unknown kind", []Rule{{Kind: "pink"}}, sinks, false},
+		{"bad direction", []Rule{{Kind: KindTransfer, Direction: "up"}}, sinks, false},
+		{"bad amount", []Rule{{Kind: KindTransfer, Min: "lots"}}, sinks, false},
+		{"bad asset", []Rule{{Kind: KindTransfer, Asset: "usdc"}}, sinks, false},
+		{"short selector", []Rule{{Kind: KindSelector, Selector: "0x095ea7"}}, sinks, false},
+		{"balance without below", []Rule{{Kind: KindBalance}}, sinks, false},
+		{"duplicate names", []Rule{{Name: "a", Kind: KindSelector, Selector: "0x095ea7b3"}, {Name: "a", Kind: KindBalance, Below: "1"}}, sinks, false},
+		{"unknown sink", []Rule{{Kind: KindBalance, Below: "1"}}, []SinkConfig{{Kind: "email", Target: "me"}}, false},
+		{"sink without target", []Rule{{Kind: KindBalance, Below: "1"}}, []SinkConfig{{Kind: "file"}}, false},
+		{"sink with unknown rule", []Rule{{Kind: KindBalance, Below: "1"}}, []SinkConfig{{Kind: "file", Target: "x", Rules: []string{"b"}}}, false},
+	}
+	for _, tt := range tests {
+		cfg := Config{Rules: tt.rules, Sinks: tt.sinks}
+		if err := cfg.Check(); (err == nil) != tt.ok {
+			t.Errorf("%s: expected ok %t, got %v", tt.name, tt.ok, err)
+		}
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals int
+		expected string
+	}{
+		{"1", 18, "1000000000000000000"},
+		{"0.5", 18, "500000000000000000"},
+		{"1000", 6, "1000000000"},
+		{"1.2345678", 6, "1234567"},
+	}
+	for _, tt := range tests {
+		got, err := ParseUnits(tt.amount, tt.decimals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != tt.expected {
+			t.Errorf("ParseUnits(%s, %d): expected %s, got %s", tt.amount, tt.decimals, tt.expected, got.String())
+		}
+	}
+}
+
+func TestNewEvent(t *testing.T) {
+	mon := base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b")
+	token := base.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	other := base.HexToAddress("0x001d14804b399c6ef80e64576f657660804fec0b")
+	tx := &types.Transaction{
+		BlockNumber: 100,
+		From:        mon,
+		To:          token,
+		Input:       "0xa9059cbb000000",
+		Receipt: &types.Receipt{
+			Logs: []types.Log{{
+				Address: token,
+				Topics: []base.Hash{
+					transferTopic,
+					base.HexToHash("0x000000000000000000000000" + mon.Hex()[2:]),
+					base.HexToHash("0x000000000000000000000000" + other.Hex()[2:]),
+				},
+				Data: "0x00000000000000000000000000000000000000000000000000000000000003e8",
+			}},
+		},
+	}
+
+	ev := NewEvent(mon, tx, nil)
+	if ev.Selector() != "0xa9059cbb" {
+		t.Errorf("expected selector 0xa9059cbb, got %s", ev.Selector())
+	}
+	if len(ev.Transfers) != 1 {
+		t.Fatalf("expected one transfer, got %d", len(ev.Transfers))
+	}
+	tr := ev.Transfers[0]
+	if tr.Asset != token || tr.From != mon || tr.To != other || tr.Amount.String() != "1000" {
+		t.Errorf("unexpected transfer %v", tr)
+	}
+}
@@ -0,0 +1,196 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Sink receives the alerts raised by the engine
+type Sink interface {
+	Deliver(alert *Alert) error
+	Close()
+}
+
+// NewSink returns the sink a configuration describes
+func NewSink(sc SinkConfig) (Sink, error) {
+	timeout := 10 * time.Second
+	if sc.Timeout > 0 {
+		timeout = time.Duration(sc.Timeout * float64(time.Second))
+	}
+
+	switch sc.Kind {
+	case "webhook":
+		retries := 3
+		if sc.Retries > 0 {
+			retries = sc.Retries
+		}
+		url := sc.Target
+		if !strings.HasPrefix(url, "http") {
+			url = "http://" + url
+		}
+		return &WebhookSink{Url: url, Retries: retries, Backoff: 500 * time.Millisecond, client: &http.Client{Timeout: timeout}}, nil
+	case "file":
+		return &FileSink{Path: sc.Target}, nil
+	case "socket":
+		return &SocketSink{Path: sc.Target, Timeout: timeout}, nil
+	case "command":
+		return &CommandSink{Cmd: sc.Target, Timeout: timeout}, nil
+	}
+	return nil, fmt.Errorf("unknown sink kind %q", sc.Kind)
+}
+
+// WebhookSink posts each alert as JSON to a url. Failed deliveries (network errors, 429s,
+// and 5xx responses) are retried with exponential backoff.
+type WebhookSink struct {
+	Url     string
+	Retries int
+	Backoff time.Duration
+	client  *http.Client
+}
+
+func (s *WebhookSink) Deliver(alert *Alert) error {
+	encoded, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshalling alert: %w", err)
+	}
+
+	client := s.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	backoff := s.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(client, encoded)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.Retries {
+			return fmt.Errorf("webhook %s: %w", s.Url, err)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends one request and reports whether a failure is worth retrying
+func (s *WebhookSink) post(client *http.Client, body []byte) (bool, error) {
+	resp, err := client.Post(s.Url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	err = fmt.Errorf("listener responded with %d: %s", resp.StatusCode, respBody)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+func (s *WebhookSink) Close() {}
+
+// FileSink appends each alert to a file as a line of JSON
+type FileSink struct {
+	Path string
+}
+
+func (s *FileSink) Deliver(alert *Alert) error {
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeLine(f, alert)
+}
+
+func (s *FileSink) Close() {}
+
+// SocketSink writes each alert as a line of JSON to a unix socket. The connection is kept
+// open between alerts and re-established once if the listener went away.
+type SocketSink struct {
+	Path    string
+	Timeout time.Duration
+	conn    net.Conn
+}
+
+func (s *SocketSink) Deliver(alert *Alert) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout("unix", s.Path, s.Timeout); err != nil {
+				s.conn = nil
+				return err
+			}
+		}
+		if s.Timeout > 0 {
+			_ = s.conn.SetWriteDeadline(time.Now().Add(s.Timeout))
+		}
+		if err = writeLine(s.conn, alert); err == nil {
+			return nil
+		}
+		s.Close()
+	}
+	return err
+}
+
+func (s *SocketSink) Close() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// CommandSink runs a shell command for each alert. The alert is passed as JSON on stdin and
+// TB_RULE, TB_MONITOR, TB_BLOCK, and TB_HASH are set in the command's environment.
+type CommandSink struct {
+	Cmd     string
+	Timeout time.Duration
+}
+
+func (s *CommandSink) Deliver(alert *Alert) error {
+	encoded, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshalling alert: %w", err)
+	}
+
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Cmd)
+	cmd.Stdin = bytes.NewReader(append(encoded, '\n'))
+	cmd.Env = append(os.Environ(),
+		"TB_RULE="+alert.Rule,
+		"TB_MONITOR="+alert.Event.Monitor.Hex(),
+		fmt.Sprintf("TB_BLOCK=%d", alert.Event.BlockNumber),
+		"TB_HASH="+alert.Event.Hash.Hex(),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("command %q: %w: %s", s.Cmd, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s *CommandSink) Close() {}
+
+func writeLine(w io.Writer, alert *Alert) error {
+	encoded, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshalling alert: %w", err)
+	}
+	_, err = w.Write(append(encoded, '\n'))
+	return err
+}
@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+func testAlert() *Alert {
+	return &Alert{
+		Rule:   "approve",
+		Kind:   KindSelector,
+		Reason: "call to 0x095ea7b3",
+		Event: Event{
+			Monitor:     base.HexToAddress("0xf503017d7baf7fbc0fff7492b751025c6a78179b"),
+			BlockNumber: 103,
+			Input:       "0x095ea7b3",
+		},
+	}
+}
+
+func TestWebhookSinkRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var alert Alert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil || alert.Rule != "approve" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := &WebhookSink{Url: server.URL, Retries: 3, Backoff: time.Millisecond}
+	if err := sink.Deliver(testAlert()); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	calls = 0
+	sink.Retries = 1
+	if err := sink.Deliver(testAlert()); err == nil {
+		t.Error("expected an error when retries run out")
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestWebhookSinkNoRetryOnClientError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sink := &WebhookSink{Url: server.URL, Retries: 3, Backoff: time.Millisecond}
+	if err := sink.Deliver(testAlert()); err == nil {
+		t.Error("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	sink := &FileSink{Path: path}
+	for i := 0; i < 2; i++ {
+		if err := sink.Deliver(testAlert()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var alert Alert
+		if err := json.Unmarshal(scanner.Bytes(), &alert); err != nil {
+			t.Fatal(err)
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 lines, got %d", lines)
+	}
+}
+
+func TestSocketSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skip("unix sockets are not available:", err)
+	}
+	defer listener.Close()
+
+	received := make(chan Alert, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var alert Alert
+		if err := json.NewDecoder(conn).Decode(&alert); err == nil {
+			received <- alert
+		}
+	}()
+
+	sink := &SocketSink{Path: path, Timeout: time.Second}
+	defer sink.Close()
+	if err := sink.Deliver(testAlert()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case alert := <-received:
+		if alert.Rule != "approve" || alert.Event.BlockNumber != 103 {
+			t.Errorf("unexpected alert %v", alert)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timed out waiting for the alert")
+	}
+}
+
+func TestCommandSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	sink := &CommandSink{Cmd: `echo "$TB_RULE $TB_BLOCK" > ` + path + ` && cat >> ` + path, Timeout: 5 * time.Second}
+	if err := sink.Deliver(testAlert()); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	if !scanner.Scan() || scanner.Text() != "approve 103" {
+		t.Errorf("expected the environment on the first line, got %q", scanner.Text())
+	}
+	var alert Alert
+	if !scanner.Scan() || json.Unmarshal(scanner.Bytes(), &alert) != nil || alert.Rule != "approve" {
+		t.Errorf("expected the alert on stdin, got %q", scanner.Text())
+	}
+
+	failing := &CommandSink{Cmd: "exit 3"}
+	if err := failing.Deliver(testAlert()); err == nil {
+		t.Error("expected an error from a failing command")
+	}
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// Reader returns the thirty-two byte contents of a storage slot
+type Reader func(slot *big.Int) ([]byte, error)
+
+const (
+	// maxElements is the most elements of an array we decode
+	maxElements = 100
+	// maxBytes is the longest string or bytes value we decode
+	maxBytes = 4096
+)
+
+// Decode reads and decodes the value at a location. Value types are returned as plain strings,
+// strings are returned as they are, and structs and arrays are returned as JSON. Mappings
+// cannot be enumerated so they decode to an empty string. Only the first hundred elements
+// of dynamic arrays are decoded.
+func (l *Layout) Decode(loc *Location, read Reader) (string, error) {
+	text, _, err := l.decode(loc.TypeId, loc.Slot, loc.Offset, read)
+	return text, err
+}
+
+// TypeLabel returns the Solidity type of the value at a location
+func (l *Layout) TypeLabel(loc *Location) string {
+	if t, err := l.typeOf(loc.TypeId); err == nil {
+		return t.Label
+	}
+	return loc.TypeId
+}
+
+// decode returns the decoded value and whether it needs quoting when it's part of a larger value
+func (l *Layout) decode(typeId string, slot *big.Int, offset uint64, read Reader) (string, bool, error) {
+	t, err := l.typeOf(typeId)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch t.Encoding {
+	case "mapping":
+		return "", true, nil
+
+	case "bytes":
+		return l.decodeBytes(t, slot, read)
+
+	case "dynamic_array":
+		contents, err := read(slot)
+		if err != nil {
+			return "", false, err
+		}
+		length := new(big.Int).SetBytes(contents)
+		n := uint64(maxElements)
+		if length.IsUint64() && length.Uint64() < n {
+			n = length.Uint64()
+		}
+		return l.decodeArray(t, dataSlot(slot), n, read)
+	}
+
+	if len(t.Members) > 0 {
+		parts := make([]string, 0, len(t.Members))
+		for _, member := range t.Members {
+			memberSlot, err := parseSlot(member.Slot)
+			if err != nil {
+				return "", false, err
+			}
+			text, quoted, err := l.decode(member.Type, new(big.Int).Add(slot, memberSlot), member.Offset, read)
+			if err != nil {
+				return "", false, err
+			}
+			parts = append(parts, strconv.Quote(member.Label)+":"+quote(text, quoted))
+		}
+		return "{" + strings.Join(parts, ",") + "}", false, nil
+	}
+
+	if length, ok := t.staticLength(); ok {
+		return l.decodeArray(t, slot, min(length, maxElements), read)
+	}
+
+	contents, err := read(slot)
+	if err != nil {
+		return "", false, err
+	}
+	return decodeValue(t, extract(contents, offset, t.size()))
+}
+
+func (l *Layout) decodeArray(t *Type, start *big.Int, n uint64, read Reader) (string, bool, error) {
+	baseType, err := l.typeOf(t.Base)
+	if err != nil {
+		return "", false, err
+	}
+	parts := make([]string, 0, n)
+	for i := uint64(0); i < n; i++ {
+		slot, offset := element(start, baseType, i)
+		text, quoted, err := l.decode(t.Base, slot, offset, read)
+		if err != nil {
+			return "", false, err
+		}
+		parts = append(parts, quote(text, quoted))
+	}
+	return "[" + strings.Join(parts, ",") + "]", false, nil
+}
+
+// decodeBytes decodes a string or bytes value. Values shorter than thirty-two bytes are stored
+// in the slot itself along with twice their length. Longer values store twice their length
+// plus one in the slot and their data in consecutive slots starting at keccak(slot).
+func (l *Layout) decodeBytes(t *Type, slot *big.Int, read Reader) (string, bool, error) {
+	contents, err := read(slot)
+	if err != nil {
+		return "", false, err
+	}
+
+	var data []byte
+	if contents[31]&1 == 0 {
+		length := int(contents[31] / 2)
+		if length > 31 {
+			return "", false, fmt.Errorf("incorrectly encoded storage byte array")
+		}
+		data = contents[:length]
+	} else {
+		length := new(big.Int).SetBytes(contents)
+		length.Sub(length, big.NewInt(1)).Rsh(length, 1)
+		n := uint64(maxBytes)
+		if length.IsUint64() && length.Uint64() < n {
+			n = length.Uint64()
+		}
+		start := dataSlot(slot)
+		data = make([]byte, 0, n+31)
+		for i := uint64(0); uint64(len(data)) < n; i++ {
+			chunk, err := read(new(big.Int).Add(start, new(big.Int).SetUint64(i)))
+			if err != nil {
+				return "", false, err
+			}
+			data = append(data, chunk...)
+		}
+		data = data[:n]
+	}
+
+	if t.Label == "string" {
+		if utf8.Valid(data) {
+			return string(data), true, nil
+		}
+		return strings.ToValidUTF8(string(data), "�"), true, nil
+	}
+	return "0x" + base.Bytes2Hex(data), true, nil
+}
+
+// extract returns the size bytes of a value stored at offset in a slot. Values are packed
+// into slots starting from the lower-order (right-most) bytes.
+func extract(contents []byte, offset, size uint64) []byte {
+	if offset+size > 32 {
+		size = 32 - min(offset, 32)
+	}
+	end := 32 - offset
+	return contents[end-size : end]
+}
+
+// decodeValue decodes a value type from its (unpadded) bytes
+func decodeValue(t *Type, value []byte) (string, bool, error) {
+	label := t.Label
+	switch {
+	case label == "bool":
+		return strconv.FormatBool(new(big.Int).SetBytes(value).Sign() != 0), false, nil
+
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		addr := base.BytesToAddress(value)
+		return addr.Hex(), true, nil
+
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(value).String(), false, nil
+
+	case strings.HasPrefix(label, "int"):
+		n := new(big.Int).SetBytes(value)
+		if len(value) > 0 && value[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(value)*8)))
+		}
+		return n.String(), false, nil
+
+	default:
+		// fixed bytes, function pointers and user defined value types
+		return "0x" + base.Bytes2Hex(value), true, nil
+	}
+}
+
+func quote(text string, quoted bool) string {
+	if quoted {
+		return strconv.Quote(text)
+	}
+	return text
+}
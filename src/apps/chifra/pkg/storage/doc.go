@@ -0,0 +1,2 @@
+// Package storage locates and decodes smart contract storage using the storage layout emitted by solc
+package storage
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// Layout is the storage layout solc emits with `--storage-layout` (or `storageLayout` in
+// standard JSON output). Slots and sizes are decimal strings in the compiler's output.
+type Layout struct {
+	Storage []Variable      `json:"storage"`
+	Types   map[string]Type `json:"types"`
+}
+
+// Variable is a state variable or a struct member
+type Variable struct {
+	Label  string `json:"label"`
+	Offset uint64 `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// Type describes how a type is stored. Encoding is one of inplace, mapping, dynamic_array or bytes.
+type Type struct {
+	Encoding      string     `json:"encoding"`
+	Label         string     `json:"label"`
+	NumberOfBytes string     `json:"numberOfBytes"`
+	Key           string     `json:"key,omitempty"`
+	Value         string     `json:"value,omitempty"`
+	Base          string     `json:"base,omitempty"`
+	Members       []Variable `json:"members,omitempty"`
+}
+
+// LoadLayout reads a storage layout from a file
+func LoadLayout(path string) (*Layout, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLayout(bytes)
+}
+
+// ParseLayout parses a storage layout. It accepts the layout itself or any object carrying it
+// under a `storageLayout` key (as in a contract's entry in solc's standard JSON output).
+func ParseLayout(bytes []byte) (*Layout, error) {
+	var wrapped struct {
+		Layout
+		StorageLayout *Layout `json:"storageLayout"`
+	}
+	if err := json.Unmarshal(bytes, &wrapped); err != nil {
+		return nil, fmt.Errorf("invalid storage layout: %w", err)
+	}
+
+	layout := &wrapped.Layout
+	if wrapped.StorageLayout != nil {
+		layout = wrapped.StorageLayout
+	}
+	if len(layout.Storage) == 0 || len(layout.Types) == 0 {
+		return nil, fmt.Errorf("invalid storage layout: no storage variables found")
+	}
+	return layout, nil
+}
+
+func (l *Layout) typeOf(typeId string) (*Type, error) {
+	if t, ok := l.Types[typeId]; ok {
+		return &t, nil
+	}
+	return nil, fmt.Errorf("type %s not found in storage layout", typeId)
+}
+
+func (l *Layout) variable(label string) (*Variable, error) {
+	for i := range l.Storage {
+		if l.Storage[i].Label == label {
+			return &l.Storage[i], nil
+		}
+	}
+	return nil, fmt.Errorf("variable %s not found in storage layout", label)
+}
+
+func (t *Type) size() uint64 {
+	n, _ := new(big.Int).SetString(t.NumberOfBytes, 10)
+	if n == nil || !n.IsUint64() {
+		return 32
+	}
+	return n.Uint64()
+}
+
+// slotsPer is the number of slots an item of this type occupies when it starts its own slot
+func (t *Type) slotsPer() uint64 {
+	return (t.size() + 31) / 32
+}
+
+// staticLength returns the length of a fixed size array from its label (e.g. uint8[4])
+func (t *Type) staticLength() (uint64, bool) {
+	if t.Encoding != "inplace" || t.Base == "" || !strings.HasSuffix(t.Label, "]") {
+		return 0, false
+	}
+	start := strings.LastIndex(t.Label, "[")
+	n, ok := new(big.Int).SetString(t.Label[start+1:len(t.Label)-1], 10)
+	if !ok || !n.IsUint64() {
+		return 0, false
+	}
+	return n.Uint64(), true
+}
+
+// parseSlot parses a slot given either in decimal (as solc writes them) or in hex
+func parseSlot(slot string) (*big.Int, error) {
+	base, digits := 10, slot
+	if strings.HasPrefix(slot, "0x") || strings.HasPrefix(slot, "0X") {
+		base, digits = 16, slot[2:]
+	}
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok || n.Sign() < 0 || n.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid slot %s", slot)
+	}
+	return n, nil
+}
@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Location is where a variable (or an element or member of one) lives in storage
+type Location struct {
+	Path   string
+	Slot   *big.Int
+	Offset uint64
+	TypeId string
+}
+
+// Hash returns the location's slot as a thirty-two byte hash
+func (loc *Location) Hash() base.Hash {
+	return SlotToHash(loc.Slot)
+}
+
+// SlotToHash returns a slot number as a thirty-two byte hash
+func SlotToHash(slot *big.Int) base.Hash {
+	return base.BytesToHash(pad32(slot.Bytes()))
+}
+
+// ParseSlot parses a raw slot number given in decimal or hex
+func ParseSlot(slot string) (*big.Int, error) {
+	return parseSlot(slot)
+}
+
+type step struct {
+	member string
+	key    string
+	isKey  bool
+}
+
+// Locate finds the slot and offset of a variable given a path such as `owner`, `balances[0x...]`,
+// `allowed[0x...][0x...]`, `items[3].name` or `config.fee`. Mapping keys are hashed with the slot,
+// array indices are applied to the array's data area and members use the struct's layout.
+// Locating needs no access to the chain.
+func (l *Layout) Locate(path string) (*Location, error) {
+	root, steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	variable, err := l.variable(root)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := parseSlot(variable.Slot)
+	if err != nil {
+		return nil, err
+	}
+	loc := &Location{Path: root, Slot: slot, Offset: variable.Offset, TypeId: variable.Type}
+
+	for _, s := range steps {
+		t, err := l.typeOf(loc.TypeId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.isKey {
+			if len(t.Members) == 0 {
+				return nil, fmt.Errorf("%s is not a struct", loc.Path)
+			}
+			found := false
+			for _, member := range t.Members {
+				if member.Label == s.member {
+					memberSlot, err := parseSlot(member.Slot)
+					if err != nil {
+						return nil, err
+					}
+					loc.Slot = new(big.Int).Add(loc.Slot, memberSlot)
+					loc.Offset = member.Offset
+					loc.TypeId = member.Type
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("%s has no member %s", loc.Path, s.member)
+			}
+			loc.Path += "." + s.member
+			continue
+		}
+
+		switch {
+		case t.Encoding == "mapping":
+			keyType, err := l.typeOf(t.Key)
+			if err != nil {
+				return nil, err
+			}
+			encoded, err := encodeKey(keyType, s.key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key for %s: %w", loc.Path, err)
+			}
+			loc.Slot = new(big.Int).SetBytes(crypto.Keccak256(encoded, pad32(loc.Slot.Bytes())))
+			loc.Offset = 0
+			loc.TypeId = t.Value
+
+		case t.Encoding == "dynamic_array":
+			index, err := parseIndex(s.key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index for %s: %w", loc.Path, err)
+			}
+			baseType, err := l.typeOf(t.Base)
+			if err != nil {
+				return nil, err
+			}
+			loc.Slot, loc.Offset = element(dataSlot(loc.Slot), baseType, index)
+			loc.TypeId = t.Base
+
+		default:
+			length, ok := t.staticLength()
+			if !ok {
+				return nil, fmt.Errorf("%s is not a mapping or an array", loc.Path)
+			}
+			index, err := parseIndex(s.key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index for %s: %w", loc.Path, err)
+			}
+			if index >= length {
+				return nil, fmt.Errorf("index %d out of range for %s", index, loc.Path)
+			}
+			baseType, err := l.typeOf(t.Base)
+			if err != nil {
+				return nil, err
+			}
+			loc.Slot, loc.Offset = element(loc.Slot, baseType, index)
+			loc.TypeId = t.Base
+		}
+		loc.Path += "[" + s.key + "]"
+	}
+
+	return loc, nil
+}
+
+// parsePath splits a path into its root variable and the member and index steps that follow it
+func parsePath(path string) (string, []step, error) {
+	path = strings.TrimSpace(path)
+	end := strings.IndexAny(path, ".[")
+	if end == -1 {
+		end = len(path)
+	}
+	root := path[:end]
+	if root == "" {
+		return "", nil, fmt.Errorf("invalid path %s", path)
+	}
+
+	steps := make([]step, 0)
+	rest := path[end:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			next := strings.IndexAny(rest, ".[")
+			if next == -1 {
+				next = len(rest)
+			}
+			if next == 0 {
+				return "", nil, fmt.Errorf("invalid path %s", path)
+			}
+			steps = append(steps, step{member: rest[:next]})
+			rest = rest[next:]
+		case '[':
+			close := strings.Index(rest, "]")
+			if close < 2 {
+				return "", nil, fmt.Errorf("invalid path %s", path)
+			}
+			steps = append(steps, step{key: strings.Trim(rest[1:close], " \"'"), isKey: true})
+			rest = rest[close+1:]
+		default:
+			return "", nil, fmt.Errorf("invalid path %s", path)
+		}
+	}
+	return root, steps, nil
+}
+
+// element returns the location of the index-th element of an array whose data starts at start.
+// Elements of sixteen bytes or less are packed into slots, larger ones start their own slot.
+func element(start *big.Int, baseType *Type, index uint64) (*big.Int, uint64) {
+	size := baseType.size()
+	if size <= 16 {
+		perSlot := 32 / size
+		slot := new(big.Int).Add(start, new(big.Int).SetUint64(index/perSlot))
+		return slot, (index % perSlot) * size
+	}
+	offset := new(big.Int).Mul(new(big.Int).SetUint64(index), new(big.Int).SetUint64(baseType.slotsPer()))
+	return new(big.Int).Add(start, offset), 0
+}
+
+// dataSlot is where the data of a dynamic array or a long string or bytes value starts
+func dataSlot(slot *big.Int) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(pad32(slot.Bytes())))
+}
+
+func parseIndex(key string) (uint64, error) {
+	n, err := parseSlot(key)
+	if err != nil || !n.IsUint64() {
+		return 0, fmt.Errorf("%s is not an array index", key)
+	}
+	return n.Uint64(), nil
+}
+
+// encodeKey encodes a mapping key the way Solidity does before hashing it with the mapping's
+// slot. Value types are padded to thirty-two bytes, strings and bytes are used as they are.
+func encodeKey(keyType *Type, key string) ([]byte, error) {
+	label := keyType.Label
+	switch {
+	case label == "string":
+		return []byte(key), nil
+
+	case label == "bytes":
+		b, err := hexKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+
+	case label == "bool":
+		switch key {
+		case "true":
+			return pad32([]byte{1}), nil
+		case "false":
+			return pad32([]byte{}), nil
+		}
+		return nil, fmt.Errorf("%s is not a bool", key)
+
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		if ok, _ := base.ValidHex(key, 20); !ok {
+			return nil, fmt.Errorf("%s is not an address", key)
+		}
+		return pad32(base.Hex2Bytes(key[2:])), nil
+
+	case strings.HasPrefix(label, "bytes"):
+		b, err := hexKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(b)) > keyType.size() {
+			return nil, fmt.Errorf("%s is too long for %s", key, label)
+		}
+		ret := make([]byte, 32)
+		copy(ret, b)
+		return ret, nil
+
+	case strings.HasPrefix(label, "int"):
+		n, ok := parseInt(key)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an integer", key)
+		}
+		if n.Sign() < 0 {
+			n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return pad32(n.Bytes()), nil
+
+	default:
+		// unsigned integers and enums
+		n, err := parseSlot(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not an unsigned integer", key)
+		}
+		return pad32(n.Bytes()), nil
+	}
+}
+
+func hexKey(key string) ([]byte, error) {
+	if !strings.HasPrefix(key, "0x") || len(key)%2 != 0 || !base.IsHex(key) {
+		return nil, fmt.Errorf("%s is not hex", key)
+	}
+	return base.Hex2Bytes(key[2:]), nil
+}
+
+func parseInt(key string) (*big.Int, bool) {
+	neg := strings.HasPrefix(key, "-")
+	n, err := parseSlot(strings.TrimPrefix(key, "-"))
+	if err != nil || n.BitLen() > 255 {
+		return nil, false
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, true
+}
+
+func pad32(b []byte) []byte {
+	if len(b) >= 32 {
+		return b[len(b)-32:]
+	}
+	ret := make([]byte, 32)
+	copy(ret[32-len(b):], b)
+	return ret
+}
@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testLayout is a trimmed version of what solc emits for:
+//
+//	contract C {
+//	    mapping(uint256 => uint256) zero;                          // slot 0
+//	    uint256[] list;                                            // slot 1
+//	    address owner; bool paused; uint8 decimals; int16 delta;   // slot 2
+//	    string name;                                               // slot 3
+//	    mapping(address => mapping(address => uint256)) allowed;   // slot 4
+//	    struct S { uint128 a; int64 b; string c; }
+//	    S config;                                                  // slots 5-6
+//	    S[] items;                                                 // slot 7
+//	    uint16[3] small;                                           // slot 8
+//	    mapping(string => bool) flags;                             // slot 9
+//	}
+const testLayout = `{
+  "storageLayout": {
+    "storage": [
+      {"label": "zero", "offset": 0, "slot": "0", "type": "t_mapping(t_uint256,t_uint256)"},
+      {"label": "list", "offset": 0, "slot": "1", "type": "t_array(t_uint256)dyn_storage"},
+      {"label": "owner", "offset": 0, "slot": "2", "type": "t_address"},
+      {"label": "paused", "offset": 20, "slot": "2", "type": "t_bool"},
+      {"label": "decimals", "offset": 21, "slot": "2", "type": "t_uint8"},
+      {"label": "delta", "offset": 22, "slot": "2", "type": "t_int16"},
+      {"label": "name", "offset": 0, "slot": "3", "type": "t_string_storage"},
+      {"label": "allowed", "offset": 0, "slot": "4", "type": "t_mapping(t_address,t_mapping(t_address,t_uint256))"},
+      {"label": "config", "offset": 0, "slot": "5", "type": "t_struct(S)_storage"},
+      {"label": "items", "offset": 0, "slot": "7", "type": "t_array(t_struct(S)_storage)dyn_storage"},
+      {"label": "small", "offset": 0, "slot": "8", "type": "t_array(t_uint16)3_storage"},
+      {"label": "flags", "offset": 0, "slot": "9", "type": "t_mapping(t_string_memory_ptr,t_bool)"}
+    ],
+    "types": {
+      "t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+      "t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+      "t_int16": {"encoding": "inplace", "label": "int16", "numberOfBytes": "2"},
+      "t_int64": {"encoding": "inplace", "label": "int64", "numberOfBytes": "8"},
+      "t_uint8": {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"},
+      "t_uint16": {"encoding": "inplace", "label": "uint16", "numberOfBytes": "2"},
+      "t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+      "t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+      "t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+      "t_string_memory_ptr": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+      "t_array(t_uint256)dyn_storage": {"encoding": "dynamic_array", "label": "uint256[]", "numberOfBytes": "32", "base": "t_uint256"},
+      "t_array(t_uint16)3_storage": {"encoding": "inplace", "label": "uint16[3]", "numberOfBytes": "32", "base": "t_uint16"},
+      "t_array(t_struct(S)_storage)dyn_storage": {"encoding": "dynamic_array", "label": "struct C.S[]", "numberOfBytes": "32", "base": "t_struct(S)_storage"},
+      "t_mapping(t_uint256,t_uint256)": {"encoding": "mapping", "label": "mapping(uint256 => uint256)", "numberOfBytes": "32", "key": "t_uint256", "value": "t_uint256"},
+      "t_mapping(t_address,t_uint256)": {"encoding": "mapping", "label": "mapping(address => uint256)", "numberOfBytes": "32", "key": "t_address", "value": "t_uint256"},
+      "t_mapping(t_address,t_mapping(t_address,t_uint256))": {"encoding": "mapping", "label": "mapping(address => mapping(address => uint256))", "numberOfBytes": "32", "key": "t_address", "value": "t_mapping(t_address,t_uint256)"},
+      "t_mapping(t_string_memory_ptr,t_bool)": {"encoding": "mapping", "label": "mapping(string => bool)", "numberOfBytes": "32", "key": "t_string_memory_ptr", "value": "t_bool"},
+      "t_struct(S)_storage": {"encoding": "inplace", "label": "struct C.S", "numberOfBytes": "64", "members": [
+        {"label": "a", "offset": 0, "slot": "0", "type": "t_uint128"},
+        {"label": "b", "offset": 16, "slot": "0", "type": "t_int64"},
+        {"label": "c", "offset": 0, "slot": "1", "type": "t_string_storage"}
+      ]}
+    }
+  }
+}`
+
+func hexToSlot(hex string) *big.Int {
+	n, _ := new(big.Int).SetString(hex[2:], 16)
+	return n
+}
+
+func TestLocate(t *testing.T) {
+	layout, err := ParseLayout([]byte(testLayout))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keccak(pad32(0) . pad32(0)) and keccak(pad32(0)) are well known
+	mappingZero := "0xad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5"
+	listData := hexToSlot("0xb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6") // keccak(pad32(1))
+
+	tests := []struct {
+		path   string
+		slot   *big.Int
+		offset uint64
+		label  string
+	}{
+		{"zero[0]", hexToSlot(mappingZero), 0, "uint256"},
+		{"list[0]", listData, 0, "uint256"},
+		{"list[2]", new(big.Int).Add(listData, big.NewInt(2)), 0, "uint256"},
+		{"paused", big.NewInt(2), 20, "bool"},
+		{"delta", big.NewInt(2), 22, "int16"},
+		{"config.b", big.NewInt(5), 16, "int64"},
+		{"config.c", big.NewInt(6), 0, "string"},
+		{"items[1].c", new(big.Int).Add(dataSlot(big.NewInt(7)), big.NewInt(3)), 0, "string"},
+		{"small[2]", big.NewInt(8), 4, "uint16"},
+	}
+
+	for _, tt := range tests {
+		loc, err := layout.Locate(tt.path)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if loc.Slot.Cmp(tt.slot) != 0 || loc.Offset != tt.offset || layout.TypeLabel(loc) != tt.label {
+			t.Errorf("%s: got slot %x offset %d (%s), want %x offset %d (%s)", tt.path, loc.Slot, loc.Offset, layout.TypeLabel(loc), tt.slot, tt.offset, tt.label)
+		}
+	}
+
+	// a mapping of mappings hashes each key in turn
+	owner := "0x00000000000000000000000000000000000000aa"
+	spender := "0x00000000000000000000000000000000000000bb"
+	outer, _ := layout.Locate("allowed[" + owner + "]")
+	inner, _ := layout.Locate("allowed[" + owner + "][" + spender + "]")
+	if outer.Slot.Cmp(new(big.Int).SetBytes(keccak(pad32(base.Hex2Bytes(owner[2:])), pad32(big.NewInt(4).Bytes())))) != 0 {
+		t.Errorf("wrong slot for the outer mapping %x", outer.Slot)
+	}
+	if inner.Slot.Cmp(new(big.Int).SetBytes(keccak(pad32(base.Hex2Bytes(spender[2:])), pad32(outer.Slot.Bytes())))) != 0 {
+		t.Errorf("wrong slot for the inner mapping %x", inner.Slot)
+	}
+
+	// string keys are hashed unpadded
+	flag, _ := layout.Locate(`flags["hello"]`)
+	if flag.Slot.Cmp(new(big.Int).SetBytes(keccak([]byte("hello"), pad32(big.NewInt(9).Bytes())))) != 0 {
+		t.Errorf("wrong slot for a string key %x", flag.Slot)
+	}
+
+	for _, bad := range []string{"missing", "owner.x", "paused[1]", "small[3]", "allowed[0x12]", "list[x]", "config.", "list[", ""} {
+		if _, err := layout.Locate(bad); err == nil {
+			t.Errorf("expected %q to fail", bad)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	layout, err := ParseLayout([]byte(testLayout))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slots := map[string][]byte{}
+	set := func(slot *big.Int, value []byte) {
+		slots[slot.String()] = pad32(value)
+	}
+	read := func(slot *big.Int) ([]byte, error) {
+		if value, ok := slots[slot.String()]; ok {
+			return value, nil
+		}
+		return make([]byte, 32), nil
+	}
+
+	// owner, paused, decimals and delta (-2) packed into slot 2
+	packed := make([]byte, 32)
+	copy(packed[12:], base.Hex2Bytes("00000000000000000000000000000000000000aa"))
+	packed[11] = 1
+	packed[10] = 18
+	packed[8], packed[9] = 0xff, 0xfe
+	set(big.NewInt(2), packed)
+
+	// a short string
+	short := make([]byte, 32)
+	copy(short, "Token")
+	short[31] = 10
+	set(big.NewInt(3), short)
+
+	// config = {a: 7, b: -1, c: <a 40 byte string>}
+	config := make([]byte, 32)
+	config[31] = 7
+	for i := 8; i < 16; i++ {
+		config[i] = 0xff
+	}
+	set(big.NewInt(5), config)
+	long := "a string long enough to need two slots.."
+	set(big.NewInt(6), big.NewInt(int64(len(long)*2+1)).Bytes())
+	set(dataSlot(big.NewInt(6)), []byte(long[:32]))
+	tail := make([]byte, 32)
+	copy(tail, long[32:])
+	set(new(big.Int).Add(dataSlot(big.NewInt(6)), big.NewInt(1)), tail)
+
+	// list = [5, 6]
+	set(big.NewInt(1), []byte{2})
+	set(dataSlot(big.NewInt(1)), []byte{5})
+	set(new(big.Int).Add(dataSlot(big.NewInt(1)), big.NewInt(1)), []byte{6})
+
+	// small = [1, 2, 3]
+	set(big.NewInt(8), []byte{0, 3, 0, 2, 0, 1})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"owner", "0x00000000000000000000000000000000000000aa"},
+		{"paused", "true"},
+		{"decimals", "18"},
+		{"delta", "-2"},
+		{"name", "Token"},
+		{"config.a", "7"},
+		{"config.b", "-1"},
+		{"config.c", long},
+		{"config", `{"a":7,"b":-1,"c":"` + long + `"}`},
+		{"list", "[5,6]"},
+		{"list[1]", "6"},
+		{"small", "[1,2,3]"},
+		{"small[1]", "2"},
+		{"items", "[]"},
+		{"zero", ""},
+	}
+
+	for _, tt := range tests {
+		loc, err := layout.Locate(tt.path)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		got, err := layout.Decode(loc, read)
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+		} else if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	if _, err := ParseLayout([]byte(`{"storage": [{"label": "x", "offset": 0, "slot": "0", "type": "t_uint256"}], "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}}}`)); err != nil {
+		t.Error("expected a bare layout to parse", err)
+	}
+	if _, err := ParseLayout([]byte(`{"abi": []}`)); err == nil {
+		t.Error("expected a file without a layout to fail")
+	}
+	if _, err := ParseLayout([]byte(`not json`)); err == nil {
+		t.Error("expected garbage to fail")
+	}
+}
+
+func keccak(data ...[]byte) []byte {
+	return crypto.Keccak256(data...)
+}
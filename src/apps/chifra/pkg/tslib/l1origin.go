@@ -0,0 +1,76 @@
+package tslib
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/file"
+)
+
+// The L1 origins database parallels the timestamps database. For chains whose rules store
+// them, the i'th record is the L1 block from which the i'th block derives. It may be shorter than
+// the timestamps database but never longer.
+const l1RecordSize = 4
+
+// NL1Origins returns the number of records in the L1 origins file (zero if there isn't one)
+func NL1Origins(chain string) base.Blknum {
+	return base.Blknum(file.FileSize(config.PathToL1Origins(chain)) / l1RecordSize)
+}
+
+// FromBnToL1 returns the L1 block from which the given block derives
+func FromBnToL1(chain string, bn base.Blknum) (base.Blknum, error) {
+	cnt := NL1Origins(chain)
+	if bn >= cnt {
+		return 0, fmt.Errorf("no L1 origin for block %d of %d", bn, cnt)
+	}
+
+	fp, err := os.Open(config.PathToL1Origins(chain))
+	if err != nil {
+		return 0, err
+	}
+	defer fp.Close()
+
+	if _, err := fp.Seek(int64(bn)*l1RecordSize, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	var origin uint32
+	if err := binary.Read(fp, binary.LittleEndian, &origin); err != nil {
+		return 0, err
+	}
+	return base.Blknum(origin), nil
+}
+
+// AppendL1 appends L1 origins to the L1 origins file. The first is for block NL1Origins.
+func AppendL1(chain string, origins []uint32) error {
+	fp, err := os.OpenFile(config.PathToL1Origins(chain), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	return binary.Write(fp, binary.LittleEndian, origins)
+}
+
+// RepairL1 replaces the L1 origin of a single block
+func RepairL1(chain string, bn base.Blknum, origin base.Blknum) error {
+	cnt := NL1Origins(chain)
+	if bn >= cnt {
+		return fmt.Errorf("block number %d out of range %d", bn, cnt)
+	}
+
+	fp, err := os.OpenFile(config.PathToL1Origins(chain), os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	if _, err := fp.Seek(int64(bn)*l1RecordSize, io.SeekStart); err != nil {
+		return err
+	}
+	return binary.Write(fp, binary.LittleEndian, uint32(origin))
+}
@@ -0,0 +1,66 @@
+package tslib
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
+)
+
+// Rules are the rules a chain's timestamp database follows. By default (as on mainnet), each
+// block's timestamp is later than the one before it. On many L2s, consecutive blocks may share a
+// timestamp, so a timestamp may resolve to the first or the last of those blocks.
+type Rules struct {
+	AllowDuplicates bool
+	ResolveFirst    bool
+	L1Origin        bool
+}
+
+// GetRules returns the rules for the chain's timestamp database from the chain's configuration
+func GetRules(chain string) Rules {
+	settings := config.GetTimestamps(chain)
+	return Rules{
+		AllowDuplicates: settings.AllowDuplicates,
+		ResolveFirst:    settings.Resolve == "first",
+		L1Origin:        settings.L1Origin,
+	}
+}
+
+// Check returns a description of each way the record breaks the rules given the record before it
+func (r Rules) Check(prev, cur TimestampRecord) []string {
+	problems := []string{}
+
+	if cur.Bn <= prev.Bn {
+		problems = append(problems, fmt.Sprintf("block number %d does not follow %d", cur.Bn, prev.Bn))
+	}
+
+	if cur.Ts < prev.Ts {
+		problems = append(problems, fmt.Sprintf("timestamp %d is earlier than previous %d", cur.Ts, prev.Ts))
+	} else if cur.Ts == prev.Ts && !r.AllowDuplicates {
+		problems = append(problems, fmt.Sprintf("timestamp %d does not increase over previous %d (set allowDuplicates for this chain if that is expected)", cur.Ts, prev.Ts))
+	}
+
+	return problems
+}
+
+// search returns the index of the record a timestamp resolves to (the last record at or before
+// the timestamp, or the first record sharing that record's timestamp if the rules resolve to the
+// first) or -1 if the timestamp is before the first record. The records must be sorted by timestamp.
+func (r Rules) search(records []TimestampRecord, ts base.Timestamp) int {
+	// Go docs: Search uses binary search to find and return the smallest index i in [0, n) at which f(i) is true,
+	index := sort.Search(len(records), func(i int) bool {
+		return base.Timestamp(records[i].Ts) > ts
+	})
+
+	// The index is one past where we want to be because it's the first block larger
+	index--
+	if index < 0 || !r.ResolveFirst {
+		return index
+	}
+
+	found := records[index].Ts
+	return sort.Search(index+1, func(i int) bool {
+		return records[i].Ts >= found
+	})
+}
@@ -0,0 +1,70 @@
+package tslib
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// several blocks a second, as on many L2s
+var l2Records = []TimestampRecord{
+	{Bn: 0, Ts: 1000},
+	{Bn: 1, Ts: 1000},
+	{Bn: 2, Ts: 1000},
+	{Bn: 3, Ts: 1001},
+	{Bn: 4, Ts: 1003},
+	{Bn: 5, Ts: 1003},
+}
+
+func TestRulesSearch(t *testing.T) {
+	tests := []struct {
+		ts    base.Timestamp
+		last  int
+		first int
+	}{
+		{999, -1, -1},
+		{1000, 2, 0},
+		{1001, 3, 3},
+		{1002, 3, 3},
+		{1003, 5, 4},
+		{2000, 5, 4},
+	}
+
+	for _, test := range tests {
+		if got := (Rules{}).search(l2Records, test.ts); got != test.last {
+			t.Error("Expected the last block at", test.ts, "to be", test.last, "got", got)
+		}
+		if got := (Rules{ResolveFirst: true}).search(l2Records, test.ts); got != test.first {
+			t.Error("Expected the first block at", test.ts, "to be", test.first, "got", got)
+		}
+	}
+}
+
+func TestRulesCheck(t *testing.T) {
+	mainnet, l2 := Rules{}, Rules{AllowDuplicates: true}
+
+	if problems := l2.Check(l2Records[0], l2Records[1]); len(problems) != 0 {
+		t.Error("Expected duplicate timestamps to be allowed, got", problems)
+	}
+	if problems := mainnet.Check(l2Records[0], l2Records[1]); len(problems) != 1 {
+		t.Error("Expected duplicate timestamps to be an error, got", problems)
+	}
+
+	backwards := TimestampRecord{Bn: 6, Ts: 1002}
+	if problems := l2.Check(l2Records[5], backwards); len(problems) != 1 {
+		t.Error("Expected a timestamp that goes backwards to be an error, got", problems)
+	}
+
+	if problems := l2.Check(l2Records[5], l2Records[4]); len(problems) != 1 {
+		t.Error("Expected a block number that goes backwards to be an error, got", problems)
+	}
+}
+
+func TestBlockTime(t *testing.T) {
+	if got := blockTime(l2Records); got != 0.6 {
+		t.Error("Expected 0.6 seconds per block, got", got)
+	}
+	if got := blockTime(l2Records[:1]); got != 13.3 {
+		t.Error("Expected the default block time, got", got)
+	}
+}
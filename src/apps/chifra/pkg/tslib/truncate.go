@@ -29,6 +29,13 @@ func Truncate(chain string, maxBn base.Blknum) error {
 		return err
 	}
 
+	// the L1 origins may never be longer than the timestamps
+	if NL1Origins(chain) > maxBn {
+		if err := os.Truncate(config.PathToL1Origins(chain), int64(maxBn)*l1RecordSize); err != nil {
+			return err
+		}
+	}
+
 	truncated := perChainTimestamps[chain].memory[0:maxBn]
 
 	tsFn := config.PathToTimestamps(chain)
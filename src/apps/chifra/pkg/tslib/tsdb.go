@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"sort"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/config"
@@ -82,7 +81,8 @@ var ErrInTheFuture = errors.New("timestamp in the future")
 
 // FromTs is a local function that returns a Timestamp record given a Unix timestamp. It
 // loads the timestamp file into memory if it isn't already. If the timestamp requested
-// is past the end of the timestamp file, it estimates the block number and returns and error
+// is past the end of the timestamp file, it estimates the block number and returns and error.
+// If more than one block shares the timestamp, the chain's rules decide which is returned.
 func FromTs(chain string, ts base.Timestamp) (*TimestampRecord, error) {
 	cnt, err := NTimestamps(chain)
 	if err != nil {
@@ -94,33 +94,47 @@ func FromTs(chain string, ts base.Timestamp) (*TimestampRecord, error) {
 		return &TimestampRecord{}, err
 	}
 
+	rules := GetRules(chain)
 	if ts > base.Timestamp(perChainTimestamps[chain].memory[cnt-1].Ts) {
 		last := perChainTimestamps[chain].memory[cnt-1]
 		secs := ts - base.Timestamp(last.Ts)
-		blks := uint32(float64(secs) / 13.3)
+		secsPerBlock := 13.3
+		if rules.AllowDuplicates {
+			secsPerBlock = blockTime(perChainTimestamps[chain].memory[:cnt])
+		}
+		blks := uint32(float64(secs) / secsPerBlock)
 		last.Bn = last.Bn + blks
 		last.Ts = uint32(ts)
 		return &last, ErrInTheFuture
 	}
 
-	// Go docs: Search uses binary search to find and return the smallest index i in [0, n) at which f(i) is true,
-	index := sort.Search(int(cnt), func(i int) bool {
-		d := perChainTimestamps[chain].memory[i]
-		v := base.Timestamp(d.Ts)
-		return v > ts
-	})
+	index := rules.search(perChainTimestamps[chain].memory[:cnt], ts)
 
 	// ts should not be before the first block
-	if index == 0 {
+	if index < 0 {
 		return nil, errors.New("timestamp is before the first block")
 	}
 
-	// The index is one past where we want to be because it's the first block larger
-	index--
-
 	return &perChainTimestamps[chain].memory[index], nil
 }
 
+// blockTime returns the average number of seconds per block over the most recent records (L2
+// blocks may come many times a second) or 13.3 if there are too few records to tell
+func blockTime(records []TimestampRecord) float64 {
+	n := len(records)
+	if n < 2 {
+		return 13.3
+	}
+	first, last := records[0], records[n-1]
+	if n > 10000 {
+		first = records[n-10000]
+	}
+	if last.Ts <= first.Ts || last.Bn <= first.Bn {
+		return 13.3
+	}
+	return float64(last.Ts-first.Ts) / float64(last.Bn-first.Bn)
+}
+
 func ClearCache(chain string) {
 	perChainTimestamps[chain] = TimestampDatabase{
 		loaded: false,
@@ -0,0 +1,33 @@
+package types
+
+// revertReasonToMap renders a decoded revert reason the same way other articulated
+// fields are rendered, carrying the builtin error's message (if any) along with it.
+func revertReasonToMap(revertReason *Function) map[string]any {
+	ret := map[string]any{
+		"name": revertReason.Name,
+	}
+	if inputModels := parametersToMap(revertReason.Inputs); inputModels != nil {
+		ret["inputs"] = inputModels
+	}
+	if revertReason.Message != "" {
+		ret["message"] = revertReason.Message
+	}
+	return ret
+}
+
+// addRevertReason adds the articulated revert reason to a model. In JSON the field is only
+// present if the item reverted. Other formats always carry the column so rows line up.
+func addRevertReason(model map[string]any, order []string, format string, revertReason *Function) []string {
+	if format == "json" {
+		if revertReason != nil {
+			model["revertReason"] = revertReasonToMap(revertReason)
+		}
+		return order
+	}
+
+	model["revertReason"] = ""
+	if revertReason != nil {
+		model["revertReason"] = makeCompressed(revertReasonToMap(revertReason))
+	}
+	return append(order, "revertReason")
+}
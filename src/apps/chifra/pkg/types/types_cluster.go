@@ -0,0 +1,100 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package types
+
+// EXISTING_CODE
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// EXISTING_CODE
+
+type Cluster struct {
+	Accepted   bool           `json:"accepted,omitempty"`
+	Anchor     base.Address   `json:"anchor"`
+	Confidence float64        `json:"confidence"`
+	Heuristic  string         `json:"heuristic"`
+	Id         string         `json:"id"`
+	Members    []base.Address `json:"members"`
+	Reason     string         `json:"reason"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (s Cluster) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func (s *Cluster) Model(chain, format string, verbose bool, extraOpts map[string]any) Model {
+	var model = map[string]any{}
+	var order = []string{}
+
+	// EXISTING_CODE
+	members := make([]string, 0, len(s.Members))
+	for _, member := range s.Members {
+		members = append(members, member.Hex())
+	}
+
+	model = map[string]any{
+		"id":         s.Id,
+		"heuristic":  s.Heuristic,
+		"anchor":     s.Anchor.Hex(),
+		"confidence": s.Confidence,
+		"nMembers":   len(s.Members),
+		"reason":     s.Reason,
+	}
+	order = []string{
+		"id",
+		"heuristic",
+		"anchor",
+		"confidence",
+		"nMembers",
+		"reason",
+	}
+
+	if name, loaded, found := nameAddress(extraOpts, s.Anchor); found {
+		model["anchorName"] = name.Name
+		order = append(order, "anchorName")
+	} else if loaded && format != "json" {
+		model["anchorName"] = ""
+		order = append(order, "anchorName")
+	}
+
+	if format == "json" {
+		model["members"] = members
+		order = append(order, "members")
+		if s.Accepted {
+			model["accepted"] = s.Accepted
+			order = append(order, "accepted")
+		}
+	} else {
+		model["members"] = strings.Join(members, "|")
+		model["accepted"] = s.Accepted
+		order = append(order, "members", "accepted")
+	}
+	// EXISTING_CODE
+
+	return Model{
+		Data:  model,
+		Order: order,
+	}
+}
+
+// FinishUnmarshal is used by the cache. It may be unused depending on auto-code-gen
+func (s *Cluster) FinishUnmarshal() {
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -359,6 +359,17 @@ func (s *Function) AbiMethodFromFunction() (ethMethod *abi.Method, err error) {
 	if err != nil {
 		return
 	}
+	if s.FunctionType == "error" {
+		// errors are encoded like functions, so we carry their inputs in an equivalent method
+		found, ok := res.Errors[s.Name]
+		if !ok {
+			err = fmt.Errorf("generating ABI method: error not found: %s", s.Name)
+			return
+		}
+		method := abi.NewMethod(found.Name, found.Name, abi.Function, "nonpayable", false, false, found.Inputs, nil)
+		ethMethod = &method
+		return
+	}
 	found, ok := res.Methods[s.Name]
 	if !ok {
 		err = fmt.Errorf("generating ABI method: method not found: %s", s.Name)
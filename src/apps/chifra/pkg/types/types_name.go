@@ -220,6 +220,7 @@ const (
 	MatchCase
 	Expanded
 	Tags
+	Regex
 	All = Regular | Custom | Prefund | Baddress
 )
 
@@ -229,6 +230,7 @@ type SortBy int
 const (
 	SortByAddress SortBy = iota
 	SortByTags
+	SortByRank
 )
 
 // EXISTING_CODE
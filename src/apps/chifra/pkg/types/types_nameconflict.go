@@ -0,0 +1,87 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package types
+
+// EXISTING_CODE
+import (
+	"encoding/json"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// EXISTING_CODE
+
+type NameConflict struct {
+	Address         base.Address   `json:"address"`
+	Base            string         `json:"base,omitempty"`
+	Field           string         `json:"field"`
+	Name            string         `json:"name"`
+	Ours            string         `json:"ours"`
+	OursAuthor      base.Address   `json:"oursAuthor"`
+	OursTimestamp   base.Timestamp `json:"oursTimestamp"`
+	Resolution      string         `json:"resolution,omitempty"`
+	Theirs          string         `json:"theirs"`
+	TheirsAuthor    base.Address   `json:"theirsAuthor"`
+	TheirsTimestamp base.Timestamp `json:"theirsTimestamp"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (s NameConflict) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func (s *NameConflict) Model(chain, format string, verbose bool, extraOpts map[string]any) Model {
+	var model = map[string]any{}
+	var order = []string{}
+
+	// EXISTING_CODE
+	model = map[string]any{
+		"address":         s.Address.Hex(),
+		"name":            s.Name,
+		"field":           s.Field,
+		"base":            s.Base,
+		"ours":            s.Ours,
+		"theirs":          s.Theirs,
+		"oursAuthor":      s.OursAuthor.Hex(),
+		"oursTimestamp":   s.OursTimestamp,
+		"theirsAuthor":    s.TheirsAuthor.Hex(),
+		"theirsTimestamp": s.TheirsTimestamp,
+		"resolution":      s.Resolution,
+	}
+	order = []string{
+		"address",
+		"name",
+		"field",
+		"base",
+		"ours",
+		"theirs",
+		"oursAuthor",
+		"oursTimestamp",
+		"theirsAuthor",
+		"theirsTimestamp",
+		"resolution",
+	}
+	// EXISTING_CODE
+
+	return Model{
+		Data:  model,
+		Order: order,
+	}
+}
+
+// FinishUnmarshal is used by the cache. It may be unused depending on auto-code-gen
+func (s *NameConflict) FinishUnmarshal() {
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
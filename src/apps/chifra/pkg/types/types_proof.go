@@ -0,0 +1,113 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package types
+
+// EXISTING_CODE
+import (
+	"encoding/json"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// EXISTING_CODE
+
+type Proof struct {
+	AccountProof []string       `json:"accountProof"`
+	Address      base.Address   `json:"address"`
+	Balance      base.Wei       `json:"balance"`
+	BlockNumber  base.Blknum    `json:"blockNumber"`
+	CodeHash     base.Hash      `json:"codeHash"`
+	Consistent   bool           `json:"consistent"`
+	Nonce        base.Value     `json:"nonce"`
+	StateRoot    base.Hash      `json:"stateRoot"`
+	StorageHash  base.Hash      `json:"storageHash"`
+	StorageProof []StorageProof `json:"storageProof,omitempty"`
+	Timestamp    base.Timestamp `json:"timestamp"`
+	Verified     bool           `json:"verified"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (s Proof) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func (s *Proof) Model(chain, format string, verbose bool, extraOpts map[string]any) Model {
+	var model = map[string]any{}
+	var order = []string{}
+
+	// EXISTING_CODE
+	model = map[string]any{
+		"blockNumber": s.BlockNumber,
+		"address":     s.Address.Hex(),
+		"stateRoot":   s.StateRoot.Hex(),
+		"balance":     s.Balance.String(),
+		"nonce":       s.Nonce,
+		"codeHash":    s.CodeHash.Hex(),
+		"storageHash": s.StorageHash.Hex(),
+		"consistent":  s.Consistent,
+		"verified":    s.Verified,
+	}
+	order = []string{"blockNumber", "address"}
+	if verbose {
+		model["timestamp"] = s.Timestamp
+		model["date"] = s.Date()
+		order = append(order, "timestamp", "date")
+	}
+	order = append(order, "stateRoot", "balance")
+
+	if extraOpts["ether"] == true {
+		model["ether"] = s.Balance.ToEtherStr(18)
+		order = append(order, "ether")
+	}
+	order = append(order, "nonce", "codeHash", "storageHash", "consistent", "verified")
+
+	// The proofs themselves are only useful in JSON where they may be checked by others
+	if format == "json" {
+		model["accountProof"] = s.AccountProof
+		order = append(order, "accountProof")
+		if len(s.StorageProof) > 0 {
+			storageProofs := make([]map[string]any, 0, len(s.StorageProof))
+			for _, proof := range s.StorageProof {
+				storageProofs = append(storageProofs, proof.Model(chain, format, verbose, extraOpts).Data)
+			}
+			model["storageProof"] = storageProofs
+			order = append(order, "storageProof")
+		}
+	}
+
+	if name, loaded, found := nameAddress(extraOpts, s.Address); found {
+		model["addressName"] = name.Name
+		order = append(order, "addressName")
+	} else if loaded && format != "json" {
+		model["addressName"] = ""
+		order = append(order, "addressName")
+	}
+	order = reorderOrdering(order)
+	// EXISTING_CODE
+
+	return Model{
+		Data:  model,
+		Order: order,
+	}
+}
+
+func (s *Proof) Date() string {
+	return base.FormattedDate(s.Timestamp)
+}
+
+// FinishUnmarshal is used by the cache. It may be unused depending on auto-code-gen
+func (s *Proof) FinishUnmarshal() {
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
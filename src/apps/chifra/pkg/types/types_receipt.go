@@ -38,6 +38,7 @@ type Receipt struct {
 	TransactionHash   base.Hash    `json:"transactionHash"`
 	TransactionIndex  base.Txnum   `json:"transactionIndex"`
 	// EXISTING_CODE
+	RevertReason *Function `json:"revertReason,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -114,6 +115,10 @@ func (s *Receipt) Model(chain, format string, verbose bool, extraOpts map[string
 		}
 	}
 
+	if extraOpts["articulate"] == true {
+		order = addRevertReason(model, order, format, s.RevertReason)
+	}
+
 	items := []namer{
 		{addr: s.ContractAddress, name: "contractName"},
 		{addr: s.From, name: "fromName"},
@@ -34,6 +34,7 @@ type Result struct {
 	// EXISTING_CODE
 	Values        map[string]string `json:"values"`
 	ReturnedBytes string
+	RevertReason  *Function `json:"revertReason,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -107,6 +108,10 @@ func (s *Result) Model(chain, format string, verbose bool, extraOpts map[string]
 		model["compressedResult"] = makeCompressed(s.Values)
 	}
 
+	if extraOpts["articulate"] == true {
+		order = addRevertReason(model, order, format, s.RevertReason)
+	}
+
 	if name, loaded, found := nameAddress(extraOpts, s.Address); found {
 		model["addressName"] = name.Name
 		order = append(order, "addressName")
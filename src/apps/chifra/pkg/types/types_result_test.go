@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+func TestResultRevertReason(t *testing.T) {
+	result := Result{
+		Address:      base.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f"),
+		BlockNumber:  18000000,
+		Encoding:     "0x70a08231",
+		Signature:    "balanceOf(address)",
+		RevertReason: &Function{Name: "Error", Message: "not allowed"},
+	}
+
+	hasColumn := func(m Model) bool {
+		for _, field := range m.Order {
+			if field == "revertReason" {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, format := range []string{"json", "csv"} {
+		plain := result.Model("mainnet", format, false, map[string]any{})
+		if _, ok := plain.Data["revertReason"]; ok || hasColumn(plain) {
+			t.Error("Expected no revertReason without articulate in", format, plain.Data)
+		}
+
+		articulated := result.Model("mainnet", format, false, map[string]any{"articulate": true})
+		if _, ok := articulated.Data["revertReason"]; !ok {
+			t.Error("Expected a revertReason with articulate in", format, articulated.Data)
+		}
+		if hasColumn(articulated) != (format != "json") {
+			t.Error("Expected the revertReason column only in", format, "(not json), got", articulated.Order)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package types
+
+// EXISTING_CODE
+import (
+	"encoding/json"
+)
+
+// EXISTING_CODE
+
+type RpcEndpoint struct {
+	Failures  uint64  `json:"failures"`
+	LastError string  `json:"lastError,omitempty"`
+	Latency   float64 `json:"latency"`
+	RateLimit float64 `json:"rateLimit,omitempty"`
+	Served    uint64  `json:"served"`
+	State     string  `json:"state"`
+	Url       string  `json:"url"`
+	Weight    uint64  `json:"weight"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (s RpcEndpoint) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func (s *RpcEndpoint) Model(chain, format string, verbose bool, extraOpts map[string]any) Model {
+	var model = map[string]any{}
+	var order = []string{}
+
+	// EXISTING_CODE
+	model = map[string]any{
+		"url":      s.Url,
+		"weight":   s.Weight,
+		"state":    s.State,
+		"served":   s.Served,
+		"failures": s.Failures,
+		"latency":  s.Latency,
+	}
+	order = []string{"url", "weight", "state", "served", "failures", "latency"}
+	if s.RateLimit > 0 {
+		model["rateLimit"] = s.RateLimit
+		order = append(order, "rateLimit")
+	}
+	if len(s.LastError) > 0 {
+		model["lastError"] = s.LastError
+		order = append(order, "lastError")
+	}
+	// EXISTING_CODE
+
+	return Model{
+		Data:  model,
+		Order: order,
+	}
+}
+
+// FinishUnmarshal is used by the cache. It may be unused depending on auto-code-gen
+func (s *RpcEndpoint) FinishUnmarshal() {
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
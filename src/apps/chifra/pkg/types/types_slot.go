@@ -0,0 +1,210 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package types
+
+// EXISTING_CODE
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/cache"
+)
+
+// EXISTING_CODE
+
+type Slot struct {
+	Address     base.Address   `json:"address"`
+	BlockNumber base.Blknum    `json:"blockNumber"`
+	Decoded     string         `json:"decoded,omitempty"`
+	Offset      uint64         `json:"offset,omitempty"`
+	Slot        base.Hash      `json:"slot"`
+	SlotType    string         `json:"slotType,omitempty"`
+	Timestamp   base.Timestamp `json:"timestamp"`
+	Value       string         `json:"value"`
+	Variable    string         `json:"variable,omitempty"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (s Slot) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func (s *Slot) Model(chain, format string, verbose bool, extraOpts map[string]any) Model {
+	var model = map[string]any{}
+	var order = []string{}
+
+	// EXISTING_CODE
+	model = map[string]any{
+		"blockNumber": s.BlockNumber,
+		"address":     s.Address.Hex(),
+		"slot":        s.Slot.Hex(),
+		"value":       s.Value,
+	}
+	order = []string{
+		"blockNumber",
+		"address",
+		"slot",
+	}
+
+	if verbose {
+		model["timestamp"] = s.Timestamp
+		model["date"] = s.Date()
+		order = append([]string{"blockNumber", "timestamp", "date"}, order[1:]...)
+	}
+
+	if extraOpts["layout"] == true {
+		model["offset"] = s.Offset
+		model["variable"] = s.Variable
+		model["slotType"] = s.SlotType
+		model["decoded"] = s.Decoded
+		order = append(order, "offset", "variable", "slotType", "value", "decoded")
+	} else {
+		order = append(order, "value")
+	}
+	// EXISTING_CODE
+
+	return Model{
+		Data:  model,
+		Order: order,
+	}
+}
+
+func (s *Slot) Date() string {
+	return base.FormattedDate(s.Timestamp)
+}
+
+func (s *Slot) CacheLocations() (string, string, string) {
+	paddedId := fmt.Sprintf("%s-%s-%09d", s.Address.Hex()[2:], s.Slot.Hex()[2:], s.BlockNumber)
+	parts := make([]string, 3)
+	parts[0] = paddedId[:2]
+	parts[1] = paddedId[2:4]
+	parts[2] = paddedId[4:6]
+	subFolder := strings.ToLower("Slot") + "s"
+	directory := filepath.Join(subFolder, filepath.Join(parts...))
+	return directory, paddedId, "bin"
+}
+
+func (s *Slot) MarshalCache(writer io.Writer) (err error) {
+	// Address
+	if err = cache.WriteValue(writer, s.Address); err != nil {
+		return err
+	}
+
+	// BlockNumber
+	if err = cache.WriteValue(writer, s.BlockNumber); err != nil {
+		return err
+	}
+
+	// Decoded
+	if err = cache.WriteValue(writer, s.Decoded); err != nil {
+		return err
+	}
+
+	// Offset
+	if err = cache.WriteValue(writer, s.Offset); err != nil {
+		return err
+	}
+
+	// Slot
+	if err = cache.WriteValue(writer, &s.Slot); err != nil {
+		return err
+	}
+
+	// SlotType
+	if err = cache.WriteValue(writer, s.SlotType); err != nil {
+		return err
+	}
+
+	// Timestamp
+	if err = cache.WriteValue(writer, s.Timestamp); err != nil {
+		return err
+	}
+
+	// Value
+	if err = cache.WriteValue(writer, s.Value); err != nil {
+		return err
+	}
+
+	// Variable
+	if err = cache.WriteValue(writer, s.Variable); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Slot) UnmarshalCache(vers uint64, reader io.Reader) (err error) {
+	// Check for compatibility and return cache.ErrIncompatibleVersion to invalidate this item (see #3638)
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	// Address
+	if err = cache.ReadValue(reader, &s.Address, vers); err != nil {
+		return err
+	}
+
+	// BlockNumber
+	if err = cache.ReadValue(reader, &s.BlockNumber, vers); err != nil {
+		return err
+	}
+
+	// Decoded
+	if err = cache.ReadValue(reader, &s.Decoded, vers); err != nil {
+		return err
+	}
+
+	// Offset
+	if err = cache.ReadValue(reader, &s.Offset, vers); err != nil {
+		return err
+	}
+
+	// Slot
+	if err = cache.ReadValue(reader, &s.Slot, vers); err != nil {
+		return err
+	}
+
+	// SlotType
+	if err = cache.ReadValue(reader, &s.SlotType, vers); err != nil {
+		return err
+	}
+
+	// Timestamp
+	if err = cache.ReadValue(reader, &s.Timestamp, vers); err != nil {
+		return err
+	}
+
+	// Value
+	if err = cache.ReadValue(reader, &s.Value, vers); err != nil {
+		return err
+	}
+
+	// Variable
+	if err = cache.ReadValue(reader, &s.Variable, vers); err != nil {
+		return err
+	}
+
+	s.FinishUnmarshal()
+
+	return nil
+}
+
+// FinishUnmarshal is used by the cache. It may be unused depending on auto-code-gen
+func (s *Slot) FinishUnmarshal() {
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
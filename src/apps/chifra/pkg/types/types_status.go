@@ -20,26 +20,27 @@ import (
 // EXISTING_CODE
 
 type Status struct {
-	CachePath     string      `json:"cachePath,omitempty"`
-	Caches        []CacheItem `json:"caches"`
-	Chain         string      `json:"chain,omitempty"`
-	ChainConfig   string      `json:"chainConfig,omitempty"`
-	ChainId       string      `json:"chainId,omitempty"`
-	Chains        []Chain     `json:"chains"`
-	ClientVersion string      `json:"clientVersion,omitempty"`
-	HasEsKey      bool        `json:"hasEsKey,omitempty"`
-	HasPinKey     bool        `json:"hasPinKey,omitempty"`
-	IndexPath     string      `json:"indexPath,omitempty"`
-	IsApi         bool        `json:"isApi,omitempty"`
-	IsArchive     bool        `json:"isArchive,omitempty"`
-	IsScraping    bool        `json:"isScraping,omitempty"`
-	IsTesting     bool        `json:"isTesting,omitempty"`
-	IsTracing     bool        `json:"isTracing,omitempty"`
-	NetworkId     string      `json:"networkId,omitempty"`
-	Progress      string      `json:"progress,omitempty"`
-	RootConfig    string      `json:"rootConfig,omitempty"`
-	RpcProvider   string      `json:"rpcProvider,omitempty"`
-	Version       string      `json:"version,omitempty"`
+	CachePath     string        `json:"cachePath,omitempty"`
+	Caches        []CacheItem   `json:"caches"`
+	Chain         string        `json:"chain,omitempty"`
+	ChainConfig   string        `json:"chainConfig,omitempty"`
+	ChainId       string        `json:"chainId,omitempty"`
+	Chains        []Chain       `json:"chains"`
+	ClientVersion string        `json:"clientVersion,omitempty"`
+	Endpoints     []RpcEndpoint `json:"endpoints,omitempty"`
+	HasEsKey      bool          `json:"hasEsKey,omitempty"`
+	HasPinKey     bool          `json:"hasPinKey,omitempty"`
+	IndexPath     string        `json:"indexPath,omitempty"`
+	IsApi         bool          `json:"isApi,omitempty"`
+	IsArchive     bool          `json:"isArchive,omitempty"`
+	IsScraping    bool          `json:"isScraping,omitempty"`
+	IsTesting     bool          `json:"isTesting,omitempty"`
+	IsTracing     bool          `json:"isTracing,omitempty"`
+	NetworkId     string        `json:"networkId,omitempty"`
+	Progress      string        `json:"progress,omitempty"`
+	RootConfig    string        `json:"rootConfig,omitempty"`
+	RpcProvider   string        `json:"rpcProvider,omitempty"`
+	Version       string        `json:"version,omitempty"`
 	// EXISTING_CODE
 	Meta  *MetaData `json:"meta,omitempty"`
 	Diffs *MetaData `json:"diffs,omitempty"`
@@ -137,6 +138,11 @@ func (s *Status) Model(chain, format string, verbose bool, extraOpts map[string]
 		model["chains"] = chains
 		order = append(order, "chains")
 	}
+
+	if len(s.Endpoints) > 0 {
+		model["endpoints"] = s.Endpoints
+		order = append(order, "endpoints")
+	}
 	// EXISTING_CODE
 
 	return Model{
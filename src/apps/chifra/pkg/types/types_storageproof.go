@@ -0,0 +1,61 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package types
+
+// EXISTING_CODE
+import (
+	"encoding/json"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/base"
+)
+
+// EXISTING_CODE
+
+type StorageProof struct {
+	Key      base.Hash `json:"key"`
+	Proof    []string  `json:"proof"`
+	Value    base.Wei  `json:"value"`
+	Verified bool      `json:"verified"`
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+func (s StorageProof) String() string {
+	bytes, _ := json.Marshal(s)
+	return string(bytes)
+}
+
+func (s *StorageProof) Model(chain, format string, verbose bool, extraOpts map[string]any) Model {
+	var model = map[string]any{}
+	var order = []string{}
+
+	// EXISTING_CODE
+	model = map[string]any{
+		"key":      s.Key.Hex(),
+		"value":    s.Value.String(),
+		"proof":    s.Proof,
+		"verified": s.Verified,
+	}
+	order = []string{"key", "value", "proof", "verified"}
+	// EXISTING_CODE
+
+	return Model{
+		Data:  model,
+		Order: order,
+	}
+}
+
+// FinishUnmarshal is used by the cache. It may be unused depending on auto-code-gen
+func (s *StorageProof) FinishUnmarshal() {
+	// EXISTING_CODE
+	// EXISTING_CODE
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
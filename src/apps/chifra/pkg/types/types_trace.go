@@ -41,6 +41,7 @@ type Trace struct {
 	TraceIndex          base.Tracenum `json:"-"`
 	sortString          string        `json:"-"`
 	TransactionPosition base.Txnum    `json:"transactionPosition,omitempty"`
+	RevertReason        *Function     `json:"revertReason,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -124,6 +125,9 @@ func (s *Trace) Model(chain, format string, verbose bool, extraOpts map[string]a
 		if isArticulated {
 			model["articulatedTrace"] = articulatedTrace
 		}
+		if extraOpts["articulate"] == true {
+			order = addRevertReason(model, order, format, s.RevertReason)
+		}
 
 	} else {
 		model["blockNumber"] = s.BlockNumber
@@ -177,6 +181,9 @@ func (s *Trace) Model(chain, format string, verbose bool, extraOpts map[string]a
 			model["compressedTrace"] = makeCompressed(articulatedTrace)
 			order = append(order, "compressedTrace")
 		}
+		if extraOpts["articulate"] == true {
+			order = addRevertReason(model, order, format, s.RevertReason)
+		}
 		order = reorderOrdering(order)
 	}
 	// EXISTING_CODE
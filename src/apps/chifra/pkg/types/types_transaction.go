@@ -69,9 +69,10 @@ type Transaction struct {
 	TransactionType      string         `json:"type"`
 	Value                base.Wei       `json:"value"`
 	// EXISTING_CODE
-	Message    string       `json:"-"`
-	Rewards    *Rewards     `json:"-"`
-	Statements *[]Statement `json:"statements"`
+	Message      string       `json:"-"`
+	Rewards      *Rewards     `json:"-"`
+	Statements   *[]Statement `json:"statements"`
+	RevertReason *Function    `json:"revertReason,omitempty"`
 	// EXISTING_CODE
 }
 
@@ -283,6 +284,10 @@ func (s *Transaction) Model(chain, format string, verbose bool, extraOpts map[st
 		}
 	}
 
+	if extraOpts["articulate"] == true {
+		order = addRevertReason(model, order, format, s.RevertReason)
+	}
+
 	asEther := true // special case for transactions, we always show --ether -- extraOpts["ether"] == true
 	if asEther {
 		model["ether"] = s.Value.ToEtherStr(18)
@@ -6,6 +6,7 @@ package walk
 
 import (
 	"context"
+	"encoding/binary"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -396,7 +397,7 @@ func GetCacheItem(chain string, testMode bool, cT CacheType, cacheInfo *CacheFil
 			"sizeInBytes": size,
 		}
 		if cT == Cache_Monitors {
-			ret["nRecords"] = size / 8 // index.AppRecordWidth - FAST
+			ret["nRecords"] = monitorRecords(cacheInfo.Path, size)
 		}
 		return ret, nil
 	default:
@@ -428,3 +429,19 @@ type CacheFileInfo struct {
 	IsDir     bool
 	Data      interface{}
 }
+
+// monitorRecords returns the number of records in a monitor file without reading them. A v2 file
+// (version byte 2) stores its count after the header (see pkg/monitor/monitor_format.go). This
+// package may not import pkg/monitor.
+func monitorRecords(path string, size int64) int64 {
+	if size >= 16 {
+		if f, err := os.Open(path); err == nil {
+			defer f.Close()
+			var b [16]byte
+			if n, _ := f.Read(b[:]); n == len(b) && b[2] >= 2 {
+				return int64(binary.LittleEndian.Uint32(b[8:12]))
+			}
+		}
+	}
+	return size / 8 // index.AppRecordWidth - FAST
+}
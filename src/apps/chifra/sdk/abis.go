@@ -15,6 +15,7 @@ import (
 	abis "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/abis"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Abis provides an interface to the command line chifra abis through the SDK.
@@ -30,5 +31,68 @@ func Abis(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// AbisOptions are the options of chifra abis for the typed functions of the SDK.
+type AbisOptions struct {
+	Addrs     []string          `json:"addrs,omitempty"`
+	Known     bool              `json:"known,omitempty"`
+	ProxyFor  string            `json:"proxyFor,omitempty"`
+	Hint      []string          `json:"hint,omitempty"`
+	Calldata  string            `json:"calldata,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra abis
+func (opts *AbisOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "known", opts.Known)
+	addValue(values, "proxyFor", opts.ProxyFor)
+	addValue(values, "hint", opts.Hint)
+	addValue(values, "calldata", opts.Calldata)
+	return values
+}
+
+// Abis runs chifra abis and returns its records.
+func (opts *AbisOptions) Abis() ([]types.Function, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Function](opts.RenderCtx, values, Abis)
+}
+
+// AbisList runs chifra abis --list and returns its records.
+func (opts *AbisOptions) AbisList() ([]types.Abi, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("list", "true")
+	return query[types.Abi](opts.RenderCtx, values, Abis)
+}
+
+// AbisCount runs chifra abis --count and returns its records.
+func (opts *AbisOptions) AbisCount() ([]types.Count, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Count](opts.RenderCtx, values, Abis)
+}
+
+// AbisFind runs chifra abis --find and returns its records.
+func (opts *AbisOptions) AbisFind(val []string) ([]types.Function, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "find", val)
+	return query[types.Function](opts.RenderCtx, values, Abis)
+}
+
+// AbisEncode runs chifra abis --encode and returns its records.
+func (opts *AbisOptions) AbisEncode(val string) ([]types.Function, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "encode", val)
+	return query[types.Function](opts.RenderCtx, values, Abis)
+}
+
+// AbisImportSigs runs chifra abis --import_sigs and returns its records.
+func (opts *AbisOptions) AbisImportSigs(val string) ([]types.Count, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "importSigs", val)
+	return query[types.Count](opts.RenderCtx, values, Abis)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
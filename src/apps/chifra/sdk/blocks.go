@@ -15,6 +15,7 @@ import (
 	blocks "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/blocks"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Blocks provides an interface to the command line chifra blocks through the SDK.
@@ -30,5 +31,86 @@ func Blocks(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// BlocksOptions are the options of chifra blocks for the typed functions of the SDK.
+type BlocksOptions struct {
+	BlockIds    []string          `json:"blocks,omitempty"`
+	Flow        string            `json:"flow,omitempty"`
+	Emitter     []string          `json:"emitter,omitempty"`
+	Topic       []string          `json:"topic,omitempty"`
+	Articulate  bool              `json:"articulate,omitempty"`
+	CacheTxs    bool              `json:"cacheTxs,omitempty"`
+	CacheTraces bool              `json:"cacheTraces,omitempty"`
+	RenderCtx   *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra blocks
+func (opts *BlocksOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "blocks", opts.BlockIds)
+	addValue(values, "flow", opts.Flow)
+	addValue(values, "emitter", opts.Emitter)
+	addValue(values, "topic", opts.Topic)
+	addValue(values, "articulate", opts.Articulate)
+	addValue(values, "cacheTxs", opts.CacheTxs)
+	addValue(values, "cacheTraces", opts.CacheTraces)
+	return values
+}
+
+// Blocks runs chifra blocks and returns its records.
+func (opts *BlocksOptions) Blocks() ([]types.Block, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Block](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksHashes runs chifra blocks --hashes and returns its records.
+func (opts *BlocksOptions) BlocksHashes() ([]types.LightBlock, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("hashes", "true")
+	return query[types.LightBlock](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksUncles runs chifra blocks --uncles and returns its records.
+func (opts *BlocksOptions) BlocksUncles() ([]types.LightBlock, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("uncles", "true")
+	return query[types.LightBlock](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksTraces runs chifra blocks --traces and returns its records.
+func (opts *BlocksOptions) BlocksTraces() ([]types.Trace, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("traces", "true")
+	return query[types.Trace](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksUniq runs chifra blocks --uniq and returns its records.
+func (opts *BlocksOptions) BlocksUniq() ([]types.Appearance, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("uniq", "true")
+	return query[types.Appearance](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksLogs runs chifra blocks --logs and returns its records.
+func (opts *BlocksOptions) BlocksLogs() ([]types.Log, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("logs", "true")
+	return query[types.Log](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksWithdrawals runs chifra blocks --withdrawals and returns its records.
+func (opts *BlocksOptions) BlocksWithdrawals() ([]types.Withdrawal, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("withdrawals", "true")
+	return query[types.Withdrawal](opts.RenderCtx, values, Blocks)
+}
+
+// BlocksCount runs chifra blocks --count and returns its records.
+func (opts *BlocksOptions) BlocksCount() ([]types.BlockCount, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.BlockCount](opts.RenderCtx, values, Blocks)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
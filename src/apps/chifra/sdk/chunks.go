@@ -15,6 +15,7 @@ import (
 	chunks "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/chunks"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Chunks provides an interface to the command line chifra chunks through the SDK.
@@ -30,5 +31,124 @@ func Chunks(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ChunksOptions are the options of chifra chunks for the typed functions of the SDK.
+type ChunksOptions struct {
+	BlockIds   []string          `json:"blocks,omitempty"`
+	Check      bool              `json:"check,omitempty"`
+	Pin        bool              `json:"pin,omitempty"`
+	Publish    bool              `json:"publish,omitempty"`
+	Publisher  string            `json:"publisher,omitempty"`
+	Remote     bool              `json:"remote,omitempty"`
+	Belongs    []string          `json:"belongs,omitempty"`
+	FirstBlock uint64            `json:"firstBlock,omitempty"`
+	LastBlock  uint64            `json:"lastBlock,omitempty"`
+	MaxAddrs   uint64            `json:"maxAddrs,omitempty"`
+	Deep       bool              `json:"deep,omitempty"`
+	Rewrite    bool              `json:"rewrite,omitempty"`
+	List       bool              `json:"list,omitempty"`
+	Unpin      bool              `json:"unpin,omitempty"`
+	Sleep      float64           `json:"sleep,omitempty"`
+	RenderCtx  *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra chunks
+func (opts *ChunksOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "blocks", opts.BlockIds)
+	addValue(values, "check", opts.Check)
+	addValue(values, "pin", opts.Pin)
+	addValue(values, "publish", opts.Publish)
+	addValue(values, "publisher", opts.Publisher)
+	addValue(values, "remote", opts.Remote)
+	addValue(values, "belongs", opts.Belongs)
+	addValue(values, "firstBlock", opts.FirstBlock)
+	addValue(values, "lastBlock", opts.LastBlock)
+	addValue(values, "maxAddrs", opts.MaxAddrs)
+	addValue(values, "deep", opts.Deep)
+	addValue(values, "rewrite", opts.Rewrite)
+	addValue(values, "list", opts.List)
+	addValue(values, "unpin", opts.Unpin)
+	addValue(values, "sleep", opts.Sleep)
+	return values
+}
+
+// ChunksManifest runs chifra chunks manifest and returns its records.
+func (opts *ChunksOptions) ChunksManifest() ([]types.Manifest, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "manifest")
+	return query[types.Manifest](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksIndex runs chifra chunks index and returns its records.
+func (opts *ChunksOptions) ChunksIndex() ([]types.ChunkIndex, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "index")
+	return query[types.ChunkIndex](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksBlooms runs chifra chunks blooms and returns its records.
+func (opts *ChunksOptions) ChunksBlooms() ([]types.ChunkBloom, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "blooms")
+	return query[types.ChunkBloom](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksPins runs chifra chunks pins and returns its records.
+func (opts *ChunksOptions) ChunksPins() ([]types.ChunkPin, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "pins")
+	return query[types.ChunkPin](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksAddresses runs chifra chunks addresses and returns its records.
+func (opts *ChunksOptions) ChunksAddresses() ([]types.ChunkAddress, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "addresses")
+	return query[types.ChunkAddress](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksAppearances runs chifra chunks appearances and returns its records.
+func (opts *ChunksOptions) ChunksAppearances() ([]types.Appearance, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "appearances")
+	return query[types.Appearance](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksStats runs chifra chunks stats and returns its records.
+func (opts *ChunksOptions) ChunksStats() ([]types.ChunkStats, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("mode", "stats")
+	return query[types.ChunkStats](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksTruncate runs chifra chunks --truncate and returns its records.
+func (opts *ChunksOptions) ChunksTruncate(val uint64) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "truncate", val)
+	return query[types.Message](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksDiff runs chifra chunks --diff and returns its records.
+func (opts *ChunksOptions) ChunksDiff() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("diff", "true")
+	return query[types.Message](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksCount runs chifra chunks --count and returns its records.
+func (opts *ChunksOptions) ChunksCount() ([]types.Count, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Count](opts.RenderCtx, values, Chunks)
+}
+
+// ChunksTag runs chifra chunks --tag and returns its records.
+func (opts *ChunksOptions) ChunksTag(val string) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "tag", val)
+	return query[types.Message](opts.RenderCtx, values, Chunks)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -0,0 +1,66 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+
+package sdk
+
+import (
+	"io"
+	"net/url"
+
+	clusters "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/clusters"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Clusters provides an interface to the command line chifra clusters through the SDK.
+func Clusters(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
+	clusters.ResetOptions(sdkTestMode)
+	opts := clusters.ClustersFinishParseInternal(w, values)
+	// EXISTING_CODE
+	// EXISTING_CODE
+	outputHelpers.InitJsonWriterApi("clusters", w, &opts.Globals)
+	err := opts.ClustersInternal(rCtx)
+	outputHelpers.CloseJsonWriterIfNeededApi("clusters", err, &opts.Globals)
+
+	return err
+}
+
+// ClustersOptions are the options of chifra clusters for the typed functions of the SDK.
+type ClustersOptions struct {
+	Addrs         []string          `json:"addrs,omitempty"`
+	Heuristics    []string          `json:"heuristics,omitempty"`
+	MinConfidence float64           `json:"minConfidence,omitempty"`
+	RenderCtx     *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra clusters
+func (opts *ClustersOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "heuristics", opts.Heuristics)
+	addValue(values, "minConfidence", opts.MinConfidence)
+	return values
+}
+
+// Clusters runs chifra clusters and returns its records.
+func (opts *ClustersOptions) Clusters() ([]types.Cluster, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Cluster](opts.RenderCtx, values, Clusters)
+}
+
+// ClustersAccept runs chifra clusters --accept and returns its records.
+func (opts *ClustersOptions) ClustersAccept() ([]types.Cluster, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("accept", "true")
+	return query[types.Cluster](opts.RenderCtx, values, Clusters)
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	config "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/config"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Config provides an interface to the command line chifra config through the SDK.
@@ -30,5 +31,33 @@ func Config(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ConfigOptions are the options of chifra config for the typed functions of the SDK.
+type ConfigOptions struct {
+	Mode      string            `json:"mode,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra config
+func (opts *ConfigOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "mode", opts.Mode)
+	return values
+}
+
+// ConfigPaths runs chifra config --paths and returns its records.
+func (opts *ConfigOptions) ConfigPaths() ([]types.CacheItem, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("paths", "true")
+	return query[types.CacheItem](opts.RenderCtx, values, Config)
+}
+
+// ConfigSession runs chifra config --session and returns its records.
+func (opts *ConfigOptions) ConfigSession() ([]types.Session, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("session", "true")
+	return query[types.Session](opts.RenderCtx, values, Config)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
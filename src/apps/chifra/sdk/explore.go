@@ -15,6 +15,7 @@ import (
 	explore "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/explore"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Explore provides an interface to the command line chifra explore through the SDK.
@@ -30,5 +31,33 @@ func Explore(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ExploreOptions are the options of chifra explore for the typed functions of the SDK.
+type ExploreOptions struct {
+	Terms     []string          `json:"terms,omitempty"`
+	NoOpen    bool              `json:"noOpen,omitempty"`
+	Local     bool              `json:"local,omitempty"`
+	Google    bool              `json:"google,omitempty"`
+	Dalle     bool              `json:"dalle,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra explore
+func (opts *ExploreOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "terms", opts.Terms)
+	addValue(values, "noOpen", opts.NoOpen)
+	addValue(values, "local", opts.Local)
+	addValue(values, "google", opts.Google)
+	addValue(values, "dalle", opts.Dalle)
+	return values
+}
+
+// Explore runs chifra explore and returns its records.
+func (opts *ExploreOptions) Explore() ([]types.Destination, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Destination](opts.RenderCtx, values, Explore)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	export "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/export"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Export provides an interface to the command line chifra export through the SDK.
@@ -30,5 +31,130 @@ func Export(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ExportOptions are the options of chifra export for the typed functions of the SDK.
+type ExportOptions struct {
+	Addrs       []string          `json:"addrs,omitempty"`
+	Topics      []string          `json:"topics,omitempty"`
+	Fourbytes   []string          `json:"fourbytes,omitempty"`
+	Accounting  bool              `json:"accounting,omitempty"`
+	Articulate  bool              `json:"articulate,omitempty"`
+	CacheTraces bool              `json:"cacheTraces,omitempty"`
+	FirstRecord uint64            `json:"firstRecord,omitempty"`
+	MaxRecords  uint64            `json:"maxRecords,omitempty"`
+	Relevant    bool              `json:"relevant,omitempty"`
+	Emitter     []string          `json:"emitter,omitempty"`
+	Topic       []string          `json:"topic,omitempty"`
+	Reverted    bool              `json:"reverted,omitempty"`
+	Asset       []string          `json:"asset,omitempty"`
+	Flow        string            `json:"flow,omitempty"`
+	Incremental bool              `json:"incremental,omitempty"`
+	Factory     bool              `json:"factory,omitempty"`
+	Unripe      bool              `json:"unripe,omitempty"`
+	Reversed    bool              `json:"reversed,omitempty"`
+	NoZero      bool              `json:"noZero,omitempty"`
+	FirstBlock  uint64            `json:"firstBlock,omitempty"`
+	LastBlock   uint64            `json:"lastBlock,omitempty"`
+	Group       string            `json:"group,omitempty"`
+	RenderCtx   *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra export
+func (opts *ExportOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "topics", opts.Topics)
+	addValue(values, "fourbytes", opts.Fourbytes)
+	addValue(values, "accounting", opts.Accounting)
+	addValue(values, "articulate", opts.Articulate)
+	addValue(values, "cacheTraces", opts.CacheTraces)
+	addValue(values, "firstRecord", opts.FirstRecord)
+	addValue(values, "maxRecords", opts.MaxRecords)
+	addValue(values, "relevant", opts.Relevant)
+	addValue(values, "emitter", opts.Emitter)
+	addValue(values, "topic", opts.Topic)
+	addValue(values, "reverted", opts.Reverted)
+	addValue(values, "asset", opts.Asset)
+	addValue(values, "flow", opts.Flow)
+	addValue(values, "incremental", opts.Incremental)
+	addValue(values, "factory", opts.Factory)
+	addValue(values, "unripe", opts.Unripe)
+	addValue(values, "reversed", opts.Reversed)
+	addValue(values, "noZero", opts.NoZero)
+	addValue(values, "firstBlock", opts.FirstBlock)
+	addValue(values, "lastBlock", opts.LastBlock)
+	addValue(values, "group", opts.Group)
+	return values
+}
+
+// Export runs chifra export and returns its records.
+func (opts *ExportOptions) Export() ([]types.Transaction, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Transaction](opts.RenderCtx, values, Export)
+}
+
+// ExportAppearances runs chifra export --appearances and returns its records.
+func (opts *ExportOptions) ExportAppearances() ([]types.Appearance, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("appearances", "true")
+	return query[types.Appearance](opts.RenderCtx, values, Export)
+}
+
+// ExportReceipts runs chifra export --receipts and returns its records.
+func (opts *ExportOptions) ExportReceipts() ([]types.Receipt, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("receipts", "true")
+	return query[types.Receipt](opts.RenderCtx, values, Export)
+}
+
+// ExportLogs runs chifra export --logs and returns its records.
+func (opts *ExportOptions) ExportLogs() ([]types.Log, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("logs", "true")
+	return query[types.Log](opts.RenderCtx, values, Export)
+}
+
+// ExportTraces runs chifra export --traces and returns its records.
+func (opts *ExportOptions) ExportTraces() ([]types.Trace, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("traces", "true")
+	return query[types.Trace](opts.RenderCtx, values, Export)
+}
+
+// ExportNeighbors runs chifra export --neighbors and returns its records.
+func (opts *ExportOptions) ExportNeighbors() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("neighbors", "true")
+	return query[types.Message](opts.RenderCtx, values, Export)
+}
+
+// ExportStatements runs chifra export --statements and returns its records.
+func (opts *ExportOptions) ExportStatements() ([]types.Statement, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("statements", "true")
+	return query[types.Statement](opts.RenderCtx, values, Export)
+}
+
+// ExportBalances runs chifra export --balances and returns its records.
+func (opts *ExportOptions) ExportBalances() ([]types.State, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("balances", "true")
+	return query[types.State](opts.RenderCtx, values, Export)
+}
+
+// ExportWithdrawals runs chifra export --withdrawals and returns its records.
+func (opts *ExportOptions) ExportWithdrawals() ([]types.Withdrawal, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("withdrawals", "true")
+	return query[types.Withdrawal](opts.RenderCtx, values, Export)
+}
+
+// ExportCount runs chifra export --count and returns its records.
+func (opts *ExportOptions) ExportCount() ([]types.Monitor, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Monitor](opts.RenderCtx, values, Export)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
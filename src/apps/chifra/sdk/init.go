@@ -15,6 +15,7 @@ import (
 	initPkg "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/init"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Init provides an interface to the command line chifra init through the SDK.
@@ -30,5 +31,44 @@ func Init(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// InitOptions are the options of chifra init for the typed functions of the SDK.
+type InitOptions struct {
+	Publisher  string            `json:"publisher,omitempty"`
+	FirstBlock uint64            `json:"firstBlock,omitempty"`
+	Sleep      float64           `json:"sleep,omitempty"`
+	RenderCtx  *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra init
+func (opts *InitOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "publisher", opts.Publisher)
+	addValue(values, "firstBlock", opts.FirstBlock)
+	addValue(values, "sleep", opts.Sleep)
+	return values
+}
+
+// InitAll runs chifra init --all and returns its records.
+func (opts *InitOptions) InitAll() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("all", "true")
+	return query[types.Message](opts.RenderCtx, values, Init)
+}
+
+// InitExample runs chifra init --example and returns its records.
+func (opts *InitOptions) InitExample(val string) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "example", val)
+	return query[types.Message](opts.RenderCtx, values, Init)
+}
+
+// InitDryRun runs chifra init --dry_run and returns its records.
+func (opts *InitOptions) InitDryRun() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("dryRun", "true")
+	return query[types.Message](opts.RenderCtx, values, Init)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	list "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/list"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // List provides an interface to the command line chifra list through the SDK.
@@ -30,5 +31,59 @@ func List(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ListOptions are the options of chifra list for the typed functions of the SDK.
+type ListOptions struct {
+	Addrs       []string          `json:"addrs,omitempty"`
+	NoZero      bool              `json:"noZero,omitempty"`
+	Unripe      bool              `json:"unripe,omitempty"`
+	Silent      bool              `json:"silent,omitempty"`
+	FirstRecord uint64            `json:"firstRecord,omitempty"`
+	MaxRecords  uint64            `json:"maxRecords,omitempty"`
+	Reversed    bool              `json:"reversed,omitempty"`
+	Publisher   string            `json:"publisher,omitempty"`
+	FirstBlock  uint64            `json:"firstBlock,omitempty"`
+	LastBlock   uint64            `json:"lastBlock,omitempty"`
+	Group       string            `json:"group,omitempty"`
+	RenderCtx   *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra list
+func (opts *ListOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "noZero", opts.NoZero)
+	addValue(values, "unripe", opts.Unripe)
+	addValue(values, "silent", opts.Silent)
+	addValue(values, "firstRecord", opts.FirstRecord)
+	addValue(values, "maxRecords", opts.MaxRecords)
+	addValue(values, "reversed", opts.Reversed)
+	addValue(values, "publisher", opts.Publisher)
+	addValue(values, "firstBlock", opts.FirstBlock)
+	addValue(values, "lastBlock", opts.LastBlock)
+	addValue(values, "group", opts.Group)
+	return values
+}
+
+// List runs chifra list and returns its records.
+func (opts *ListOptions) List() ([]types.Appearance, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Appearance](opts.RenderCtx, values, List)
+}
+
+// ListCount runs chifra list --count and returns its records.
+func (opts *ListOptions) ListCount() ([]types.Monitor, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Monitor](opts.RenderCtx, values, List)
+}
+
+// ListBounds runs chifra list --bounds and returns its records.
+func (opts *ListOptions) ListBounds() ([]types.Bounds, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("bounds", "true")
+	return query[types.Bounds](opts.RenderCtx, values, List)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	logs "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/logs"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Logs provides an interface to the command line chifra logs through the SDK.
@@ -30,5 +31,31 @@ func Logs(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// LogsOptions are the options of chifra logs for the typed functions of the SDK.
+type LogsOptions struct {
+	TransactionIds []string          `json:"transactions,omitempty"`
+	Emitter        []string          `json:"emitter,omitempty"`
+	Topic          []string          `json:"topic,omitempty"`
+	Articulate     bool              `json:"articulate,omitempty"`
+	RenderCtx      *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra logs
+func (opts *LogsOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "transactions", opts.TransactionIds)
+	addValue(values, "emitter", opts.Emitter)
+	addValue(values, "topic", opts.Topic)
+	addValue(values, "articulate", opts.Articulate)
+	return values
+}
+
+// Logs runs chifra logs and returns its records.
+func (opts *LogsOptions) Logs() ([]types.Log, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Log](opts.RenderCtx, values, Logs)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
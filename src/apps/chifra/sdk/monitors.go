@@ -15,6 +15,7 @@ import (
 	monitors "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/monitors"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Monitors provides an interface to the command line chifra monitors through the SDK.
@@ -30,5 +31,84 @@ func Monitors(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// MonitorsOptions are the options of chifra monitors for the typed functions of the SDK.
+type MonitorsOptions struct {
+	Addrs     []string          `json:"addrs,omitempty"`
+	Delete    bool              `json:"delete,omitempty"`
+	Undelete  bool              `json:"undelete,omitempty"`
+	Remove    bool              `json:"remove,omitempty"`
+	Staged    bool              `json:"staged,omitempty"`
+	Watch     bool              `json:"watch,omitempty"`
+	Watchlist string            `json:"watchlist,omitempty"`
+	Commands  string            `json:"commands,omitempty"`
+	BatchSize uint64            `json:"batchSize,omitempty"`
+	RunCount  uint64            `json:"runCount,omitempty"`
+	Sleep     float64           `json:"sleep,omitempty"`
+	Subscribe bool              `json:"subscribe,omitempty"`
+	Rules     string            `json:"rules,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra monitors
+func (opts *MonitorsOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "delete", opts.Delete)
+	addValue(values, "undelete", opts.Undelete)
+	addValue(values, "remove", opts.Remove)
+	addValue(values, "staged", opts.Staged)
+	addValue(values, "watch", opts.Watch)
+	addValue(values, "watchlist", opts.Watchlist)
+	addValue(values, "commands", opts.Commands)
+	addValue(values, "batchSize", opts.BatchSize)
+	addValue(values, "runCount", opts.RunCount)
+	addValue(values, "sleep", opts.Sleep)
+	addValue(values, "subscribe", opts.Subscribe)
+	addValue(values, "rules", opts.Rules)
+	return values
+}
+
+// Monitors runs chifra monitors and returns its records.
+func (opts *MonitorsOptions) Monitors() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Message](opts.RenderCtx, values, Monitors)
+}
+
+// MonitorsClean runs chifra monitors --clean and returns its records.
+func (opts *MonitorsOptions) MonitorsClean() ([]types.MonitorClean, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("clean", "true")
+	return query[types.MonitorClean](opts.RenderCtx, values, Monitors)
+}
+
+// MonitorsList runs chifra monitors --list and returns its records.
+func (opts *MonitorsOptions) MonitorsList() ([]types.Monitor, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("list", "true")
+	return query[types.Monitor](opts.RenderCtx, values, Monitors)
+}
+
+// MonitorsCount runs chifra monitors --count and returns its records.
+func (opts *MonitorsOptions) MonitorsCount() ([]types.Count, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Count](opts.RenderCtx, values, Monitors)
+}
+
+// MonitorsMigrate runs chifra monitors --migrate and returns its records.
+func (opts *MonitorsOptions) MonitorsMigrate(val string) ([]types.Monitor, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "migrate", val)
+	return query[types.Monitor](opts.RenderCtx, values, Monitors)
+}
+
+// MonitorsGroup runs chifra monitors --group and returns its records.
+func (opts *MonitorsOptions) MonitorsGroup(val string) ([]types.Monitor, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "group", val)
+	return query[types.Monitor](opts.RenderCtx, values, Monitors)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
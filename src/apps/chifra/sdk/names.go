@@ -15,6 +15,7 @@ import (
 	names "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/names"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Names provides an interface to the command line chifra names through the SDK.
@@ -34,5 +35,133 @@ func Names(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// NamesOptions are the options of chifra names for the typed functions of the SDK.
+type NamesOptions struct {
+	Terms     []string          `json:"terms,omitempty"`
+	Expand    bool              `json:"expand,omitempty"`
+	MatchCase bool              `json:"matchCase,omitempty"`
+	All       bool              `json:"all,omitempty"`
+	Custom    bool              `json:"custom,omitempty"`
+	Prefund   bool              `json:"prefund,omitempty"`
+	Regular   bool              `json:"regular,omitempty"`
+	DryRun    bool              `json:"dryRun,omitempty"`
+	Regex     bool              `json:"regex,omitempty"`
+	Rank      bool              `json:"rank,omitempty"`
+	Strategy  string            `json:"strategy,omitempty"`
+	Sources   []string          `json:"sources,omitempty"`
+	TokenList string            `json:"tokenList,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra names
+func (opts *NamesOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "terms", opts.Terms)
+	addValue(values, "expand", opts.Expand)
+	addValue(values, "matchCase", opts.MatchCase)
+	addValue(values, "all", opts.All)
+	addValue(values, "custom", opts.Custom)
+	addValue(values, "prefund", opts.Prefund)
+	addValue(values, "regular", opts.Regular)
+	addValue(values, "dryRun", opts.DryRun)
+	addValue(values, "regex", opts.Regex)
+	addValue(values, "rank", opts.Rank)
+	addValue(values, "strategy", opts.Strategy)
+	addValue(values, "sources", opts.Sources)
+	addValue(values, "tokenList", opts.TokenList)
+	return values
+}
+
+// Names runs chifra names and returns its records.
+func (opts *NamesOptions) Names() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesAddr runs chifra names --addr and returns its records.
+func (opts *NamesOptions) NamesAddr() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("addr", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesTags runs chifra names --tags and returns its records.
+func (opts *NamesOptions) NamesTags() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("tags", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesClean runs chifra names --clean and returns its records.
+func (opts *NamesOptions) NamesClean() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("clean", "true")
+	return query[types.Message](opts.RenderCtx, values, Names)
+}
+
+// NamesAutoname runs chifra names --autoname and returns its records.
+func (opts *NamesOptions) NamesAutoname(val string) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "autoname", val)
+	return query[types.Message](opts.RenderCtx, values, Names)
+}
+
+// NamesExport runs chifra names --export and returns its records.
+func (opts *NamesOptions) NamesExport(val string) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "export", val)
+	return query[types.Message](opts.RenderCtx, values, Names)
+}
+
+// NamesImport runs chifra names --import and returns its records.
+func (opts *NamesOptions) NamesImport(val string) ([]types.NameConflict, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "import", val)
+	return query[types.NameConflict](opts.RenderCtx, values, Names)
+}
+
+// NamesDiscover runs chifra names --discover and returns its records.
+func (opts *NamesOptions) NamesDiscover() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("discover", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesCreate runs chifra names --create and returns its records.
+func (opts *NamesOptions) NamesCreate() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("create", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesUpdate runs chifra names --update and returns its records.
+func (opts *NamesOptions) NamesUpdate() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("update", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesDelete runs chifra names --delete and returns its records.
+func (opts *NamesOptions) NamesDelete() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("delete", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesUndelete runs chifra names --undelete and returns its records.
+func (opts *NamesOptions) NamesUndelete() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("undelete", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
+// NamesRemove runs chifra names --remove and returns its records.
+func (opts *NamesOptions) NamesRemove() ([]types.Name, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("remove", "true")
+	return query[types.Name](opts.RenderCtx, values, Names)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
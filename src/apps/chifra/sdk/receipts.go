@@ -15,6 +15,7 @@ import (
 	receipts "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/receipts"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Receipts provides an interface to the command line chifra receipts through the SDK.
@@ -30,5 +31,27 @@ func Receipts(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ReceiptsOptions are the options of chifra receipts for the typed functions of the SDK.
+type ReceiptsOptions struct {
+	TransactionIds []string          `json:"transactions,omitempty"`
+	Articulate     bool              `json:"articulate,omitempty"`
+	RenderCtx      *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra receipts
+func (opts *ReceiptsOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "transactions", opts.TransactionIds)
+	addValue(values, "articulate", opts.Articulate)
+	return values
+}
+
+// Receipts runs chifra receipts and returns its records.
+func (opts *ReceiptsOptions) Receipts() ([]types.Receipt, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Receipt](opts.RenderCtx, values, Receipts)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	scrape "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/scrape"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Scrape provides an interface to the command line chifra scrape through the SDK.
@@ -30,5 +31,48 @@ func Scrape(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// ScrapeOptions are the options of chifra scrape for the typed functions of the SDK.
+type ScrapeOptions struct {
+	BlockCnt  uint64            `json:"blockCnt,omitempty"`
+	Sleep     float64           `json:"sleep,omitempty"`
+	Publisher string            `json:"publisher,omitempty"`
+	Subscribe bool              `json:"subscribe,omitempty"`
+	Notify    bool              `json:"notify,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra scrape
+func (opts *ScrapeOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "blockCnt", opts.BlockCnt)
+	addValue(values, "sleep", opts.Sleep)
+	addValue(values, "publisher", opts.Publisher)
+	addValue(values, "subscribe", opts.Subscribe)
+	addValue(values, "notify", opts.Notify)
+	return values
+}
+
+// ScrapeTouch runs chifra scrape --touch and returns its records.
+func (opts *ScrapeOptions) ScrapeTouch(val uint64) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "touch", val)
+	return query[types.Message](opts.RenderCtx, values, Scrape)
+}
+
+// ScrapeRunCount runs chifra scrape --run_count and returns its records.
+func (opts *ScrapeOptions) ScrapeRunCount(val uint64) ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "runCount", val)
+	return query[types.Message](opts.RenderCtx, values, Scrape)
+}
+
+// ScrapeDryRun runs chifra scrape --dry_run and returns its records.
+func (opts *ScrapeOptions) ScrapeDryRun() ([]types.Message, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("dryRun", "true")
+	return query[types.Message](opts.RenderCtx, values, Scrape)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
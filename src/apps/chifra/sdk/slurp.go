@@ -15,6 +15,7 @@ import (
 	slurp "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/slurp"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Slurp provides an interface to the command line chifra slurp through the SDK.
@@ -30,5 +31,55 @@ func Slurp(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// SlurpOptions are the options of chifra slurp for the typed functions of the SDK.
+type SlurpOptions struct {
+	Addrs      []string          `json:"addrs,omitempty"`
+	BlockIds   []string          `json:"blocks,omitempty"`
+	Parts      []string          `json:"parts,omitempty"`
+	Articulate bool              `json:"articulate,omitempty"`
+	Source     string            `json:"source,omitempty"`
+	Page       uint64            `json:"page,omitempty"`
+	PageId     string            `json:"pageId,omitempty"`
+	PerPage    uint64            `json:"perPage,omitempty"`
+	Sleep      float64           `json:"sleep,omitempty"`
+	RenderCtx  *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra slurp
+func (opts *SlurpOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "blocks", opts.BlockIds)
+	addValue(values, "parts", opts.Parts)
+	addValue(values, "articulate", opts.Articulate)
+	addValue(values, "source", opts.Source)
+	addValue(values, "page", opts.Page)
+	addValue(values, "pageId", opts.PageId)
+	addValue(values, "perPage", opts.PerPage)
+	addValue(values, "sleep", opts.Sleep)
+	return values
+}
+
+// Slurp runs chifra slurp and returns its records.
+func (opts *SlurpOptions) Slurp() ([]types.Slurp, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Slurp](opts.RenderCtx, values, Slurp)
+}
+
+// SlurpAppearances runs chifra slurp --appearances and returns its records.
+func (opts *SlurpOptions) SlurpAppearances() ([]types.Appearance, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("appearances", "true")
+	return query[types.Appearance](opts.RenderCtx, values, Slurp)
+}
+
+// SlurpCount runs chifra slurp --count and returns its records.
+func (opts *SlurpOptions) SlurpCount() ([]types.Monitor, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Monitor](opts.RenderCtx, values, Slurp)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	state "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/state"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // State provides an interface to the command line chifra state through the SDK.
@@ -30,5 +31,62 @@ func State(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// StateOptions are the options of chifra state for the typed functions of the SDK.
+type StateOptions struct {
+	Addrs      []string          `json:"addrs,omitempty"`
+	BlockIds   []string          `json:"blocks,omitempty"`
+	Parts      []string          `json:"parts,omitempty"`
+	Changes    bool              `json:"changes,omitempty"`
+	NoZero     bool              `json:"noZero,omitempty"`
+	Articulate bool              `json:"articulate,omitempty"`
+	ProxyFor   string            `json:"proxyFor,omitempty"`
+	Layout     string            `json:"layout,omitempty"`
+	Root       string            `json:"root,omitempty"`
+	RenderCtx  *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra state
+func (opts *StateOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "blocks", opts.BlockIds)
+	addValue(values, "parts", opts.Parts)
+	addValue(values, "changes", opts.Changes)
+	addValue(values, "noZero", opts.NoZero)
+	addValue(values, "articulate", opts.Articulate)
+	addValue(values, "proxyFor", opts.ProxyFor)
+	addValue(values, "layout", opts.Layout)
+	addValue(values, "root", opts.Root)
+	return values
+}
+
+// State runs chifra state and returns its records.
+func (opts *StateOptions) State() ([]types.State, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.State](opts.RenderCtx, values, State)
+}
+
+// StateCall runs chifra state --call and returns its records.
+func (opts *StateOptions) StateCall(val string) ([]types.Result, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "call", val)
+	return query[types.Result](opts.RenderCtx, values, State)
+}
+
+// StateStorage runs chifra state --storage and returns its records.
+func (opts *StateOptions) StateStorage(val string) ([]types.Slot, *types.MetaData, error) {
+	values := opts.toValues()
+	addValue(values, "storage", val)
+	return query[types.Slot](opts.RenderCtx, values, State)
+}
+
+// StateProof runs chifra state --proof and returns its records.
+func (opts *StateOptions) StateProof() ([]types.Proof, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("proof", "true")
+	return query[types.Proof](opts.RenderCtx, values, State)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
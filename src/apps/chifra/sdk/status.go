@@ -15,6 +15,7 @@ import (
 	status "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/status"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Status provides an interface to the command line chifra status through the SDK.
@@ -30,5 +31,170 @@ func Status(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// StatusOptions are the options of chifra status for the typed functions of the SDK.
+type StatusOptions struct {
+	FirstRecord uint64            `json:"firstRecord,omitempty"`
+	MaxRecords  uint64            `json:"maxRecords,omitempty"`
+	Chains      bool              `json:"chains,omitempty"`
+	RenderCtx   *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra status
+func (opts *StatusOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "firstRecord", opts.FirstRecord)
+	addValue(values, "maxRecords", opts.MaxRecords)
+	addValue(values, "chains", opts.Chains)
+	return values
+}
+
+// StatusIndex runs chifra status index and returns its records.
+func (opts *StatusOptions) StatusIndex() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "index")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusBlooms runs chifra status blooms and returns its records.
+func (opts *StatusOptions) StatusBlooms() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "blooms")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusBlocks runs chifra status blocks and returns its records.
+func (opts *StatusOptions) StatusBlocks() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "blocks")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusTransactions runs chifra status transactions and returns its records.
+func (opts *StatusOptions) StatusTransactions() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "transactions")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusTraces runs chifra status traces and returns its records.
+func (opts *StatusOptions) StatusTraces() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "traces")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusLogs runs chifra status logs and returns its records.
+func (opts *StatusOptions) StatusLogs() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "logs")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusStatements runs chifra status statements and returns its records.
+func (opts *StatusOptions) StatusStatements() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "statements")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusResults runs chifra status results and returns its records.
+func (opts *StatusOptions) StatusResults() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "results")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusState runs chifra status state and returns its records.
+func (opts *StatusOptions) StatusState() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "state")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusTokens runs chifra status tokens and returns its records.
+func (opts *StatusOptions) StatusTokens() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "tokens")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusMonitors runs chifra status monitors and returns its records.
+func (opts *StatusOptions) StatusMonitors() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "monitors")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusNames runs chifra status names and returns its records.
+func (opts *StatusOptions) StatusNames() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "names")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusAbis runs chifra status abis and returns its records.
+func (opts *StatusOptions) StatusAbis() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "abis")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusSlurps runs chifra status slurps and returns its records.
+func (opts *StatusOptions) StatusSlurps() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "slurps")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusStaging runs chifra status staging and returns its records.
+func (opts *StatusOptions) StatusStaging() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "staging")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusUnripe runs chifra status unripe and returns its records.
+func (opts *StatusOptions) StatusUnripe() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "unripe")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusMaps runs chifra status maps and returns its records.
+func (opts *StatusOptions) StatusMaps() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "maps")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusSome runs chifra status some and returns its records.
+func (opts *StatusOptions) StatusSome() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "some")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusAll runs chifra status all and returns its records.
+func (opts *StatusOptions) StatusAll() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("modes", "all")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusDiagnose runs chifra status --diagnose and returns its records.
+func (opts *StatusOptions) StatusDiagnose() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("diagnose", "true")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
+// StatusHealthcheck runs chifra status --healthcheck and returns its records.
+func (opts *StatusOptions) StatusHealthcheck() ([]types.Status, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("healthcheck", "true")
+	return query[types.Status](opts.RenderCtx, values, Status)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
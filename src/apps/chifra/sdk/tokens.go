@@ -15,6 +15,7 @@ import (
 	tokens "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/tokens"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Tokens provides an interface to the command line chifra tokens through the SDK.
@@ -30,5 +31,35 @@ func Tokens(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// TokensOptions are the options of chifra tokens for the typed functions of the SDK.
+type TokensOptions struct {
+	Addrs     []string          `json:"addrs,omitempty"`
+	BlockIds  []string          `json:"blocks,omitempty"`
+	Parts     []string          `json:"parts,omitempty"`
+	ByAcct    bool              `json:"byAcct,omitempty"`
+	Changes   bool              `json:"changes,omitempty"`
+	NoZero    bool              `json:"noZero,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra tokens
+func (opts *TokensOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "addrs", opts.Addrs)
+	addValue(values, "blocks", opts.BlockIds)
+	addValue(values, "parts", opts.Parts)
+	addValue(values, "byAcct", opts.ByAcct)
+	addValue(values, "changes", opts.Changes)
+	addValue(values, "noZero", opts.NoZero)
+	return values
+}
+
+// Tokens runs chifra tokens and returns its records.
+func (opts *TokensOptions) Tokens() ([]types.Token, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Token](opts.RenderCtx, values, Tokens)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -15,6 +15,7 @@ import (
 	traces "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/traces"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Traces provides an interface to the command line chifra traces through the SDK.
@@ -30,5 +31,36 @@ func Traces(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// TracesOptions are the options of chifra traces for the typed functions of the SDK.
+type TracesOptions struct {
+	TransactionIds []string          `json:"transactions,omitempty"`
+	Articulate     bool              `json:"articulate,omitempty"`
+	Filter         string            `json:"filter,omitempty"`
+	RenderCtx      *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra traces
+func (opts *TracesOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "transactions", opts.TransactionIds)
+	addValue(values, "articulate", opts.Articulate)
+	addValue(values, "filter", opts.Filter)
+	return values
+}
+
+// Traces runs chifra traces and returns its records.
+func (opts *TracesOptions) Traces() ([]types.Trace, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Trace](opts.RenderCtx, values, Traces)
+}
+
+// TracesCount runs chifra traces --count and returns its records.
+func (opts *TracesOptions) TracesCount() ([]types.TraceCount, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.TraceCount](opts.RenderCtx, values, Traces)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
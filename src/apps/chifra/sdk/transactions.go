@@ -15,6 +15,7 @@ import (
 	transactions "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/transactions"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // Transactions provides an interface to the command line chifra transactions through the SDK.
@@ -30,5 +31,56 @@ func Transactions(rCtx *output.RenderCtx, w io.Writer, values url.Values) error
 	return err
 }
 
+// TransactionsOptions are the options of chifra transactions for the typed functions of the SDK.
+type TransactionsOptions struct {
+	TransactionIds []string          `json:"transactions,omitempty"`
+	Articulate     bool              `json:"articulate,omitempty"`
+	Flow           string            `json:"flow,omitempty"`
+	Emitter        []string          `json:"emitter,omitempty"`
+	Topic          []string          `json:"topic,omitempty"`
+	CacheTraces    bool              `json:"cacheTraces,omitempty"`
+	RenderCtx      *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra transactions
+func (opts *TransactionsOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "transactions", opts.TransactionIds)
+	addValue(values, "articulate", opts.Articulate)
+	addValue(values, "flow", opts.Flow)
+	addValue(values, "emitter", opts.Emitter)
+	addValue(values, "topic", opts.Topic)
+	addValue(values, "cacheTraces", opts.CacheTraces)
+	return values
+}
+
+// Transactions runs chifra transactions and returns its records.
+func (opts *TransactionsOptions) Transactions() ([]types.Transaction, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.Transaction](opts.RenderCtx, values, Transactions)
+}
+
+// TransactionsTraces runs chifra transactions --traces and returns its records.
+func (opts *TransactionsOptions) TransactionsTraces() ([]types.Trace, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("traces", "true")
+	return query[types.Trace](opts.RenderCtx, values, Transactions)
+}
+
+// TransactionsUniq runs chifra transactions --uniq and returns its records.
+func (opts *TransactionsOptions) TransactionsUniq() ([]types.Appearance, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("uniq", "true")
+	return query[types.Appearance](opts.RenderCtx, values, Transactions)
+}
+
+// TransactionsLogs runs chifra transactions --logs and returns its records.
+func (opts *TransactionsOptions) TransactionsLogs() ([]types.Log, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("logs", "true")
+	return query[types.Log](opts.RenderCtx, values, Transactions)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+// Each command has, in addition to the function that writes its output to an io.Writer, an
+// options struct (for example, BlocksOptions) and a function for each kind of record it
+// produces (for example, BlocksOptions.BlocksHashes), which returns the records themselves.
+
+// Globals are the options shared by the commands. Not every command accepts every option.
+type Globals struct {
+	Chain   string `json:"chain,omitempty"`
+	Ether   bool   `json:"ether,omitempty"`
+	Cache   bool   `json:"cache,omitempty"`
+	Decache bool   `json:"decache,omitempty"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+func (g *Globals) toValues() url.Values {
+	values := url.Values{}
+	addValue(values, "chain", g.Chain)
+	addValue(values, "ether", g.Ether)
+	addValue(values, "cache", g.Cache)
+	addValue(values, "decache", g.Decache)
+	addValue(values, "verbose", g.Verbose)
+	return values
+}
+
+// addValue adds the value to the query parameters unless it is the zero value of its type
+func addValue(values url.Values, key string, value any) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			values.Set(key, "true")
+		}
+	case string:
+		if len(v) > 0 {
+			values.Set(key, v)
+		}
+	case []string:
+		for _, item := range v {
+			values.Add(key, item)
+		}
+	case uint64:
+		if v != 0 {
+			values.Set(key, fmt.Sprint(v))
+		}
+	case float64:
+		if v != 0 {
+			values.Set(key, fmt.Sprint(v))
+		}
+	default:
+		panic(fmt.Sprintf("sdk: unsupported type %T for %s", value, key))
+	}
+}
+
+// query runs the command with a streaming render context and returns the records it produces,
+// which must all be of type T, along with the meta data that accompanies its JSON output. Errors
+// the command reports along the way are returned with whatever records it did produce.
+func query[T any](rCtx *output.RenderCtx, values url.Values, run func(*output.RenderCtx, io.Writer, url.Values) error) ([]T, *types.MetaData, error) {
+	values.Set("fmt", "json")
+	sCtx := output.NewStreamingContext()
+	defer sCtx.Cancel()
+	if rCtx != nil {
+		// the caller may cancel the command with its own render context
+		go func() {
+			select {
+			case <-rCtx.Ctx.Done():
+				sCtx.Cancel()
+			case <-sCtx.Ctx.Done():
+			}
+		}()
+	}
+
+	// the records go to the channel, so all that is written is the meta data (and any error
+	// getting it)
+	var buffer bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- run(sCtx, &buffer, values)
+	}()
+
+	ret := make([]T, 0)
+	errs := []error{}
+	for {
+		select {
+		case model := <-sCtx.ModelChan:
+			if record, ok := any(model).(*T); ok {
+				ret = append(ret, *record)
+			} else {
+				errs = append(errs, fmt.Errorf("expected records of type %T, got %T", *new(T), model))
+			}
+		case err := <-sCtx.ErrorChan:
+			errs = append(errs, err)
+		case err := <-done:
+			if err != nil {
+				return ret, nil, err
+			}
+			var response struct {
+				Meta   *types.MetaData `json:"meta"`
+				Errors []string        `json:"errors"`
+			}
+			if err := json.Unmarshal(buffer.Bytes(), &response); err != nil {
+				errs = append(errs, err)
+			}
+			for _, msg := range response.Errors {
+				errs = append(errs, errors.New(msg))
+			}
+			return ret, response.Meta, errors.Join(errs...)
+		}
+	}
+}
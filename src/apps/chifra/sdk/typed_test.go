@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
+)
+
+func TestToValues(t *testing.T) {
+	opts := ChunksOptions{
+		BlockIds:   []string{"100", "200"},
+		Check:      true,
+		FirstBlock: 12,
+		Sleep:      .5,
+		Globals:    Globals{Chain: "sepolia"},
+	}
+	values := opts.toValues()
+	expected := "blocks=100&blocks=200&chain=sepolia&check=true&firstBlock=12&sleep=0.5"
+	if got := values.Encode(); got != expected {
+		t.Error("Expected", expected, "got", got)
+	}
+}
+
+// fakeNames streams the names in the values' terms (and one error) the way a command would
+func fakeNames(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
+	fetchData := func(modelChan chan types.Modeler, errorChan chan error) {
+		for _, term := range values["terms"] {
+			if term == "bad" {
+				errorChan <- errors.New("bad term")
+				continue
+			}
+			modelChan <- &types.Name{Name: term}
+		}
+	}
+	if err := output.StreamMany(rCtx, fetchData, output.OutputOptions{}); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, `{ "data": [], "meta": { "client": 100, "chainId": 1 } }`)
+	return err
+}
+
+func TestQuery(t *testing.T) {
+	values := url.Values{"terms": []string{"first", "bad", "second"}}
+	names, meta, err := query[types.Name](nil, values, fakeNames)
+	if len(names) != 2 || names[0].Name != "first" || names[1].Name != "second" {
+		t.Error("Expected both names, got", names)
+	}
+	if meta == nil || meta.Latest != 100 || meta.ChainId != 1 {
+		t.Error("Expected the meta data, got", meta)
+	}
+	if err == nil || err.Error() != "bad term" {
+		t.Error("Expected the streamed error, got", err)
+	}
+
+	if _, _, err := query[types.Block](nil, url.Values{"terms": []string{"first"}}, fakeNames); err == nil {
+		t.Error("Expected records of the wrong type to be reported")
+	}
+}
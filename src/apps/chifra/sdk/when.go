@@ -15,6 +15,7 @@ import (
 	when "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/internal/when"
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output"
 	outputHelpers "github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/output/helpers"
+	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/types"
 )
 
 // When provides an interface to the command line chifra when through the SDK.
@@ -30,5 +31,56 @@ func When(rCtx *output.RenderCtx, w io.Writer, values url.Values) error {
 	return err
 }
 
+// WhenOptions are the options of chifra when for the typed functions of the SDK.
+type WhenOptions struct {
+	BlockIds  []string          `json:"blocks,omitempty"`
+	Truncate  uint64            `json:"truncate,omitempty"`
+	Repair    bool              `json:"repair,omitempty"`
+	Check     bool              `json:"check,omitempty"`
+	Update    bool              `json:"update,omitempty"`
+	Deep      bool              `json:"deep,omitempty"`
+	RenderCtx *output.RenderCtx `json:"-"`
+	Globals
+}
+
+// toValues returns the options as the query parameters of chifra when
+func (opts *WhenOptions) toValues() url.Values {
+	values := opts.Globals.toValues()
+	addValue(values, "blocks", opts.BlockIds)
+	addValue(values, "truncate", opts.Truncate)
+	addValue(values, "repair", opts.Repair)
+	addValue(values, "check", opts.Check)
+	addValue(values, "update", opts.Update)
+	addValue(values, "deep", opts.Deep)
+	return values
+}
+
+// When runs chifra when and returns its records.
+func (opts *WhenOptions) When() ([]types.NamedBlock, *types.MetaData, error) {
+	values := opts.toValues()
+	return query[types.NamedBlock](opts.RenderCtx, values, When)
+}
+
+// WhenList runs chifra when --list and returns its records.
+func (opts *WhenOptions) WhenList() ([]types.NamedBlock, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("list", "true")
+	return query[types.NamedBlock](opts.RenderCtx, values, When)
+}
+
+// WhenTimestamps runs chifra when --timestamps and returns its records.
+func (opts *WhenOptions) WhenTimestamps() ([]types.Timestamp, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("timestamps", "true")
+	return query[types.Timestamp](opts.RenderCtx, values, When)
+}
+
+// WhenCount runs chifra when --count and returns its records.
+func (opts *WhenOptions) WhenCount() ([]types.Count, *types.MetaData, error) {
+	values := opts.toValues()
+	values.Set("count", "true")
+	return query[types.Count](opts.RenderCtx, values, When)
+}
+
 // EXISTING_CODE
 // EXISTING_CODE
@@ -41,6 +41,13 @@ func (cb *CodeBase) Description() string {
 	return strings.Trim(file.AsciiFileToString(apiPath), ws)
 }
 
+// OpenApi - returns the openapi.yaml document describing the routes and their models. It
+// is written both to the docs and into the daemon, which serves it and validates against it.
+func (cb *CodeBase) OpenApi() string {
+	apiPath := filepath.Join(GetTemplatePath(), "api/openapi.yaml.tmpl")
+	return cb.executeTemplate("openapi", file.AsciiFileToString(apiPath))
+}
+
 // RouteToGroup - returns the group given a route
 func (cb *CodeBase) RouteToGroup(route string) string {
 	for _, c := range cb.Commands {
@@ -445,6 +445,44 @@ func (c *Command) IsRoute() bool {
 	return !strings.Contains(c.Attributes, "notApi")
 }
 
+// Scope returns the API key scope the daemon requires for the command's mutating requests. Commands
+// declare it with a scope= attribute. Those that don't need only read-only access.
+func (c *Command) Scope() string {
+	if scope := getAttribute(c.Attributes, "scope"); len(scope) > 0 {
+		return scope
+	}
+	return "read-only"
+}
+
+// MutatingParams returns, as a Go expression, the API names of the command's options that make a
+// request change local data. Options declare it with the mutating attribute. Every command that
+// caches also has --decache.
+func (c *Command) MutatingParams() string {
+	params := []string{}
+	for _, op := range c.Options {
+		if op.IsMutating() {
+			params = append(params, fmt.Sprintf("%q", CamelCase(op.LongName)))
+		}
+	}
+	if strings.Contains(c.Capabilities, "caching") {
+		params = append(params, `"decache"`)
+	}
+	if len(params) == 0 {
+		return "nil"
+	}
+	return "[]string{" + strings.Join(params, ", ") + "}"
+}
+
+// getAttribute returns the value of a name=value entry in a |-separated list of attributes
+func getAttribute(attributes, name string) string {
+	for _, part := range strings.Split(attributes, "|") {
+		if strings.HasPrefix(part, name+"=") {
+			return strings.TrimPrefix(part, name+"=")
+		}
+	}
+	return ""
+}
+
 func (c *Command) Example() string {
 	examplePath := filepath.Join(GetTemplatePath(), "api/examples/"+c.Route+".json")
 	contents := strings.Trim(file.AsciiFileToString(examplePath), ws)
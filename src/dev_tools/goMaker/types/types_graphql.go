@@ -0,0 +1,37 @@
+package types
+
+// Tags found in src_apps_chifra_internal_daemon_graphql_schema.go (the daemon's GraphQL schema)
+
+// IsGraphQLField for tag {{.IsGraphQLField}} returns true if the member appears in the GraphQL
+// schema, which includes every member the JSON output does
+func (m *Member) IsGraphQLField() bool {
+	return !m.IsRemoved() && !m.IsNoTag()
+}
+
+// GraphQLType for tag {{.GraphQLType}} returns the type of the member in the GraphQL schema. Members
+// that are models have the model's type, other objects are JSON, and the basic types are one of
+// GraphQL's scalars or, for 64-bit integers (which GraphQL's Int cannot hold), Int64.
+func (m *Member) GraphQLType() string {
+	if m.IsObject() {
+		for _, st := range m.stPtr.cbPtr.Structures {
+			if st.Class == m.Type && !st.DisableGo {
+				return m.Type
+			}
+		}
+		return "JSON"
+	}
+
+	switch m.Type {
+	case "blknum", "txnum", "lognum", "timestamp", "gas", "uint64", "int64", "int", "value":
+		return "Int64"
+	case "uint32":
+		return "Int"
+	case "float", "float64":
+		return "Float"
+	case "bool", "uint8":
+		return "Boolean"
+	case "address", "datetime", "hash", "ipfshash", "blkrange", "topic", "int256", "uint256", "wei", "bytes", "string", "ether":
+		return "String"
+	}
+	return "JSON"
+}
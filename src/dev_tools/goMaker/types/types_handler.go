@@ -27,6 +27,7 @@ func (h *Handler) Test() string {
 		return r
 	} else {
 		if h.Option.IsArray() ||
+			h.Option.IsEnum() ||
 			strings.Contains(h.Option.DataType, "string") ||
 			strings.Contains(h.Option.DataType, "address") {
 			return "len(opts." + h.Name + ") > 0"
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"go/token"
 	"strings"
 
 	"github.com/TrueBlocks/trueblocks-core/src/apps/chifra/pkg/logger"
@@ -52,6 +53,12 @@ func (op *Option) IsVisibleDocs() bool {
 	return strings.Contains(op.Attributes, "docs")
 }
 
+// IsMutating returns true if the option makes a request change local data (names, monitors, the
+// index, or the caches)
+func (op *Option) IsMutating() bool {
+	return strings.Contains(op.Attributes, "mutating")
+}
+
 func (op *Option) IsDeprecated() bool {
 	return strings.Contains(op.Attributes, "deprecated")
 }
@@ -777,16 +784,25 @@ func (opts *{{firstUpper .Route}}Options) {{firstUpper .Route}}{{.GoName}}({{.To
 `
 
 var fuzzerSwitch = `	case "{{.Tool}}":
-if {{.Tool}}, _, err := opts.{{firstUpper .Route}}{{.GoName}}({{.ToolParameters true}}); err != nil {
+if {{.ToolVar}}, _, err := opts.{{firstUpper .Route}}{{.GoName}}({{.ToolParameters true}}); err != nil {
 	ReportError(fn, opts, err)
 } else {
-	if err := SaveToFile[{{.SdkCoreType}}](fn, {{.Tool}}); err != nil {
+	if err := SaveToFile[{{.SdkCoreType}}](fn, {{.ToolVar}}); err != nil {
 		ReportError2(fn, err)
 	} else {
 		ReportOkay(fn)
 	}
 }`
 
+// ToolVar for tag {{.ToolVar}} is the name of the variable holding the results of a tool. Tools
+// named after GoLang reserved words (such as names --import) get a suffix.
+func (op *Option) ToolVar() string {
+	if token.IsKeyword(op.Tool) {
+		return op.Tool + "Val"
+	}
+	return op.Tool
+}
+
 func (op *Option) FuzzerSwitch() string {
 	tmplName := "fuzzerSwitch"
 	tmpl := fuzzerSwitch
@@ -10,11 +10,11 @@ func (c *Command) HasSorts() bool {
 }
 
 func getSorts(attributes string) []string {
-	parts := strings.Split(attributes, "=")
-	if len(parts) < 2 {
+	value := getAttribute(attributes, "sorts")
+	if len(value) == 0 {
 		return []string{}
 	}
-	parts = strings.Split(parts[1], ":")
+	parts := strings.Split(value, ":")
 	ret := []string{}
 	for _, part := range parts {
 		ret = append(ret, FirstUpper(part))
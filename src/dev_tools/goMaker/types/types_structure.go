@@ -170,6 +170,8 @@ func (s *Structure) CacheIdStr() string {
 		return "\"%s-%09d\", s.Address.Hex()[2:], s.BlockNumber"
 	case "address,block,fourbyte":
 		return "\"%s-%s-%09d\", s.Address.Hex()[2:], s.Encoding[2:], s.BlockNumber"
+	case "address,block,slot":
+		return "\"%s-%s-%09d\", s.Address.Hex()[2:], s.Slot.Hex()[2:], s.BlockNumber"
 	case "address,tx":
 		return "\"%s-%09d-%05d\", s.Address.Hex()[2:], s.BlockNumber, s.TransactionIndex"
 	case "block":
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"strings"
+)
+
+// Tags found in src_apps_chifra_sdk_route.go (the typed functions of the in-tree SDK)
+
+// typedEndpoint carries what the template needs to write one of a command's typed functions
+type typedEndpoint struct {
+	Option
+	Name       string
+	Switch     string
+	Parameter  string
+	Assignment string
+	ReturnType string
+}
+
+var typedEndpointTmpl = `// {{.Name}} runs chifra {{toLower .Route}}{{.Switch}} and returns its records.
+func (opts *{{toProper .Route}}Options) {{.Name}}({{.Parameter}}) ([]{{.ReturnType}}, *types.MetaData, error) {
+	values := opts.toValues()
+{{.Assignment}}	return query[{{.ReturnType}}](opts.RenderCtx, values, {{toProper .Route}})
+}
+`
+
+// typedModeTypes are the records produced by the modes whose type isn't named after the mode
+var typedModeTypes = map[string]string{
+	"types.ChunkManifest":   "types.Manifest",
+	"types.ChunkAppearance": "types.Appearance",
+}
+
+// TypedEndpoints for tag {{.TypedEndpoints}} returns a typed function for each of the command's
+// options that produces records
+func (c *Command) TypedEndpoints() string {
+	ret := []string{}
+	for _, op := range c.Options {
+		if len(op.ReturnType) > 0 {
+			ret = append(ret, op.typedEndpoints()...)
+		}
+	}
+	return strings.Join(ret, "\n")
+}
+
+func (op *Option) typedEndpoints() []string {
+	tmplName := "typedEndpoint"
+	prefix := Proper(op.Route)
+	key := CamelCase(op.LongName)
+
+	if op.IsMode() {
+		ret := []string{}
+		for _, enum := range op.Enums {
+			te := typedEndpoint{
+				Option:     *op,
+				Name:       prefix + FirstUpper(enum),
+				Switch:     " " + enum,
+				Assignment: "\tvalues.Set(\"" + key + "\", \"" + enum + "\")\n",
+			}
+			te.GoName = FirstUpper(enum)
+			te.ReturnType = te.SdkCoreType()
+			if t, ok := typedModeTypes[te.ReturnType]; ok {
+				te.ReturnType = t
+			}
+			ret = append(ret, executeTemplate(&te, "option", tmplName, typedEndpointTmpl))
+		}
+		return ret
+	}
+
+	te := typedEndpoint{
+		Option:     *op,
+		Name:       prefix + op.GoName,
+		ReturnType: op.SdkCoreType(),
+	}
+	if op.IsPositional() {
+		te.Name = prefix
+	} else if op.DataType == "<boolean>" {
+		te.Switch = " --" + op.LongName
+		te.Assignment = "\tvalues.Set(\"" + key + "\", \"true\")\n"
+	} else {
+		te.Switch = " --" + op.LongName
+		te.Parameter = "val " + op.TypedSdkType()
+		te.Assignment = "\taddValue(values, \"" + key + "\", val)\n"
+	}
+	return []string{executeTemplate(&te, "option", tmplName, typedEndpointTmpl)}
+}
+
+// TypedSdkType for tag {{.TypedSdkType}} is the type of the option in the typed SDK. It is the
+// option's type with addresses as strings (so they may be ENS names) and block numbers as uint64.
+func (op *Option) TypedSdkType() string {
+	return strings.Replace(op.GoOptionsType, "base.Blknum", "uint64", -1)
+}
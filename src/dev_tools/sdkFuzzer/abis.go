@@ -31,6 +31,7 @@ func DoAbis() {
 	known := []bool{false, true}
 	proxyFor := fuzzProxyFors
 	hint := fuzzHints
+	// calldata is a <string> --other
 	// Fuzz Loop
 	// EXISTING_CODE
 	_ = hint
@@ -166,6 +167,16 @@ func TestAbis(which, value, fn string, opts *sdk.AbisOptions) {
 				ReportOkay(fn)
 			}
 		}
+	case "importsigs":
+		if importsigs, _, err := opts.AbisImportSigs(value); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Count](fn, importsigs); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
 	default:
 		ReportError(fn, opts, fmt.Errorf("unknown which: %s", which))
 		logger.Fatal("Quitting...")
@@ -0,0 +1,62 @@
+// Copyright 2016, 2024 The TrueBlocks Authors. All rights reserved.
+// Use of this source code is governed by a license that can
+// be found in the LICENSE file.
+/*
+ * Parts of this file were auto generated. Edit only those parts of
+ * the code inside of 'EXISTING_CODE' tags.
+ */
+package main
+
+// EXISTING_CODE
+// EXISTING_CODE
+
+// DoClusters tests the Clusters sdk function
+func DoClusters() {
+	file.EstablishFolder("sdkFuzzer-output/clusters")
+	opts := sdk.ClustersOptions{}
+	ShowHeader("DoClusters", opts)
+
+	globs := noCache(noEther(globals))
+	// Option 'heuristics.list<enum>' is an emum
+	// minConfidence is a <float64> --other
+	// Fuzz Loop
+	// EXISTING_CODE
+	// EXISTING_CODE
+	Wait()
+}
+
+func TestClusters(which, value, fn string, opts *sdk.ClustersOptions) {
+	fn = strings.Replace(fn, ".json", "-"+which+".json", 1)
+	// EXISTING_CODE
+	// EXISTING_CODE
+
+	switch which {
+	case "clusters":
+		if clusters, _, err := opts.Clusters(); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Cluster](fn, clusters); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
+	case "accept":
+		if accept, _, err := opts.ClustersAccept(); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Cluster](fn, accept); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
+	default:
+		ReportError(fn, opts, fmt.Errorf("unknown which: %s", which))
+		logger.Fatal("Quitting...")
+		return
+	}
+}
+
+// EXISTING_CODE
+// EXISTING_CODE
@@ -38,12 +38,14 @@ func DoExport() {
 	reverted := []bool{false, true}
 	asset := fuzzAssets
 	// Option 'flow.enum' is an emum
+	incremental := []bool{false, true}
 	factory := []bool{false, true}
 	unripe := []bool{false, true}
 	reversed := []bool{false, true}
 	noZero := []bool{false, true}
 	// firstBlock is a <blknum> --other
 	// lastBlock is a <blknum> --other
+	// group is a <string> --other
 	// firstRecord is not fuzzed
 	// maxRecords is not fuzzed
 	// Fuzz Loop
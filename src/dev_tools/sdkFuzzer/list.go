@@ -34,6 +34,7 @@ func DoList() {
 	reversed := []bool{false, true}
 	// firstBlock is a <blknum> --other
 	// lastBlock is a <blknum> --other
+	// group is a <string> --other
 	// firstRecord is not fuzzed
 	// maxRecords is not fuzzed
 	// publisher is not fuzzed
@@ -26,7 +26,7 @@ func getFuzzFuncs() []FuzzFuncs {
 		if len(os.Args) > 1 {
 			enabled = os.Args[1]
 		} else {
-			enabled = "receipts|abis|blocks|chunks|config|export|list|logs|names|slurp|state|status|tokens|traces|transactions|when"
+			enabled = "receipts|abis|blocks|chunks|config|export|list|logs|names|slurp|state|status|tokens|traces|transactions|when|clusters"
 		}
 	}
 
@@ -72,4 +72,5 @@ var commandMap = map[string]FuzzFuncs{
 	"daemon":       {19, "daemon", DoDaemon, false},
 	// "scrape":    {20, "scrape", DoScrape, false},
 	// "explore":   {21, "explore", DoExplore, false},
+	"clusters":     {22, "clusters", DoClusters, false},
 }
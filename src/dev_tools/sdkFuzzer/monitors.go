@@ -31,7 +31,10 @@ func DoMonitors() {
 	undelete := []bool{false, true}
 	remove := []bool{false, true}
 	staged := []bool{false, true}
+	// Option 'migrate.enum' is an emum
 	watch := []bool{false, true}
+	subscribe := []bool{false, true}
+	// rules is a <string> --other
 	// watchlist is not fuzzed
 	// commands is not fuzzed
 	// batchSize is not fuzzed
@@ -147,6 +150,26 @@ func TestMonitors(which, value, fn string, opts *sdk.MonitorsOptions) {
 				ReportOkay(fn)
 			}
 		}
+	case "migrate":
+		if migrate, _, err := opts.MonitorsMigrate(value); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Monitor](fn, migrate); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
+	case "group":
+		if group, _, err := opts.MonitorsGroup(value); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Monitor](fn, group); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
 	default:
 		ReportError(fn, opts, fmt.Errorf("unknown which: %s", which))
 		logger.Fatal("Quitting...")
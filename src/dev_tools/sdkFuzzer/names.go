@@ -35,6 +35,11 @@ func DoNames() {
 	prefund := []bool{false, true}
 	regular := []bool{false, true}
 	dryRun := []bool{false, true}
+	regex := []bool{false, true}
+	rank := []bool{false, true}
+	// Option 'strategy.enum' is an emum
+	// Option 'sources.list<enum>' is an emum
+	// tokenList is a <string> --other
 	// Fuzz Loop
 	// EXISTING_CODE
 	_ = dryRun
@@ -145,6 +150,36 @@ func TestNames(which, value, fn string, opts *sdk.NamesOptions) {
 				ReportOkay(fn)
 			}
 		}
+	case "export":
+		if export, _, err := opts.NamesExport(value); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Message](fn, export); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
+	case "import":
+		if importVal, _, err := opts.NamesImport(value); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.NameConflict](fn, importVal); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
+	case "discover":
+		if discover, _, err := opts.NamesDiscover(); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Name](fn, discover); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
 	case "create":
 		if create, _, err := opts.NamesCreate(); err != nil {
 			ReportError(fn, opts, err)
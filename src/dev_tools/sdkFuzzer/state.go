@@ -33,6 +33,8 @@ func DoState() {
 	noZero := []bool{false, true}
 	articulate := []bool{false, true}
 	proxyFor := fuzzProxyFors
+	// layout is a <string> --other
+	// root is a <string> --other
 	// blocks is not fuzzed
 	// Fuzz Loop
 	// EXISTING_CODE
@@ -145,6 +147,26 @@ func TestState(which, value, fn string, opts *sdk.StateOptions) {
 				ReportOkay(fn)
 			}
 		}
+	case "storage":
+		if storage, _, err := opts.StateStorage(value); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Slot](fn, storage); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
+	case "proof":
+		if proof, _, err := opts.StateProof(); err != nil {
+			ReportError(fn, opts, err)
+		} else {
+			if err := SaveToFile[types.Proof](fn, proof); err != nil {
+				ReportError2(fn, err)
+			} else {
+				ReportOkay(fn)
+			}
+		}
 	default:
 		ReportError(fn, opts, fmt.Errorf("unknown which: %s", which))
 		logger.Fatal("Quitting...")
@@ -38,6 +38,7 @@ var routeMap = map[string]helper{
 	"daemon":       {"apps", 19, true},
 	"explore":      {"apps", 20, true},
 	"init":         {"apps", 21, true},
+	"clusters":     {"apps", 22, true},
 }
 
 var modeMap = map[string]helper{
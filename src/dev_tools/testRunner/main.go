@@ -22,6 +22,15 @@ import (
 
 func init() {
 	os.Setenv("TB_NO_USERQUERY", "true")
+
+	// The tests run in their own folders, so the fixture folder must be absolute
+	for _, key := range []string{"TB_RPC_RECORD", "TB_RPC_REPLAY"} {
+		if folder := os.Getenv(key); len(folder) > 0 {
+			if abs, err := filepath.Abs(folder); err == nil {
+				os.Setenv(key, abs)
+			}
+		}
+	}
 }
 
 func main() {
@@ -37,8 +46,11 @@ func main() {
 	if testMap, casesPath, err := loadTestCases(); err != nil {
 		logger.Fatal(err)
 	} else {
-		if err := downloadAbis(); err != nil {
-			logger.Fatal(err)
+		if os.Getenv("TB_RPC_REPLAY") == "" {
+			// when replaying there's no network, so the abis must already be in the cache
+			if err := downloadAbis(); err != nil {
+				logger.Fatal(err)
+			}
 		}
 
 		routeList, modeList := getRoutesAndModes()
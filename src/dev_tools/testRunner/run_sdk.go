@@ -168,6 +168,15 @@ func (t *TestCase) SdkTest() (string, error) {
 		err = opts.AbisBytes(buf)
 		return buf.String(), err
 
+	case "clusters":
+		opts, err := sdk.GetClustersOptions(t.SdkOptionsArray)
+		reportFunc(opts)
+		if err != nil {
+			return "", err
+		}
+		err = opts.ClustersBytes(buf)
+		return buf.String(), err
+
 	case "when":
 		opts, err := sdk.GetWhenOptions(t.SdkOptionsArray)
 		reportFunc(opts)